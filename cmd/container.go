@@ -2,11 +2,16 @@ package main
 
 import (
 	"hrm/config"
+	"hrm/directory"
 	"hrm/domain"
 	"hrm/handler"
 	"hrm/handler/routes"
+	"hrm/mail"
+	"hrm/middleware"
+	"hrm/oidc"
 	"hrm/repository"
 	"hrm/usecase"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,17 +24,59 @@ import (
 // - Business logic services
 // - HTTP handlers
 type Container struct {
-	Config            *config.Config                       // Application configuration
-	UserRepo          domain.UserRepositoryInterface       // User data access layer
-	UserService       domain.UserServiceInterface          // User business logic layer
-	AttendanceRepo    domain.AttendanceRepositoryInterface // Attendance data access layer
-	BreakRepo         domain.BreakRepositoryInterface      // Break data access layer
-	LeaveRepo         domain.LeaveRepositoryInterface      // Leave data access layer
-	LeaveTypeRepo     domain.LeaveTypeRepositoryInterface  // Leave type data access layer
-	AttendanceService domain.AttendanceServiceInterface    // Attendance business logic layer
-	BreakService      domain.BreakServiceInterface         // Break business logic layer
-	LeaveService      domain.LeaveServiceInterface         // Leave business logic layer
-	LeaveTypeService  domain.LeaveTypeServiceInterface     // Leave type business logic layer
+	Config                 *config.Config                                     // Application configuration
+	UserRepo               domain.UserRepositoryInterface                     // User data access layer
+	UserService            domain.UserServiceInterface                        // User business logic layer
+	AuthSessionRepo        domain.AuthSessionRepositoryInterface              // Sign-in session data access layer
+	AuthService            domain.AuthServiceInterface                        // Sign-in session and token logic
+	SigningKeyRepo         domain.SigningKeyRepositoryInterface               // Access token signing key data access layer
+	SigningKeyService      domain.SigningKeyServiceInterface                  // Access token signing and key rotation logic
+	AccountTokenRepo       domain.AccountTokenRepositoryInterface             // Password reset and email verification token data access layer
+	SignInAttemptRepo      domain.SignInAttemptRepositoryInterface            // Sign-in history data access layer
+	MailSender             domain.MailSenderInterface                         // Outgoing email delivery
+	AccountService         domain.AccountServiceInterface                     // Password reset and email verification logic
+	TOTPCredentialRepo     domain.TOTPCredentialRepositoryInterface           // Authenticator app enrollment and recovery code data access layer
+	TwoFactorChallengeRepo domain.TwoFactorChallengeRepositoryInterface       // Pending two-factor sign-in data access layer
+	TwoFactorService       domain.TwoFactorServiceInterface                   // TOTP two-factor authentication logic
+	OIDCIdentityRepo       domain.OIDCIdentityRepositoryInterface             // Identity provider account link data access layer
+	OIDCLoginStateRepo     domain.OIDCLoginStateRepositoryInterface           // Pending single sign-on data access layer
+	OIDCService            domain.OIDCServiceInterface                        // OpenID Connect single sign-on logic (nil when not configured)
+	Directory              domain.DirectoryInterface                          // LDAP directory users sign in with (nil when not configured)
+	DirectorySyncService   domain.DirectorySyncServiceInterface               // Directory user sync logic (nil when not configured)
+	SCIMService            domain.SCIMServiceInterface                        // SCIM user and role provisioning logic (nil when not configured)
+	APITokenRepo           domain.APITokenRepositoryInterface                 // Personal access token data access layer
+	APITokenService        domain.APITokenServiceInterface                    // Personal access token and service account logic
+	AttendanceRepo         domain.AttendanceRepositoryInterface               // Attendance data access layer
+	SessionRepo            domain.AttendanceSessionRepositoryInterface        // Attendance session data access layer
+	BreakRepo              domain.BreakRepositoryInterface                    // Break data access layer
+	BreakTypeRepo          domain.BreakTypeRepositoryInterface                // Break type data access layer
+	LeaveRepo              domain.LeaveRepositoryInterface                    // Leave data access layer
+	LeaveTypeRepo          domain.LeaveTypeRepositoryInterface                // Leave type data access layer
+	LocationRepo           domain.LocationRepositoryInterface                 // Location data access layer
+	HolidayRepo            domain.HolidayRepositoryInterface                  // Holiday data access layer
+	OvertimeEntryRepo      domain.OvertimeEntryRepositoryInterface            // Overtime ledger data access layer
+	OvertimeRequestRepo    domain.OvertimeRequestRepositoryInterface          // Overtime pre-approval data access layer
+	RegularizationRepo     domain.AttendanceRegularizationRepositoryInterface // Attendance regularization data access layer
+	TimesheetRepo          domain.TimesheetRepositoryInterface                // Weekly timesheet data access layer
+	ProjectRepo            domain.ProjectRepositoryInterface                  // Project data access layer
+	TimeEntryRepo          domain.TimeEntryRepositoryInterface                // Project time entry data access layer
+	KioskRepo              domain.KioskRepositoryInterface                    // Kiosk device data access layer
+	AttendanceService      domain.AttendanceServiceInterface                  // Attendance business logic layer
+	BreakService           domain.BreakServiceInterface                       // Break business logic layer
+	BreakTypeService       domain.BreakTypeServiceInterface                   // Break type business logic layer
+	LeaveService           domain.LeaveServiceInterface                       // Leave business logic layer
+	LeaveTypeService       domain.LeaveTypeServiceInterface                   // Leave type business logic layer
+	LocationService        domain.LocationServiceInterface                    // Location business logic layer
+	RegularizationService  domain.AttendanceRegularizationServiceInterface    // Attendance regularization business logic layer
+	HolidayService         domain.HolidayServiceInterface                     // Holiday business logic layer
+	OvertimeService        domain.OvertimeServiceInterface                    // Overtime engine and approval logic
+	TimesheetService       domain.TimesheetServiceInterface                   // Weekly timesheet and approval logic
+	ProjectService         domain.ProjectServiceInterface                     // Project business logic layer
+	TimeEntryService       domain.TimeEntryServiceInterface                   // Project time allocation and utilization logic
+	KioskService           domain.KioskServiceInterface                       // Kiosk devices and kiosk punch logic
+	PunchImportService     domain.PunchImportServiceInterface                 // Time clock punch log import logic
+	AutoCloseService       domain.AttendanceAutoCloseServiceInterface         // Forgotten check-out closing job
+	AbsenceService         domain.AbsenceMarkingServiceInterface              // Daily absence marking job
 }
 
 // NewContainer creates and initializes all application dependencies.
@@ -47,35 +94,178 @@ func NewContainer() *Container {
 	// Step 2: Initialize repositories (Data Access Layer)
 	// Repositories handle all database operations and implement domain interfaces
 	userRepo := repository.NewUserRepository(cfg.DB)
+	authSessionRepo := repository.NewAuthSessionRepository(cfg.DB)
+	signingKeyRepo := repository.NewSigningKeyRepository(cfg.DB)
+	accountTokenRepo := repository.NewAccountTokenRepository(cfg.DB)
+	signInAttemptRepo := repository.NewSignInAttemptRepository(cfg.DB)
+	passwordHistoryRepo := repository.NewPasswordHistoryRepository(cfg.DB)
+	totpCredentialRepo := repository.NewTOTPCredentialRepository(cfg.DB)
+	twoFactorChallengeRepo := repository.NewTwoFactorChallengeRepository(cfg.DB)
+	oidcIdentityRepo := repository.NewOIDCIdentityRepository(cfg.DB)
+	oidcLoginStateRepo := repository.NewOIDCLoginStateRepository(cfg.DB)
+	apiTokenRepo := repository.NewAPITokenRepository(cfg.DB)
 	attendanceRepo := repository.NewAttendanceRepository(cfg.DB)
+	sessionRepo := repository.NewAttendanceSessionRepository(cfg.DB)
 	breakRepo := repository.NewBreakRepository(cfg.DB)
+	breakTypeRepo := repository.NewBreakTypeRepository(cfg.DB)
 	leaveRepo := repository.NewLeaveRepository(cfg.DB)
 	leaveTypeRepo := repository.NewLeaveTypeRepository(cfg.DB)
+	locationRepo := repository.NewLocationRepository(cfg.DB)
+	holidayRepo := repository.NewHolidayRepository(cfg.DB)
+	overtimeEntryRepo := repository.NewOvertimeEntryRepository(cfg.DB)
+	overtimeRequestRepo := repository.NewOvertimeRequestRepository(cfg.DB)
+	regularizationRepo := repository.NewAttendanceRegularizationRepository(cfg.DB)
+	timesheetRepo := repository.NewTimesheetRepository(cfg.DB)
+	projectRepo := repository.NewProjectRepository(cfg.DB)
+	timeEntryRepo := repository.NewTimeEntryRepository(cfg.DB)
+	kioskRepo := repository.NewKioskRepository(cfg.DB)
 
 	// Step 3: Initialize services (Business Logic Layer)
 	// Services contain business logic and orchestrate operations between repositories
-	userService := usecase.NewUserService(userRepo)
-	attendanceService := usecase.NewAttendanceService(attendanceRepo, userRepo)
-	breakService := usecase.NewBreakService(breakRepo, attendanceRepo)
+	mailSender := newMailSender(cfg.Mail)
+	userDirectory := newDirectory(cfg.LDAP)
+	userService := usecase.NewUserService(userRepo, locationRepo, authSessionRepo, signInAttemptRepo, passwordHistoryRepo, cfg.Auth.SignIn, cfg.Password, userDirectory)
+	signingKeyService := usecase.NewSigningKeyService(signingKeyRepo, cfg.Auth.SigningAlg, cfg.Auth.KeyRotation, cfg.Auth.AccessTokenTTL)
+	authService := usecase.NewAuthService(authSessionRepo, userRepo, signingKeyService, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+	accountService := usecase.NewAccountService(accountTokenRepo, userRepo, authSessionRepo, passwordHistoryRepo, mailSender, cfg.Account.AppBaseURL, cfg.Account.PasswordResetTTL, cfg.Account.EmailVerificationTTL, cfg.Password, userDirectory != nil)
+	twoFactorService := usecase.NewTwoFactorService(totpCredentialRepo, twoFactorChallengeRepo, userRepo, signInAttemptRepo, cfg.Auth.TOTPIssuer, cfg.Auth.TwoFactorRoles, cfg.Auth.TwoFactorTTL, cfg.Auth.SignIn)
+	oidcService := newOIDCService(cfg.OIDC, oidcIdentityRepo, oidcLoginStateRepo, userRepo, signInAttemptRepo)
+	var directorySyncService domain.DirectorySyncServiceInterface
+	if userDirectory != nil {
+		directorySyncService = usecase.NewDirectorySyncService(userDirectory, userRepo, authSessionRepo)
+	}
+	var scimService domain.SCIMServiceInterface
+	if cfg.SCIM.Enabled() {
+		scimService = usecase.NewSCIMService(userRepo, authSessionRepo, apiTokenRepo, passwordHistoryRepo, cfg.Password, cfg.SCIM.Token, cfg.SCIM.BaseURL)
+	}
+	apiTokenService := usecase.NewAPITokenService(apiTokenRepo, userRepo, cfg.Auth.APITokenTTL, cfg.Auth.APITokenMaxTTL)
+	attendanceService := usecase.NewAttendanceService(attendanceRepo, sessionRepo, breakRepo, timesheetRepo, userRepo, locationRepo, cfg.Attendance)
+	breakService := usecase.NewBreakService(breakRepo, breakTypeRepo, attendanceRepo, cfg.Attendance)
+	breakTypeService := usecase.NewBreakTypeService(breakTypeRepo, breakRepo)
 	leaveService := usecase.NewLeaveService(leaveRepo, userRepo)
 	leaveTypeService := usecase.NewLeaveTypeService(leaveTypeRepo)
+	locationService := usecase.NewLocationService(locationRepo)
+	holidayService := usecase.NewHolidayService(holidayRepo, locationRepo)
+	absenceService := usecase.NewAbsenceMarkingService(attendanceRepo, timesheetRepo, userRepo, leaveRepo, holidayRepo, locationRepo, cfg.Attendance)
+	overtimeService := usecase.NewOvertimeService(overtimeEntryRepo, overtimeRequestRepo, attendanceRepo, holidayRepo, userRepo, locationRepo, cfg.Attendance, cfg.Overtime)
+	autoCloseService := usecase.NewAttendanceAutoCloseService(attendanceRepo, sessionRepo, breakRepo, cfg.Attendance)
+	regularizationService := usecase.NewAttendanceRegularizationService(regularizationRepo, attendanceRepo, sessionRepo, breakRepo, timesheetRepo, userRepo, locationRepo, cfg.Attendance)
+	timesheetService := usecase.NewTimesheetService(timesheetRepo, attendanceRepo, userRepo, locationRepo, cfg.Attendance, cfg.Overtime.WeekStart)
+	projectService := usecase.NewProjectService(projectRepo, timeEntryRepo)
+	timeEntryService := usecase.NewTimeEntryService(timeEntryRepo, projectRepo, attendanceRepo)
+	kioskService := usecase.NewKioskService(kioskRepo, userRepo, locationRepo, attendanceService, breakService, cfg.Kiosk.PINPolicy)
+	punchImportService := usecase.NewPunchImportService(attendanceRepo, sessionRepo, breakRepo, timesheetRepo, userRepo, locationRepo, cfg.Attendance)
 
 	// Step 4: Create and return the container with all dependencies
 	return &Container{
-		Config:            cfg,
-		UserRepo:          userRepo,
-		UserService:       userService,
-		AttendanceRepo:    attendanceRepo,
-		BreakRepo:         breakRepo,
-		LeaveRepo:         leaveRepo,
-		LeaveTypeRepo:     leaveTypeRepo,
-		AttendanceService: attendanceService,
-		BreakService:      breakService,
-		LeaveService:      leaveService,
-		LeaveTypeService:  leaveTypeService,
+		Config:                 cfg,
+		UserRepo:               userRepo,
+		UserService:            userService,
+		AuthSessionRepo:        authSessionRepo,
+		AuthService:            authService,
+		SigningKeyRepo:         signingKeyRepo,
+		SigningKeyService:      signingKeyService,
+		AccountTokenRepo:       accountTokenRepo,
+		SignInAttemptRepo:      signInAttemptRepo,
+		MailSender:             mailSender,
+		AccountService:         accountService,
+		TOTPCredentialRepo:     totpCredentialRepo,
+		TwoFactorChallengeRepo: twoFactorChallengeRepo,
+		TwoFactorService:       twoFactorService,
+		OIDCIdentityRepo:       oidcIdentityRepo,
+		OIDCLoginStateRepo:     oidcLoginStateRepo,
+		OIDCService:            oidcService,
+		Directory:              userDirectory,
+		DirectorySyncService:   directorySyncService,
+		SCIMService:            scimService,
+		APITokenRepo:           apiTokenRepo,
+		APITokenService:        apiTokenService,
+		AttendanceRepo:         attendanceRepo,
+		SessionRepo:            sessionRepo,
+		BreakRepo:              breakRepo,
+		BreakTypeRepo:          breakTypeRepo,
+		LeaveRepo:              leaveRepo,
+		LeaveTypeRepo:          leaveTypeRepo,
+		LocationRepo:           locationRepo,
+		HolidayRepo:            holidayRepo,
+		OvertimeEntryRepo:      overtimeEntryRepo,
+		OvertimeRequestRepo:    overtimeRequestRepo,
+		RegularizationRepo:     regularizationRepo,
+		TimesheetRepo:          timesheetRepo,
+		ProjectRepo:            projectRepo,
+		TimeEntryRepo:          timeEntryRepo,
+		KioskRepo:              kioskRepo,
+		AttendanceService:      attendanceService,
+		BreakService:           breakService,
+		BreakTypeService:       breakTypeService,
+		LeaveService:           leaveService,
+		LeaveTypeService:       leaveTypeService,
+		LocationService:        locationService,
+		RegularizationService:  regularizationService,
+		HolidayService:         holidayService,
+		OvertimeService:        overtimeService,
+		TimesheetService:       timesheetService,
+		ProjectService:         projectService,
+		TimeEntryService:       timeEntryService,
+		KioskService:           kioskService,
+		PunchImportService:     punchImportService,
+		AutoCloseService:       autoCloseService,
+		AbsenceService:         absenceService,
+	}
+}
+
+// newMailSender creates the mail sender selected by MAIL_DRIVER
+func newMailSender(cfg config.MailConfig) domain.MailSenderInterface {
+	switch cfg.Driver {
+	case "smtp":
+		return mail.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "file":
+		return mail.NewFileSender(cfg.Dir, cfg.From)
+	default:
+		return mail.NewLogSender()
 	}
 }
 
+// newDirectory creates the directory selected by LDAP_URL, or returns nil when none is configured
+func newDirectory(cfg config.LDAPConfig) domain.DirectoryInterface {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if path, ok := strings.CutPrefix(cfg.URL, "file://"); ok {
+		return directory.NewFileDirectory(path)
+	}
+	return directory.NewLDAPDirectory(directory.LDAPOptions{
+		URL:                cfg.URL,
+		StartTLS:           cfg.StartTLS,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		BindDN:             cfg.BindDN,
+		BindPassword:       cfg.BindPassword,
+		BaseDN:             cfg.BaseDN,
+		UserFilter:         cfg.UserFilter,
+		LoginAttribute:     cfg.LoginAttribute,
+		EmailAttribute:     cfg.EmailAttribute,
+		NameAttribute:      cfg.NameAttribute,
+		ManagerAttribute:   cfg.ManagerAttribute,
+		DisabledAttribute:  cfg.DisabledAttribute,
+		Timeout:            cfg.Timeout,
+	})
+}
+
+// newOIDCService creates the single sign-on service, or returns nil when no identity provider is configured
+func newOIDCService(
+	cfg config.OIDCConfig,
+	identityRepo domain.OIDCIdentityRepositoryInterface,
+	stateRepo domain.OIDCLoginStateRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	signInAttemptRepo domain.SignInAttemptRepositoryInterface,
+) domain.OIDCServiceInterface {
+	if !cfg.Enabled() {
+		return nil
+	}
+	provider := oidc.NewProvider(cfg.Issuer, cfg.ClientID, cfg.ClientSecret, cfg.RedirectURL, cfg.Scopes, cfg.GroupsClaim)
+	return usecase.NewOIDCService(provider, identityRepo, stateRepo, userRepo, signInAttemptRepo, cfg.GroupRoles, cfg.AutoProvision, cfg.StateTTL)
+}
+
 // SetupRoutes configures all HTTP routes for the application.
 // This function sets up the routing structure and connects HTTP endpoints
 // to their corresponding handlers. It organizes routes into logical groups:
@@ -85,6 +275,20 @@ func NewContainer() *Container {
 // - Break management routes
 // - Leave management routes
 // - Leave type management routes
+// - Location management routes
+// - Attendance regularization routes
+// - Holiday management routes
+// - Overtime routes
+// - Timesheet routes
+// - Project and time entry routes
+// - Kiosk management and kiosk device routes
+// - Punch log import routes
+// - Break type routes
+// - JWKS route
+// - OpenID Connect single sign-on routes, when an identity provider is configured
+// - Directory sync route, when an LDAP directory is configured
+// - SCIM 2.0 provisioning routes, when a SCIM token is configured
+// - Personal access token and service account routes
 //
 // Parameters:
 //   - router: The Gin router instance to configure
@@ -94,8 +298,20 @@ func (c *Container) SetupRoutes(router *gin.Engine) {
 	routes.SetupHealthRoutes(router)
 
 	// Step 2: Setup user management routes
-	// These routes handle all user-related operations (CRUD, authentication)
-	routes.SetupUserRoutes(router, c.UserService, c.AttendanceService)
+	// These routes handle all user-related operations (CRUD, authentication, sessions,
+	// password reset, email verification and two-factor authentication); access tokens are verified with the current
+	// signing keys, and those of revoked sessions are rejected on every protected route;
+	// administrative routes check the user's role; API tokens are accepted where their scopes allow.
+	// The middleware checks are registered here, once, before the server accepts requests.
+	middleware.SetKeyResolver(c.SigningKeyService)
+	middleware.SetSessionValidator(c.AuthService)
+	middleware.SetAPITokenAuthenticator(c.APITokenService)
+	middleware.SetRoleResolver(c.UserService)
+	if c.Config.Account.RequireVerifiedEmail {
+		middleware.SetEmailVerifier(c.AccountService)
+	}
+	routes.SetupUserRoutes(router, c.UserService, c.AuthService, c.AccountService, c.TwoFactorService, c.AttendanceService)
+	routes.SetupTwoFactorRoutes(router, c.TwoFactorService, c.AuthService, c.AttendanceService)
 
 	// Step 3: Setup attendance management routes
 	// These routes handle all attendance-related operations (check-in, check-out)
@@ -113,4 +329,66 @@ func (c *Container) SetupRoutes(router *gin.Engine) {
 	// These routes handle all leave type-related operations (CRUD for leave types)
 	leaveTypeHandler := handler.NewLeaveTypeHandler(c.LeaveTypeService)
 	routes.SetupLeaveTypeRoutes(router, leaveTypeHandler)
+
+	// Step 7: Setup location management routes
+	// These routes handle office/site definitions and their time zones
+	routes.SetupLocationRoutes(router, c.LocationService)
+
+	// Step 8: Setup attendance regularization routes
+	// These routes handle correction requests for missed or wrong punches and their approval
+	routes.SetupAttendanceRegularizationRoutes(router, c.RegularizationService)
+
+	// Step 9: Setup holiday management routes
+	// These routes handle the holiday calendar used when marking absences
+	routes.SetupHolidayRoutes(router, c.HolidayService)
+
+	// Step 10: Setup overtime routes
+	// These routes handle the overtime ledger and its pre- and post-facto approval
+	routes.SetupOvertimeRoutes(router, c.OvertimeService)
+
+	// Step 11: Setup timesheet routes
+	// These routes handle weekly timesheet submission and approval
+	routes.SetupTimesheetRoutes(router, c.TimesheetService)
+
+	// Step 12: Setup project routes
+	// These routes handle projects, time allocation on attendance days and utilization reports
+	routes.SetupProjectRoutes(router, c.ProjectService, c.TimeEntryService)
+
+	// Step 13: Setup kiosk routes
+	// These routes handle shared kiosk devices and the punches employees make on them
+	routes.SetupKioskRoutes(router, c.KioskService, c.Config.Kiosk.RateLimit, c.Config.Kiosk.RateWindow)
+
+	// Step 14: Setup punch import routes
+	// These routes handle importing punch logs exported by hardware time clocks
+	routes.SetupPunchImportRoutes(router, c.PunchImportService)
+
+	// Step 15: Setup break type routes
+	// These routes handle break types and the paid status and limits that apply to them
+	routes.SetupBreakTypeRoutes(router, c.BreakTypeService)
+
+	// Step 16: Setup JWKS route
+	// This route publishes the public keys other services verify HRM access tokens with
+	routes.SetupJWKSRoutes(router, c.SigningKeyService)
+
+	// Step 17: Setup single sign-on routes
+	// These routes sign users in through the configured OpenID Connect provider
+	if c.OIDCService != nil {
+		routes.SetupOIDCRoutes(router, c.OIDCService, c.TwoFactorService, c.AuthService, c.AttendanceService)
+	}
+
+	// Step 18: Setup directory sync route
+	// This route lets admins sync users from the LDAP directory, or preview the changes with a dry run
+	if c.DirectorySyncService != nil {
+		routes.SetupDirectoryRoutes(router, c.DirectorySyncService)
+	}
+
+	// Step 19: Setup SCIM routes
+	// These routes let identity providers provision users and assign roles through SCIM 2.0
+	if c.SCIMService != nil {
+		routes.SetupSCIMRoutes(router, c.SCIMService)
+	}
+
+	// Step 20: Setup API token routes
+	// These routes let users create personal access tokens and admins manage service accounts for integrations
+	routes.SetupAPITokenRoutes(router, c.APITokenService)
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hrm/domain"
+)
+
+// runSyncDirectory syncs users from the LDAP directory and prints what changed.
+// With -dry-run it prints the changes a sync would make as a diff, without saving anything.
+// It exits with 1 when entries could not be synced so scripts can alert on them.
+func runSyncDirectory(container *Container, args []string) int {
+	flags := flag.NewFlagSet("sync-directory", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would change without saving anything")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if container.DirectorySyncService == nil {
+		fmt.Fprintln(os.Stderr, "sync-directory: no directory configured, set LDAP_URL")
+		return 2
+	}
+
+	report, err := container.DirectorySyncService.Sync(*dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sync-directory: %v\n", err)
+		return 1
+	}
+
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was saved")
+	}
+	printDirectoryChanges("+", report.Created)
+	printDirectoryChanges("~", report.Updated)
+	printDirectoryChanges("-", report.Deactivated)
+
+	fmt.Printf("Entries read: %d\n", report.Entries)
+	fmt.Printf("Created:      %d\n", len(report.Created))
+	fmt.Printf("Updated:      %d\n", len(report.Updated))
+	fmt.Printf("Deactivated:  %d\n", len(report.Deactivated))
+	fmt.Printf("Unchanged:    %d\n", report.Unchanged)
+	if len(report.Issues) == 0 {
+		return 0
+	}
+
+	fmt.Printf("Issues:       %d\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		fmt.Printf("  %s: %s\n", issue.DN, issue.Message)
+	}
+	return 1
+}
+
+// printDirectoryChanges prints each user with a marker (+ created, ~ updated, - deactivated)
+// followed by the fields that change
+func printDirectoryChanges(marker string, changes []domain.DirectoryUserChange) {
+	for _, change := range changes {
+		if change.UserID != 0 {
+			fmt.Printf("%s %s (user %d, %s)\n", marker, change.Email, change.UserID, change.DN)
+		} else {
+			fmt.Printf("%s %s (%s)\n", marker, change.Email, change.DN)
+		}
+		for _, field := range change.Changes {
+			fmt.Printf("    %-12s %q -> %q\n", field.Field, field.From, field.To)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hrm/domain"
+)
+
+// runCommand runs a one-off command given on the command line instead of starting the server.
+// It returns the process exit code.
+//
+// Parameters:
+//   - container: The initialized dependency container
+//   - args: The command line arguments after the program name
+//
+// Returns:
+//   - int: 0 on success, 1 if the command failed, 2 on usage errors
+func runCommand(container *Container, args []string) int {
+	switch args[0] {
+	case "import-punches":
+		return runImportPunches(container, args[1:])
+	case "set-role":
+		return runSetRole(container, args[1:])
+	case "sync-directory":
+		return runSyncDirectory(container, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\nusage: hrm [import-punches -file <log> [-format csv|json] [-map field=column,...] [-dry-run]]\n"+
+			"       hrm [set-role -email <email> -role employee|admin|security_admin]\n"+
+			"       hrm [sync-directory [-dry-run]]\n", args[0])
+		return 2
+	}
+}
+
+// runImportPunches imports a time clock punch log and prints the import report.
+// It exits with 1 when rows could not be imported so scripts can alert on partial imports.
+func runImportPunches(container *Container, args []string) int {
+	flags := flag.NewFlagSet("import-punches", flag.ContinueOnError)
+	file := flags.String("file", "", "punch log to import (\"-\" reads standard input)")
+	format := flags.String("format", "", "log format: csv or json (default: from the file extension, else csv)")
+	mapping := flags.String("map", "", "columns holding the punch fields, e.g. employee_code=EmpNo,timestamp=PunchTime")
+	dryRun := flags.Bool("dry-run", false, "report what would be imported without saving anything")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "import-punches: -file is required")
+		flags.Usage()
+		return 2
+	}
+
+	options := domain.PunchImportOptions{Format: *format, DryRun: *dryRun}
+	if options.Format == "" {
+		options.Format = domain.PunchLogFormatCSV
+		if strings.EqualFold(filepath.Ext(*file), ".json") {
+			options.Format = domain.PunchLogFormatJSON
+		}
+	}
+	for _, pair := range strings.Split(*mapping, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "import-punches: invalid mapping %q, expected field=column\n", pair)
+			return 2
+		}
+		if err := options.Mapping.Set(strings.TrimSpace(field), strings.TrimSpace(column)); err != nil {
+			fmt.Fprintf(os.Stderr, "import-punches: %v\n", err)
+			return 2
+		}
+	}
+
+	var log io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import-punches: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		log = f
+	}
+
+	report, err := container.PunchImportService.ImportPunchLog(log, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import-punches: %v\n", err)
+		return 1
+	}
+
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was saved")
+	}
+	fmt.Printf("Rows read:        %d\n", report.Rows)
+	fmt.Printf("Duplicates:       %d\n", report.Duplicates)
+	fmt.Printf("Sessions created: %d\n", report.SessionsCreated)
+	fmt.Printf("Sessions closed:  %d\n", report.SessionsClosed)
+	fmt.Printf("Sessions opened:  %d\n", report.SessionsOpened)
+	fmt.Printf("Attendance days:  %d\n", report.AttendanceDays)
+	if len(report.Issues) == 0 {
+		return 0
+	}
+
+	fmt.Printf("Issues:           %d\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		code := issue.EmployeeCode
+		if code == "" {
+			code = "-"
+		}
+		fmt.Printf("  line %d (%s): %s\n", issue.Line, code, issue.Message)
+	}
+	return 1
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
+	_ "time/tzdata" // Embed the time zone database so employee zones resolve in minimal containers
 
 	"hrm/handler"
 
@@ -12,16 +14,23 @@ import (
 // main is the entry point of the HRM application.
 // This function orchestrates the entire application startup process including:
 // 1. Loading configuration and establishing database connection
-// 2. Setting up dependency injection container
+// 2. Setting up dependency injection container, or running a one-off command if one is given
 // 3. Configuring HTTP server with middleware
 // 4. Setting up API routes
-// 5. Starting the HTTP server
+// 5. Starting background jobs
+// 6. Starting the HTTP server
 func main() {
 	// Step 1: Initialize dependency injection container
 	// This creates all the necessary dependencies (repositories, services, handlers)
 	// and establishes the database connection
 	container := NewContainer()
 
+	// Run a one-off command instead of the server when one is given,
+	// e.g. "hrm import-punches -file punches.csv"
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(container, os.Args[1:]))
+	}
+
 	// Step 2: Configure Gin framework mode
 	// Set to release mode when running on production host for better performance
 	if container.Config.Server.Host == "0.0.0.0" {
@@ -34,7 +43,10 @@ func main() {
 	// - CORS handling
 	// - Error recovery
 	// - Custom error handling
-	router := gin.New()
+	router, err := newRouter(container.Config.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	router.Use(handler.Recovery())       // Recover from panics
 	router.Use(handler.Logger())         // Log all requests
 	router.Use(handler.CORSMiddleware()) // Handle CORS
@@ -44,7 +56,11 @@ func main() {
 	// Configure all the HTTP endpoints for the application
 	container.SetupRoutes(router)
 
-	// Step 5: Start the HTTP server
+	// Step 5: Start background jobs
+	// Periodic maintenance such as closing forgotten check-outs
+	container.StartScheduler()
+
+	// Step 6: Start the HTTP server
 	// Build the server address and start listening for requests
 	serverAddr := fmt.Sprintf("%s:%s", container.Config.Server.Host, container.Config.Server.Port)
 	log.Printf("Server starting on %s", serverAddr)
@@ -54,3 +70,14 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// newRouter creates the Gin router without any middleware.
+// Only the given proxies may set the client IP through X-Forwarded-For,
+// which location network restrictions rely on.
+func newRouter(trustedProxies []string) (*gin.Engine, error) {
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	return router, nil
+}
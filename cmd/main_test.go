@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hrm/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewRouterResolvesClientIPThroughTrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	location := &domain.Location{AllowedNetworks: "203.0.113.0/24"}
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		wantAllowed    bool
+	}{
+		{"direct request from an allowed network", nil, "203.0.113.5:4000", "", true},
+		{"direct request from another network", nil, "198.51.100.7:4000", "", false},
+		{"forwarded header ignored without trusted proxies", nil, "198.51.100.7:4000", "203.0.113.5", false},
+		{"forwarded header of an untrusted peer ignored", []string{"10.0.0.1"}, "198.51.100.7:4000", "203.0.113.5", false},
+		{"trusted proxy forwarding an allowed client", []string{"10.0.0.0/8"}, "10.0.0.1:4000", "203.0.113.5", true},
+		{"trusted proxy forwarding another client", []string{"10.0.0.0/8"}, "10.0.0.1:4000", "198.51.100.7", false},
+		{"client-supplied entries before the proxy are ignored", []string{"10.0.0.0/8"}, "10.0.0.1:4000", "203.0.113.5, 198.51.100.7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := newRouter(tt.trustedProxies)
+			if err != nil {
+				t.Fatalf("newRouter: %v", err)
+			}
+			router.GET("/punch", func(c *gin.Context) {
+				err := domain.AttendancePolicy{}.CheckNetwork(location, domain.Punch{IPAddress: c.ClientIP()})
+				if err != nil {
+					c.Status(http.StatusForbidden)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/punch", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if allowed := rec.Code == http.StatusOK; allowed != tt.wantAllowed {
+				t.Fatalf("allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestNewRouterRejectsInvalidTrustedProxies(t *testing.T) {
+	if _, err := newRouter([]string{"not-a-network"}); err == nil {
+		t.Fatal("newRouter accepted an invalid trusted proxy")
+	}
+}
@@ -0,0 +1,347 @@
+// Command mockoidc is a minimal OpenID Connect provider for trying out and testing HRM single sign-on locally.
+// Every sign-in shows a form where any email, name and groups can be entered; nothing is checked.
+//
+//	go run ./cmd/mockoidc -addr :9000 -client-id hrm -client-secret secret
+//
+// and start HRM with OIDC_ISSUER=http://localhost:9000, OIDC_CLIENT_ID=hrm and OIDC_CLIENT_SECRET=secret.
+// Never expose it beyond your machine.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// keyID names the provider's only signing key
+	keyID = "mockoidc-1"
+
+	// codeTTL is how long an authorization code can be redeemed
+	codeTTL = time.Minute
+
+	// tokenTTL is the lifetime of issued ID and access tokens
+	tokenTTL = time.Hour
+)
+
+// identity is what was entered on the sign-in form
+type identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// authorization is an issued authorization code waiting to be redeemed
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	identity      identity
+	expiresAt     time.Time
+}
+
+// provider holds the signing key and the codes and access tokens issued so far
+type provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu           sync.Mutex
+	codes        map[string]authorization
+	accessTokens map[string]identity
+}
+
+var signInForm = template.Must(template.New("signin").Parse(`<!doctype html>
+<title>Mock OIDC sign-in</title>
+<h1>Mock OIDC sign-in</h1>
+<form method="post" action="/authorize">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<p><label>Email <input name="email" value="jane@example.com" required></label></p>
+<p><label>Name <input name="name" value="Jane Doe"></label></p>
+<p><label>Subject <input name="sub" placeholder="defaults to the email"></label></p>
+<p><label>Groups <input name="groups" placeholder="comma separated, e.g. hr-admins"></label></p>
+<p><label><input type="checkbox" name="email_verified" value="true" checked> Email verified</label></p>
+<p><button type="submit">Sign in</button></p>
+</form>
+`))
+
+func main() {
+	addr := flag.String("addr", ":9000", "address to listen on")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL the provider is reached at")
+	clientID := flag.String("client-id", "hrm", "client ID the provider accepts")
+	clientSecret := flag.String("client-secret", "", "client secret; leave empty for a public client")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+
+	p := &provider{
+		issuer:       strings.TrimSuffix(*issuer, "/"),
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		key:          key,
+		codes:        make(map[string]authorization),
+		accessTokens: make(map[string]identity),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/userinfo", p.userinfo)
+	mux.HandleFunc("/jwks", p.jwks)
+
+	log.Printf("Mock OIDC provider %s listening on %s (client %q)", p.issuer, *addr, p.clientID)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// discovery serves the OpenID Connect discovery document
+func (p *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"userinfo_endpoint":                     p.issuer + "/userinfo",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "profile", "email", "groups"},
+	})
+}
+
+// authorize shows the sign-in form (GET) and redirects back with a code once it is submitted (POST)
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	params := map[string]string{}
+	for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+		params[name] = r.Form.Get(name)
+	}
+
+	// Errors about the client or redirect URI are shown rather than redirected, as the spec requires
+	if params["client_id"] != p.clientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(params["redirect_uri"])
+	if err != nil || params["redirect_uri"] == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if params["response_type"] != "code" || params["code_challenge"] == "" || params["code_challenge_method"] != "S256" {
+		redirectWith(w, r, redirectURI, url.Values{"error": {"invalid_request"}, "state": {params["state"]}})
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		signInForm.Execute(w, map[string]interface{}{"Params": params})
+		return
+	}
+
+	user := identity{
+		Subject:       strings.TrimSpace(r.Form.Get("sub")),
+		Email:         strings.TrimSpace(r.Form.Get("email")),
+		EmailVerified: r.Form.Get("email_verified") == "true",
+		Name:          strings.TrimSpace(r.Form.Get("name")),
+		Groups:        []string{},
+	}
+	if user.Subject == "" {
+		user.Subject = user.Email
+	}
+	for _, group := range strings.Split(r.Form.Get("groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			user.Groups = append(user.Groups, group)
+		}
+	}
+
+	code := randomToken()
+	p.mu.Lock()
+	p.codes[code] = authorization{
+		clientID:      params["client_id"],
+		redirectURI:   params["redirect_uri"],
+		codeChallenge: params["code_challenge"],
+		nonce:         params["nonce"],
+		identity:      user,
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	p.mu.Unlock()
+
+	log.Printf("Issued code for %q", user.Subject)
+	redirectWith(w, r, redirectURI, url.Values{"code": {code}, "state": {params["state"]}})
+}
+
+// token redeems an authorization code for an ID token and access token
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		tokenError(w, http.StatusMethodNotAllowed, "invalid_request", "use POST")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request", "unreadable form")
+		return
+	}
+
+	// Step 1: Authenticate the client with client_secret_basic or client_secret_post
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.Form.Get("client_id"), r.Form.Get("client_secret")
+	}
+	if clientID != p.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1 {
+		tokenError(w, http.StatusUnauthorized, "invalid_client", "unknown client or wrong secret")
+		return
+	}
+
+	// Step 2: Use up the code and check it was issued to this client and redirect URI
+	if r.Form.Get("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+	code := r.Form.Get("code")
+	p.mu.Lock()
+	auth, found := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+	if !found || time.Now().After(auth.expiresAt) || auth.clientID != clientID || auth.redirectURI != r.Form.Get("redirect_uri") {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "unknown, used or expired code")
+		return
+	}
+
+	// Step 3: Check the PKCE verifier against the challenge of the authorization request
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "code_verifier does not match the code_challenge")
+		return
+	}
+
+	// Step 4: Sign the ID token and hand out an access token for the userinfo endpoint
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            auth.identity.Subject,
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(tokenTTL).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.identity.Email,
+		"email_verified": auth.identity.EmailVerified,
+		"name":           auth.identity.Name,
+		"groups":         auth.identity.Groups,
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+
+	accessToken := randomToken()
+	p.mu.Lock()
+	p.accessTokens[accessToken] = auth.identity
+	p.mu.Unlock()
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenTTL.Seconds()),
+		"id_token":     signed,
+	})
+}
+
+// userinfo returns the claims of the user an access token was issued for
+func (p *provider) userinfo(w http.ResponseWriter, r *http.Request) {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	p.mu.Lock()
+	user, found := p.accessTokens[accessToken]
+	p.mu.Unlock()
+	if !found {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, "invalid access token", http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+		"groups":         user.Groups,
+	})
+}
+
+// jwks publishes the public half of the signing key
+func (p *provider) jwks(w http.ResponseWriter, r *http.Request) {
+	publicKey := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// redirectWith sends the browser back to the client with the given query parameters added
+func redirectWith(w http.ResponseWriter, r *http.Request, target *url.URL, params url.Values) {
+	redirect := *target
+	query := redirect.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// tokenError writes an OAuth 2.0 error response
+func tokenError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
+
+// writeJSON writes value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// randomToken returns a random hex string for codes and access tokens
+func randomToken() string {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("Failed to generate random token: %v", err)
+	}
+	return hex.EncodeToString(buf)
+}
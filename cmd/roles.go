@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runSetRole assigns a role to the user with the given email.
+// It is how the first admin is created, since assigning roles through the API requires an admin.
+func runSetRole(container *Container, args []string) int {
+	flags := flag.NewFlagSet("set-role", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	role := flags.String("role", "", "role to assign: employee, admin or security_admin")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *email == "" || *role == "" {
+		fmt.Fprintln(os.Stderr, "set-role: -email and -role are required")
+		flags.Usage()
+		return 2
+	}
+
+	user, err := container.UserRepo.GetByEmail(*email)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "set-role: %v\n", err)
+		return 1
+	}
+
+	user, err = container.UserService.SetUserRole(user.ID, *role)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "set-role: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("%s (user %d) is now %s\n", user.Email, user.ID, user.Role)
+	return 0
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// StartScheduler launches the background jobs of the application.
+// Each job runs in its own goroutine on a fixed interval; a job with a zero
+// interval is disabled.
+func (c *Container) StartScheduler() {
+	// Close check-outs employees forgot once their shift is well over
+	runPeriodically("auto-close check-outs", c.Config.Attendance.AutoCloseInterval, func() error {
+		closed, err := c.AutoCloseService.CloseForgottenCheckOuts(time.Now().UTC())
+		if closed > 0 {
+			log.Printf("Auto-closed %d open attendance(s)", closed)
+		}
+		return err
+	})
+
+	// Record absences for past working days nobody checked in on
+	runPeriodically("mark absences", c.Config.Attendance.AbsenceInterval, func() error {
+		created, err := c.AbsenceService.MarkAbsences(time.Now().UTC())
+		if created > 0 {
+			log.Printf("Recorded %d absence/leave day(s)", created)
+		}
+		return err
+	})
+
+	// Keep the overtime ledger in step with recent attendance
+	runPeriodically("recalculate overtime", c.Config.Overtime.Interval, func() error {
+		changed, err := c.OvertimeService.RecalculateRecent(time.Now().UTC())
+		if changed > 0 {
+			log.Printf("Updated %d overtime ledger entry(ies)", changed)
+		}
+		return err
+	})
+
+	// Replace the access token signing key once it reaches its rotation age
+	runPeriodically("rotate signing keys", c.Config.Auth.KeyCheckInterval, func() error {
+		_, err := c.SigningKeyService.RotateKeys(time.Now().UTC())
+		return err
+	})
+
+	// Create, update and deactivate users to match the LDAP directory
+	if c.DirectorySyncService != nil {
+		runPeriodically("sync directory users", c.Config.LDAP.SyncInterval, func() error {
+			_, err := c.DirectorySyncService.Sync(false)
+			return err
+		})
+	}
+}
+
+// runPeriodically runs job every interval until the process exits.
+// Errors are logged and do not stop the schedule.
+func runPeriodically(name string, interval time.Duration, job func() error) {
+	if interval <= 0 {
+		log.Printf("Job %q disabled", name)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(); err != nil {
+				log.Printf("Job %q failed: %v", name, err)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -4,6 +4,10 @@ import (
 	"hrm/domain"
 	"log"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
@@ -14,15 +18,122 @@ import (
 // This struct centralizes all configuration data including database connection,
 // server settings, and other environment-specific configurations.
 type Config struct {
-	DB     *gorm.DB     // Database connection instance
-	Server ServerConfig // Server configuration settings
+	DB         *gorm.DB                // Database connection instance
+	Server     ServerConfig            // Server configuration settings
+	Attendance domain.AttendancePolicy // Attendance and break rules
+	Overtime   domain.OvertimePolicy   // Overtime thresholds, multipliers and pay periods
+	Kiosk      KioskConfig             // Shared kiosk device settings
+	Auth       AuthConfig              // Access and refresh token lifetimes and signing keys
+	Account    AccountConfig           // Password reset and email verification settings
+	Password   domain.PasswordPolicy   // Rules for new passwords
+	Mail       MailConfig              // How outgoing emails are delivered
+	OIDC       OIDCConfig              // Single sign-on through an OpenID Connect provider
+	LDAP       LDAPConfig              // Sign-in against and user sync from an LDAP directory
+	SCIM       SCIMConfig              // User and role provisioning by identity providers through SCIM 2.0
 }
 
 // ServerConfig holds server-specific configuration settings.
 // This struct contains all the settings needed to configure the HTTP server.
 type ServerConfig struct {
-	Port string // Server port (e.g., "8080")
-	Host string // Server host (e.g., "0.0.0.0" for all interfaces)
+	Port           string   // Server port (e.g., "8080")
+	Host           string   // Server host (e.g., "0.0.0.0" for all interfaces)
+	TrustedProxies []string // Proxies whose X-Forwarded-For header is trusted for the client IP
+}
+
+// KioskConfig holds settings for shared kiosk devices.
+type KioskConfig struct {
+	RateLimit  int                   // Requests one kiosk may make per window (0 disables the limit)
+	RateWindow time.Duration         // Length of the rate limit window
+	PINPolicy  domain.KioskPINPolicy // Lockout of employee codes after wrong PINs
+}
+
+// AuthConfig holds settings for sign-in sessions.
+type AuthConfig struct {
+	AccessTokenTTL   time.Duration       // Lifetime of access tokens; revocation takes effect on the next request regardless
+	RefreshTokenTTL  time.Duration       // How long a session stays signed in without being used
+	SigningAlg       string              // Access token algorithm: HS256 (JWT_SECRET), RS256 or EdDSA
+	KeyRotation      time.Duration       // Age at which RS256/EdDSA signing keys are replaced (0 disables rotation)
+	KeyCheckInterval time.Duration       // How often the rotation job checks the signing key's age
+	SignIn           domain.SignInPolicy // Lockout and throttling of failed sign-in attempts
+	TOTPIssuer       string              // Name authenticator apps show next to the account
+	TwoFactorRoles   []string            // Roles that must use two-factor authentication; everyone else may opt in
+	TwoFactorTTL     time.Duration       // How long a sign-in waits for the two-factor code
+	APITokenTTL      time.Duration       // Lifetime of API tokens created without an expiry
+	APITokenMaxTTL   time.Duration       // Longest lifetime an API token can be created with
+}
+
+// AccountConfig holds settings for password reset and email verification.
+type AccountConfig struct {
+	AppBaseURL           string        // Web app URL the links in emails point to
+	PasswordResetTTL     time.Duration // How long a password reset link can be used
+	EmailVerificationTTL time.Duration // How long an email verification link can be used
+	RequireVerifiedEmail bool          // Block requesting and reviewing leave, overtime, timesheets and corrections until the email is verified
+}
+
+// MailConfig holds settings for outgoing emails.
+type MailConfig struct {
+	Driver       string // "log" writes emails to the log, "file" to .eml files in Dir, "smtp" sends them
+	From         string // Sender address, e.g. "HRM <no-reply@example.com>"
+	Dir          string // Directory for the file driver
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string // Leave empty for servers without authentication
+	SMTPPassword string
+}
+
+// OIDCConfig holds settings for single sign-on through an OpenID Connect provider.
+// Single sign-on is enabled when an issuer is set.
+type OIDCConfig struct {
+	Issuer        string            // Provider URL the discovery document is fetched from
+	ClientID      string            // Client registered for HRM at the provider
+	ClientSecret  string            // Leave empty for a public client that relies on PKCE alone
+	RedirectURL   string            // Callback URL registered at the provider
+	Scopes        []string          // Scopes requested; "openid" is always included
+	GroupsClaim   string            // ID token or userinfo claim listing the user's groups
+	GroupRoles    map[string]string // Provider group to HRM role; without it roles are managed in HRM
+	AutoProvision bool              // Create accounts for unknown users instead of refusing them
+	StateTTL      time.Duration     // How long a sign-in at the provider may take
+}
+
+// Enabled returns true if single sign-on is configured
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
+}
+
+// LDAPConfig holds settings for the LDAP directory users sign in with and are synced from.
+// The directory is used when a URL is set.
+type LDAPConfig struct {
+	URL                string        // ldap:// or ldaps:// server URL, or file:// JSON file of entries for local testing
+	StartTLS           bool          // Upgrade ldap:// connections with StartTLS
+	InsecureSkipVerify bool          // Accept any server certificate; only for testing
+	BindDN             string        // Service account the directory is searched with; empty binds anonymously
+	BindPassword       string        // Password of the service account
+	BaseDN             string        // Subtree users are searched in
+	UserFilter         string        // LDAP filter matching the users to sync
+	LoginAttribute     string        // Attribute the email users sign in with is matched against
+	EmailAttribute     string        // Attribute holding the email address
+	NameAttribute      string        // Attribute holding the full name
+	ManagerAttribute   string        // Attribute holding the DN of the user's manager
+	DisabledAttribute  string        // Attribute marking disabled accounts, e.g. userAccountControl or nsAccountLock
+	Timeout            time.Duration // Limit for connecting and for each request
+	SyncInterval       time.Duration // How often users are synced (0 disables the job)
+}
+
+// Enabled returns true if an LDAP directory is configured
+func (c LDAPConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// SCIMConfig holds settings for the SCIM 2.0 provisioning endpoint.
+// The endpoint is served when a token is set.
+type SCIMConfig struct {
+	Token   string // Bearer token SCIM clients authenticate with
+	BaseURL string // Public URL of the SCIM endpoint, used in resource locations
+}
+
+// Enabled returns true if SCIM provisioning is configured
+func (c SCIMConfig) Enabled() bool {
+	return c.Token != ""
 }
 
 // LoadConfig loads and initializes all application configuration.
@@ -48,12 +159,258 @@ func LoadConfig() *Config {
 	return &Config{
 		DB: db,
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Host:           getEnv("SERVER_HOST", "0.0.0.0"),
+			TrustedProxies: getEnvList("TRUSTED_PROXIES", nil),
 		},
+		Attendance: loadAttendancePolicy(),
+		Overtime:   loadOvertimePolicy(),
+		Kiosk: KioskConfig{
+			RateLimit:  getEnvInt("KIOSK_RATE_LIMIT", 30),
+			RateWindow: getEnvDuration("KIOSK_RATE_WINDOW", time.Minute),
+			PINPolicy: domain.KioskPINPolicy{
+				MaxFailedAttempts: getEnvInt("KIOSK_PIN_MAX_FAILED_ATTEMPTS", 5),
+				LockoutDuration:   getEnvDuration("KIOSK_PIN_LOCKOUT_DURATION", 15*time.Minute),
+			},
+		},
+		Auth: loadAuthConfig(),
+		Account: AccountConfig{
+			AppBaseURL:           getEnv("APP_BASE_URL", "http://localhost:3000"),
+			PasswordResetTTL:     getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+			EmailVerificationTTL: getEnvDuration("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+			RequireVerifiedEmail: getEnvBool("REQUIRE_VERIFIED_EMAIL", false),
+		},
+		Mail:     loadMailConfig(),
+		Password: loadPasswordPolicy(),
+		OIDC:     loadOIDCConfig(),
+		LDAP:     loadLDAPConfig(),
+		SCIM:     loadSCIMConfig(),
 	}
 }
 
+// loadMailConfig builds the outgoing mail settings from environment variables.
+//
+// Returns:
+//   - MailConfig: Settings with defaults applied for unset variables
+func loadMailConfig() MailConfig {
+	mail := MailConfig{
+		Driver:       getEnv("MAIL_DRIVER", "log"),
+		From:         getEnv("MAIL_FROM", "HRM <no-reply@localhost>"),
+		Dir:          getEnv("MAIL_DIR", "tmp/mail"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+	}
+
+	switch mail.Driver {
+	case "log", "file", "smtp":
+	default:
+		log.Fatalf("Invalid MAIL_DRIVER %q: use log, file or smtp", mail.Driver)
+	}
+
+	return mail
+}
+
+// loadOIDCConfig builds the single sign-on settings from environment variables.
+// OIDC_GROUP_ROLES maps provider groups to roles, e.g. "hr-admins=admin,security=security_admin".
+//
+// Returns:
+//   - OIDCConfig: Settings with defaults applied for unset variables
+func loadOIDCConfig() OIDCConfig {
+	oidc := OIDCConfig{
+		Issuer:        getEnv("OIDC_ISSUER", ""),
+		ClientID:      getEnv("OIDC_CLIENT_ID", ""),
+		ClientSecret:  getEnv("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:   getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/users/oidc/callback"),
+		Scopes:        strings.Fields(getEnv("OIDC_SCOPES", "openid profile email")),
+		GroupsClaim:   getEnv("OIDC_GROUPS_CLAIM", "groups"),
+		GroupRoles:    map[string]string{},
+		AutoProvision: getEnvBool("OIDC_AUTO_PROVISION", true),
+		StateTTL:      getEnvDuration("OIDC_STATE_TTL", 10*time.Minute),
+	}
+	if !oidc.Enabled() {
+		return oidc
+	}
+
+	if oidc.ClientID == "" {
+		log.Fatal("OIDC_CLIENT_ID is required when OIDC_ISSUER is set")
+	}
+	if !slices.Contains(oidc.Scopes, "openid") {
+		oidc.Scopes = append([]string{"openid"}, oidc.Scopes...)
+	}
+	for _, entry := range getEnvList("OIDC_GROUP_ROLES", nil) {
+		group, role, found := strings.Cut(entry, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !found || group == "" || !domain.IsValidRole(role) {
+			log.Fatalf("Invalid group mapping %q in OIDC_GROUP_ROLES: use group=role with one of the known roles", entry)
+		}
+		oidc.GroupRoles[group] = role
+	}
+
+	return oidc
+}
+
+// loadLDAPConfig builds the LDAP directory settings from environment variables.
+//
+// Returns:
+//   - LDAPConfig: Settings with defaults applied for unset variables
+func loadLDAPConfig() LDAPConfig {
+	ldap := LDAPConfig{
+		URL:                getEnv("LDAP_URL", ""),
+		StartTLS:           getEnvBool("LDAP_START_TLS", false),
+		InsecureSkipVerify: getEnvBool("LDAP_INSECURE_SKIP_VERIFY", false),
+		BindDN:             getEnv("LDAP_BIND_DN", ""),
+		BindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+		BaseDN:             getEnv("LDAP_BASE_DN", ""),
+		UserFilter:         getEnv("LDAP_USER_FILTER", "(objectClass=person)"),
+		LoginAttribute:     getEnv("LDAP_LOGIN_ATTRIBUTE", "mail"),
+		EmailAttribute:     getEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+		NameAttribute:      getEnv("LDAP_NAME_ATTRIBUTE", "cn"),
+		ManagerAttribute:   getEnv("LDAP_MANAGER_ATTRIBUTE", "manager"),
+		DisabledAttribute:  getEnv("LDAP_DISABLED_ATTRIBUTE", ""),
+		Timeout:            getEnvDuration("LDAP_TIMEOUT", 10*time.Second),
+		SyncInterval:       getEnvDuration("LDAP_SYNC_INTERVAL", time.Hour),
+	}
+	if !ldap.Enabled() {
+		return ldap
+	}
+
+	switch {
+	case strings.HasPrefix(ldap.URL, "file://"):
+	case strings.HasPrefix(ldap.URL, "ldap://"), strings.HasPrefix(ldap.URL, "ldaps://"):
+		if ldap.BaseDN == "" {
+			log.Fatal("LDAP_BASE_DN is required when LDAP_URL is set")
+		}
+		if !strings.HasPrefix(ldap.UserFilter, "(") {
+			log.Fatalf("Invalid LDAP_USER_FILTER %q: the filter must be enclosed in parentheses", ldap.UserFilter)
+		}
+	default:
+		log.Fatalf("Invalid LDAP_URL %q: use ldap://, ldaps:// or file://", ldap.URL)
+	}
+
+	return ldap
+}
+
+// loadSCIMConfig builds the SCIM provisioning settings from environment variables.
+//
+// Returns:
+//   - SCIMConfig: Settings with defaults applied for unset variables
+func loadSCIMConfig() SCIMConfig {
+	scim := SCIMConfig{
+		Token:   getEnv("SCIM_TOKEN", ""),
+		BaseURL: strings.TrimSuffix(getEnv("SCIM_BASE_URL", "http://localhost:8080/scim/v2"), "/"),
+	}
+	if scim.Enabled() && len(scim.Token) < 32 {
+		log.Fatal("SCIM_TOKEN must be at least 32 characters long")
+	}
+	return scim
+}
+
+// loadAuthConfig builds the sign-in session settings from environment variables.
+//
+// Returns:
+//   - AuthConfig: Settings with defaults applied for unset variables
+func loadAuthConfig() AuthConfig {
+	auth := AuthConfig{
+		AccessTokenTTL:   getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:  getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		SigningAlg:       getEnv("JWT_SIGNING_ALG", domain.SigningAlgHS256),
+		KeyRotation:      getEnvDuration("JWT_KEY_ROTATION", 30*24*time.Hour),
+		KeyCheckInterval: getEnvDuration("JWT_KEY_CHECK_INTERVAL", time.Hour),
+		SignIn: domain.SignInPolicy{
+			MaxFailedAttempts:      getEnvInt("SIGNIN_MAX_FAILED_ATTEMPTS", 5),
+			LockoutDuration:        getEnvDuration("SIGNIN_LOCKOUT_DURATION", 15*time.Minute),
+			FailureWindow:          getEnvDuration("SIGNIN_FAILURE_WINDOW", 15*time.Minute),
+			MaxFailedAttemptsPerIP: getEnvInt("SIGNIN_MAX_FAILED_PER_IP", 20),
+			BaseDelay:              getEnvDuration("SIGNIN_BASE_DELAY", time.Second),
+			MaxDelay:               getEnvDuration("SIGNIN_MAX_DELAY", 30*time.Second),
+		},
+		TOTPIssuer:     getEnv("TOTP_ISSUER", "HRM"),
+		TwoFactorRoles: getEnvList("TWO_FACTOR_REQUIRED_ROLES", nil),
+		TwoFactorTTL:   getEnvDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+		APITokenTTL:    getEnvDuration("API_TOKEN_TTL", 90*24*time.Hour),
+		APITokenMaxTTL: getEnvDuration("API_TOKEN_MAX_TTL", 365*24*time.Hour),
+	}
+
+	if auth.SigningAlg != domain.SigningAlgHS256 && !domain.IsAsymmetricAlgorithm(auth.SigningAlg) {
+		log.Fatalf("Invalid JWT_SIGNING_ALG %q: use HS256, RS256 or EdDSA", auth.SigningAlg)
+	}
+	if auth.APITokenTTL <= 0 || auth.APITokenTTL > auth.APITokenMaxTTL {
+		log.Fatal("API_TOKEN_TTL must be positive and not longer than API_TOKEN_MAX_TTL")
+	}
+	for _, role := range auth.TwoFactorRoles {
+		if !domain.IsValidRole(role) {
+			log.Fatalf("Invalid role %q in TWO_FACTOR_REQUIRED_ROLES", role)
+		}
+	}
+
+	return auth
+}
+
+// loadAttendancePolicy builds the attendance policy from environment variables.
+//
+// Returns:
+//   - domain.AttendancePolicy: Policy with defaults applied for unset variables
+func loadAttendancePolicy() domain.AttendancePolicy {
+	policy := domain.AttendancePolicy{
+		DefaultTimezone:       getEnv("DEFAULT_TIMEZONE", "UTC"),
+		ClockSkewTolerance:    getEnvDuration("CLOCK_SKEW_TOLERANCE", 5*time.Minute),
+		RejectClockSkew:       getEnvBool("REJECT_CLOCK_SKEW", false),
+		ShiftEnd:              getEnvTimeOfDay("SHIFT_END", 18*time.Hour),
+		AutoCloseCutoff:       getEnvDuration("AUTO_CLOSE_CUTOFF", 4*time.Hour),
+		MaxSessionLength:      getEnvDuration("MAX_SESSION_LENGTH", 12*time.Hour),
+		AutoCloseInterval:     getEnvDuration("AUTO_CLOSE_INTERVAL", 15*time.Minute),
+		WeekendDays:           getEnvWeekdays("WEEKEND_DAYS", []time.Weekday{time.Saturday, time.Sunday}),
+		AbsenceInterval:       getEnvDuration("ABSENCE_MARK_INTERVAL", time.Hour),
+		AbsenceLookbackDays:   getEnvInt("ABSENCE_LOOKBACK_DAYS", 3),
+		RejectOutsideGeofence: getEnvBool("GEOFENCE_REJECT", false),
+		RequirePunchLocation:  getEnvBool("GEOFENCE_REQUIRE_LOCATION", false),
+		MaxLocationAccuracy:   getEnvFloat("GEOFENCE_MAX_ACCURACY", 100),
+		MandatoryBreaks:       getEnvMandatoryBreaks("MANDATORY_BREAKS", nil),
+	}
+
+	if _, err := time.LoadLocation(policy.DefaultTimezone); err != nil {
+		log.Fatalf("Invalid DEFAULT_TIMEZONE %q: %v", policy.DefaultTimezone, err)
+	}
+
+	return policy
+}
+
+// loadOvertimePolicy builds the overtime policy from environment variables.
+//
+// Returns:
+//   - domain.OvertimePolicy: Policy with defaults applied for unset variables
+func loadOvertimePolicy() domain.OvertimePolicy {
+	policy := domain.OvertimePolicy{
+		DailyThresholdHours:  getEnvFloat("OVERTIME_DAILY_HOURS", 8),
+		WeeklyThresholdHours: getEnvFloat("OVERTIME_WEEKLY_HOURS", 40),
+		Multiplier:           getEnvFloat("OVERTIME_MULTIPLIER", 1.5),
+		WeekendMultiplier:    getEnvFloat("OVERTIME_WEEKEND_MULTIPLIER", 2),
+		HolidayMultiplier:    getEnvFloat("OVERTIME_HOLIDAY_MULTIPLIER", 2),
+		WeekStart:            getEnvWeekday("WEEK_START", time.Monday),
+		PayPeriod:            domain.PayPeriodFrequency(getEnv("PAY_PERIOD", string(domain.PayPeriodMonthly))),
+		Interval:             getEnvDuration("OVERTIME_INTERVAL", time.Hour),
+		LookbackDays:         getEnvInt("OVERTIME_LOOKBACK_DAYS", 7),
+	}
+
+	switch policy.PayPeriod {
+	case domain.PayPeriodWeekly, domain.PayPeriodBiweekly, domain.PayPeriodSemiMonthly, domain.PayPeriodMonthly:
+	default:
+		log.Fatalf("Invalid PAY_PERIOD %q: use weekly, biweekly, semimonthly or monthly", policy.PayPeriod)
+	}
+
+	if anchor := os.Getenv("PAY_PERIOD_ANCHOR"); anchor != "" {
+		parsed, err := time.Parse("2006-01-02", anchor)
+		if err != nil {
+			log.Fatalf("Invalid PAY_PERIOD_ANCHOR %q: %v", anchor, err)
+		}
+		policy.PayPeriodAnchor = parsed
+	}
+
+	return policy
+}
+
 // connectDB establishes a connection to the MySQL database.
 // This function:
 // 1. Reads the database connection string from environment variables
@@ -72,7 +429,10 @@ func connectDB() *gorm.DB {
 	}
 
 	// Step 2: Connect to MySQL database using GORM
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// All instants are stored in UTC; local times are derived per employee when needed
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
 	if err != nil {
 		log.Fatalf("Database connection failed: %v", err)
 	}
@@ -80,9 +440,32 @@ func connectDB() *gorm.DB {
 	// Step 3: Run database migrations for User entity
 	// This will create the users table if it doesn't exist
 	err = db.AutoMigrate(
+		&domain.Location{},
+		&domain.Holiday{},
 		&domain.User{},
+		&domain.AuthSession{},
+		&domain.SigningKey{},
+		&domain.AccountToken{},
+		&domain.SignInAttempt{},
+		&domain.PasswordHistory{},
+		&domain.TOTPCredential{},
+		&domain.RecoveryCode{},
+		&domain.TwoFactorChallenge{},
+		&domain.OIDCIdentity{},
+		&domain.OIDCLoginState{},
+		&domain.APIToken{},
 		&domain.Attendance{},
+		&domain.AttendanceSession{},
+		&domain.AttendanceRegularization{},
+		&domain.BreakType{},
 		&domain.Break{},
+		&domain.OvertimeEntry{},
+		&domain.OvertimeRequest{},
+		&domain.Timesheet{},
+		&domain.TimesheetDayNote{},
+		&domain.Project{},
+		&domain.TimeEntry{},
+		&domain.Kiosk{},
 		&domain.LeaveType{}, // Create leave_types table first
 		&domain.Leave{},     // Then create leaves table
 	)
@@ -90,8 +473,9 @@ func connectDB() *gorm.DB {
 		log.Fatal("Migration failed:", err)
 	}
 
-	// Step 4: Seed leave_types table with default data
+	// Step 4: Seed leave_types and break_types tables with default data
 	seedLeaveTypes(db)
+	seedBreakTypes(db)
 
 	// Step 5: Create foreign key constraint after both tables exist
 	createForeignKeyConstraint(db)
@@ -116,6 +500,236 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvDuration retrieves a duration environment variable (e.g. "5m", "90s") with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default value to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - time.Duration: The parsed duration or the default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s: %v, using default %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvBool retrieves a boolean environment variable with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default value to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - bool: The parsed boolean or the default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean for %s: %v, using default %t", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvTimeOfDay retrieves a time of day environment variable in "HH:MM" format with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default offset from midnight to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - time.Duration: The time of day as an offset from midnight
+func getEnvTimeOfDay(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		log.Printf("Invalid time of day for %s: %v, using default %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+}
+
+// getEnvInt retrieves an integer environment variable with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default value to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - int: The parsed integer or the default value
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s: %v, using default %d", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvList retrieves a comma-separated list environment variable with a fallback default value.
+// Surrounding whitespace and empty entries are dropped.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default list to return if the variable is not set
+//
+// Returns:
+//   - []string: The list entries or the default value
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvWeekdays retrieves a comma-separated list of weekday names (e.g. "Saturday,Sunday")
+// with a fallback default value. The value "none" means there are no weekend days.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default days to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - []time.Weekday: The parsed weekdays or the default value
+func getEnvWeekdays(key string, defaultValue []time.Weekday) []time.Weekday {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if strings.EqualFold(value, "none") {
+		return nil
+	}
+
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		day, ok := parseWeekday(name)
+		if !ok {
+			log.Printf("Invalid weekday %q in %s, using default %v", name, key, defaultValue)
+			return defaultValue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// getEnvWeekday retrieves a weekday name environment variable (e.g. "Monday") with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default day to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - time.Weekday: The parsed weekday or the default value
+func getEnvWeekday(key string, defaultValue time.Weekday) time.Weekday {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	day, ok := parseWeekday(value)
+	if !ok {
+		log.Printf("Invalid weekday %q for %s, using default %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return day
+}
+
+// parseWeekday parses a full or three-letter weekday name, ignoring case and surrounding spaces
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.TrimSpace(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) || strings.EqualFold(day.String()[:3], name) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// getEnvMandatoryBreaks retrieves a comma-separated list of mandatory break rules, each written as
+// "<working time>:<minimum break>" (e.g. "6h:30m,9h:45m"), with a fallback default value.
+// The value "none" disables mandatory breaks.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default rules to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - domain.MandatoryBreakRules: The parsed rules or the default value
+func getEnvMandatoryBreaks(key string, defaultValue domain.MandatoryBreakRules) domain.MandatoryBreakRules {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if strings.EqualFold(value, "none") {
+		return nil
+	}
+
+	var rules domain.MandatoryBreakRules
+	for _, entry := range strings.Split(value, ",") {
+		after, minimum, found := strings.Cut(strings.TrimSpace(entry), ":")
+		afterDuration, afterErr := time.ParseDuration(after)
+		minimumDuration, minimumErr := time.ParseDuration(minimum)
+		if !found || afterErr != nil || minimumErr != nil || afterDuration < 0 || minimumDuration <= 0 {
+			log.Printf("Invalid mandatory break rule %q in %s, using default %v", entry, key, defaultValue)
+			return defaultValue
+		}
+		rules = append(rules, domain.MandatoryBreakRule{After: afterDuration, Minimum: minimumDuration})
+	}
+	return rules
+}
+
+// getEnvFloat retrieves a decimal environment variable with a fallback default value.
+//
+// Parameters:
+//   - key: The environment variable name
+//   - defaultValue: The default value to return if the variable is not set or cannot be parsed
+//
+// Returns:
+//   - float64: The parsed number or the default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Invalid number for %s: %v, using default %g", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
 // seedLeaveTypes seeds the leave_types table with default data.
 // This function is called after the leave_types table is created in the database.
 //
@@ -202,6 +816,34 @@ func seedLeaveTypes(db *gorm.DB) {
 	}
 }
 
+// seedBreakTypes seeds the break_types table with the common kinds of breaks.
+// Only an empty table is seeded, so types changed or removed later are not recreated.
+//
+// Parameters:
+//   - db: The database connection instance
+func seedBreakTypes(db *gorm.DB) {
+	var count int64
+	db.Model(&domain.BreakType{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	defaultBreakTypes := []domain.BreakType{
+		{Code: "lunch", Name: "Lunch Break", Description: "Meal break in the middle of the shift.", IsPaid: false, MaxDuration: 60, MaxPerDay: 1, IsActive: true},
+		{Code: "prayer", Name: "Prayer Break", Description: "Short break for prayer.", IsPaid: true, MaxDuration: 15, MaxPerDay: 5, IsActive: true},
+		{Code: "smoke", Name: "Smoke Break", Description: "Short break outside the workplace.", IsPaid: false, MaxDuration: 10, MaxPerDay: 3, IsActive: true},
+		{Code: "personal", Name: "Personal Break", Description: "Break for personal matters.", IsPaid: false, MaxDuration: 30, MaxPerDay: 2, IsActive: true},
+	}
+
+	for _, breakType := range defaultBreakTypes {
+		if err := db.Create(&breakType).Error; err != nil {
+			log.Printf("Error seeding break type %s: %v", breakType.Code, err)
+		}
+	}
+
+	log.Println("Break types seeded successfully")
+}
+
 // createForeignKeyConstraint creates the foreign key constraint after both tables exist.
 // This function is called after the leave_types table is seeded.
 //
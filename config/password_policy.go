@@ -0,0 +1,73 @@
+package config
+
+import (
+	"bufio"
+	_ "embed"
+	"hrm/domain"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// commonPasswords is the built-in list of frequently used and breached passwords
+//
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// loadPasswordPolicy builds the password policy from environment variables.
+//
+// Returns:
+//   - domain.PasswordPolicy: Policy with defaults applied for unset variables
+func loadPasswordPolicy() domain.PasswordPolicy {
+	policy := domain.PasswordPolicy{
+		MinLength:     getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		MaxLength:     getEnvInt("PASSWORD_MAX_LENGTH", 72),
+		RequireUpper:  getEnvBool("PASSWORD_REQUIRE_UPPER", false),
+		RequireLower:  getEnvBool("PASSWORD_REQUIRE_LOWER", false),
+		RequireDigit:  getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+		RequireSymbol: getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		HistorySize:   getEnvInt("PASSWORD_HISTORY", 5),
+		Blocklist:     map[string]struct{}{},
+	}
+
+	if policy.MinLength < 1 {
+		log.Fatalf("Invalid PASSWORD_MIN_LENGTH %d: must be at least 1", policy.MinLength)
+	}
+	if policy.MaxLength < policy.MinLength || policy.MaxLength > 72 {
+		log.Fatalf("Invalid PASSWORD_MAX_LENGTH %d: must be between PASSWORD_MIN_LENGTH and 72", policy.MaxLength)
+	}
+	if policy.HistorySize < 0 {
+		log.Fatalf("Invalid PASSWORD_HISTORY %d: must not be negative", policy.HistorySize)
+	}
+
+	// Common passwords are refused unless turned off; a file can add more, e.g. a breached password list
+	if getEnvBool("PASSWORD_BLOCK_COMMON", true) {
+		addBlockedPasswords(policy.Blocklist, strings.NewReader(commonPasswords))
+	}
+	if path := getEnv("PASSWORD_BLOCKLIST_FILE", ""); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open PASSWORD_BLOCKLIST_FILE: %v", err)
+		}
+		defer file.Close()
+		addBlockedPasswords(policy.Blocklist, file)
+	}
+
+	return policy
+}
+
+// addBlockedPasswords reads one password per line into the blocklist, skipping blank lines and # comments
+func addBlockedPasswords(blocklist map[string]struct{}, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("Failed to read password blocklist: %v", err)
+	}
+}
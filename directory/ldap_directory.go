@@ -0,0 +1,191 @@
+package directory
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"hrm/domain"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// searchPageSize is how many entries are requested per page when listing the directory
+const searchPageSize = 500
+
+// LDAPOptions configure the connection to the directory and the attributes users are read from
+type LDAPOptions struct {
+	URL                string // ldap:// or ldaps:// URL of the server
+	StartTLS           bool   // Upgrade ldap:// connections with StartTLS
+	InsecureSkipVerify bool   // Accept any server certificate; only for testing
+	BindDN             string // Service account the directory is searched with; empty binds anonymously
+	BindPassword       string
+	BaseDN             string // Subtree users are searched in
+	UserFilter         string // LDAP filter matching user entries, e.g. "(objectClass=person)"
+	LoginAttribute     string // Attribute the email users sign in with is matched against
+	EmailAttribute     string
+	NameAttribute      string
+	ManagerAttribute   string // Attribute holding the DN of the user's manager
+	DisabledAttribute  string // Attribute marking disabled accounts; "userAccountControl" is read as Active Directory flags
+	Timeout            time.Duration
+}
+
+// LDAPDirectory implements the DirectoryInterface for an LDAP server such as OpenLDAP or Active Directory.
+// Each operation opens its own connection, so a restarted server is picked up without reconnect logic.
+type LDAPDirectory struct {
+	options LDAPOptions
+}
+
+// NewLDAPDirectory creates a new instance of LDAPDirectory
+func NewLDAPDirectory(options LDAPOptions) domain.DirectoryInterface {
+	return &LDAPDirectory{options: options}
+}
+
+// Authenticate finds the entry by its login attribute with the service account and binds as it with the password
+func (d *LDAPDirectory) Authenticate(login, password string) (*domain.DirectoryEntry, error) {
+	// An empty password would be an unauthenticated bind, which servers accept for any DN
+	if login == "" || password == "" {
+		return nil, domain.ErrDirectoryInvalidCredentials
+	}
+
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf("(&%s(%s=%s))", d.options.UserFilter, d.options.LoginAttribute, ldap.EscapeFilter(login))
+	result, err := conn.Search(d.searchRequest(filter, 2))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDirectoryUnavailable, err)
+	}
+	if len(result.Entries) != 1 {
+		if len(result.Entries) > 1 {
+			log.Printf("Directory login %q matches more than one entry; refusing sign-in", login)
+		}
+		return nil, domain.ErrDirectoryInvalidCredentials
+	}
+
+	entry := d.toEntry(result.Entries[0])
+	if err := conn.Bind(result.Entries[0].DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, domain.ErrDirectoryInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrDirectoryUnavailable, err)
+	}
+
+	return &entry, nil
+}
+
+// ListEntries returns every entry matching the user filter, reading the directory page by page
+func (d *LDAPDirectory) ListEntries() ([]domain.DirectoryEntry, error) {
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	result, err := conn.SearchWithPaging(d.searchRequest(d.options.UserFilter, 0), searchPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDirectoryUnavailable, err)
+	}
+
+	entries := make([]domain.DirectoryEntry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		entries = append(entries, d.toEntry(entry))
+	}
+	return entries, nil
+}
+
+// connect dials the server, upgrades the connection if configured and binds as the service account
+func (d *LDAPDirectory) connect() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.options.InsecureSkipVerify}
+	conn, err := ldap.DialURL(d.options.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: d.options.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDirectoryUnavailable, err)
+	}
+	conn.SetTimeout(d.options.Timeout)
+
+	if d.options.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: StartTLS failed: %v", domain.ErrDirectoryUnavailable, err)
+		}
+	}
+	if d.options.BindDN != "" {
+		if err := conn.Bind(d.options.BindDN, d.options.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: service account bind failed: %v", domain.ErrDirectoryUnavailable, err)
+		}
+	}
+
+	return conn, nil
+}
+
+// searchRequest builds a subtree search for user entries returning the configured attributes
+func (d *LDAPDirectory) searchRequest(filter string, sizeLimit int) *ldap.SearchRequest {
+	attributes := []string{d.options.EmailAttribute, d.options.NameAttribute, d.options.ManagerAttribute}
+	if d.options.DisabledAttribute != "" {
+		attributes = append(attributes, d.options.DisabledAttribute)
+	}
+	return ldap.NewSearchRequest(
+		d.options.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		sizeLimit, int(d.options.Timeout.Seconds()), false,
+		filter, attributes, nil,
+	)
+}
+
+// toEntry reads the configured attributes of a search result
+func (d *LDAPDirectory) toEntry(entry *ldap.Entry) domain.DirectoryEntry {
+	return domain.DirectoryEntry{
+		DN:        NormalizeDN(entry.DN),
+		Email:     strings.TrimSpace(entry.GetAttributeValue(d.options.EmailAttribute)),
+		Name:      strings.TrimSpace(entry.GetAttributeValue(d.options.NameAttribute)),
+		ManagerDN: NormalizeDN(entry.GetAttributeValue(d.options.ManagerAttribute)),
+		Disabled:  d.isDisabled(entry),
+	}
+}
+
+// isDisabled reads the disabled attribute. Active Directory's userAccountControl is disabled when
+// the ACCOUNTDISABLE flag (0x2) is set; any other attribute when it is "true", "yes" or "1".
+func (d *LDAPDirectory) isDisabled(entry *ldap.Entry) bool {
+	if d.options.DisabledAttribute == "" {
+		return false
+	}
+	value := strings.TrimSpace(entry.GetAttributeValue(d.options.DisabledAttribute))
+	if strings.EqualFold(d.options.DisabledAttribute, "userAccountControl") {
+		flags, err := strconv.Atoi(value)
+		return err == nil && flags&0x2 != 0
+	}
+	switch strings.ToLower(value) {
+	case "true", "yes", "1":
+		return true
+	}
+	return false
+}
+
+// NormalizeDN returns the form DNs are stored and compared in. DNs are case-insensitive,
+// and manager attributes do not always repeat the DN exactly as the server returns it.
+func NormalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+
+	rdns := make([]string, len(parsed.RDNs))
+	for i, rdn := range parsed.RDNs {
+		parts := make([]string, len(rdn.Attributes))
+		for j, attribute := range rdn.Attributes {
+			parts[j] = strings.ToLower(attribute.Type) + "=" + ldap.EscapeDN(strings.ToLower(attribute.Value))
+		}
+		rdns[i] = strings.Join(parts, "+")
+	}
+	return strings.Join(rdns, ",")
+}
@@ -0,0 +1,108 @@
+package directory
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"hrm/domain"
+)
+
+// MemoryEntry is a directory entry of the in-process stand-in, with its password in plain text
+type MemoryEntry struct {
+	DN        string `json:"dn"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	ManagerDN string `json:"manager_dn"`
+	Disabled  bool   `json:"disabled"`
+	Password  string `json:"password"`
+}
+
+// MemoryDirectory implements the DirectoryInterface without an LDAP server.
+// It stands in for the directory in local development and tests; passwords are compared in plain text.
+type MemoryDirectory struct {
+	entries []MemoryEntry
+}
+
+// NewMemoryDirectory creates a new instance of MemoryDirectory holding the given entries
+func NewMemoryDirectory(entries []MemoryEntry) *MemoryDirectory {
+	return &MemoryDirectory{entries: entries}
+}
+
+// Authenticate checks the password of the entry with the given email
+func (d *MemoryDirectory) Authenticate(login, password string) (*domain.DirectoryEntry, error) {
+	if login == "" || password == "" {
+		return nil, domain.ErrDirectoryInvalidCredentials
+	}
+	for _, entry := range d.entries {
+		if strings.EqualFold(entry.Email, login) && subtle.ConstantTimeCompare([]byte(entry.Password), []byte(password)) == 1 {
+			result := entry.toEntry()
+			return &result, nil
+		}
+	}
+	return nil, domain.ErrDirectoryInvalidCredentials
+}
+
+// ListEntries returns all entries
+func (d *MemoryDirectory) ListEntries() ([]domain.DirectoryEntry, error) {
+	entries := make([]domain.DirectoryEntry, len(d.entries))
+	for i, entry := range d.entries {
+		entries[i] = entry.toEntry()
+	}
+	return entries, nil
+}
+
+// toEntry converts the stand-in entry to a directory entry
+func (e MemoryEntry) toEntry() domain.DirectoryEntry {
+	return domain.DirectoryEntry{
+		DN:        NormalizeDN(e.DN),
+		Email:     strings.TrimSpace(e.Email),
+		Name:      strings.TrimSpace(e.Name),
+		ManagerDN: NormalizeDN(e.ManagerDN),
+		Disabled:  e.Disabled,
+	}
+}
+
+// FileDirectory implements the DirectoryInterface with a MemoryDirectory read from a JSON file of MemoryEntry objects.
+// The file is read again on every call, so edits show up at the next sign-in or sync.
+type FileDirectory struct {
+	path string
+}
+
+// NewFileDirectory creates a new instance of FileDirectory
+func NewFileDirectory(path string) domain.DirectoryInterface {
+	return &FileDirectory{path: path}
+}
+
+// Authenticate checks the password of the entry with the given email
+func (d *FileDirectory) Authenticate(login, password string) (*domain.DirectoryEntry, error) {
+	directory, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	return directory.Authenticate(login, password)
+}
+
+// ListEntries returns all entries of the file
+func (d *FileDirectory) ListEntries() ([]domain.DirectoryEntry, error) {
+	directory, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	return directory.ListEntries()
+}
+
+// load reads the entries from the file
+func (d *FileDirectory) load() (*MemoryDirectory, error) {
+	data, err := os.ReadFile(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDirectoryUnavailable, err)
+	}
+	var entries []MemoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w: invalid directory file %s: %v", domain.ErrDirectoryUnavailable, d.path, err)
+	}
+	return NewMemoryDirectory(entries), nil
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// AccountToken is a one-time token mailed to a user to prove they own their email address,
+// either to reset a forgotten password or to verify the address. Tokens expire, can be used
+// once and are only stored hashed.
+type AccountToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"not null;size:30;index" json:"purpose"` // AccountTokenPasswordReset or AccountTokenEmailVerification
+	TokenHash string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	Email     string     `gorm:"not null;size:255" json:"email"` // Address the token was sent to; it stops working if the user's email changes
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Purposes an account token can be used for
+const (
+	AccountTokenPasswordReset     = "password_reset"
+	AccountTokenEmailVerification = "email_verification"
+)
+
+// AccountTokenRepositoryInterface defines the contract for account token data operations
+type AccountTokenRepositoryInterface interface {
+	Create(token *AccountToken) error
+	GetByTokenHash(tokenHash string) (*AccountToken, error)
+	GetLatest(userID uint, purpose string) (*AccountToken, error)
+	Update(token *AccountToken) error
+	InvalidateByUserID(userID uint, purpose string) error
+}
+
+// AccountServiceInterface defines the contract for password reset and email verification
+type AccountServiceInterface interface {
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	SendEmailVerification(userID uint) error
+	VerifyEmail(token string) (*User, error)
+	IsEmailVerified(userID uint) (bool, error)
+}
+
+// Domain-specific errors for account token operations
+var (
+	ErrAccountTokenNotFound     = errors.New("account token not found")
+	ErrInvalidAccountToken      = errors.New("invalid or expired token")
+	ErrInvalidAccountTokenData  = errors.New("account token must belong to a user and have a purpose, hash and email")
+	ErrAccountTokenRecentlySent = errors.New("an email was sent recently, please wait before requesting another")
+	ErrEmailAlreadyVerified     = errors.New("email address is already verified")
+	ErrEmailNotVerified         = errors.New("email address is not verified")
+)
+
+// Validate checks if the account token data is valid
+func (t *AccountToken) Validate() error {
+	if t.UserID == 0 || t.TokenHash == "" || t.Email == "" {
+		return ErrInvalidAccountTokenData
+	}
+	if t.Purpose != AccountTokenPasswordReset && t.Purpose != AccountTokenEmailVerification {
+		return ErrInvalidAccountTokenData
+	}
+	return nil
+}
+
+// IsUsed returns true if the token has already been used or was invalidated
+func (t *AccountToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsUsableAt returns true if the token is neither used nor expired at the given time
+func (t *AccountToken) IsUsableAt(now time.Time) bool {
+	return !t.IsUsed() && now.Before(t.ExpiresAt)
+}
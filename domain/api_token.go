@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// APIToken is a personal access token scripts and integrations authenticate with instead of signing in as a person.
+// It acts as the user it belongs to, limited to its scopes, until it expires or is revoked.
+// Tokens are sent like access tokens ("Authorization: Bearer hrm_pat_...") and are only stored hashed.
+type APIToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	Name       string     `gorm:"not null;size:100" json:"name"` // What the token is for, e.g. "Payroll export"
+	TokenHash  string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	Hint       string     `gorm:"size:20" json:"hint"`        // Start of the token, to recognize it in listings
+	Scopes     string     `gorm:"not null;size:500" json:"-"` // Space-separated Scope* values
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `gorm:"size:45" json:"last_used_ip"`
+	RevokedAt  *time.Time `gorm:"index" json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// APITokenPrefix starts every API token, which tells them apart from JWT access tokens
+const APITokenPrefix = "hrm_pat_"
+
+// APITokenLastUsedInterval is how often the last use of a token is recorded; uses in between are not saved
+const APITokenLastUsedInterval = time.Minute
+
+// Scopes an API token can be granted. Read scopes cover viewing, write scopes creating, changing and
+// deleting, and approve scopes reviewing other users' requests. The token's user must still be allowed
+// to do what the scope covers.
+const (
+	ScopeProfileRead       = "profile:read"       // The token user's own account
+	ScopeAttendanceRead    = "attendance:read"    // Attendance, breaks and correction requests
+	ScopeAttendanceWrite   = "attendance:write"   // Punching, breaks, correction requests and punch log imports
+	ScopeAttendanceApprove = "attendance:approve" // Reviewing attendance correction requests
+	ScopeLeaveRead         = "leave:read"
+	ScopeLeaveWrite        = "leave:write"
+	ScopeLeaveApprove      = "leave:approve"
+	ScopeOvertimeRead      = "overtime:read"
+	ScopeOvertimeWrite     = "overtime:write"
+	ScopeOvertimeApprove   = "overtime:approve"
+	ScopeTimesheetRead     = "timesheet:read"
+	ScopeTimesheetWrite    = "timesheet:write"
+	ScopeTimesheetApprove  = "timesheet:approve"
+	ScopeProjectsRead      = "projects:read"  // Projects and logged time
+	ScopeProjectsWrite     = "projects:write" // Projects and logged time
+	ScopeSettingsRead      = "settings:read"  // Locations, holidays and break types
+	ScopeSettingsWrite     = "settings:write" // Locations, holidays and break types
+)
+
+// APITokenScopes lists every scope an API token can be granted
+var APITokenScopes = []string{
+	ScopeProfileRead,
+	ScopeAttendanceRead, ScopeAttendanceWrite, ScopeAttendanceApprove,
+	ScopeLeaveRead, ScopeLeaveWrite, ScopeLeaveApprove,
+	ScopeOvertimeRead, ScopeOvertimeWrite, ScopeOvertimeApprove,
+	ScopeTimesheetRead, ScopeTimesheetWrite, ScopeTimesheetApprove,
+	ScopeProjectsRead, ScopeProjectsWrite,
+	ScopeSettingsRead, ScopeSettingsWrite,
+}
+
+// APITokenRepositoryInterface defines the contract for API token data operations
+type APITokenRepositoryInterface interface {
+	Create(token *APIToken) error
+	GetByID(id uint) (*APIToken, error)
+	GetByTokenHash(tokenHash string) (*APIToken, error)
+	GetByUserID(userID uint) ([]APIToken, error)
+	Update(token *APIToken) error
+	RecordUse(id uint, usedAt time.Time, ipAddress string) error
+	RevokeAllByUserID(userID uint) error
+}
+
+// APITokenServiceInterface defines the contract for personal access tokens and service accounts
+type APITokenServiceInterface interface {
+	// CreateToken issues a token for a user. It returns the token record and the token itself, which is not shown again.
+	// A nil expiry uses the default lifetime.
+	CreateToken(userID uint, name string, scopes []string, expiresAt *time.Time) (*APIToken, string, error)
+
+	// ListTokens retrieves a user's tokens, revoked and expired ones included, newest first
+	ListTokens(userID uint) ([]APIToken, error)
+
+	// RevokeToken revokes one of a user's tokens; revoking it again is not an error
+	RevokeToken(userID, tokenID uint) error
+
+	// AuthenticateAPIToken checks a token sent with a request, records its use and returns it with its user
+	AuthenticateAPIToken(token, clientIP string) (*APIToken, *User, error)
+
+	// CreateServiceAccount creates a user for an integration, which only authenticates with API tokens
+	CreateServiceAccount(name, role string) (*User, error)
+
+	// ListServiceAccounts retrieves all service accounts
+	ListServiceAccounts() ([]User, error)
+
+	// GetServiceAccount retrieves a service account by user ID
+	GetServiceAccount(id uint) (*User, error)
+
+	// DeleteServiceAccount revokes a service account's tokens and deletes it
+	DeleteServiceAccount(id uint) error
+}
+
+// Domain-specific errors for API token operations
+var (
+	ErrAPITokenNotFound       = errors.New("API token not found")
+	ErrInvalidAPIToken        = errors.New("invalid, expired or revoked API token")
+	ErrInvalidAPITokenName    = errors.New("token name must be 1-100 characters")
+	ErrAPITokenScopesRequired = errors.New("at least one scope is required")
+	ErrInvalidAPITokenScope   = errors.New("unknown API token scope")
+	ErrInvalidAPITokenExpiry  = errors.New("token expiry must be in the future and within the maximum token lifetime")
+	ErrServiceAccountNotFound = errors.New("service account not found")
+)
+
+// Validate checks if the API token data is valid
+func (t *APIToken) Validate() error {
+	name := strings.TrimSpace(t.Name)
+	if name == "" || len(name) > 100 {
+		return ErrInvalidAPITokenName
+	}
+	scopes := t.ScopeList()
+	if len(scopes) == 0 {
+		return ErrAPITokenScopesRequired
+	}
+	for _, scope := range scopes {
+		if !IsValidAPITokenScope(scope) {
+			return ErrInvalidAPITokenScope
+		}
+	}
+	return nil
+}
+
+// ScopeList returns the scopes granted to the token
+func (t *APIToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// HasScope returns true if the token was granted the scope
+func (t *APIToken) HasScope(scope string) bool {
+	return slices.Contains(t.ScopeList(), scope)
+}
+
+// IsRevoked returns true if the token has been revoked
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsUsableAt returns true if the token is neither revoked nor expired at the given time
+func (t *APIToken) IsUsableAt(now time.Time) bool {
+	return !t.IsRevoked() && now.Before(t.ExpiresAt)
+}
+
+// IsValidAPITokenScope returns true for the scopes an API token can be granted
+func IsValidAPITokenScope(scope string) bool {
+	return slices.Contains(APITokenScopes, scope)
+}
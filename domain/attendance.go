@@ -5,21 +5,29 @@ import (
 	"time"
 )
 
-// Attendance represents an employee's attendance record for a specific date
+// Attendance represents an employee's attendance record for a specific date.
+// CheckInTime is the first check-in of the day and CheckOutTime the last check-out;
+// the individual check-in/check-out pairs are kept in Sessions.
 type Attendance struct {
-	ID             uint       `gorm:"primaryKey" json:"id"`
-	UserID         uint       `gorm:"not null" json:"user_id"`
-	Date           time.Time  `gorm:"not null;type:date" json:"date"`
-	CheckInTime    *time.Time `json:"check_in_time"`
-	CheckOutTime   *time.Time `json:"check_out_time"`
-	TotalWorkHours float64    `json:"total_work_hours"` // in hours
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	UserID              uint       `gorm:"not null" json:"user_id"`
+	Date                time.Time  `gorm:"not null;type:date" json:"date"`
+	CheckInTime         *time.Time `json:"check_in_time"`
+	CheckOutTime        *time.Time `json:"check_out_time"`
+	TotalWorkHours      float64    `json:"total_work_hours"`                          // in hours
+	BreakDeduction      float64    `gorm:"not null;default:0" json:"break_deduction"` // Minutes deducted for a mandatory break that was not taken
+	Timezone            string     `gorm:"size:64" json:"timezone"`                   // Zone the work day was derived in
+	Flagged             bool       `gorm:"default:false" json:"flagged"`
+	FlagReason          string     `gorm:"type:text" json:"flag_reason,omitempty"`
+	LockedByTimesheetID *uint      `gorm:"index" json:"locked_by_timesheet_id"` // Approved timesheet that locks this record
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
-	Status    string    `json:"-"` // "present", "absent", "late", "early_leave", "completed"
+	Status    string    `json:"-"` // "present", "absent", "on_leave", "late", "early_leave", "completed", "auto_closed"
 
-	User   User    `gorm:"foreignKey:UserID" json:"-"`
-	Breaks []Break `gorm:"foreignKey:AttendanceID" json:"breaks,omitempty"`
+	User     User                `gorm:"foreignKey:UserID" json:"-"`
+	Breaks   []Break             `gorm:"foreignKey:AttendanceID" json:"breaks,omitempty"`
+	Sessions []AttendanceSession `gorm:"foreignKey:AttendanceID" json:"sessions,omitempty"`
 }
 
 // AttendanceRepositoryInterface defines the contract for attendance data operations
@@ -27,6 +35,8 @@ type AttendanceRepositoryInterface interface {
 	Create(attendance *Attendance) error
 	GetByID(id uint) (*Attendance, error)
 	GetByUserID(userID uint, date time.Time) (*Attendance, error)
+	GetOpenByUserID(userID uint) (*Attendance, error)
+	GetAllOpen() ([]Attendance, error)
 	GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]Attendance, error)
 	GetByDate(date time.Time) ([]Attendance, error)
 	Update(attendance *Attendance) error
@@ -34,13 +44,14 @@ type AttendanceRepositoryInterface interface {
 	GetAll() ([]Attendance, error)
 	GetWithBreaks(id uint) (*Attendance, error)
 	GetLastNByUserID(userID uint, limit int) ([]Attendance, error)
+	LockByUserIDAndDateRange(userID uint, startDate, endDate time.Time, timesheetID uint) error
 }
 
 // AttendanceServiceInterface defines the contract for attendance business logic
 type AttendanceServiceInterface interface {
 	CreateAttendance(userID uint, date time.Time) (*Attendance, error)
-	CheckIn(userID uint, date time.Time) (*Attendance, error)
-	CheckOut(userID uint, date time.Time) (*Attendance, error)
+	CheckIn(userID uint, punch Punch) (*Attendance, error)
+	CheckOut(userID uint, punch Punch) (*Attendance, error)
 	GetAttendanceByID(id uint) (*Attendance, error)
 	GetUserAttendance(userID uint, date time.Time) (*Attendance, error)
 	GetUserAttendanceRange(userID uint, startDate, endDate time.Time) ([]Attendance, error)
@@ -51,14 +62,38 @@ type AttendanceServiceInterface interface {
 	GetLastNAttendanceByUserID(userID uint, limit int) ([]Attendance, error)
 }
 
+// AttendanceAutoCloseServiceInterface defines the contract for closing check-outs employees forgot
+type AttendanceAutoCloseServiceInterface interface {
+	CloseForgottenCheckOuts(now time.Time) (int, error)
+}
+
+// AbsenceMarkingServiceInterface defines the contract for recording days employees did not attend
+type AbsenceMarkingServiceInterface interface {
+	MarkAbsences(now time.Time) (int, error)
+}
+
+// Attendance statuses set by the system rather than derived from punches
+const (
+	AttendanceStatusAbsent     = "absent"      // No check-in on a working day
+	AttendanceStatusOnLeave    = "on_leave"    // No check-in, covered by an approved leave
+	AttendanceStatusAutoClosed = "auto_closed" // Last session was closed by the system
+)
+
 // Domain-specific errors for attendance operations
 var (
 	ErrInvalidUserID      = errors.New("invalid user ID")
 	ErrInvalidDate        = errors.New("invalid date")
 	ErrAttendanceNotFound = errors.New("attendance not found")
-	ErrAlreadyCheckedIn   = errors.New("already checked in for this date")
+	ErrAlreadyCheckedIn   = errors.New("already checked in; check out before starting a new session")
 	ErrAlreadyCheckedOut  = errors.New("already checked out for this date")
 	ErrNotCheckedIn       = errors.New("not checked in yet")
+	ErrClockSkew          = errors.New("client timestamp drifts too far from server time")
+	ErrAttendanceLocked   = errors.New("attendance is locked by an approved timesheet")
+
+	ErrOutsideGeofence        = errors.New("punch location is outside the permitted area")
+	ErrPunchLocationRequired  = errors.New("device location is required to punch at this site")
+	ErrPunchLocationImprecise = errors.New("device location is not accurate enough")
+	ErrNetworkNotAllowed      = errors.New("punches are not allowed from this network")
 )
 
 // Validate checks if the attendance data is valid
@@ -72,23 +107,39 @@ func (a *Attendance) Validate() error {
 	return nil
 }
 
-// CalculateWorkHours calculates the total work hours for the attendance
-func (a *Attendance) CalculateWorkHours() {
-	if a.CheckInTime == nil || a.CheckOutTime == nil {
-		a.TotalWorkHours = 0
-		return
-	}
+// CalculateWorkHours calculates the total work hours for the attendance.
+// Hours are summed across all closed sessions that have not been superseded by a correction;
+// records created before sessions existed fall back to the span between check-in and check-out.
+// Ended unpaid breaks are subtracted as far as they fall within those spans; paid breaks count as work time.
+// When the breaks taken fall short of the mandatory break for the time worked, the shortfall
+// is deducted as well and recorded in BreakDeduction.
+func (a *Attendance) CalculateWorkHours(mandatoryBreaks MandatoryBreakRules) {
+	a.TotalWorkHours = 0
+	a.BreakDeduction = 0
 
-	duration := a.CheckOutTime.Sub(*a.CheckInTime)
-	a.TotalWorkHours = duration.Hours()
-
-	// Subtract break durations
+	spans := a.workSpans()
+	var worked, taken, unpaid time.Duration
+	for _, span := range spans {
+		worked += span.end.Sub(span.start)
+	}
 	for _, breakItem := range a.Breaks {
-		if breakItem.EndTime != nil {
-			breakDuration := breakItem.EndTime.Sub(breakItem.StartTime)
-			a.TotalWorkHours -= breakDuration.Hours()
+		if breakItem.EndTime == nil {
+			continue
 		}
+		inside := overlapWithSpans(breakItem.StartTime, *breakItem.EndTime, spans)
+		taken += inside
+		if !breakItem.IsPaid {
+			unpaid += inside
+		}
+	}
+
+	// Deduct the part of the mandatory break that was not taken
+	deduction := mandatoryBreaks.Required(worked-taken) - taken
+	if deduction < 0 {
+		deduction = 0
 	}
+	a.BreakDeduction = deduction.Minutes()
+	a.TotalWorkHours = (worked - unpaid - deduction).Hours()
 
 	// Ensure work hours is not negative
 	if a.TotalWorkHours < 0 {
@@ -96,35 +147,161 @@ func (a *Attendance) CalculateWorkHours() {
 	}
 }
 
+// CoversBreak reports whether a break lies within a single session that still counts.
+// A break in progress must lie within the open session. Records without sessions
+// use the span between check-in and check-out.
+func (a *Attendance) CoversBreak(b *Break) bool {
+	if len(a.Sessions) == 0 {
+		if a.CheckInTime == nil || b.StartTime.Before(*a.CheckInTime) {
+			return false
+		}
+		return a.CheckOutTime == nil || (b.EndTime != nil && !b.EndTime.After(*a.CheckOutTime))
+	}
+
+	for i := range a.Sessions {
+		session := &a.Sessions[i]
+		if session.IsSuperseded() || b.StartTime.Before(session.CheckInTime) {
+			continue
+		}
+		if session.IsOpen() || (b.EndTime != nil && !b.EndTime.After(*session.CheckOutTime)) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeSpan is a period of time from start to end
+type timeSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// workSpans returns the closed periods that count as time at work: the sessions that have not
+// been superseded, or the span between check-in and check-out for records without sessions
+func (a *Attendance) workSpans() []timeSpan {
+	if len(a.Sessions) == 0 {
+		if a.CheckInTime == nil || a.CheckOutTime == nil {
+			return nil
+		}
+		return []timeSpan{{start: *a.CheckInTime, end: *a.CheckOutTime}}
+	}
+
+	spans := make([]timeSpan, 0, len(a.Sessions))
+	for _, session := range a.Sessions {
+		if session.IsSuperseded() || session.IsOpen() {
+			continue
+		}
+		spans = append(spans, timeSpan{start: session.CheckInTime, end: *session.CheckOutTime})
+	}
+	return spans
+}
+
+// overlapWithSpans returns how much of the period from start to end falls within the spans
+func overlapWithSpans(start, end time.Time, spans []timeSpan) time.Duration {
+	var total time.Duration
+	for _, span := range spans {
+		from, to := start, end
+		if span.start.After(from) {
+			from = span.start
+		}
+		if span.end.Before(to) {
+			to = span.end
+		}
+		if to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	return total
+}
+
+// RefreshPunchTimes derives CheckInTime and CheckOutTime from the loaded sessions that still count:
+// the first check-in of the day, and the last check-out unless a session is still open.
+// Records without sessions are left unchanged.
+func (a *Attendance) RefreshPunchTimes() {
+	var firstCheckIn, lastCheckOut *time.Time
+	open := false
+	for i := range a.Sessions {
+		session := &a.Sessions[i]
+		if session.IsSuperseded() {
+			continue
+		}
+		if firstCheckIn == nil || session.CheckInTime.Before(*firstCheckIn) {
+			checkIn := session.CheckInTime
+			firstCheckIn = &checkIn
+		}
+		if session.IsOpen() {
+			open = true
+		} else if lastCheckOut == nil || session.CheckOutTime.After(*lastCheckOut) {
+			checkOut := *session.CheckOutTime
+			lastCheckOut = &checkOut
+		}
+	}
+	if firstCheckIn == nil {
+		return
+	}
+
+	a.CheckInTime = firstCheckIn
+	a.CheckOutTime = lastCheckOut
+	if open {
+		a.CheckOutTime = nil
+	}
+}
+
+// Flag marks the attendance for review and records the reason
+func (a *Attendance) Flag(reason string) {
+	a.Flagged = true
+	if a.FlagReason == "" {
+		a.FlagReason = reason
+		return
+	}
+	a.FlagReason += "; " + reason
+}
+
 // GetStatus returns the attendance status based on check-in/out times
 func (a *Attendance) GetStatus() string {
 	if a.CheckInTime == nil {
-		return "absent"
+		if a.Status == AttendanceStatusOnLeave {
+			return AttendanceStatusOnLeave
+		}
+		return AttendanceStatusAbsent
 	}
 	if a.CheckOutTime == nil {
 		return "present"
 	}
+	if a.Status == AttendanceStatusAutoClosed {
+		return AttendanceStatusAutoClosed
+	}
 
 	// You can add more logic here for "late" or "early_leave" based on your business rules
 	return "completed"
 }
 
-// IsCheckedIn returns true if the user has checked in
+// IsLocked returns true if an approved timesheet covers this attendance
+func (a *Attendance) IsLocked() bool {
+	return a.LockedByTimesheetID != nil
+}
+
+// IsCheckedIn returns true if the user has checked in at least once
 func (a *Attendance) IsCheckedIn() bool {
 	return a.CheckInTime != nil
 }
 
+// HasOpenSession returns true if the user is currently checked in (checked in and not yet checked out)
+func (a *Attendance) HasOpenSession() bool {
+	return a.IsCheckedIn() && !a.IsCheckedOut()
+}
+
 // IsCheckedOut returns true if the user has checked out
 func (a *Attendance) IsCheckedOut() bool {
 	return a.CheckOutTime != nil
 }
 
-// CanCheckIn returns true if the user can check in (not already checked in)
+// CanCheckIn returns true if the user can start a new session (no session currently open)
 func (a *Attendance) CanCheckIn() bool {
-	return !a.IsCheckedIn()
+	return !a.HasOpenSession()
 }
 
-// CanCheckOut returns true if the user can check out (checked in but not checked out)
+// CanCheckOut returns true if the user can check out (a session is currently open)
 func (a *Attendance) CanCheckOut() bool {
-	return a.IsCheckedIn() && !a.IsCheckedOut()
+	return a.HasOpenSession()
 }
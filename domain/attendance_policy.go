@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AttendancePolicy holds the organisation-wide rules applied to attendance and break operations.
+// It is loaded once from configuration and injected into the services that need it.
+type AttendancePolicy struct {
+	DefaultTimezone       string              // IANA zone used when neither the user nor their location defines one
+	ClockSkewTolerance    time.Duration       // Maximum accepted difference between a client timestamp and server time (0 disables the check)
+	RejectClockSkew       bool                // Reject drifting client timestamps instead of flagging them for review
+	ShiftEnd              time.Duration       // Local time of day the shift ends, as an offset from midnight
+	AutoCloseCutoff       time.Duration       // How long after the shift end an open session is closed automatically
+	MaxSessionLength      time.Duration       // Length auto-closed sessions that started after the shift end are given
+	AutoCloseInterval     time.Duration       // How often the auto-close job runs (0 disables it)
+	WeekendDays           []time.Weekday      // Days on which no attendance is expected
+	AbsenceInterval       time.Duration       // How often the absence marking job runs (0 disables it)
+	AbsenceLookbackDays   int                 // How many past days the absence marking job checks, to catch up after downtime
+	RejectOutsideGeofence bool                // Reject punches outside the location's geofence instead of flagging them (locations may override)
+	RequirePunchLocation  bool                // Punches at geofenced locations must carry coordinates
+	MaxLocationAccuracy   float64             // Reported accuracy in meters above which coordinates cannot be trusted (0 accepts any)
+	MandatoryBreaks       MandatoryBreakRules // Minimum breaks required by working time; shortfalls are deducted from the work hours
+}
+
+// MandatoryBreakRule requires a minimum total break once the working time exceeds a threshold
+type MandatoryBreakRule struct {
+	After   time.Duration // Working time, excluding breaks, above which the rule applies
+	Minimum time.Duration // Total break time required once it applies
+}
+
+// MandatoryBreakRules is the set of mandatory break rules of a policy
+type MandatoryBreakRules []MandatoryBreakRule
+
+// Required returns the total break required for the given working time:
+// the largest minimum among the rules whose threshold is exceeded, or zero when none applies.
+func (rules MandatoryBreakRules) Required(worked time.Duration) time.Duration {
+	var required time.Duration
+	for _, rule := range rules {
+		if worked > rule.After && rule.Minimum > required {
+			required = rule.Minimum
+		}
+	}
+	return required
+}
+
+// Punch carries the client-side context of a check-in, check-out or break action.
+// The server clock is always authoritative; the client values are only used for verification.
+type Punch struct {
+	ClientTime *time.Time // Timestamp reported by the client device, if any
+	Latitude   *float64   // Device position, if shared
+	Longitude  *float64
+	Accuracy   *float64 // Accuracy radius of the position in meters, if reported
+	IPAddress  string   // Source IP of the request, as resolved through the trusted proxies
+	KioskID    *uint    // Shared kiosk the punch was made on, if any
+}
+
+// CheckNetwork verifies that a punch comes from one of the networks allowed at the employee's location.
+// Locations without an allow-list accept any network.
+func (p AttendancePolicy) CheckNetwork(location *Location, punch Punch) error {
+	if location == nil || !location.HasNetworkRestriction() {
+		return nil
+	}
+	if !location.AllowsIP(punch.IPAddress) {
+		return ErrNetworkNotAllowed
+	}
+	return nil
+}
+
+// HasLocation returns true if the punch carries device coordinates
+func (p Punch) HasLocation() bool {
+	return p.Latitude != nil && p.Longitude != nil
+}
+
+// CheckGeofence verifies a punch against the geofence of the employee's location.
+// It returns the captured position (with the distance to the geofence center when known),
+// ErrOutsideGeofence/ErrPunchLocationRequired when the punch must be refused,
+// or a non-empty flag reason when it should only be recorded for review.
+func (p AttendancePolicy) CheckGeofence(location *Location, punch Punch) (PunchLocation, string, error) {
+	var captured PunchLocation
+	if punch.HasLocation() {
+		if !ValidCoordinates(*punch.Latitude, *punch.Longitude) {
+			return captured, "", ErrInvalidCoordinates
+		}
+		captured = PunchLocation{Latitude: punch.Latitude, Longitude: punch.Longitude, Accuracy: punch.Accuracy}
+	}
+
+	if location == nil || !location.HasGeofence() {
+		return captured, "", nil
+	}
+
+	reject := p.RejectOutsideGeofence
+	if location.GeofenceMode != "" {
+		reject = location.GeofenceMode == GeofenceModeReject
+	}
+
+	if !punch.HasLocation() {
+		if !p.RequirePunchLocation {
+			return captured, "", nil
+		}
+		if reject {
+			return captured, "", ErrPunchLocationRequired
+		}
+		return captured, "no device location at geofenced site " + location.Name, nil
+	}
+
+	distance := location.DistanceTo(*punch.Latitude, *punch.Longitude)
+	captured.Distance = &distance
+
+	// Give the device the benefit of its reported accuracy, as long as it is trustworthy
+	allowed := location.GeofenceRadius
+	if punch.Accuracy != nil && *punch.Accuracy > 0 {
+		if p.MaxLocationAccuracy > 0 && *punch.Accuracy > p.MaxLocationAccuracy {
+			if reject {
+				return captured, "", ErrPunchLocationImprecise
+			}
+			return captured, fmt.Sprintf("device location accuracy of %.0fm is too low", *punch.Accuracy), nil
+		}
+		allowed += *punch.Accuracy
+	}
+
+	if distance <= allowed {
+		return captured, "", nil
+	}
+	if reject {
+		return captured, "", ErrOutsideGeofence
+	}
+	return captured, fmt.Sprintf("%.0fm from %s, outside its %.0fm geofence", distance, location.Name, location.GeofenceRadius), nil
+}
+
+// CheckClientTime compares a client-reported timestamp with the server time.
+// It returns ErrClockSkew when the drift exceeds the tolerance and the policy rejects drift,
+// or a non-empty flag reason when the drift should only be recorded for review.
+func (p AttendancePolicy) CheckClientTime(clientTime *time.Time, serverTime time.Time) (string, error) {
+	if clientTime == nil || clientTime.IsZero() || p.ClockSkewTolerance <= 0 {
+		return "", nil
+	}
+
+	drift := serverTime.Sub(*clientTime)
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= p.ClockSkewTolerance {
+		return "", nil
+	}
+
+	if p.RejectClockSkew {
+		return "", ErrClockSkew
+	}
+	return fmt.Sprintf("client clock drift of %s", drift.Round(time.Second)), nil
+}
+
+// DefaultLocation returns the policy's default time zone, falling back to UTC
+func (p AttendancePolicy) DefaultLocation() *time.Location {
+	if p.DefaultTimezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ShiftEndOn returns the instant the shift ends on the given work day in the given time zone
+func (p AttendancePolicy) ShiftEndOn(day time.Time, loc *time.Location) time.Time {
+	hours := int(p.ShiftEnd / time.Hour)
+	minutes := int((p.ShiftEnd % time.Hour) / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), hours, minutes, 0, 0, loc).UTC()
+}
+
+// IsWeekend returns true if the given work day falls on a weekend day
+func (p AttendancePolicy) IsWeekend(day time.Time) bool {
+	for _, weekend := range p.WeekendDays {
+		if day.Weekday() == weekend {
+			return true
+		}
+	}
+	return false
+}
+
+// StartOfWeek returns the first day of the week that contains the given work day
+func StartOfWeek(day time.Time, weekStart time.Weekday) time.Time {
+	day = DateOnly(day)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// WorkDay returns the calendar day an instant falls on in the given time zone.
+// The result is midnight UTC of that local date, which is how attendance dates are stored.
+func WorkDay(instant time.Time, loc *time.Location) time.Time {
+	local := instant.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DateOnly strips the time of day from a date, keeping the calendar date as written
+func DateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkDay(t *testing.T) {
+	karachi, err := time.LoadLocation("Asia/Karachi")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		instant time.Time
+		loc     *time.Location
+		want    string
+	}{
+		{"same day in UTC", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), time.UTC, "2024-01-15"},
+		{"next day east of UTC", time.Date(2024, 1, 15, 20, 0, 0, 0, time.UTC), karachi, "2024-01-16"},
+		{"previous day west of UTC", time.Date(2024, 1, 15, 3, 0, 0, 0, time.UTC), newYork, "2024-01-14"},
+		{"local midnight belongs to the new day", time.Date(2024, 1, 15, 19, 0, 0, 0, time.UTC), karachi, "2024-01-16"},
+		{"across a year boundary", time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC), karachi, "2024-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WorkDay(tt.instant, tt.loc)
+			if got.Format("2006-01-02") != tt.want {
+				t.Fatalf("WorkDay = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+			if got.Location() != time.UTC || got.Hour() != 0 || got.Minute() != 0 {
+				t.Fatalf("WorkDay = %v, want midnight UTC", got)
+			}
+		})
+	}
+}
+
+func TestCheckClientTime(t *testing.T) {
+	server := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *time.Time {
+		clientTime := server.Add(offset)
+		return &clientTime
+	}
+
+	tests := []struct {
+		name       string
+		policy     AttendancePolicy
+		clientTime *time.Time
+		wantFlag   bool
+		wantErr    error
+	}{
+		{"no client time", AttendancePolicy{ClockSkewTolerance: time.Minute}, nil, false, nil},
+		{"check disabled", AttendancePolicy{}, at(time.Hour), false, nil},
+		{"within tolerance", AttendancePolicy{ClockSkewTolerance: time.Minute}, at(-30 * time.Second), false, nil},
+		{"drift flagged", AttendancePolicy{ClockSkewTolerance: time.Minute}, at(5 * time.Minute), true, nil},
+		{"drift behind flagged", AttendancePolicy{ClockSkewTolerance: time.Minute}, at(-5 * time.Minute), true, nil},
+		{"drift rejected", AttendancePolicy{ClockSkewTolerance: time.Minute, RejectClockSkew: true}, at(5 * time.Minute), false, ErrClockSkew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag, err := tt.policy.CheckClientTime(tt.clientTime, server)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if (flag != "") != tt.wantFlag {
+				t.Fatalf("flag = %q, want flagged = %v", flag, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestCheckGeofence(t *testing.T) {
+	ptr := func(value float64) *float64 { return &value }
+	site := &Location{Name: "HQ", Latitude: ptr(24.86), Longitude: ptr(67.0), GeofenceRadius: 100}
+	rejectingSite := *site
+	rejectingSite.GeofenceMode = GeofenceModeReject
+	flaggingSite := *site
+	flaggingSite.GeofenceMode = GeofenceModeFlag
+	// About 55m and 167m north of the site center
+	near := Punch{Latitude: ptr(24.8605), Longitude: ptr(67.0)}
+	far := Punch{Latitude: ptr(24.8615), Longitude: ptr(67.0)}
+	farWithinAccuracy := far
+	farWithinAccuracy.Accuracy = ptr(80)
+	farAndUntrusted := far
+	farAndUntrusted.Accuracy = ptr(500)
+
+	tests := []struct {
+		name         string
+		policy       AttendancePolicy
+		location     *Location
+		punch        Punch
+		wantFlag     bool
+		wantErr      error
+		wantDistance bool
+	}{
+		{"no location assigned", AttendancePolicy{}, nil, far, false, nil, false},
+		{"location without geofence", AttendancePolicy{}, &Location{Name: "Remote"}, far, false, nil, false},
+		{"invalid coordinates", AttendancePolicy{}, site, Punch{Latitude: ptr(91), Longitude: ptr(0)}, false, ErrInvalidCoordinates, false},
+		{"inside the radius", AttendancePolicy{RejectOutsideGeofence: true}, site, near, false, nil, true},
+		{"outside the radius flagged", AttendancePolicy{}, site, far, true, nil, true},
+		{"outside the radius rejected", AttendancePolicy{RejectOutsideGeofence: true}, site, far, false, ErrOutsideGeofence, true},
+		{"location mode overrides a flagging policy", AttendancePolicy{}, &rejectingSite, far, false, ErrOutsideGeofence, true},
+		{"location mode overrides a rejecting policy", AttendancePolicy{RejectOutsideGeofence: true}, &flaggingSite, far, true, nil, true},
+		{"accuracy extends the radius", AttendancePolicy{RejectOutsideGeofence: true, MaxLocationAccuracy: 100}, site, farWithinAccuracy, false, nil, true},
+		{"untrusted accuracy flagged", AttendancePolicy{MaxLocationAccuracy: 100}, site, farAndUntrusted, true, nil, true},
+		{"untrusted accuracy rejected", AttendancePolicy{RejectOutsideGeofence: true, MaxLocationAccuracy: 100}, site, farAndUntrusted, false, ErrPunchLocationImprecise, true},
+		{"missing position accepted", AttendancePolicy{RejectOutsideGeofence: true}, site, Punch{}, false, nil, false},
+		{"missing position flagged", AttendancePolicy{RequirePunchLocation: true}, site, Punch{}, true, nil, false},
+		{"missing position rejected", AttendancePolicy{RequirePunchLocation: true, RejectOutsideGeofence: true}, site, Punch{}, false, ErrPunchLocationRequired, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured, flag, err := tt.policy.CheckGeofence(tt.location, tt.punch)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if (flag != "") != tt.wantFlag {
+				t.Fatalf("flag = %q, want flagged = %v", flag, tt.wantFlag)
+			}
+			if (captured.Distance != nil) != tt.wantDistance {
+				t.Fatalf("distance = %v, want recorded = %v", captured.Distance, tt.wantDistance)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RegularizationStatus represents the status of an attendance correction request
+type RegularizationStatus string
+
+const (
+	RegularizationStatusPending   RegularizationStatus = "pending"
+	RegularizationStatusApproved  RegularizationStatus = "approved"
+	RegularizationStatusRejected  RegularizationStatus = "rejected"
+	RegularizationStatusCancelled RegularizationStatus = "cancelled"
+)
+
+// AttendanceRegularization represents an employee's request to correct the punches of an attendance day.
+// The punches recorded at request time are kept alongside the requested ones so the original
+// data is never lost, even after the correction has been approved and applied.
+type AttendanceRegularization struct {
+	ID                    uint                 `gorm:"primaryKey" json:"id"`
+	UserID                uint                 `gorm:"not null;index" json:"user_id"`
+	AttendanceID          *uint                `gorm:"index" json:"attendance_id"`
+	Date                  time.Time            `gorm:"not null;type:date" json:"date"`
+	OriginalCheckInTime   *time.Time           `json:"original_check_in_time"`
+	OriginalCheckOutTime  *time.Time           `json:"original_check_out_time"`
+	RequestedCheckInTime  time.Time            `gorm:"not null" json:"requested_check_in_time"`
+	RequestedCheckOutTime time.Time            `gorm:"not null" json:"requested_check_out_time"`
+	Reason                string               `gorm:"not null;type:text" json:"reason"`
+	Status                RegularizationStatus `gorm:"not null;type:varchar(20);default:'pending'" json:"status"`
+	ReviewedBy            *uint                `gorm:"index" json:"reviewed_by"`
+	ReviewedAt            *time.Time           `json:"reviewed_at"`
+	ReviewNote            string               `gorm:"type:text" json:"review_note"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttendanceRegularizationRepositoryInterface defines the contract for regularization data operations
+type AttendanceRegularizationRepositoryInterface interface {
+	Create(regularization *AttendanceRegularization) error
+	GetByID(id uint) (*AttendanceRegularization, error)
+	GetByUserID(userID uint) ([]AttendanceRegularization, error)
+	GetByStatus(status RegularizationStatus) ([]AttendanceRegularization, error)
+	GetPendingByUserIDAndDate(userID uint, date time.Time) (*AttendanceRegularization, error)
+	Update(regularization *AttendanceRegularization) error
+}
+
+// AttendanceRegularizationServiceInterface defines the contract for regularization business logic
+type AttendanceRegularizationServiceInterface interface {
+	RequestRegularization(userID uint, regularization *AttendanceRegularization) error
+	GetRegularizationByID(id uint) (*AttendanceRegularization, error)
+	GetUserRegularizations(userID uint) ([]AttendanceRegularization, error)
+	GetPendingRegularizations(approverID uint) ([]AttendanceRegularization, error)
+	ApproveRegularization(id uint, approverID uint, note string) error
+	RejectRegularization(id uint, approverID uint, note string) error
+	CancelRegularization(id uint, userID uint) error
+}
+
+// Domain-specific errors for regularization operations
+var (
+	ErrRegularizationNotFound      = errors.New("regularization request not found")
+	ErrRegularizationNotPending    = errors.New("regularization request is no longer pending")
+	ErrRegularizationAlreadyExists = errors.New("a pending regularization request already exists for this date")
+	ErrInvalidRegularizationTimes  = errors.New("requested check-out must be after check-in, on the given date and not in the future")
+	ErrRegularizationReason        = errors.New("reason is required")
+	ErrSelfApproval                = errors.New("you cannot review your own request")
+)
+
+// Validate checks if the regularization data is valid
+func (r *AttendanceRegularization) Validate() error {
+	if r.UserID == 0 {
+		return ErrInvalidUserID
+	}
+	if r.Date.IsZero() {
+		return ErrInvalidDate
+	}
+	if r.RequestedCheckInTime.IsZero() || r.RequestedCheckOutTime.IsZero() ||
+		!r.RequestedCheckOutTime.After(r.RequestedCheckInTime) {
+		return ErrInvalidRegularizationTimes
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return ErrRegularizationReason
+	}
+	return nil
+}
+
+// IsPending returns true if the request is waiting for review
+func (r *AttendanceRegularization) IsPending() bool {
+	return r.Status == RegularizationStatusPending
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Session sources describe how a check-in/check-out pair was recorded
+const (
+	SessionSourcePunch          = "punch"          // Recorded by the employee checking in and out
+	SessionSourceRegularization = "regularization" // Created from an approved correction request
+	SessionSourceKiosk          = "kiosk"          // Checked in on a shared kiosk
+	SessionSourceImport         = "import"         // Imported from a time clock punch log
+)
+
+// PunchLocation is the device position captured with a check-in or check-out, kept for review
+type PunchLocation struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Accuracy  *float64 `json:"accuracy"` // Reported accuracy radius in meters
+	Distance  *float64 `json:"distance"` // Meters from the geofence center of the employee's location
+}
+
+// IsEmpty returns true if no position was captured
+func (l PunchLocation) IsEmpty() bool {
+	return l.Latitude == nil || l.Longitude == nil
+}
+
+// AttendanceSession represents one check-in/check-out pair within an attendance day.
+// An employee may have several sessions per day, e.g. when leaving for a client visit and returning.
+type AttendanceSession struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AttendanceID   uint       `gorm:"not null;index" json:"attendance_id"`
+	CheckInTime    time.Time  `gorm:"not null" json:"check_in_time"`
+	CheckOutTime   *time.Time `json:"check_out_time"`
+	Source         string     `gorm:"size:20;not null;default:'punch'" json:"source"`
+	SupersededByID *uint      `gorm:"index" json:"superseded_by_id"`    // Regularization that replaced this session; kept for audit only
+	AutoClosed     bool       `gorm:"default:false" json:"auto_closed"` // Check-out was set by the system because the employee forgot it
+
+	CheckInLocation  PunchLocation `gorm:"embedded;embeddedPrefix:check_in_" json:"check_in_location"`
+	CheckOutLocation PunchLocation `gorm:"embedded;embeddedPrefix:check_out_" json:"check_out_location"`
+	CheckInIP        string        `gorm:"size:45" json:"check_in_ip"` // Source IP of the check-in request
+	CheckOutIP       string        `gorm:"size:45" json:"check_out_ip"`
+	CheckInKioskID   *uint         `json:"check_in_kiosk_id"` // Shared kiosk used to check in, if any
+	CheckOutKioskID  *uint         `json:"check_out_kiosk_id"`
+	CheckInDevice    string        `gorm:"size:64" json:"check_in_device"` // Time clock an imported check-in came from
+	CheckOutDevice   string        `gorm:"size:64" json:"check_out_device"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttendanceSessionRepositoryInterface defines the contract for attendance session data operations
+type AttendanceSessionRepositoryInterface interface {
+	Create(session *AttendanceSession) error
+	GetByID(id uint) (*AttendanceSession, error)
+	GetByAttendanceID(attendanceID uint) ([]AttendanceSession, error)
+	GetOpenByAttendanceID(attendanceID uint) (*AttendanceSession, error)
+	Update(session *AttendanceSession) error
+	Delete(id uint) error
+}
+
+// Domain-specific errors for attendance session operations
+var (
+	ErrSessionNotFound    = errors.New("attendance session not found")
+	ErrInvalidSessionTime = errors.New("session check-out must be after check-in")
+)
+
+// Validate checks if the session data is valid
+func (s *AttendanceSession) Validate() error {
+	if s.AttendanceID == 0 {
+		return ErrInvalidAttendanceID
+	}
+	if s.CheckInTime.IsZero() {
+		return ErrInvalidSessionTime
+	}
+	if s.CheckOutTime != nil && s.CheckOutTime.Before(s.CheckInTime) {
+		return ErrInvalidSessionTime
+	}
+	return nil
+}
+
+// IsOpen returns true if the session has not been checked out yet
+func (s *AttendanceSession) IsOpen() bool {
+	return s.CheckOutTime == nil
+}
+
+// IsSuperseded returns true if the session was replaced by an approved correction
+func (s *AttendanceSession) IsSuperseded() bool {
+	return s.SupersededByID != nil
+}
+
+// Overlaps reports whether the session shares any time with the span from start to end.
+// Open sessions are treated as still running.
+func (s *AttendanceSession) Overlaps(start, end time.Time) bool {
+	if s.CheckOutTime != nil && !s.CheckOutTime.After(start) {
+		return false
+	}
+	return s.CheckInTime.Before(end)
+}
+
+// Duration returns the length of a closed session, or zero while it is still open
+func (s *AttendanceSession) Duration() time.Duration {
+	if s.CheckOutTime == nil {
+		return 0
+	}
+	return s.CheckOutTime.Sub(s.CheckInTime)
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// at returns an instant on 2024-01-15 at the given UTC hour and minute
+func at(hour, minute int) time.Time {
+	return time.Date(2024, 1, 15, hour, minute, 0, 0, time.UTC)
+}
+
+// closedSession returns a session from start to end
+func closedSession(start, end time.Time) AttendanceSession {
+	return AttendanceSession{CheckInTime: start, CheckOutTime: &end}
+}
+
+// endedBreak returns an ended break from start to end
+func endedBreak(start, end time.Time, paid bool) Break {
+	return Break{StartTime: start, EndTime: &end, IsPaid: paid}
+}
+
+func TestCalculateWorkHoursAcrossSessions(t *testing.T) {
+	regularizationID := uint(7)
+	superseded := closedSession(at(8, 0), at(20, 0))
+	superseded.SupersededByID = &regularizationID
+	open := AttendanceSession{CheckInTime: at(18, 0)}
+	checkIn, checkOut := at(9, 0), at(17, 30)
+
+	tests := []struct {
+		name       string
+		attendance Attendance
+		wantHours  float64
+	}{
+		{
+			name:       "single session",
+			attendance: Attendance{Sessions: []AttendanceSession{closedSession(at(9, 0), at(17, 0))}},
+			wantHours:  8,
+		},
+		{
+			name: "sessions are summed",
+			attendance: Attendance{Sessions: []AttendanceSession{
+				closedSession(at(9, 0), at(12, 0)),
+				closedSession(at(13, 0), at(17, 30)),
+			}},
+			wantHours: 7.5,
+		},
+		{
+			name: "superseded sessions do not count",
+			attendance: Attendance{Sessions: []AttendanceSession{
+				superseded,
+				closedSession(at(9, 0), at(17, 0)),
+			}},
+			wantHours: 8,
+		},
+		{
+			name: "open sessions do not count yet",
+			attendance: Attendance{Sessions: []AttendanceSession{
+				closedSession(at(9, 0), at(12, 0)),
+				open,
+			}},
+			wantHours: 3,
+		},
+		{
+			name:       "records without sessions use check-in and check-out",
+			attendance: Attendance{CheckInTime: &checkIn, CheckOutTime: &checkOut},
+			wantHours:  8.5,
+		},
+		{
+			name:       "records without sessions that are still open",
+			attendance: Attendance{CheckInTime: &checkIn},
+			wantHours:  0,
+		},
+		{
+			name: "unpaid breaks are subtracted, paid breaks are not",
+			attendance: Attendance{
+				Sessions: []AttendanceSession{closedSession(at(9, 0), at(17, 0))},
+				Breaks: []Break{
+					endedBreak(at(12, 0), at(12, 30), false),
+					endedBreak(at(15, 0), at(15, 15), true),
+				},
+			},
+			wantHours: 7.5,
+		},
+		{
+			name: "breaks only count within sessions",
+			attendance: Attendance{
+				Sessions: []AttendanceSession{
+					closedSession(at(9, 0), at(12, 0)),
+					closedSession(at(13, 0), at(17, 0)),
+				},
+				Breaks: []Break{endedBreak(at(11, 30), at(13, 30), false)},
+			},
+			wantHours: 6,
+		},
+		{
+			name: "breaks in progress are not subtracted",
+			attendance: Attendance{
+				Sessions: []AttendanceSession{closedSession(at(9, 0), at(17, 0))},
+				Breaks:   []Break{{StartTime: at(12, 0)}},
+			},
+			wantHours: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendance := tt.attendance
+			attendance.CalculateWorkHours(nil)
+			if math.Abs(attendance.TotalWorkHours-tt.wantHours) > 1e-9 {
+				t.Fatalf("TotalWorkHours = %v, want %v", attendance.TotalWorkHours, tt.wantHours)
+			}
+			if attendance.BreakDeduction != 0 {
+				t.Fatalf("BreakDeduction = %v, want 0 without mandatory break rules", attendance.BreakDeduction)
+			}
+		})
+	}
+}
+
+// statutoryBreaks requires 30 minutes of break after 6 hours of work and 45 minutes after 9 hours
+var statutoryBreaks = MandatoryBreakRules{
+	{After: 6 * time.Hour, Minimum: 30 * time.Minute},
+	{After: 9 * time.Hour, Minimum: 45 * time.Minute},
+}
+
+func TestMandatoryBreakRulesRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  MandatoryBreakRules
+		worked time.Duration
+		want   time.Duration
+	}{
+		{"no rules", nil, 10 * time.Hour, 0},
+		{"below every threshold", statutoryBreaks, 5 * time.Hour, 0},
+		{"exactly at a threshold", statutoryBreaks, 6 * time.Hour, 0},
+		{"above the first threshold", statutoryBreaks, 6*time.Hour + time.Minute, 30 * time.Minute},
+		{"above both thresholds", statutoryBreaks, 10 * time.Hour, 45 * time.Minute},
+		{"largest minimum regardless of order", MandatoryBreakRules{statutoryBreaks[1], statutoryBreaks[0]}, 10 * time.Hour, 45 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Required(tt.worked); got != tt.want {
+				t.Fatalf("Required(%v) = %v, want %v", tt.worked, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateWorkHoursDeductsMissingMandatoryBreaks(t *testing.T) {
+	workDay := []AttendanceSession{closedSession(at(9, 0), at(17, 0))}
+
+	tests := []struct {
+		name          string
+		attendance    Attendance
+		wantHours     float64
+		wantDeduction float64 // minutes
+	}{
+		{
+			name:          "no break taken",
+			attendance:    Attendance{Sessions: workDay},
+			wantHours:     7.5,
+			wantDeduction: 30,
+		},
+		{
+			name:       "mandatory break taken",
+			attendance: Attendance{Sessions: workDay, Breaks: []Break{endedBreak(at(12, 0), at(12, 30), false)}},
+			wantHours:  7.5,
+		},
+		{
+			name:          "break too short",
+			attendance:    Attendance{Sessions: workDay, Breaks: []Break{endedBreak(at(12, 0), at(12, 15), false)}},
+			wantHours:     7.5,
+			wantDeduction: 15,
+		},
+		{
+			name:       "paid breaks count towards the mandatory break",
+			attendance: Attendance{Sessions: workDay, Breaks: []Break{endedBreak(at(12, 0), at(12, 30), true)}},
+			wantHours:  8,
+		},
+		{
+			name:          "breaks outside the sessions do not count",
+			attendance:    Attendance{Sessions: workDay, Breaks: []Break{endedBreak(at(17, 0), at(17, 30), false)}},
+			wantHours:     7.5,
+			wantDeduction: 30,
+		},
+		{
+			name: "higher minimum after more work",
+			attendance: Attendance{
+				Sessions: []AttendanceSession{closedSession(at(8, 0), at(18, 30))},
+				Breaks:   []Break{endedBreak(at(12, 0), at(12, 30), false)},
+			},
+			wantHours:     9.75,
+			wantDeduction: 15,
+		},
+		{
+			name:       "short days need no break",
+			attendance: Attendance{Sessions: []AttendanceSession{closedSession(at(9, 0), at(15, 0))}},
+			wantHours:  6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendance := tt.attendance
+			attendance.CalculateWorkHours(statutoryBreaks)
+			if math.Abs(attendance.TotalWorkHours-tt.wantHours) > 1e-9 {
+				t.Fatalf("TotalWorkHours = %v, want %v", attendance.TotalWorkHours, tt.wantHours)
+			}
+			if math.Abs(attendance.BreakDeduction-tt.wantDeduction) > 1e-9 {
+				t.Fatalf("BreakDeduction = %v, want %v", attendance.BreakDeduction, tt.wantDeduction)
+			}
+		})
+	}
+}
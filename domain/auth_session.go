@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// AuthSession is a signed-in device of a user. It holds the refresh token the device renews its
+// short-lived access tokens with; access tokens name their session and stop working once it is revoked.
+// Refresh tokens are only stored hashed and are replaced on every use.
+type AuthSession struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	UserID            uint       `gorm:"not null;index" json:"user_id"`
+	RefreshTokenHash  string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	PreviousTokenHash string     `gorm:"index;size:64" json:"-"` // Refresh token replaced by the last rotation; presenting it again revokes the session
+	ExpiresAt         time.Time  `gorm:"not null" json:"expires_at"`
+	UserAgent         string     `gorm:"size:255" json:"user_agent"`
+	IPAddress         string     `gorm:"size:45" json:"ip_address"`
+	LastUsedAt        *time.Time `json:"last_used_at"`
+	RevokedAt         *time.Time `gorm:"index" json:"revoked_at"`
+	RevokedReason     string     `gorm:"size:50" json:"revoked_reason,omitempty"` // One of the SessionRevoked* reasons
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Reasons a session was revoked
+const (
+	SessionRevokedLogout          = "logout"
+	SessionRevokedLogoutAll       = "logout_all"
+	SessionRevokedPasswordChanged = "password_changed"
+	SessionRevokedUserDeactivated = "user_deactivated"
+	SessionRevokedUserDeleted     = "user_deleted"
+	SessionRevokedTokenReuse      = "refresh_token_reuse"
+)
+
+// AuthClient describes the device a sign-in or token refresh comes from
+type AuthClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// TokenPair is the result of a sign-in or refresh: a short-lived access token and the refresh token to renew it with
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+	SessionID             uint
+}
+
+// AuthSessionRepositoryInterface defines the contract for auth session data operations
+type AuthSessionRepositoryInterface interface {
+	Create(session *AuthSession) error
+	GetByID(id uint) (*AuthSession, error)
+	GetByRefreshTokenHash(tokenHash string) (*AuthSession, error)
+	GetByPreviousTokenHash(tokenHash string) (*AuthSession, error)
+	GetActiveByUserID(userID uint) ([]AuthSession, error)
+	Update(session *AuthSession) error
+	RevokeAllByUserID(userID uint, reason string) error
+	RevokeOthersByUserID(userID, keepSessionID uint, reason string) error
+}
+
+// AuthServiceInterface defines the contract for issuing, refreshing and revoking sign-in sessions
+type AuthServiceInterface interface {
+	IssueTokens(user *User, client AuthClient) (*TokenPair, error)
+	RefreshTokens(refreshToken string, client AuthClient) (*TokenPair, error)
+	Logout(userID, sessionID uint) error
+	LogoutAll(userID uint) error
+	GetActiveSessions(userID uint) ([]AuthSession, error)
+	ValidateSession(userID, sessionID uint) error
+}
+
+// Domain-specific errors for auth session operations
+var (
+	ErrAuthSessionNotFound = errors.New("session not found")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrAuthSessionRevoked  = errors.New("session has been revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; the session has been revoked")
+	ErrInvalidAuthSession  = errors.New("session must belong to a user")
+)
+
+// Validate checks if the session data is valid
+func (s *AuthSession) Validate() error {
+	if s.UserID == 0 || s.RefreshTokenHash == "" {
+		return ErrInvalidAuthSession
+	}
+	return nil
+}
+
+// IsRevoked returns true if the session was signed out or revoked
+func (s *AuthSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsActiveAt returns true if the session is neither revoked nor expired at the given time
+func (s *AuthSession) IsActiveAt(now time.Time) bool {
+	return !s.IsRevoked() && now.Before(s.ExpiresAt)
+}
+
+// Revoke ends the session for the given reason. Sessions already revoked keep their first reason.
+func (s *AuthSession) Revoke(reason string, now time.Time) {
+	if s.IsRevoked() {
+		return
+	}
+	s.RevokedAt = &now
+	s.RevokedReason = reason
+}
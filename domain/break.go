@@ -13,6 +13,12 @@ type Break struct {
 	EndTime      *time.Time `json:"end_time"`
 	Duration     float64    `json:"duration"` // in minutes
 	Reason       string     `json:"reason"`
+	BreakTypeID  *uint      `gorm:"index" json:"break_type_id"`            // Kind of break, if one was chosen
+	IsPaid       bool       `gorm:"not null;default:false" json:"is_paid"` // Copied from the break type; paid breaks count as work time
+	Flagged      bool       `gorm:"default:false" json:"flagged"`
+	FlagReason   string     `gorm:"type:text" json:"flag_reason,omitempty"`
+	StartIP      string     `gorm:"size:45" json:"start_ip"` // Source IP of the request that started the break
+	EndIP        string     `gorm:"size:45" json:"end_ip"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -30,18 +36,38 @@ type BreakRepositoryInterface interface {
 	Delete(id uint) error
 	GetAll() ([]Break, error)
 	GetBreaksByDateRange(startDate, endDate time.Time) ([]Break, error)
+	CountByBreakTypeID(breakTypeID uint) (int64, error)
 }
 
 // BreakServiceInterface defines the contract for break business logic
 type BreakServiceInterface interface {
-	CreateBreak(attendanceID uint, startTime time.Time, reason string) (*Break, error)
-	GetBreakByID(id uint) (*Break, error)
-	GetBreaksByAttendanceID(attendanceID uint) ([]Break, error)
+	CreateBreak(userID uint, attendanceID uint, punch Punch, breakTypeID *uint, reason string) (*Break, error)
+	GetBreakByID(userID uint, id uint) (*Break, error)
+	GetBreaksByAttendanceID(userID uint, attendanceID uint) ([]Break, error)
 	GetAllBreaks() ([]Break, error)
-	UpdateBreak(breakItem *Break) error
-	DeleteBreak(id uint) error
-	EndBreak(breakID uint, endTime time.Time) error
+	UpdateBreak(userID uint, breakItem *Break) error
+	DeleteBreak(userID uint, id uint) error
+	EndBreak(userID uint, breakID uint, punch Punch) error
 	CalculateBreakDuration(breakItem *Break) error
+	StartMyBreak(userID uint, punch Punch, breakTypeID *uint, reason string) (*Break, error)
+	EndMyBreak(userID uint, punch Punch) (*Break, error)
+	GetBreakStatus(userID uint) (*BreakStatus, error)
+}
+
+// Break states reported to employees
+const (
+	BreakStateWorking    = "working"
+	BreakStateOnBreak    = "on_break"
+	BreakStateCheckedOut = "checked_out"
+)
+
+// BreakStatus describes whether an employee is working or on a break right now
+type BreakStatus struct {
+	State       string        // BreakStateWorking, BreakStateOnBreak or BreakStateCheckedOut
+	Attendance  *Attendance   // Attendance with the open session; nil when checked out
+	ActiveBreak *Break        // Break in progress, if any
+	Since       *time.Time    // When the current state began; nil when checked out
+	Elapsed     time.Duration // Time spent in the current state so far
 }
 
 // BreakRequest represents the request structure for break operations
@@ -76,6 +102,8 @@ var (
 	ErrBreakInProgress     = errors.New("break already in progress")
 	ErrBreakNotFound       = errors.New("break not found")
 	ErrBreakAlreadyEnded   = errors.New("break already ended")
+	ErrBreakOutsideSession = errors.New("break must fall within a checked-in session")
+	ErrNoActiveBreak       = errors.New("no break in progress")
 )
 
 // Validate checks if the break data is valid
@@ -100,6 +128,16 @@ func (b *Break) CalculateDuration() {
 	b.Duration = duration.Minutes()
 }
 
+// Flag marks the break for review and records the reason
+func (b *Break) Flag(reason string) {
+	b.Flagged = true
+	if b.FlagReason == "" {
+		b.FlagReason = reason
+		return
+	}
+	b.FlagReason += "; " + reason
+}
+
 // IsEnded returns true if the break has ended
 func (b *Break) IsEnded() bool {
 	return b.EndTime != nil
@@ -129,3 +167,40 @@ func (b *Break) GetDurationInMinutes() float64 {
 func (b *Break) GetDurationInSeconds() float64 {
 	return b.Duration * 60.0
 }
+
+// BreakStatusAt reports the state of an attendance with an open session at the given time.
+// While working, the state began at the later of the session's check-in and the end of the last break.
+// The attendance must have its breaks and sessions loaded.
+func BreakStatusAt(attendance *Attendance, now time.Time) *BreakStatus {
+	status := &BreakStatus{State: BreakStateWorking, Attendance: attendance}
+
+	var since time.Time
+	if attendance.CheckInTime != nil {
+		since = *attendance.CheckInTime
+	}
+	for i := range attendance.Sessions {
+		session := &attendance.Sessions[i]
+		if session.IsOpen() && !session.IsSuperseded() {
+			since = session.CheckInTime
+		}
+	}
+
+	for i := range attendance.Breaks {
+		breakItem := &attendance.Breaks[i]
+		if breakItem.IsInProgress() {
+			status.State = BreakStateOnBreak
+			status.ActiveBreak = breakItem
+			since = breakItem.StartTime
+			break
+		}
+		if breakItem.EndTime.After(since) {
+			since = *breakItem.EndTime
+		}
+	}
+
+	status.Since = &since
+	if now.After(since) {
+		status.Elapsed = now.Sub(since)
+	}
+	return status
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BreakType defines a kind of break, e.g. lunch or prayer, with the rules that apply to it.
+// Breaks without a type are unpaid and unlimited.
+type BreakType struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Code        string    `gorm:"uniqueIndex;not null;size:20" json:"code"` // Short unique code, e.g. "lunch"
+	Name        string    `gorm:"not null;size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	IsPaid      bool      `gorm:"not null;default:false" json:"is_paid"`  // Paid breaks count as work time
+	MaxDuration int       `gorm:"not null;default:0" json:"max_duration"` // Minutes a single break may last (0 = no limit)
+	MaxPerDay   int       `gorm:"not null;default:0" json:"max_per_day"`  // Breaks of this type allowed per attendance day (0 = no limit)
+	IsActive    bool      `gorm:"not null;default:true" json:"is_active"` // Inactive types keep their history but cannot be used for new breaks
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BreakTypeRepositoryInterface defines the contract for break type data operations
+type BreakTypeRepositoryInterface interface {
+	Create(breakType *BreakType) error
+	GetByID(id uint) (*BreakType, error)
+	GetByCode(code string) (*BreakType, error)
+	GetAll() ([]BreakType, error)
+	Update(breakType *BreakType) error
+	Delete(id uint) error
+}
+
+// BreakTypeServiceInterface defines the contract for break type business logic
+type BreakTypeServiceInterface interface {
+	CreateBreakType(breakType *BreakType) error
+	GetBreakTypeByID(id uint) (*BreakType, error)
+	GetAllBreakTypes() ([]BreakType, error)
+	UpdateBreakType(breakType *BreakType) error
+	DeleteBreakType(id uint) error
+}
+
+// Domain-specific errors for break type operations
+var (
+	ErrBreakTypeNotFound    = errors.New("break type not found")
+	ErrInvalidBreakTypeName = errors.New("break type name cannot be empty")
+	ErrInvalidBreakTypeCode = errors.New("break type code cannot be empty")
+	ErrBreakTypeCodeExists  = errors.New("break type code already exists")
+	ErrInvalidBreakLimit    = errors.New("break limits cannot be negative")
+	ErrBreakTypeInactive    = errors.New("break type is not active")
+	ErrBreakTypeInUse       = errors.New("break type has recorded breaks; deactivate it instead")
+)
+
+// Validate checks if the break type data is valid
+func (t *BreakType) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrInvalidBreakTypeName
+	}
+	if strings.TrimSpace(t.Code) == "" {
+		return ErrInvalidBreakTypeCode
+	}
+	if t.MaxDuration < 0 || t.MaxPerDay < 0 {
+		return ErrInvalidBreakLimit
+	}
+	return nil
+}
+
+// CheckCount returns a flag reason if starting another break of this type exceeds the daily allowance.
+// taken is the number of breaks of this type already recorded on the attendance day.
+func (t *BreakType) CheckCount(taken int) string {
+	if t.MaxPerDay == 0 || taken < t.MaxPerDay {
+		return ""
+	}
+	return fmt.Sprintf("%s break %d of %d allowed per day", t.Code, taken+1, t.MaxPerDay)
+}
+
+// CheckDuration returns a flag reason if an ended break lasted longer than this type allows
+func (t *BreakType) CheckDuration(breakItem *Break) string {
+	if t.MaxDuration == 0 || breakItem.EndTime == nil || breakItem.Duration <= float64(t.MaxDuration) {
+		return ""
+	}
+	return fmt.Sprintf("%s break lasted %.0f minutes, limit is %d", t.Code, breakItem.Duration, t.MaxDuration)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DirectoryEntry is a person in the LDAP directory, with the attributes HRM takes over
+type DirectoryEntry struct {
+	DN        string // Distinguished name, normalized to lower case; identifies the user across syncs
+	Email     string
+	Name      string
+	ManagerDN string // DN of the manager entry, normalized like DN; empty without a manager
+	Disabled  bool   // The account is disabled in the directory
+}
+
+// DirectoryInterface defines the contract for an LDAP directory users sign in with and are synced from
+type DirectoryInterface interface {
+	// Authenticate checks the password of the entry the login (an email address) belongs to
+	// and returns ErrDirectoryInvalidCredentials if the login is unknown or the password is wrong
+	Authenticate(login, password string) (*DirectoryEntry, error)
+
+	// ListEntries returns every entry matching the configured user filter
+	ListEntries() ([]DirectoryEntry, error)
+}
+
+// Directory sync fields a change can be reported for
+const (
+	DirectoryFieldName    = "name"
+	DirectoryFieldEmail   = "email"
+	DirectoryFieldActive  = "is_active"
+	DirectoryFieldManager = "manager"
+	DirectoryFieldDN      = "directory_dn"
+)
+
+// DirectoryFieldChange is one attribute a sync changes, with the values as shown in the report
+type DirectoryFieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// DirectoryUserChange is a user a sync creates, updates or deactivates
+type DirectoryUserChange struct {
+	UserID  uint // 0 for users a dry run would create
+	DN      string
+	Email   string
+	Changes []DirectoryFieldChange
+}
+
+// DirectorySyncIssue describes a directory entry that could not be synced
+type DirectorySyncIssue struct {
+	DN      string
+	Message string
+}
+
+// DirectorySyncReport summarizes what a directory sync changed, or would change in a dry run
+type DirectorySyncReport struct {
+	DryRun      bool
+	Entries     int // Entries read from the directory
+	Created     []DirectoryUserChange
+	Updated     []DirectoryUserChange
+	Deactivated []DirectoryUserChange // Directory users no longer found in the directory
+	Unchanged   int
+	Issues      []DirectorySyncIssue
+}
+
+// DirectorySyncServiceInterface defines the contract for syncing users and managers from the directory
+type DirectorySyncServiceInterface interface {
+	Sync(dryRun bool) (*DirectorySyncReport, error)
+}
+
+// Domain-specific errors for the LDAP directory
+var (
+	ErrDirectoryInvalidCredentials = errors.New("invalid directory credentials")
+	ErrDirectoryUnavailable        = errors.New("the directory is unavailable")
+	ErrDirectoryEmpty              = errors.New("the directory returned no users; refusing to deactivate everyone")
+	ErrPasswordManagedByDirectory  = errors.New("the password of this account is managed in the company directory")
+)
+
+// AddIssue records a directory entry that could not be synced
+func (r *DirectorySyncReport) AddIssue(dn, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, DirectorySyncIssue{DN: dn, Message: fmt.Sprintf(format, args...)})
+}
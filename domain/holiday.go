@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Holiday represents a public or company holiday on which no attendance is expected.
+// A holiday without a location applies to every location.
+type Holiday struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Name       string    `gorm:"not null;size:100" json:"name"`
+	Date       time.Time `gorm:"not null;type:date;index" json:"date"`
+	LocationID *uint     `gorm:"index" json:"location_id"` // Location the holiday is observed at; nil for all locations
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HolidayRepositoryInterface defines the contract for holiday data operations
+type HolidayRepositoryInterface interface {
+	Create(holiday *Holiday) error
+	GetByID(id uint) (*Holiday, error)
+	GetAll() ([]Holiday, error)
+	GetByDateRange(startDate, endDate time.Time) ([]Holiday, error)
+	Update(holiday *Holiday) error
+	Delete(id uint) error
+}
+
+// HolidayServiceInterface defines the contract for holiday business logic
+type HolidayServiceInterface interface {
+	CreateHoliday(holiday *Holiday) error
+	GetHolidayByID(id uint) (*Holiday, error)
+	GetAllHolidays() ([]Holiday, error)
+	GetHolidaysByDateRange(startDate, endDate time.Time) ([]Holiday, error)
+	UpdateHoliday(holiday *Holiday) error
+	DeleteHoliday(id uint) error
+}
+
+// Domain-specific errors for holiday operations
+var (
+	ErrHolidayNotFound    = errors.New("holiday not found")
+	ErrInvalidHolidayName = errors.New("holiday name cannot be empty")
+)
+
+// Validate checks if the holiday data is valid
+func (h *Holiday) Validate() error {
+	if strings.TrimSpace(h.Name) == "" {
+		return ErrInvalidHolidayName
+	}
+	if h.Date.IsZero() {
+		return ErrInvalidDate
+	}
+	return nil
+}
+
+// AppliesTo returns true if the holiday is observed at the given location
+func (h *Holiday) AppliesTo(locationID *uint) bool {
+	if h.LocationID == nil {
+		return true
+	}
+	return locationID != nil && *h.LocationID == *locationID
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Kiosk represents a shared device, e.g. a tablet at a factory gate, that employees punch on
+// without signing in. The device authenticates with its own token, which is only stored hashed.
+type Kiosk struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Name       string     `gorm:"not null;size:100" json:"name"`
+	LocationID *uint      `gorm:"index" json:"location_id"` // Site the kiosk stands at; employees of other sites are refused
+	TokenHash  string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	IsActive   bool       `gorm:"not null;default:true" json:"is_active"` // Inactive kiosks are refused until re-activated
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// KioskCredentials identify an employee at a kiosk: either an employee code with its PIN, or a badge ID
+type KioskCredentials struct {
+	EmployeeCode string
+	PIN          string
+	BadgeID      string
+}
+
+// KioskPINPolicy holds the brute-force protection of kiosk PINs.
+// The kiosk rate limit only slows down a single device, so wrong PINs are also counted per employee code.
+type KioskPINPolicy struct {
+	MaxFailedAttempts int           // Consecutive wrong PINs for an employee code before it is locked at all kiosks (0 disables lockout)
+	LockoutDuration   time.Duration // How long a locked employee code is refused
+}
+
+// KioskRepositoryInterface defines the contract for kiosk data operations
+type KioskRepositoryInterface interface {
+	Create(kiosk *Kiosk) error
+	GetByID(id uint) (*Kiosk, error)
+	GetByTokenHash(tokenHash string) (*Kiosk, error)
+	GetAll() ([]Kiosk, error)
+	Update(kiosk *Kiosk) error
+	Delete(id uint) error
+}
+
+// KioskServiceInterface defines the contract for kiosk registration and kiosk punches
+type KioskServiceInterface interface {
+	RegisterKiosk(kiosk *Kiosk) (string, error)
+	GetKioskByID(id uint) (*Kiosk, error)
+	GetAllKiosks() ([]Kiosk, error)
+	UpdateKiosk(kiosk *Kiosk) error
+	RotateKioskToken(id uint) (string, error)
+	DeleteKiosk(id uint) error
+	AuthenticateKiosk(token string) (*Kiosk, error)
+	CheckIn(kiosk *Kiosk, credentials KioskCredentials, punch Punch) (*User, *Attendance, error)
+	CheckOut(kiosk *Kiosk, credentials KioskCredentials, punch Punch) (*User, *Attendance, error)
+	StartBreak(kiosk *Kiosk, credentials KioskCredentials, punch Punch, breakTypeID *uint, reason string) (*User, *Break, error)
+	EndBreak(kiosk *Kiosk, credentials KioskCredentials, punch Punch) (*User, *Break, error)
+}
+
+// Domain-specific errors for kiosk operations
+var (
+	ErrKioskNotFound            = errors.New("kiosk not found")
+	ErrInvalidKioskName         = errors.New("kiosk name cannot be empty")
+	ErrInvalidKioskToken        = errors.New("invalid kiosk token")
+	ErrKioskInactive            = errors.New("kiosk is deactivated")
+	ErrKioskCredentialsRequired = errors.New("employee code and PIN, or badge ID, are required")
+	ErrInvalidKioskCredentials  = errors.New("invalid employee code, PIN or badge")
+	ErrKioskLocationMismatch    = errors.New("employee is not assigned to this kiosk's location")
+	ErrKioskPINLocked           = errors.New("employee code is temporarily locked after too many wrong PINs")
+)
+
+// Validate checks if the kiosk data is valid
+func (k *Kiosk) Validate() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return ErrInvalidKioskName
+	}
+	return nil
+}
+
+// Validate checks that the credentials name either an employee code with a PIN or a badge
+func (c KioskCredentials) Validate() error {
+	if c.BadgeID != "" {
+		return nil
+	}
+	if c.EmployeeCode == "" || c.PIN == "" {
+		return ErrKioskCredentialsRequired
+	}
+	return nil
+}
+
+// UsesBadge returns true if the employee identifies with a badge rather than a code and PIN
+func (c KioskCredentials) UsesBadge() bool {
+	return c.BadgeID != ""
+}
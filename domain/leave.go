@@ -60,6 +60,7 @@ type LeaveRepositoryInterface interface {
 	GetByUserID(userID uint) ([]Leave, error)
 	GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]Leave, error)
 	GetByStatus(status LeaveStatus) ([]Leave, error)
+	GetApprovedOnDate(date time.Time) ([]Leave, error)
 	GetByType(leaveType LeaveTypeName) ([]Leave, error)
 	GetPendingLeaves() ([]Leave, error)
 	Update(leave *Leave) error
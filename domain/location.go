@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"time"
+)
+
+// Geofence modes decide what happens to a punch outside a location's geofence
+const (
+	GeofenceModeFlag   = "flag"   // Accept the punch and flag the attendance for review
+	GeofenceModeReject = "reject" // Refuse the punch
+)
+
+// earthRadiusMeters is the mean Earth radius used for distance calculations
+const earthRadiusMeters = 6371000.0
+
+// Location represents an office or site that employees are assigned to
+type Location struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Timezone string `gorm:"not null;size:64" json:"timezone"` // IANA time zone name, e.g. "Asia/Karachi"
+
+	// Geofence: punches of employees assigned to this location must be within GeofenceRadius meters of the center
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	GeofenceRadius float64  `gorm:"default:0" json:"geofence_radius"` // 0 disables the geofence
+	GeofenceMode   string   `gorm:"size:10" json:"geofence_mode"`     // "flag" or "reject"; empty uses the attendance policy
+
+	// Comma-separated CIDR ranges (or single IPs) employees of this location must punch from; empty allows any network
+	AllowedNetworks string `gorm:"type:text" json:"allowed_networks"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LocationRepositoryInterface defines the contract for location data operations
+type LocationRepositoryInterface interface {
+	Create(location *Location) error
+	GetByID(id uint) (*Location, error)
+	GetAll() ([]Location, error)
+	Update(location *Location) error
+	Delete(id uint) error
+}
+
+// LocationServiceInterface defines the contract for location business logic
+type LocationServiceInterface interface {
+	CreateLocation(location *Location) error
+	GetLocationByID(id uint) (*Location, error)
+	GetAllLocations() ([]Location, error)
+	UpdateLocation(location *Location) error
+	DeleteLocation(id uint) error
+}
+
+// Domain-specific errors for location operations
+var (
+	ErrLocationNotFound    = errors.New("location not found")
+	ErrInvalidLocationName = errors.New("location name cannot be empty")
+	ErrInvalidTimezone     = errors.New("invalid time zone")
+	ErrInvalidCoordinates  = errors.New("latitude must be between -90 and 90 and longitude between -180 and 180")
+	ErrGeofenceCenter      = errors.New("geofence requires latitude and longitude")
+	ErrInvalidGeofence     = errors.New("geofence radius cannot be negative")
+	ErrInvalidGeofenceMode = errors.New("geofence mode must be flag or reject")
+	ErrInvalidNetwork      = errors.New("allowed networks must be CIDR ranges or IP addresses")
+)
+
+// Validate checks if the location data is valid
+func (l *Location) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrInvalidLocationName
+	}
+	if l.Timezone == "" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(l.Timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	if (l.Latitude == nil) != (l.Longitude == nil) {
+		return ErrGeofenceCenter
+	}
+	if l.Latitude != nil && !ValidCoordinates(*l.Latitude, *l.Longitude) {
+		return ErrInvalidCoordinates
+	}
+	if l.GeofenceRadius < 0 {
+		return ErrInvalidGeofence
+	}
+	if l.GeofenceRadius > 0 && l.Latitude == nil {
+		return ErrGeofenceCenter
+	}
+	if l.GeofenceMode != "" && l.GeofenceMode != GeofenceModeFlag && l.GeofenceMode != GeofenceModeReject {
+		return ErrInvalidGeofenceMode
+	}
+	if _, err := l.Networks(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NetworkList returns the configured allow-list entries
+func (l *Location) NetworkList() []string {
+	var entries []string
+	for _, entry := range strings.Split(l.AllowedNetworks, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Networks parses the allow-list; single IP addresses are treated as /32 (or /128) ranges
+func (l *Location) Networks() ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range l.NetworkList() {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, ErrInvalidNetwork
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, ErrInvalidNetwork
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// HasNetworkRestriction returns true if punches at this location must come from an allowed network
+func (l *Location) HasNetworkRestriction() bool {
+	return len(l.NetworkList()) > 0
+}
+
+// AllowsIP returns true if the address belongs to one of the allowed networks
+func (l *Location) AllowsIP(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	networks, err := l.Networks()
+	if err != nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasGeofence returns true if punches at this location are checked against a geofence
+func (l *Location) HasGeofence() bool {
+	return l.Latitude != nil && l.Longitude != nil && l.GeofenceRadius > 0
+}
+
+// DistanceTo returns the great-circle distance in meters between the location's center and a point
+func (l *Location) DistanceTo(latitude, longitude float64) float64 {
+	if l.Latitude == nil || l.Longitude == nil {
+		return 0
+	}
+	return haversineMeters(*l.Latitude, *l.Longitude, latitude, longitude)
+}
+
+// ValidCoordinates returns true if the latitude and longitude are within their ranges
+func ValidCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// haversineMeters computes the great-circle distance between two points in meters
+func haversineMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// TimeLocation returns the loaded time zone of the location, falling back to UTC
+func (l *Location) TimeLocation() *time.Location {
+	loc, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestAllowsIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks string
+		address  string
+		want     bool
+	}{
+		{"inside a range", "203.0.113.0/24", "203.0.113.42", true},
+		{"outside a range", "203.0.113.0/24", "198.51.100.7", false},
+		{"single address", "198.51.100.7", "198.51.100.7", true},
+		{"other single address", "198.51.100.7", "198.51.100.8", false},
+		{"any of several entries", "10.0.0.0/8, 198.51.100.7", "198.51.100.7", true},
+		{"IPv6 range", "2001:db8::/32", "2001:db8::1", true},
+		{"IPv4 address in an IPv6 range", "2001:db8::/32", "203.0.113.42", false},
+		{"IPv4-mapped IPv6 address", "203.0.113.0/24", "::ffff:203.0.113.42", true},
+		{"unparsable address", "203.0.113.0/24", "not-an-ip", false},
+		{"empty address", "203.0.113.0/24", "", false},
+		{"invalid allow-list", "203.0.113.0/33", "203.0.113.42", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := &Location{AllowedNetworks: tt.networks}
+			if got := location.AllowsIP(tt.address); got != tt.want {
+				t.Fatalf("AllowsIP(%q) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+// MailMessage is a plain-text email sent to a single recipient
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MailSenderInterface defines the contract for delivering emails.
+// Implementations can send through SMTP or, for local use, write messages to files or the log.
+type MailSenderInterface interface {
+	Send(message MailMessage) error
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// OIDCIdentity links an account at the identity provider to an HRM user.
+// Users are found by the provider's stable subject, so changing the email address there keeps the link.
+type OIDCIdentity struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Issuer      string     `gorm:"not null;size:255;uniqueIndex:idx_oidc_issuer_subject" json:"issuer"`
+	Subject     string     `gorm:"not null;size:255;uniqueIndex:idx_oidc_issuer_subject" json:"subject"`
+	Email       string     `gorm:"size:255" json:"email"` // Email address the provider reported at the last sign-in
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// OIDCLoginState is a sign-in started at HRM and not yet returned from the identity provider.
+// It carries the PKCE code verifier and nonce the callback is checked with.
+type OIDCLoginState struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	StateHash    string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	Nonce        string     `gorm:"not null;size:64" json:"-"`
+	CodeVerifier string     `gorm:"not null;size:128" json:"-"`
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt       *time.Time `json:"used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// OIDCClaims is what HRM uses from a verified ID token and the userinfo endpoint
+type OIDCClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// OIDCLoginStart is where to send the browser to sign in at the identity provider
+type OIDCLoginStart struct {
+	AuthorizationURL string
+	State            string // Also set as a cookie, so the callback can only be completed by the same browser
+}
+
+// OIDCProviderInterface defines the contract for talking to the identity provider
+type OIDCProviderInterface interface {
+	// AuthorizationURL returns the provider URL that starts an authorization code flow with PKCE (S256)
+	AuthorizationURL(state, nonce, codeChallenge string) (string, error)
+
+	// Exchange redeems an authorization code and returns the claims of the verified ID token;
+	// the token must carry the given nonce
+	Exchange(code, codeVerifier, nonce string) (*OIDCClaims, error)
+}
+
+// OIDCIdentityRepositoryInterface defines the contract for identity link data operations
+type OIDCIdentityRepositoryInterface interface {
+	Create(identity *OIDCIdentity) error
+	GetBySubject(issuer, subject string) (*OIDCIdentity, error)
+	GetByUserID(issuer string, userID uint) (*OIDCIdentity, error)
+	Update(identity *OIDCIdentity) error
+}
+
+// OIDCLoginStateRepositoryInterface defines the contract for pending OIDC sign-in data operations
+type OIDCLoginStateRepositoryInterface interface {
+	Create(state *OIDCLoginState) error
+	GetByStateHash(stateHash string) (*OIDCLoginState, error)
+	Update(state *OIDCLoginState) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// OIDCServiceInterface defines the contract for single sign-on through an OpenID Connect provider
+type OIDCServiceInterface interface {
+	StartLogin() (*OIDCLoginStart, error)
+	CompleteLogin(state, code string, client AuthClient) (*User, error)
+}
+
+// Domain-specific errors for OIDC sign-in
+var (
+	ErrOIDCIdentityNotFound    = errors.New("identity not linked")
+	ErrOIDCLoginStateNotFound  = errors.New("login state not found")
+	ErrInvalidOIDCState        = errors.New("invalid or expired sign-in, please start again")
+	ErrOIDCLoginFailed         = errors.New("sign-in at the identity provider failed")
+	ErrOIDCEmailNotVerified    = errors.New("the identity provider did not confirm a verified email address")
+	ErrOIDCUserNotProvisioned  = errors.New("no HRM account exists for this identity")
+	ErrOIDCIdentityConflict    = errors.New("the HRM account with this email is linked to another identity")
+	ErrInvalidOIDCIdentityData = errors.New("identity must belong to a user and have an issuer and subject")
+	ErrInvalidOIDCStateData    = errors.New("login state must have a state hash, nonce and code verifier")
+)
+
+// Validate checks if the identity data is valid
+func (i *OIDCIdentity) Validate() error {
+	if i.UserID == 0 || i.Issuer == "" || i.Subject == "" {
+		return ErrInvalidOIDCIdentityData
+	}
+	return nil
+}
+
+// Validate checks if the login state data is valid
+func (s *OIDCLoginState) Validate() error {
+	if s.StateHash == "" || s.Nonce == "" || s.CodeVerifier == "" {
+		return ErrInvalidOIDCStateData
+	}
+	return nil
+}
+
+// IsUsableAt returns true if the sign-in can still be completed at the given time
+func (s *OIDCLoginState) IsUsableAt(now time.Time) bool {
+	return s.UsedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RoleForGroups returns the highest role granted by any of the groups under the mapping of group names to roles,
+// or RoleEmployee if none of the groups is mapped
+func RoleForGroups(groups []string, groupRoles map[string]string) string {
+	rank := map[string]int{RoleEmployee: 0, RoleSecurityAdmin: 1, RoleAdmin: 2}
+	role := RoleEmployee
+	for _, group := range groups {
+		if mapped, ok := groupRoles[group]; ok && rank[mapped] > rank[role] {
+			role = mapped
+		}
+	}
+	return role
+}
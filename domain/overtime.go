@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// PayPeriodFrequency represents how often employees are paid
+type PayPeriodFrequency string
+
+const (
+	PayPeriodWeekly      PayPeriodFrequency = "weekly"
+	PayPeriodBiweekly    PayPeriodFrequency = "biweekly"
+	PayPeriodSemiMonthly PayPeriodFrequency = "semimonthly"
+	PayPeriodMonthly     PayPeriodFrequency = "monthly"
+)
+
+// OvertimeKind describes why hours were counted as overtime
+type OvertimeKind string
+
+const (
+	OvertimeKindDaily   OvertimeKind = "daily"   // Hours beyond the daily threshold
+	OvertimeKindWeekly  OvertimeKind = "weekly"  // Regular hours beyond the weekly threshold
+	OvertimeKindWeekend OvertimeKind = "weekend" // All hours worked on a weekend day
+	OvertimeKindHoliday OvertimeKind = "holiday" // All hours worked on a holiday
+)
+
+// OvertimeStatus represents the approval state of overtime
+type OvertimeStatus string
+
+const (
+	OvertimeStatusPending   OvertimeStatus = "pending"
+	OvertimeStatusApproved  OvertimeStatus = "approved"
+	OvertimeStatusRejected  OvertimeStatus = "rejected"
+	OvertimeStatusCancelled OvertimeStatus = "cancelled"
+)
+
+// OvertimePolicy holds the organisation-wide overtime rules.
+// It is loaded once from configuration and injected into the overtime service.
+type OvertimePolicy struct {
+	DailyThresholdHours  float64            // Hours per day after which overtime starts (0 disables the daily rule)
+	WeeklyThresholdHours float64            // Regular hours per week after which overtime starts (0 disables the weekly rule)
+	Multiplier           float64            // Pay multiplier for daily and weekly overtime
+	WeekendMultiplier    float64            // Pay multiplier for hours worked on weekend days
+	HolidayMultiplier    float64            // Pay multiplier for hours worked on holidays
+	WeekStart            time.Weekday       // First day of the overtime week
+	PayPeriod            PayPeriodFrequency // How the overtime ledger is grouped
+	PayPeriodAnchor      time.Time          // Any first day of a pay period; used by weekly and biweekly periods
+	Interval             time.Duration      // How often overtime is recalculated in the background (0 disables it)
+	LookbackDays         int                // How many past days the background recalculation covers
+}
+
+// OvertimeEntry is one line of the overtime ledger: the overtime of a user on one work day.
+// Entries are recalculated from attendance; when the hours of an entry change it goes back
+// to pending unless a pre-approval covers it.
+type OvertimeEntry struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	UserID         uint           `gorm:"not null;uniqueIndex:idx_overtime_user_date" json:"user_id"`
+	AttendanceID   uint           `gorm:"not null;index" json:"attendance_id"`
+	Date           time.Time      `gorm:"not null;type:date;uniqueIndex:idx_overtime_user_date" json:"date"`
+	PayPeriodStart time.Time      `gorm:"not null;type:date;index" json:"pay_period_start"`
+	PayPeriodEnd   time.Time      `gorm:"not null;type:date" json:"pay_period_end"`
+	WorkedHours    float64        `json:"worked_hours"`
+	OvertimeHours  float64        `json:"overtime_hours"`
+	Multiplier     float64        `json:"multiplier"`
+	WeightedHours  float64        `json:"weighted_hours"` // OvertimeHours × Multiplier
+	Kind           OvertimeKind   `gorm:"not null;type:varchar(20)" json:"kind"`
+	Status         OvertimeStatus `gorm:"not null;type:varchar(20);default:'pending'" json:"status"`
+	RequestID      *uint          `gorm:"index" json:"request_id"` // Pre-approval that covered this overtime
+	ReviewedBy     *uint          `gorm:"index" json:"reviewed_by"`
+	ReviewedAt     *time.Time     `json:"reviewed_at"`
+	ReviewNote     string         `gorm:"type:text" json:"review_note"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OvertimeLedger summarises the overtime of a user for one pay period
+type OvertimeLedger struct {
+	UserID                uint
+	PeriodStart           time.Time
+	PeriodEnd             time.Time
+	Entries               []OvertimeEntry
+	TotalOvertimeHours    float64
+	TotalWeightedHours    float64
+	ApprovedOvertimeHours float64
+	ApprovedWeightedHours float64
+	PendingOvertimeHours  float64
+	RejectedOvertimeHours float64
+}
+
+// OvertimeEntryRepositoryInterface defines the contract for overtime ledger data operations
+type OvertimeEntryRepositoryInterface interface {
+	Create(entry *OvertimeEntry) error
+	GetByID(id uint) (*OvertimeEntry, error)
+	GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]OvertimeEntry, error)
+	GetByStatus(status OvertimeStatus) ([]OvertimeEntry, error)
+	Update(entry *OvertimeEntry) error
+	Delete(id uint) error
+}
+
+// OvertimeServiceInterface defines the contract for overtime business logic
+type OvertimeServiceInterface interface {
+	RecalculateUserRange(userID uint, requesterID uint, startDate, endDate time.Time) error
+	RecalculateRecent(now time.Time) (int, error)
+	GetLedger(userID uint, viewerID uint, date time.Time) (*OvertimeLedger, error)
+	GetEntryByID(id uint) (*OvertimeEntry, error)
+	GetPendingEntries(approverID uint) ([]OvertimeEntry, error)
+	ApproveEntry(id uint, approverID uint, note string) error
+	RejectEntry(id uint, approverID uint, note string) error
+	RequestPreApproval(userID uint, request *OvertimeRequest) error
+	GetRequestByID(id uint) (*OvertimeRequest, error)
+	GetUserRequests(userID uint) ([]OvertimeRequest, error)
+	GetPendingRequests(approverID uint) ([]OvertimeRequest, error)
+	ApproveRequest(id uint, approverID uint, note string) error
+	RejectRequest(id uint, approverID uint, note string) error
+	CancelRequest(id uint, userID uint) error
+}
+
+// Domain-specific errors for overtime operations
+var (
+	ErrOvertimeEntryNotFound = errors.New("overtime entry not found")
+	ErrOvertimeNotPending    = errors.New("overtime is no longer pending")
+	ErrOvertimeRangeTooLong  = errors.New("the date range must lie within one pay period")
+)
+
+// PayPeriodFor returns the first and last day of the pay period that contains the given work day
+func (p OvertimePolicy) PayPeriodFor(day time.Time) (time.Time, time.Time) {
+	day = DateOnly(day)
+
+	switch p.PayPeriod {
+	case PayPeriodWeekly, PayPeriodBiweekly:
+		length := 7
+		if p.PayPeriod == PayPeriodBiweekly {
+			length = 14
+		}
+		anchor := DateOnly(p.PayPeriodAnchor)
+		if p.PayPeriodAnchor.IsZero() {
+			// Without an anchor, periods start on the week start counted from a fixed epoch
+			anchor = p.WeekStartFor(time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC))
+		}
+		days := int(day.Sub(anchor).Hours() / 24)
+		offset := ((days % length) + length) % length
+		start := day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, length-1)
+	case PayPeriodSemiMonthly:
+		if day.Day() <= 15 {
+			start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 0, 14)
+		}
+		start := time.Date(day.Year(), day.Month(), 16, 0, 0, 0, 0, time.UTC)
+		return start, time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1)
+	}
+}
+
+// WeekStartFor returns the first day of the overtime week that contains the given work day
+func (p OvertimePolicy) WeekStartFor(day time.Time) time.Time {
+	return StartOfWeek(day, p.WeekStart)
+}
+
+// IsPending returns true if the overtime entry is waiting for review
+func (e *OvertimeEntry) IsPending() bool {
+	return e.Status == OvertimeStatusPending
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// OvertimeRequest represents an employee asking in advance to work overtime on a day.
+// Once approved, overtime recorded on that day up to the approved hours is approved automatically.
+type OvertimeRequest struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	UserID     uint           `gorm:"not null;index" json:"user_id"`
+	Date       time.Time      `gorm:"not null;type:date" json:"date"`
+	Hours      float64        `gorm:"not null" json:"hours"`
+	Reason     string         `gorm:"not null;type:text" json:"reason"`
+	Status     OvertimeStatus `gorm:"not null;type:varchar(20);default:'pending'" json:"status"`
+	ReviewedBy *uint          `gorm:"index" json:"reviewed_by"`
+	ReviewedAt *time.Time     `json:"reviewed_at"`
+	ReviewNote string         `gorm:"type:text" json:"review_note"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OvertimeRequestRepositoryInterface defines the contract for overtime pre-approval data operations
+type OvertimeRequestRepositoryInterface interface {
+	Create(request *OvertimeRequest) error
+	GetByID(id uint) (*OvertimeRequest, error)
+	GetByUserID(userID uint) ([]OvertimeRequest, error)
+	GetByStatus(status OvertimeStatus) ([]OvertimeRequest, error)
+	GetApprovedByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]OvertimeRequest, error)
+	GetOpenByUserIDAndDate(userID uint, date time.Time) (*OvertimeRequest, error)
+	Update(request *OvertimeRequest) error
+}
+
+// Domain-specific errors for overtime request operations
+var (
+	ErrOvertimeRequestNotFound      = errors.New("overtime request not found")
+	ErrOvertimeRequestAlreadyExists = errors.New("an overtime request already exists for this date")
+	ErrInvalidOvertimeHours         = errors.New("overtime hours must be between 0 and 24")
+	ErrOvertimeReason               = errors.New("reason is required")
+	ErrOvertimeRequestInPast        = errors.New("overtime must be requested before the day it is worked")
+)
+
+// Validate checks if the overtime request data is valid
+func (r *OvertimeRequest) Validate() error {
+	if r.UserID == 0 {
+		return ErrInvalidUserID
+	}
+	if r.Date.IsZero() {
+		return ErrInvalidDate
+	}
+	if r.Hours <= 0 || r.Hours > 24 {
+		return ErrInvalidOvertimeHours
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return ErrOvertimeReason
+	}
+	return nil
+}
+
+// IsPending returns true if the request is waiting for review
+func (r *OvertimeRequest) IsPending() bool {
+	return r.Status == OvertimeStatusPending
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPayPeriodFor(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		policy    OvertimePolicy
+		day       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"monthly", OvertimePolicy{PayPeriod: PayPeriodMonthly}, date(2024, 2, 14), date(2024, 2, 1), date(2024, 2, 29)},
+		{"semimonthly first half", OvertimePolicy{PayPeriod: PayPeriodSemiMonthly}, date(2024, 1, 15), date(2024, 1, 1), date(2024, 1, 15)},
+		{"semimonthly second half", OvertimePolicy{PayPeriod: PayPeriodSemiMonthly}, date(2024, 1, 16), date(2024, 1, 16), date(2024, 1, 31)},
+		{"weekly from the week start", OvertimePolicy{PayPeriod: PayPeriodWeekly, WeekStart: time.Monday}, date(2024, 1, 17), date(2024, 1, 15), date(2024, 1, 21)},
+		{"biweekly from the anchor", OvertimePolicy{PayPeriod: PayPeriodBiweekly, PayPeriodAnchor: date(2024, 1, 1)}, date(2024, 1, 20), date(2024, 1, 15), date(2024, 1, 28)},
+		{"biweekly before the anchor", OvertimePolicy{PayPeriod: PayPeriodBiweekly, PayPeriodAnchor: date(2024, 1, 15)}, date(2024, 1, 10), date(2024, 1, 1), date(2024, 1, 14)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.policy.PayPeriodFor(tt.day)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Fatalf("PayPeriodFor = %s to %s, want %s to %s",
+					start.Format("2006-01-02"), end.Format("2006-01-02"), tt.wantStart.Format("2006-01-02"), tt.wantEnd.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PasswordPolicy holds the rules new passwords have to follow.
+// Existing passwords keep working until they are changed.
+type PasswordPolicy struct {
+	MinLength     int                 // Minimum number of characters
+	MaxLength     int                 // Maximum length in bytes (bcrypt only hashes the first 72)
+	RequireUpper  bool                // At least one uppercase letter
+	RequireLower  bool                // At least one lowercase letter
+	RequireDigit  bool                // At least one digit
+	RequireSymbol bool                // At least one character that is not a letter or digit
+	HistorySize   int                 // How many of the user's most recent passwords, including the current one, cannot be reused (0 allows reuse)
+	Blocklist     map[string]struct{} // Common and breached passwords, lower-cased
+}
+
+// PasswordHistory is the hash of a password a user had before, kept to refuse its reuse
+type PasswordHistory struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index" json:"user_id"`
+	PasswordHash string    `gorm:"not null;size:255" json:"-"`
+	CreatedAt    time.Time `json:"created_at"` // When the password was replaced
+}
+
+// PasswordHistoryRepositoryInterface defines the contract for password history data operations
+type PasswordHistoryRepositoryInterface interface {
+	Create(entry *PasswordHistory) error
+	ListRecent(userID uint, limit int) ([]PasswordHistory, error)
+	DeleteAllButRecent(userID uint, keep int) error
+}
+
+// PasswordPolicyError explains why the password policy refuses a new password
+type PasswordPolicyError struct {
+	Reason string
+}
+
+// Error returns the reason the password was refused
+func (e *PasswordPolicyError) Error() string {
+	return e.Reason
+}
+
+// Unwrap makes policy errors match ErrInvalidPassword
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrInvalidPassword
+}
+
+// Domain-specific errors for password changes
+var (
+	ErrPasswordReused      = errors.New("password was used recently, choose a different one")
+	ErrIncorrectPassword   = errors.New("current password is incorrect")
+	ErrInvalidPasswordData = errors.New("password history entry must belong to a user and have a hash")
+)
+
+// Validate checks if the password history entry is valid
+func (h *PasswordHistory) Validate() error {
+	if h.UserID == 0 || h.PasswordHash == "" {
+		return ErrInvalidPasswordData
+	}
+	return nil
+}
+
+// Check returns a *PasswordPolicyError if the password breaks one of the rules.
+// The user's email address and name are refused as part of their password.
+func (p PasswordPolicy) Check(password string, user *User) error {
+	if len([]rune(password)) < p.MinLength {
+		return &PasswordPolicyError{Reason: fmt.Sprintf("password must be at least %d characters", p.MinLength)}
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return &PasswordPolicyError{Reason: fmt.Sprintf("password must be at most %d characters", p.MaxLength)}
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	var missing []string
+	if p.RequireUpper && !hasUpper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) > 0 {
+		return &PasswordPolicyError{Reason: "password must contain " + strings.Join(missing, ", ")}
+	}
+
+	lower := strings.ToLower(password)
+	if _, blocked := p.Blocklist[lower]; blocked {
+		return &PasswordPolicyError{Reason: "password is too common or has appeared in a data breach"}
+	}
+	if user != nil && containsPersonalInfo(lower, user) {
+		return &PasswordPolicyError{Reason: "password must not contain your email address or name"}
+	}
+
+	return nil
+}
+
+// containsPersonalInfo reports whether a lower-cased password contains the user's email name or full name
+func containsPersonalInfo(password string, user *User) bool {
+	candidates := []string{strings.ToLower(strings.TrimSpace(user.Name))}
+	if at := strings.Index(user.Email, "@"); at > 0 {
+		candidates = append(candidates, strings.ToLower(user.Email[:at]))
+	}
+	for _, candidate := range candidates {
+		if len(candidate) >= 4 && strings.Contains(password, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Project represents a client project or internal initiative that time can be booked against
+type Project struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"not null;size:100" json:"name"`
+	Code        string    `gorm:"uniqueIndex;not null;size:20" json:"code"` // Short unique code, e.g. "ACME-01"
+	Description string    `gorm:"type:text" json:"description"`
+	IsActive    bool      `gorm:"not null;default:true" json:"is_active"` // Inactive projects keep their history but accept no new time
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProjectRepositoryInterface defines the contract for project data operations
+type ProjectRepositoryInterface interface {
+	Create(project *Project) error
+	GetByID(id uint) (*Project, error)
+	GetByCode(code string) (*Project, error)
+	GetAll() ([]Project, error)
+	Update(project *Project) error
+	Delete(id uint) error
+}
+
+// ProjectServiceInterface defines the contract for project business logic
+type ProjectServiceInterface interface {
+	CreateProject(project *Project) error
+	GetProjectByID(id uint) (*Project, error)
+	GetAllProjects() ([]Project, error)
+	UpdateProject(project *Project) error
+	DeleteProject(id uint) error
+}
+
+// Domain-specific errors for project operations
+var (
+	ErrProjectNotFound    = errors.New("project not found")
+	ErrInvalidProjectName = errors.New("project name cannot be empty")
+	ErrInvalidProjectCode = errors.New("project code cannot be empty")
+	ErrProjectCodeExists  = errors.New("project code already exists")
+	ErrProjectInactive    = errors.New("project is not active")
+	ErrProjectInUse       = errors.New("project has time entries; deactivate it instead")
+)
+
+// Validate checks if the project data is valid
+func (p *Project) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrInvalidProjectName
+	}
+	if strings.TrimSpace(p.Code) == "" {
+		return ErrInvalidProjectCode
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"io"
+)
+
+// Punch log formats accepted by the importer
+const (
+	PunchLogFormatCSV  = "csv"
+	PunchLogFormatJSON = "json"
+)
+
+// Punch directions in imported logs; punches without a direction alternate between check-in and check-out
+const (
+	PunchDirectionIn  = "in"
+	PunchDirectionOut = "out"
+)
+
+// Fields of a punch log row that can be mapped to a column (CSV) or key (JSON)
+const (
+	PunchFieldEmployeeCode = "employee_code"
+	PunchFieldTimestamp    = "timestamp"
+	PunchFieldDevice       = "device"
+	PunchFieldDirection    = "direction"
+)
+
+// PunchFieldMapping names the column or key holding each punch field.
+// Empty entries are looked up by common header names such as "emp_code" or "punch_time".
+type PunchFieldMapping struct {
+	EmployeeCode string
+	Timestamp    string
+	Device       string
+	Direction    string
+}
+
+// PunchImportOptions control how a punch log is read and applied
+type PunchImportOptions struct {
+	Format  string // PunchLogFormatCSV or PunchLogFormatJSON
+	Mapping PunchFieldMapping
+	DryRun  bool // Report what would be imported without saving anything
+}
+
+// RawPunch is one row of a time clock export before it is matched to an employee
+type RawPunch struct {
+	Line         int    // Row of the CSV file (the header is row 1) or position in the JSON list
+	EmployeeCode string // Matched against the employee code users punch with at kiosks
+	Timestamp    string // As exported; times without a zone are read in the employee's time zone
+	Device       string // Time clock that recorded the punch
+	Direction    string // As exported, e.g. "in", "out", "0", "1"; empty when the clock does not record it
+}
+
+// PunchImportIssue describes a row or punch that could not be imported
+type PunchImportIssue struct {
+	Line         int
+	EmployeeCode string
+	Message      string
+}
+
+// PunchImportReport summarizes the result of importing a punch log
+type PunchImportReport struct {
+	DryRun          bool
+	Rows            int // Rows read from the log
+	Duplicates      int // Punches repeated in the log or already recorded
+	SessionsCreated int // Check-in/check-out pairs added
+	SessionsClosed  int // Sessions that were open and got their check-out from the log
+	SessionsOpened  int // Recent check-ins added without a check-out yet
+	AttendanceDays  int // Attendance records created or updated
+	Issues          []PunchImportIssue
+}
+
+// PunchImportServiceInterface defines the contract for importing punch logs exported by time clocks
+type PunchImportServiceInterface interface {
+	ImportPunchLog(log io.Reader, options PunchImportOptions) (*PunchImportReport, error)
+}
+
+// Domain-specific errors for punch imports
+var (
+	ErrUnsupportedPunchLogFormat = errors.New("punch log format must be csv or json")
+	ErrInvalidPunchLog           = errors.New("invalid punch log")
+	ErrUnknownPunchField         = errors.New("unknown punch field; use employee_code, timestamp, device or direction")
+)
+
+// Set maps a punch field to the column or key that holds it
+func (m *PunchFieldMapping) Set(field, column string) error {
+	switch field {
+	case PunchFieldEmployeeCode:
+		m.EmployeeCode = column
+	case PunchFieldTimestamp:
+		m.Timestamp = column
+	case PunchFieldDevice:
+		m.Device = column
+	case PunchFieldDirection:
+		m.Direction = column
+	default:
+		return ErrUnknownPunchField
+	}
+	return nil
+}
+
+// Column returns the column or key explicitly mapped to a punch field, or "" if none is
+func (m PunchFieldMapping) Column(field string) string {
+	switch field {
+	case PunchFieldEmployeeCode:
+		return m.EmployeeCode
+	case PunchFieldTimestamp:
+		return m.Timestamp
+	case PunchFieldDevice:
+		return m.Device
+	case PunchFieldDirection:
+		return m.Direction
+	}
+	return ""
+}
+
+// AddIssue records a punch that could not be imported
+func (r *PunchImportReport) AddIssue(line int, employeeCode, message string) {
+	r.Issues = append(r.Issues, PunchImportIssue{Line: line, EmployeeCode: employeeCode, Message: message})
+}
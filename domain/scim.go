@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SCIM 2.0 schema and message URNs
+const (
+	SCIMSchemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMSchemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaListResponse   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError          = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIMUser is a user as exchanged with SCIM clients such as identity providers.
+// userName is the email address users sign in with; emails are derived from it.
+type SCIMUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id,omitempty"`
+	ExternalID  string              `json:"externalId,omitempty"` // Identifier the client knows the user by
+	UserName    string              `json:"userName"`
+	Name        *SCIMName           `json:"name,omitempty"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Emails      []SCIMMultiValue    `json:"emails,omitempty"`
+	Active      *bool               `json:"active,omitempty"`   // Omitted on input keeps the current status
+	Timezone    *string             `json:"timezone,omitempty"` // Omitted on input keeps the current time zone; empty removes it
+	Password    string              `json:"password,omitempty"` // Write-only; never returned
+	Groups      []SCIMMultiValue    `json:"groups,omitempty"`   // Read-only; the user's role when it is a group
+	Enterprise  *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *SCIMMeta           `json:"meta,omitempty"`
+}
+
+// SCIMName holds the name of a SCIM user; HRM keeps the formatted name only
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEnterpriseUser holds the enterprise extension attributes HRM takes over.
+// Sending the extension without a manager removes the user's manager.
+type SCIMEnterpriseUser struct {
+	Manager *SCIMManager `json:"manager,omitempty"`
+}
+
+// SCIMManager refers to the SCIM user managing a user
+type SCIMManager struct {
+	Value       string `json:"value"` // ID of the manager's SCIM user
+	Ref         string `json:"$ref,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// UnmarshalJSON also accepts the manager as a bare ID, as some clients send it
+func (m *SCIMManager) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*m = SCIMManager{Value: id}
+		return nil
+	}
+	type manager SCIMManager
+	return json.Unmarshal(data, (*manager)(m))
+}
+
+// SCIMMultiValue is an entry of a multi-valued SCIM attribute such as emails, groups or members
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// SCIMGroup is a group as exchanged with SCIM clients.
+// Groups are HRM's roles other than employee: members have the role, and users removed from it become employees.
+type SCIMGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []SCIMMultiValue `json:"members"`
+	Meta        *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMMeta holds the resource metadata returned with SCIM resources
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMPatchOperation is one operation of a SCIM PATCH request
+type SCIMPatchOperation struct {
+	Op    string      `json:"op"`             // add, replace or remove, in any case
+	Path  string      `json:"path,omitempty"` // Attribute path, optionally with a value filter; empty applies value to the resource
+	Value interface{} `json:"value,omitempty"`
+}
+
+// SCIMListQuery selects and pages the resources a SCIM list request returns
+type SCIMListQuery struct {
+	Filter     string // SCIM filter expression; empty returns every resource
+	StartIndex int    // 1-based index of the first resource returned
+	Count      int    // Maximum number of resources returned
+}
+
+// SCIM list paging limits
+const (
+	SCIMDefaultCount = 100
+	SCIMMaxCount     = 200
+)
+
+// SCIMServiceInterface defines the contract for provisioning users and role groups through SCIM 2.0
+type SCIMServiceInterface interface {
+	// Authenticate checks the bearer token SCIM clients authenticate with
+	Authenticate(token string) error
+
+	// ListUsers returns the page of users matching the query and the number of matching users
+	ListUsers(query SCIMListQuery) ([]SCIMUser, int, error)
+
+	// GetUser retrieves a user by SCIM ID
+	GetUser(id string) (*SCIMUser, error)
+
+	// CreateUser provisions a new user
+	CreateUser(resource *SCIMUser) (*SCIMUser, error)
+
+	// ReplaceUser replaces the attributes of a user
+	ReplaceUser(id string, resource *SCIMUser) (*SCIMUser, error)
+
+	// PatchUser applies PATCH operations to a user; deactivating a user signs them out everywhere
+	PatchUser(id string, operations []SCIMPatchOperation) (*SCIMUser, error)
+
+	// DeleteUser removes a user and signs them out everywhere
+	DeleteUser(id string) error
+
+	// ListGroups returns the page of groups matching the query and the number of matching groups
+	ListGroups(query SCIMListQuery) ([]SCIMGroup, int, error)
+
+	// GetGroup retrieves a group by SCIM ID, which is the role name
+	GetGroup(id string) (*SCIMGroup, error)
+
+	// ReplaceGroup replaces the members of a group
+	ReplaceGroup(id string, resource *SCIMGroup) (*SCIMGroup, error)
+
+	// PatchGroup applies PATCH operations to a group's members
+	PatchGroup(id string, operations []SCIMPatchOperation) (*SCIMGroup, error)
+}
+
+// Domain-specific errors for SCIM provisioning
+var (
+	ErrInvalidSCIMToken   = errors.New("invalid SCIM bearer token")
+	ErrSCIMInvalidFilter  = errors.New("invalid filter")
+	ErrSCIMInvalidPath    = errors.New("invalid attribute path")
+	ErrSCIMNoTarget       = errors.New("the path matches no value")
+	ErrSCIMInvalidValue   = errors.New("invalid attribute value")
+	ErrSCIMInvalidPatch   = errors.New("invalid patch operation")
+	ErrSCIMGroupNotFound  = errors.New("group not found")
+	ErrSCIMGroupImmutable = errors.New("groups are HRM roles and cannot be created, renamed or deleted")
+)
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SignInAttempt is one entry of the sign-in history: who tried to sign in, from where, and the outcome.
+// Attempts with an unknown email have no user.
+type SignInAttempt struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    *uint     `gorm:"index" json:"user_id"`
+	Email     string    `gorm:"size:255;index" json:"email"` // Email as entered
+	IPAddress string    `gorm:"size:45;index:idx_sign_in_attempts_ip_created" json:"ip_address"`
+	UserAgent string    `gorm:"size:255" json:"user_agent"`
+	Result    string    `gorm:"not null;size:30;index" json:"result"` // One of the SignInResult* outcomes
+	CreatedAt time.Time `gorm:"index:idx_sign_in_attempts_ip_created" json:"created_at"`
+}
+
+// Outcomes of a sign-in attempt
+const (
+	SignInResultSuccess             = "success"
+	SignInResultInvalidCredentials  = "invalid_credentials"   // Unknown email or wrong password; counts towards lockout
+	SignInResultLocked              = "locked"                // The account was locked
+	SignInResultThrottled           = "throttled"             // Too soon after the last failure, or too many failures from the IP
+	SignInResultInactive            = "inactive"              // Correct password for a deactivated account
+	SignInResultInvalidSecondFactor = "invalid_second_factor" // Correct password but a wrong two-factor code
+)
+
+// SignInPolicy holds the brute-force protection rules for sign-in
+type SignInPolicy struct {
+	MaxFailedAttempts      int           // Failed attempts on an account within FailureWindow before it is locked (0 disables lockout)
+	LockoutDuration        time.Duration // How long a locked account stays locked
+	FailureWindow          time.Duration // Failures older than this are forgotten
+	MaxFailedAttemptsPerIP int           // Failed attempts from one IP within FailureWindow before it is throttled (0 disables the limit)
+	BaseDelay              time.Duration // Wait after the second failed attempt, doubled after each further one (0 disables delays)
+	MaxDelay               time.Duration // Longest wait between attempts
+}
+
+// SignInAttemptFilter narrows the sign-in history shown to security admins
+type SignInAttemptFilter struct {
+	UserID    *uint
+	Email     string
+	IPAddress string
+	Result    string
+	Limit     int
+	Offset    int
+}
+
+// SignInAttemptRepositoryInterface defines the contract for sign-in history data operations
+type SignInAttemptRepositoryInterface interface {
+	Create(attempt *SignInAttempt) error
+	List(filter SignInAttemptFilter) ([]SignInAttempt, error)
+	CountFailuresByIP(ipAddress string, since time.Time) (int64, error)
+}
+
+// SignInBlockedError is returned when a sign-in is refused before the password is checked.
+// It wraps ErrAccountLocked or ErrTooManySignInAttempts and tells when to try again.
+type SignInBlockedError struct {
+	Reason  error
+	RetryAt time.Time
+}
+
+// Error describes why the sign-in was refused
+func (e *SignInBlockedError) Error() string {
+	return fmt.Sprintf("%v, try again after %s", e.Reason, e.RetryAt.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrAccountLocked or ErrTooManySignInAttempts
+func (e *SignInBlockedError) Unwrap() error {
+	return e.Reason
+}
+
+// Domain-specific errors for sign-in protection
+var (
+	ErrAccountLocked         = errors.New("account is temporarily locked after too many failed sign-in attempts")
+	ErrTooManySignInAttempts = errors.New("too many sign-in attempts")
+	ErrInvalidSignInAttempt  = errors.New("sign-in attempt must have a result")
+)
+
+// Validate checks if the sign-in attempt data is valid
+func (a *SignInAttempt) Validate() error {
+	if a.Result == "" {
+		return ErrInvalidSignInAttempt
+	}
+	return nil
+}
+
+// DelayAfter returns how long to wait after the given number of consecutive failed attempts.
+// The first failure is free; each further one doubles the wait, up to MaxDelay.
+func (p SignInPolicy) DelayAfter(failures int) time.Duration {
+	if p.BaseDelay <= 0 || failures < 2 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 2; i < failures; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
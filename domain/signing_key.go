@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Algorithms access tokens can be signed with
+const (
+	SigningAlgHS256 = "HS256" // Shared JWT_SECRET; tokens can only be verified by HRM itself
+	SigningAlgRS256 = "RS256" // RSA key pairs published as a JWKS
+	SigningAlgEdDSA = "EdDSA" // Ed25519 key pairs published as a JWKS
+)
+
+// SigningKey is a key pair access tokens are signed with. Tokens name their key in the "kid" header.
+// The newest key that is not retired signs new tokens; retired keys stay published until the
+// tokens they signed have expired, so other services can keep verifying them.
+type SigningKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	KID         string     `gorm:"column:kid;uniqueIndex;not null;size:64" json:"kid"`
+	Algorithm   string     `gorm:"not null;size:10" json:"algorithm"` // SigningAlgRS256 or SigningAlgEdDSA
+	PrivateKey  string     `gorm:"type:text;not null" json:"-"`       // PKCS #8 PEM
+	PublicKey   string     `gorm:"type:text;not null" json:"public_key"`
+	RetiredAt   *time.Time `gorm:"index" json:"retired_at"`   // No longer signs new tokens
+	VerifyUntil *time.Time `gorm:"index" json:"verify_until"` // Removed once the tokens it signed have expired
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// JSONWebKey is the public half of a signing key as published in the JWKS (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA public exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// SigningKeyRepositoryInterface defines the contract for signing key data operations
+type SigningKeyRepositoryInterface interface {
+	Create(key *SigningKey) error
+	GetVerifiable(now time.Time) ([]SigningKey, error)
+	Update(key *SigningKey) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// SigningKeyServiceInterface defines the contract for signing access tokens and rotating their keys
+type SigningKeyServiceInterface interface {
+	SignToken(claims map[string]interface{}) (string, error)
+	VerificationKey(kid, algorithm string) (interface{}, error)
+	RotateKeys(now time.Time) (bool, error)
+	GetJWKS() (*JSONWebKeySet, error)
+}
+
+// Domain-specific errors for signing key operations
+var (
+	ErrSigningKeyNotFound   = errors.New("unknown signing key")
+	ErrInvalidSigningKey    = errors.New("signing key must have a key ID, a supported algorithm and both key halves")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrJWTSecretMissing     = errors.New("JWT_SECRET environment variable is required")
+)
+
+// IsAsymmetricAlgorithm returns true for the algorithms that sign with published key pairs
+func IsAsymmetricAlgorithm(algorithm string) bool {
+	return algorithm == SigningAlgRS256 || algorithm == SigningAlgEdDSA
+}
+
+// Validate checks if the signing key data is valid
+func (k *SigningKey) Validate() error {
+	if k.KID == "" || !IsAsymmetricAlgorithm(k.Algorithm) || k.PrivateKey == "" || k.PublicKey == "" {
+		return ErrInvalidSigningKey
+	}
+	return nil
+}
+
+// IsRetired returns true if the key no longer signs new tokens
+func (k *SigningKey) IsRetired() bool {
+	return k.RetiredAt != nil
+}
+
+// IsVerifiableAt returns true if tokens signed with the key are still accepted at the given time
+func (k *SigningKey) IsVerifiableAt(now time.Time) bool {
+	return k.VerifyUntil == nil || now.Before(*k.VerifyUntil)
+}
+
+// Retire stops the key from signing new tokens and keeps it verifiable for the given time
+func (k *SigningKey) Retire(now time.Time, keepFor time.Duration) {
+	if k.IsRetired() {
+		return
+	}
+	verifyUntil := now.Add(keepFor)
+	k.RetiredAt = &now
+	k.VerifyUntil = &verifyUntil
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TimeEntry allocates part of an attendance day's worked hours to a project
+type TimeEntry struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index:idx_time_entry_user_date" json:"user_id"`
+	AttendanceID uint      `gorm:"not null;index" json:"attendance_id"`
+	ProjectID    uint      `gorm:"not null;index" json:"project_id"`
+	Date         time.Time `gorm:"not null;type:date;index:idx_time_entry_user_date" json:"date"` // Work day of the attendance
+	Hours        float64   `gorm:"not null" json:"hours"`
+	Task         string    `gorm:"size:200" json:"task"`
+	Note         string    `gorm:"type:text" json:"note"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID" json:"-"`
+}
+
+// TimeEntryRepositoryInterface defines the contract for time entry data operations
+type TimeEntryRepositoryInterface interface {
+	Create(entry *TimeEntry) error
+	GetByID(id uint) (*TimeEntry, error)
+	GetByAttendanceID(attendanceID uint) ([]TimeEntry, error)
+	GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]TimeEntry, error)
+	// GetByDateRange retrieves entries between two dates; a zero projectID or userID matches all
+	GetByDateRange(startDate, endDate time.Time, projectID uint, userID uint) ([]TimeEntry, error)
+	CountByProjectID(projectID uint) (int64, error)
+	Update(entry *TimeEntry) error
+	Delete(id uint) error
+}
+
+// TimeEntryServiceInterface defines the contract for time allocation business logic
+type TimeEntryServiceInterface interface {
+	LogTime(userID uint, entry *TimeEntry) error
+	GetTimeEntryByID(id uint) (*TimeEntry, error)
+	GetUserTimeEntries(userID uint, startDate, endDate time.Time) ([]TimeEntry, error)
+	UpdateTimeEntry(userID uint, entry *TimeEntry) error
+	DeleteTimeEntry(id uint, userID uint) error
+	GetUtilization(startDate, endDate time.Time, projectID uint, userID uint) (*UtilizationReport, error)
+}
+
+// UtilizationReport summarises how worked hours were allocated to projects over a date range
+type UtilizationReport struct {
+	StartDate      time.Time
+	EndDate        time.Time
+	WorkedHours    float64 // Attendance hours of the users in the report
+	AllocatedHours float64 // Hours booked on the projects in the report
+	Projects       []ProjectUtilization
+	Users          []UserUtilization
+}
+
+// ProjectUtilization is the time booked on one project, split per user
+type ProjectUtilization struct {
+	ProjectID   uint
+	ProjectName string
+	ProjectCode string
+	Hours       float64
+	Users       []ProjectUserHours
+}
+
+// ProjectUserHours is the time one user booked on a project
+type ProjectUserHours struct {
+	UserID uint
+	Hours  float64
+}
+
+// UserUtilization compares the hours a user worked with the hours they allocated
+type UserUtilization struct {
+	UserID         uint
+	WorkedHours    float64
+	AllocatedHours float64
+	Utilization    float64 // Allocated hours as a percentage of worked hours
+}
+
+// Domain-specific errors for time entry operations
+var (
+	ErrTimeEntryNotFound         = errors.New("time entry not found")
+	ErrInvalidTimeEntryHours     = errors.New("time entry hours must be greater than zero")
+	ErrInvalidTimeEntryProject   = errors.New("time entry must reference a project")
+	ErrTimeEntryExceedsWorkHours = errors.New("allocated hours exceed the hours worked on this day")
+)
+
+// Validate checks if the time entry data is valid
+func (e *TimeEntry) Validate() error {
+	if e.ProjectID == 0 {
+		return ErrInvalidTimeEntryProject
+	}
+	if e.Hours <= 0 {
+		return ErrInvalidTimeEntryHours
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TimesheetStatus represents the state of a weekly timesheet
+type TimesheetStatus string
+
+const (
+	TimesheetStatusDraft     TimesheetStatus = "draft"
+	TimesheetStatusSubmitted TimesheetStatus = "submitted"
+	TimesheetStatusApproved  TimesheetStatus = "approved"
+	TimesheetStatusReturned  TimesheetStatus = "returned"
+)
+
+// Timesheet represents an employee's week of attendance submitted for approval.
+// The daily figures are aggregated from Attendance and Break records when the timesheet
+// is read; the totals are stored when it is submitted so the approved numbers are kept.
+// Approving a timesheet locks the attendance rows of the week.
+type Timesheet struct {
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	UserID            uint            `gorm:"not null;uniqueIndex:idx_timesheet_user_week" json:"user_id"`
+	WeekStart         time.Time       `gorm:"not null;type:date;uniqueIndex:idx_timesheet_user_week" json:"week_start"`
+	WeekEnd           time.Time       `gorm:"not null;type:date" json:"week_end"`
+	Status            TimesheetStatus `gorm:"not null;type:varchar(20);default:'draft'" json:"status"`
+	EmployeeNote      string          `gorm:"type:text" json:"employee_note"`
+	TotalWorkHours    float64         `json:"total_work_hours"`
+	TotalBreakMinutes float64         `json:"total_break_minutes"`
+	SubmittedAt       *time.Time      `json:"submitted_at"`
+	ReviewedBy        *uint           `gorm:"index" json:"reviewed_by"`
+	ReviewedAt        *time.Time      `json:"reviewed_at"`
+	ReviewNote        string          `gorm:"type:text" json:"review_note"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	DayNotes []TimesheetDayNote `gorm:"foreignKey:TimesheetID" json:"day_notes,omitempty"`
+	Days     []TimesheetDay     `gorm:"-" json:"days,omitempty"`
+}
+
+// TimesheetDayNote is an employee's annotation on one day of a timesheet
+type TimesheetDayNote struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TimesheetID uint      `gorm:"not null;index" json:"timesheet_id"`
+	Date        time.Time `gorm:"not null;type:date" json:"date"`
+	Note        string    `gorm:"type:text" json:"note"`
+}
+
+// TimesheetDay is the aggregated view of one day of a timesheet; it is not stored
+type TimesheetDay struct {
+	Date         time.Time
+	AttendanceID *uint
+	Status       string
+	CheckInTime  *time.Time
+	CheckOutTime *time.Time
+	WorkHours    float64
+	BreakMinutes float64
+	BreakCount   int
+	Note         string
+}
+
+// TimesheetRepositoryInterface defines the contract for timesheet data operations
+type TimesheetRepositoryInterface interface {
+	Create(timesheet *Timesheet) error
+	GetByID(id uint) (*Timesheet, error)
+	GetByUserIDAndWeek(userID uint, weekStart time.Time) (*Timesheet, error)
+	GetApprovedByUserIDAndDate(userID uint, date time.Time) (*Timesheet, error)
+	GetByUserID(userID uint) ([]Timesheet, error)
+	GetByStatus(status TimesheetStatus) ([]Timesheet, error)
+	Update(timesheet *Timesheet) error
+	ReplaceDayNotes(timesheetID uint, notes []TimesheetDayNote) error
+}
+
+// TimesheetServiceInterface defines the contract for timesheet business logic
+type TimesheetServiceInterface interface {
+	GetWeek(userID uint, viewerID uint, date time.Time) (*Timesheet, error)
+	GetTimesheetByID(id uint, viewerID uint) (*Timesheet, error)
+	GetUserTimesheets(userID uint) ([]Timesheet, error)
+	Annotate(userID uint, date time.Time, note string, dayNotes []TimesheetDayNote) (*Timesheet, error)
+	Submit(userID uint, date time.Time) (*Timesheet, error)
+	GetPendingTimesheets(approverID uint) ([]Timesheet, error)
+	Approve(id uint, approverID uint, note string) (*Timesheet, error)
+	Return(id uint, approverID uint, note string) (*Timesheet, error)
+}
+
+// Domain-specific errors for timesheet operations
+var (
+	ErrTimesheetNotFound     = errors.New("timesheet not found")
+	ErrTimesheetNotEditable  = errors.New("timesheet can only be changed while in draft or returned")
+	ErrTimesheetNotSubmitted = errors.New("timesheet is not submitted")
+	ErrTimesheetOpenSession  = errors.New("timesheet week still has an open check-in")
+	ErrTimesheetWeekNotEnded = errors.New("timesheet week has not ended yet")
+	ErrInvalidTimesheetDay   = errors.New("day note is outside the timesheet week")
+)
+
+// IsEditable returns true if the employee may still annotate or submit the timesheet
+func (t *Timesheet) IsEditable() bool {
+	return t.Status == TimesheetStatusDraft || t.Status == TimesheetStatusReturned
+}
+
+// Contains returns true if the given work day belongs to the timesheet week
+func (t *Timesheet) Contains(day time.Time) bool {
+	day = DateOnly(day)
+	return !day.Before(t.WeekStart) && !day.After(t.WeekEnd)
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TOTPCredential is a user's authenticator app enrollment for time-based one-time passwords (RFC 6238).
+// It only protects sign-in once confirmed with a first valid code.
+type TOTPCredential struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"uniqueIndex;not null" json:"user_id"`
+	Secret       string     `gorm:"not null;size:64" json:"-"`   // Base32 shared secret
+	ConfirmedAt  *time.Time `json:"confirmed_at"`                // When the user proved the app works; unconfirmed enrollments do not apply
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"` // Time step of the last accepted code, so a code cannot be replayed
+	FailedCodes  int        `gorm:"not null;default:0" json:"-"` // Wrong sign-in codes since the last accepted one; a correct password does not reset them
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the authenticator app is lost.
+// Codes are only stored hashed.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null;size:64" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TwoFactorChallenge is the pending second step of a sign-in whose password was correct.
+// Its token is exchanged for access tokens together with a valid code.
+type TwoFactorChallenge struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	UserID        uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash     string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	SetupRequired bool       `gorm:"not null;default:false" json:"setup_required"` // The user's role requires 2FA but they have not enrolled yet
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`           // Wrong codes entered so far
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt        *time.Time `json:"used_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TwoFactorTicket is handed to the client when sign-in needs a second factor
+type TwoFactorTicket struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+	SetupRequired  bool
+}
+
+// TOTPEnrollment is what an authenticator app needs to be set up
+type TOTPEnrollment struct {
+	Secret          string // Base32 secret for manual entry
+	ProvisioningURI string // otpauth:// URI to render as a QR code
+}
+
+// TwoFactorSignIn is the result of a completed second sign-in step
+type TwoFactorSignIn struct {
+	User          *User
+	RecoveryCodes []string // Set when the sign-in also completed a required enrollment
+}
+
+// TwoFactorStatus describes a user's two-factor authentication setup
+type TwoFactorStatus struct {
+	Enabled                bool
+	ConfirmedAt            *time.Time
+	RecoveryCodesRemaining int
+	Required               bool // The user's role requires two-factor authentication
+}
+
+// TOTPCredentialRepositoryInterface defines the contract for TOTP enrollment and recovery code data operations
+type TOTPCredentialRepositoryInterface interface {
+	GetByUserID(userID uint) (*TOTPCredential, error)
+	Save(credential *TOTPCredential) error
+	DeleteByUserID(userID uint) error
+	ReplaceRecoveryCodes(userID uint, codes []RecoveryCode) error
+	GetUnusedRecoveryCodes(userID uint) ([]RecoveryCode, error)
+	UseRecoveryCode(id uint, usedAt time.Time) error
+}
+
+// TwoFactorChallengeRepositoryInterface defines the contract for pending sign-in challenge data operations
+type TwoFactorChallengeRepositoryInterface interface {
+	Create(challenge *TwoFactorChallenge) error
+	GetByTokenHash(tokenHash string) (*TwoFactorChallenge, error)
+	Update(challenge *TwoFactorChallenge) error
+}
+
+// TwoFactorServiceInterface defines the contract for TOTP two-factor authentication
+type TwoFactorServiceInterface interface {
+	BeginSignIn(user *User) (*TwoFactorTicket, error)
+	StartChallengeSetup(challengeToken string) (*TOTPEnrollment, error)
+	CompleteSignIn(challengeToken, code string, client AuthClient) (*TwoFactorSignIn, error)
+	GetStatus(userID uint) (*TwoFactorStatus, error)
+	StartEnrollment(userID uint) (*TOTPEnrollment, error)
+	ConfirmEnrollment(userID uint, code string) ([]string, error)
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	Disable(userID uint, code string) error
+	Reset(userID uint) error
+}
+
+// Domain-specific errors for two-factor authentication
+var (
+	ErrTOTPCredentialNotFound     = errors.New("two-factor authentication is not set up")
+	ErrTwoFactorChallengeNotFound = errors.New("two-factor challenge not found")
+	ErrInvalidTwoFactorChallenge  = errors.New("invalid or expired two-factor challenge, please sign in again")
+	ErrInvalidTwoFactorCode       = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired          = errors.New("two-factor authentication is required for your role and cannot be disabled")
+	ErrTwoFactorSetupNotRequired  = errors.New("this sign-in does not need a new two-factor setup")
+	ErrInvalidTOTPCredential      = errors.New("TOTP credential must belong to a user and have a secret")
+	ErrInvalidTwoFactorData       = errors.New("two-factor challenge must belong to a user and have a token hash")
+)
+
+// Validate checks if the TOTP credential data is valid
+func (c *TOTPCredential) Validate() error {
+	if c.UserID == 0 || c.Secret == "" {
+		return ErrInvalidTOTPCredential
+	}
+	return nil
+}
+
+// IsConfirmed returns true if the enrollment was confirmed and protects sign-in
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.ConfirmedAt != nil
+}
+
+// Validate checks if the challenge data is valid
+func (c *TwoFactorChallenge) Validate() error {
+	if c.UserID == 0 || c.TokenHash == "" {
+		return ErrInvalidTwoFactorData
+	}
+	return nil
+}
+
+// IsUsableAt returns true if the challenge is neither used nor expired at the given time
+func (c *TwoFactorChallenge) IsUsableAt(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt)
+}
@@ -8,14 +8,39 @@ import (
 // User represents a user entity in the HRM system.
 // This is the core business object that contains all user-related data.
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`                       // Unique identifier for the user
-	Name      string    `json:"name" gorm:"not null"`                       // Full name of the user
-	Email     string    `json:"email" gorm:"uniqueIndex;not null;size:255"` // Email address (unique, max 255 chars)
-	Password  string    `json:"-" gorm:"not null"`                          // Hashed password (hidden from JSON)
-	CreatedAt time.Time `json:"created_at"`                                 // When the user was created
-	UpdatedAt time.Time `json:"updated_at"`                                 // When the user was last updated
+	ID                  uint       `json:"id" gorm:"primaryKey"`                          // Unique identifier for the user
+	Name                string     `json:"name" gorm:"not null"`                          // Full name of the user
+	Email               string     `json:"email" gorm:"uniqueIndex;not null;size:255"`    // Email address (unique, max 255 chars)
+	Password            string     `json:"-" gorm:"not null"`                             // Hashed password (hidden from JSON)
+	Timezone            string     `json:"timezone" gorm:"size:64"`                       // IANA time zone override (empty uses the location's zone)
+	LocationID          *uint      `json:"location_id" gorm:"index"`                      // Office or site the user is assigned to
+	ManagerID           *uint      `json:"manager_id" gorm:"index"`                       // Direct manager who reviews the user's requests
+	IsActive            bool       `json:"is_active" gorm:"default:true"`                 // Inactive users cannot sign in and are skipped by attendance jobs
+	Role                string     `json:"role" gorm:"size:30;not null;default:employee"` // One of the Role* values; grants administrative access
+	EmployeeCode        *string    `json:"-" gorm:"uniqueIndex;size:32"`                  // Code the user enters at shared kiosks (only shown to admins)
+	KioskPIN            string     `json:"-" gorm:"size:255"`                             // Hashed PIN confirming the employee code at kiosks
+	FailedKioskPINs     int        `json:"-" gorm:"not null;default:0"`                   // Consecutive wrong PINs entered at kiosks for the employee code
+	KioskPINLockedUntil *time.Time `json:"-"`                                             // Kiosks refuse the employee code until this time after too many wrong PINs
+	BadgeID             *string    `json:"-" gorm:"uniqueIndex;size:64"`                  // Badge the user can scan at kiosks instead of code and PIN
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`                             // When the user proved they own the email address; cleared when it changes
+	FailedSignIns       int        `json:"-" gorm:"not null;default:0"`                   // Consecutive failed sign-in attempts within the failure window
+	LastFailedSignInAt  *time.Time `json:"-"`                                             // When the last failed sign-in attempt was made
+	LockedUntil         *time.Time `json:"locked_until"`                                  // Sign-in is refused until this time after too many failed attempts
+	DirectoryDN         *string    `json:"directory_dn" gorm:"uniqueIndex;size:255"`      // LDAP entry the user is synced from and signs in with; nil for local accounts
+	ExternalID          *string    `json:"external_id" gorm:"index;size:255"`             // Identifier the SCIM client provisioning the user knows them by
+	IsServiceAccount    bool       `json:"is_service_account" gorm:"default:false"`       // Integration account that only authenticates with API tokens
+	DeprovisionedAt     *time.Time `json:"deprovisioned_at"`                              // When a SCIM client deleted the user; the user is kept inactive and SCIM no longer finds them
+	CreatedAt           time.Time  `json:"created_at"`                                    // When the user was created
+	UpdatedAt           time.Time  `json:"updated_at"`                                    // When the user was last updated
 }
 
+// Roles a user can have
+const (
+	RoleEmployee      = "employee"       // Regular access to one's own data
+	RoleAdmin         = "admin"          // Full administrative access, including assigning roles
+	RoleSecurityAdmin = "security_admin" // Reviews sign-in history and unlocks accounts
+)
+
 // UserRepositoryInterface defines the contract for user data access operations.
 // This interface allows us to easily swap database implementations (MySQL, PostgreSQL, etc.)
 // and makes testing easier by allowing us to create mock repositories.
@@ -37,6 +62,27 @@ type UserRepositoryInterface interface {
 
 	// List retrieves a paginated list of users
 	List(limit, offset int) ([]User, error)
+
+	// ListActive retrieves all people that are currently active; service accounts are left out
+	ListActive() ([]User, error)
+
+	// GetByEmployeeCode retrieves a user by their kiosk employee code
+	GetByEmployeeCode(code string) (*User, error)
+
+	// GetByBadgeID retrieves a user by their kiosk badge
+	GetByBadgeID(badgeID string) (*User, error)
+
+	// GetByDirectoryDN retrieves the user synced from the given LDAP entry
+	GetByDirectoryDN(dn string) (*User, error)
+
+	// ListFromDirectory retrieves all users synced from the LDAP directory, active or not
+	ListFromDirectory() ([]User, error)
+
+	// ListAll retrieves every user, active or not, ordered by ID
+	ListAll() ([]User, error)
+
+	// ListServiceAccounts retrieves all service accounts ordered by name
+	ListServiceAccounts() ([]User, error)
 }
 
 // UserServiceInterface defines the contract for user business logic operations.
@@ -46,7 +92,7 @@ type UserServiceInterface interface {
 	SignUp(user *User) error
 
 	// SignIn authenticates a user with email and password
-	SignIn(email, password string) (*User, error)
+	SignIn(email, password string, client AuthClient) (*User, error)
 
 	// GetUserByID retrieves a user by ID (with business logic)
 	GetUserByID(id uint) (*User, error)
@@ -54,29 +100,59 @@ type UserServiceInterface interface {
 	// GetCurrentUser retrieves the current authenticated user
 	GetCurrentUser(userID uint) (*User, error)
 
-	// UpdateUser modifies an existing user (with validation)
+	// UpdateUser modifies an existing user's profile (with validation); the password is kept
 	UpdateUser(user *User) error
 
+	// ChangePassword replaces the user's password after checking the current one
+	ChangePassword(userID, sessionID uint, currentPassword, newPassword string) error
+
+	// GetPasswordPolicy returns the rules new passwords have to follow
+	GetPasswordPolicy() PasswordPolicy
+
 	// DeleteUser removes a user from the system
 	DeleteUser(id uint) error
 
 	// ListUsers retrieves a paginated list of users
 	ListUsers(limit, offset int) ([]User, error)
 
-	// GenerateJWTToken generates a JWT token for the user
-	GenerateJWTToken(user *User) (string, error)
+	// SetUserActive activates or deactivates a user
+	SetUserActive(id uint, active bool) error
+
+	// SetKioskCredentials assigns the employee code, PIN and badge used at shared kiosks;
+	// a nil PIN or badge keeps the current value and an empty one removes it
+	SetKioskCredentials(id uint, employeeCode string, pin, badgeID *string) (*User, error)
+
+	// SetUserRole assigns one of the Role* roles to a user
+	SetUserRole(id uint, role string) (*User, error)
+
+	// GetUserRole returns the role of a user, for route authorization
+	GetUserRole(id uint) (string, error)
+
+	// UnlockUser lifts a sign-in or kiosk PIN lockout and forgets the failed attempts
+	UnlockUser(id uint) error
+
+	// ListSignInAttempts retrieves the sign-in history, newest first
+	ListSignInAttempts(filter SignInAttemptFilter) ([]SignInAttempt, error)
 }
 
 // Domain-specific errors that can occur during business operations.
 // These errors are defined here so they can be used consistently across all layers.
 var (
-	ErrInvalidEmail       = errors.New("invalid email format")                   // Email format is not valid
-	ErrInvalidPassword    = errors.New("password must be at least 6 characters") // Password is too short
-	ErrInvalidName        = errors.New("name cannot be empty")                   // Name field is required
-	ErrUserNotFound       = errors.New("user not found")                         // User doesn't exist
-	ErrUserAlreadyExists  = errors.New("user already exists")                    // User with this email already exists
-	ErrInvalidCredentials = errors.New("invalid credentials")                    // Wrong email or password
-	ErrUnauthorized       = errors.New("unauthorized access")                    // User not authorized
+	ErrInvalidEmail        = errors.New("invalid email format")                           // Email format is not valid
+	ErrInvalidPassword     = errors.New("password does not meet the password policy")     // Password is missing or too weak
+	ErrInvalidName         = errors.New("name cannot be empty")                           // Name field is required
+	ErrUserNotFound        = errors.New("user not found")                                 // User doesn't exist
+	ErrUserAlreadyExists   = errors.New("user already exists")                            // User with this email already exists
+	ErrInvalidCredentials  = errors.New("invalid credentials")                            // Wrong email or password
+	ErrUnauthorized        = errors.New("unauthorized access")                            // User not authorized
+	ErrInvalidManager      = errors.New("a user cannot be their own manager")             // Manager must be another user
+	ErrManagerNotFound     = errors.New("manager not found")                              // Assigned manager does not exist
+	ErrUserInactive        = errors.New("user account is deactivated")                    // User has been deactivated
+	ErrInvalidEmployeeCode = errors.New("employee code must be 1-32 characters")          // Kiosk employee code is missing or too long
+	ErrEmployeeCodeExists  = errors.New("employee code is already assigned")              // Another user has this employee code
+	ErrInvalidKioskPIN     = errors.New("kiosk PIN must be 4 to 8 digits")                // PIN is not numeric or has the wrong length
+	ErrBadgeIDExists       = errors.New("badge is already assigned")                      // Another user has this badge
+	ErrInvalidRole         = errors.New("role must be employee, admin or security_admin") // Unknown role
 )
 
 // Validate performs business rule validation on the User entity.
@@ -93,14 +169,137 @@ func (u *User) Validate() error {
 		return ErrInvalidEmail
 	}
 
-	// Check if password meets minimum length requirement
-	if len(u.Password) < 6 {
+	// Check if password is provided; the password policy is applied when it is set
+	if u.Password == "" {
 		return ErrInvalidPassword
 	}
 
+	// Check that the user is not their own manager
+	if u.ManagerID != nil && u.ID != 0 && *u.ManagerID == u.ID {
+		return ErrInvalidManager
+	}
+
+	// Check that the role is one of the known roles
+	if u.Role != "" && !IsValidRole(u.Role) {
+		return ErrInvalidRole
+	}
+
+	// Check if the time zone override is a known IANA zone
+	if u.Timezone != "" {
+		if _, err := time.LoadLocation(u.Timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+
 	return nil
 }
 
+// IsEmailVerified returns true if the user has proved they own their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsFromDirectory returns true if the user is synced from the LDAP directory
+func (u *User) IsFromDirectory() bool {
+	return u.DirectoryDN != nil
+}
+
+// IsValidRole returns true for the roles a user can be assigned
+func IsValidRole(role string) bool {
+	return role == RoleEmployee || role == RoleAdmin || role == RoleSecurityAdmin
+}
+
+// HasRole returns true if the user has one of the given roles
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLockedAt returns true if sign-in is refused at the given time after too many failed attempts
+func (u *User) IsLockedAt(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// NextSignInAt returns the earliest time the next sign-in attempt is accepted under the policy's progressive delay
+func (u *User) NextSignInAt(policy SignInPolicy) time.Time {
+	if u.LastFailedSignInAt == nil {
+		return time.Time{}
+	}
+	return u.LastFailedSignInAt.Add(policy.DelayAfter(u.FailedSignIns))
+}
+
+// RecordFailedSignIn counts a failed sign-in attempt and locks the account once the policy's limit is reached.
+// Failures older than the failure window are forgotten first. It returns true if the account was locked.
+func (u *User) RecordFailedSignIn(now time.Time, policy SignInPolicy) bool {
+	if u.LastFailedSignInAt == nil || now.Sub(*u.LastFailedSignInAt) > policy.FailureWindow {
+		u.FailedSignIns = 0
+	}
+	u.FailedSignIns++
+	u.LastFailedSignInAt = &now
+
+	if policy.MaxFailedAttempts <= 0 || u.FailedSignIns < policy.MaxFailedAttempts {
+		return false
+	}
+	lockedUntil := now.Add(policy.LockoutDuration)
+	u.LockedUntil = &lockedUntil
+	u.FailedSignIns = 0
+	u.LastFailedSignInAt = nil
+	return true
+}
+
+// ResetFailedSignIns forgets failed sign-in attempts and lifts a lockout
+func (u *User) ResetFailedSignIns() {
+	u.FailedSignIns = 0
+	u.LastFailedSignInAt = nil
+	u.LockedUntil = nil
+}
+
+// IsKioskPINLockedAt returns true if kiosks refuse the employee code at the given time after too many wrong PINs
+func (u *User) IsKioskPINLockedAt(now time.Time) bool {
+	return u.KioskPINLockedUntil != nil && now.Before(*u.KioskPINLockedUntil)
+}
+
+// RecordFailedKioskPIN counts a wrong kiosk PIN and locks the employee code once the policy's limit is reached.
+// It returns true if the employee code was locked.
+func (u *User) RecordFailedKioskPIN(now time.Time, policy KioskPINPolicy) bool {
+	u.FailedKioskPINs++
+	if policy.MaxFailedAttempts <= 0 || u.FailedKioskPINs < policy.MaxFailedAttempts {
+		return false
+	}
+	lockedUntil := now.Add(policy.LockoutDuration)
+	u.KioskPINLockedUntil = &lockedUntil
+	u.FailedKioskPINs = 0
+	return true
+}
+
+// ResetFailedKioskPINs forgets wrong kiosk PINs and lifts a lockout of the employee code
+func (u *User) ResetFailedKioskPINs() {
+	u.FailedKioskPINs = 0
+	u.KioskPINLockedUntil = nil
+}
+
+// HasKioskPIN returns true if the user can confirm their employee code with a PIN at kiosks
+func (u *User) HasKioskPIN() bool {
+	return u.KioskPIN != ""
+}
+
+// CanBeReviewedBy reports whether the given user may approve or reject this user's requests.
+// Users with a manager can only be reviewed by that manager; users without one can only be
+// reviewed by an admin. Nobody can review their own requests.
+func (u *User) CanBeReviewedBy(reviewer *User) bool {
+	if reviewer == nil || reviewer.ID == u.ID {
+		return false
+	}
+	if u.ManagerID != nil {
+		return *u.ManagerID == reviewer.ID
+	}
+	return reviewer.HasRole(RoleAdmin)
+}
+
 // Sanitize removes sensitive information from the user object
 // before sending it to the client. This ensures that passwords
 // and other sensitive data are never exposed in API responses.
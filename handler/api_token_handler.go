@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APITokenHandler handles HTTP requests for personal access tokens and the service accounts integrations use
+type APITokenHandler struct {
+	apiTokenService domain.APITokenServiceInterface
+}
+
+// NewAPITokenHandler creates a new instance of APITokenHandler
+func NewAPITokenHandler(apiTokenService domain.APITokenServiceInterface) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+	}
+}
+
+// GetMyTokens lists the current user's personal access tokens
+func (h *APITokenHandler) GetMyTokens(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	h.listTokens(c, userID)
+}
+
+// CreateMyToken creates a personal access token for the current user and returns the token once
+func (h *APITokenHandler) CreateMyToken(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	h.createToken(c, userID)
+}
+
+// RevokeMyToken revokes one of the current user's personal access tokens
+func (h *APITokenHandler) RevokeMyToken(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	h.revokeToken(c, userID, c.Param("id"))
+}
+
+// CreateServiceAccount creates a service account for an integration
+func (h *APITokenHandler) CreateServiceAccount(c *gin.Context) {
+	var req request.ServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	user, err := h.apiTokenService.CreateServiceAccount(req.Name, req.Role)
+	if err != nil {
+		h.handleError(c, err, "create service account")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Service account created successfully", response.ToServiceAccountResponse(user))
+}
+
+// GetServiceAccounts lists all service accounts
+func (h *APITokenHandler) GetServiceAccounts(c *gin.Context) {
+	users, err := h.apiTokenService.ListServiceAccounts()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get service accounts: "+err.Error())
+		return
+	}
+
+	accountResponses := response.ToServiceAccountResponseList(users)
+	SuccessResponse(c, http.StatusOK, "Service accounts retrieved successfully", response.ServiceAccountListResponse{
+		ServiceAccounts: accountResponses,
+		Total:           len(accountResponses),
+	})
+}
+
+// GetServiceAccount retrieves a service account by ID
+func (h *APITokenHandler) GetServiceAccount(c *gin.Context) {
+	id, ok := h.serviceAccountID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.apiTokenService.GetServiceAccount(id)
+	if err != nil {
+		h.handleError(c, err, "get service account")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Service account retrieved successfully", response.ToServiceAccountResponse(user))
+}
+
+// DeleteServiceAccount revokes a service account's tokens and deletes it
+func (h *APITokenHandler) DeleteServiceAccount(c *gin.Context) {
+	id, ok := h.serviceAccountID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiTokenService.DeleteServiceAccount(id); err != nil {
+		h.handleError(c, err, "delete service account")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Service account deleted successfully", nil)
+}
+
+// GetServiceAccountTokens lists the tokens of a service account
+func (h *APITokenHandler) GetServiceAccountTokens(c *gin.Context) {
+	id, ok := h.serviceAccount(c)
+	if !ok {
+		return
+	}
+
+	h.listTokens(c, id)
+}
+
+// CreateServiceAccountToken creates a token for a service account and returns the token once
+func (h *APITokenHandler) CreateServiceAccountToken(c *gin.Context) {
+	id, ok := h.serviceAccount(c)
+	if !ok {
+		return
+	}
+
+	h.createToken(c, id)
+}
+
+// RevokeServiceAccountToken revokes one of a service account's tokens
+func (h *APITokenHandler) RevokeServiceAccountToken(c *gin.Context) {
+	id, ok := h.serviceAccount(c)
+	if !ok {
+		return
+	}
+
+	h.revokeToken(c, id, c.Param("token_id"))
+}
+
+// listTokens responds with a user's tokens
+func (h *APITokenHandler) listTokens(c *gin.Context, userID uint) {
+	tokens, err := h.apiTokenService.ListTokens(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get API tokens: "+err.Error())
+		return
+	}
+
+	tokenResponses := response.ToAPITokenResponseList(tokens)
+	SuccessResponse(c, http.StatusOK, "API tokens retrieved successfully", response.APITokenListResponse{
+		Tokens: tokenResponses,
+		Total:  len(tokenResponses),
+	})
+}
+
+// createToken creates a token for a user from the request body
+func (h *APITokenHandler) createToken(c *gin.Context, userID uint) {
+	var req request.APITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	apiToken, token, err := h.apiTokenService.CreateToken(userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		h.handleError(c, err, "create API token")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "API token created successfully; store the token now, it is not shown again", response.CreatedAPITokenResponse{
+		APIToken: response.ToAPITokenResponse(apiToken),
+		Token:    token,
+	})
+}
+
+// revokeToken revokes the user's token with the given ID
+func (h *APITokenHandler) revokeToken(c *gin.Context, userID uint, tokenIDParam string) {
+	tokenID, err := strconv.ParseUint(tokenIDParam, 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid token ID")
+		return
+	}
+
+	if err := h.apiTokenService.RevokeToken(userID, uint(tokenID)); err != nil {
+		h.handleError(c, err, "revoke API token")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "API token revoked successfully", nil)
+}
+
+// serviceAccountID parses the service account ID of the route
+func (h *APITokenHandler) serviceAccountID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid service account ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// serviceAccount parses the service account ID of the route and checks that it is a service account,
+// so token routes cannot be used on other users
+func (h *APITokenHandler) serviceAccount(c *gin.Context) (uint, bool) {
+	id, ok := h.serviceAccountID(c)
+	if !ok {
+		return 0, false
+	}
+
+	if _, err := h.apiTokenService.GetServiceAccount(id); err != nil {
+		h.handleError(c, err, "get service account")
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps errors of API token operations to HTTP responses
+func (h *APITokenHandler) handleError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, domain.ErrAPITokenNotFound):
+		NotFoundResponse(c, "API token not found")
+	case errors.Is(err, domain.ErrServiceAccountNotFound), errors.Is(err, domain.ErrUserNotFound):
+		NotFoundResponse(c, "Service account not found")
+	case errors.Is(err, domain.ErrUserInactive):
+		ForbiddenResponse(c, err.Error())
+	case errors.Is(err, domain.ErrInvalidAPITokenName),
+		errors.Is(err, domain.ErrAPITokenScopesRequired),
+		errors.Is(err, domain.ErrInvalidAPITokenScope),
+		errors.Is(err, domain.ErrInvalidAPITokenExpiry),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidRole):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+action+": "+err.Error())
+	}
+}
@@ -64,13 +64,31 @@ func (attendanceHandler *AttendanceHandler) CheckIn(c *gin.Context) {
 		return
 	}
 
-	attendance, err := attendanceHandler.attendanceService.CheckIn(userID, req.Date)
+	punch := domain.Punch{
+		ClientTime: req.Date,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Accuracy:   req.Accuracy,
+		IPAddress:  c.ClientIP(),
+	}
+
+	attendance, err := attendanceHandler.attendanceService.CheckIn(userID, punch)
 	if err != nil {
+		if handlePunchPolicyError(c, err) {
+			return
+		}
 		if errors.Is(err, domain.ErrAlreadyCheckedIn) {
 			c.JSON(http.StatusConflict, Response{
 				Success: false,
-				Message: "Already checked in for this date",
+				Message: "Already checked in; check out before starting a new session",
 			})
+		} else if errors.Is(err, domain.ErrAttendanceLocked) {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
+		} else if errors.Is(err, domain.ErrClockSkew) {
+			BadRequestResponse(c, "Device clock is out of sync with server time")
 		} else if errors.Is(err, domain.ErrUserNotFound) {
 			NotFoundResponse(c, "User not found")
 		} else {
@@ -101,13 +119,31 @@ func (attendanceHandler *AttendanceHandler) CheckOut(c *gin.Context) {
 		return
 	}
 
-	attendance, err := attendanceHandler.attendanceService.CheckOut(userID, req.Date)
+	punch := domain.Punch{
+		ClientTime: req.Date,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Accuracy:   req.Accuracy,
+		IPAddress:  c.ClientIP(),
+	}
+
+	attendance, err := attendanceHandler.attendanceService.CheckOut(userID, punch)
 	if err != nil {
+		if handlePunchPolicyError(c, err) {
+			return
+		}
 		if errors.Is(err, domain.ErrAlreadyCheckedOut) {
 			c.JSON(http.StatusConflict, Response{
 				Success: false,
 				Message: "Already checked out for this date",
 			})
+		} else if errors.Is(err, domain.ErrAttendanceLocked) {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
+		} else if errors.Is(err, domain.ErrClockSkew) {
+			BadRequestResponse(c, "Device clock is out of sync with server time")
 		} else if errors.Is(err, domain.ErrNotCheckedIn) {
 			BadRequestResponse(c, "Not checked in yet")
 		} else if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrAttendanceNotFound) {
@@ -254,6 +290,11 @@ func (attendanceHandler *AttendanceHandler) DeleteAttendance(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, domain.ErrAttendanceNotFound) {
 			NotFoundResponse(c, "Attendance not found")
+		} else if errors.Is(err, domain.ErrAttendanceLocked) {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
 		} else {
 			InternalServerErrorResponse(c, "Failed to delete attendance: "+err.Error())
 		}
@@ -263,6 +304,26 @@ func (attendanceHandler *AttendanceHandler) DeleteAttendance(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "Attendance deleted successfully", nil)
 }
 
+// handlePunchPolicyError writes the response for punches refused by the network or geofence rules
+// of the employee's location. It returns false if the error is not one of them.
+func handlePunchPolicyError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrNetworkNotAllowed):
+		ErrorResponseWithCode(c, http.StatusForbidden, ErrorCodeNetworkNotAllowed, "Punches are only allowed from your office network")
+	case errors.Is(err, domain.ErrOutsideGeofence):
+		ErrorResponseWithCode(c, http.StatusForbidden, ErrorCodeOutsideGeofence, "You are outside the permitted area of your location")
+	case errors.Is(err, domain.ErrPunchLocationRequired):
+		ErrorResponseWithCode(c, http.StatusBadRequest, ErrorCodeLocationRequired, "Device location is required to punch at your location")
+	case errors.Is(err, domain.ErrPunchLocationImprecise):
+		ErrorResponseWithCode(c, http.StatusBadRequest, ErrorCodeLocationImprecise, "Device location is not accurate enough")
+	case errors.Is(err, domain.ErrInvalidCoordinates):
+		BadRequestResponse(c, err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
 // convertToAttendanceResponse converts domain Attendance to response AttendanceResponse
 func (attendanceHandler *AttendanceHandler) convertToAttendanceResponse(attendance domain.Attendance) response.AttendanceResponse {
 	breakResponses := make([]response.BreakResponse, len(attendance.Breaks))
@@ -271,16 +332,22 @@ func (attendanceHandler *AttendanceHandler) convertToAttendanceResponse(attendan
 	}
 
 	return response.AttendanceResponse{
-		ID:             attendance.ID,
-		UserID:         attendance.UserID,
-		Date:           attendance.Date,
-		CheckInTime:    attendance.CheckInTime,
-		CheckOutTime:   attendance.CheckOutTime,
-		TotalWorkHours: attendance.TotalWorkHours,
-		Status:         attendance.Status,
+		ID:                  attendance.ID,
+		UserID:              attendance.UserID,
+		Date:                attendance.Date,
+		CheckInTime:         attendance.CheckInTime,
+		CheckOutTime:        attendance.CheckOutTime,
+		TotalWorkHours:      attendance.TotalWorkHours,
+		BreakDeduction:      attendance.BreakDeduction,
+		Timezone:            attendance.Timezone,
+		Status:              attendance.Status,
+		Flagged:             attendance.Flagged,
+		FlagReason:          attendance.FlagReason,
+		LockedByTimesheetID: attendance.LockedByTimesheetID,
 		//CreatedAt:      attendance.CreatedAt,
 		//UpdatedAt:      attendance.UpdatedAt,
-		Breaks: breakResponses,
+		Breaks:   breakResponses,
+		Sessions: response.ToAttendanceSessionResponseList(attendance.Sessions),
 	}
 }
 
@@ -293,6 +360,12 @@ func (attendanceHandler *AttendanceHandler) convertToBreakResponse(breakItem *do
 		EndTime:      breakItem.EndTime,
 		Duration:     breakItem.Duration,
 		Reason:       breakItem.Reason,
+		BreakTypeID:  breakItem.BreakTypeID,
+		IsPaid:       breakItem.IsPaid,
+		Flagged:      breakItem.Flagged,
+		FlagReason:   breakItem.FlagReason,
+		StartIP:      breakItem.StartIP,
+		EndIP:        breakItem.EndIP,
 		CreatedAt:    breakItem.CreatedAt,
 		UpdatedAt:    breakItem.UpdatedAt,
 	}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceRegularizationHandler handles HTTP requests related to attendance correction requests
+type AttendanceRegularizationHandler struct {
+	regularizationService domain.AttendanceRegularizationServiceInterface
+}
+
+// NewAttendanceRegularizationHandler creates a new instance of AttendanceRegularizationHandler
+func NewAttendanceRegularizationHandler(regularizationService domain.AttendanceRegularizationServiceInterface) *AttendanceRegularizationHandler {
+	return &AttendanceRegularizationHandler{
+		regularizationService: regularizationService,
+	}
+}
+
+// RequestRegularization submits a correction of the punches of a past day
+func (h *AttendanceRegularizationHandler) RequestRegularization(c *gin.Context) {
+	var req request.RegularizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	regularization := &domain.AttendanceRegularization{
+		Date:                  req.Date,
+		RequestedCheckInTime:  req.CheckInTime,
+		RequestedCheckOutTime: req.CheckOutTime,
+		Reason:                req.Reason,
+	}
+
+	if err := h.regularizationService.RequestRegularization(userID, regularization); err != nil {
+		if errors.Is(err, domain.ErrRegularizationAlreadyExists) {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "A pending regularization request already exists for this date",
+			})
+		} else if errors.Is(err, domain.ErrInvalidRegularizationTimes) ||
+			errors.Is(err, domain.ErrRegularizationReason) ||
+			errors.Is(err, domain.ErrInvalidDate) {
+			BadRequestResponse(c, err.Error())
+		} else if errors.Is(err, domain.ErrUserNotFound) {
+			NotFoundResponse(c, "User not found")
+		} else {
+			InternalServerErrorResponse(c, "Failed to request regularization: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Regularization requested successfully", response.ToRegularizationResponse(regularization))
+}
+
+// GetMyRegularizations retrieves the regularization requests of the authenticated user
+func (h *AttendanceRegularizationHandler) GetMyRegularizations(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	regularizations, err := h.regularizationService.GetUserRegularizations(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get regularizations: "+err.Error())
+		return
+	}
+
+	listResp := response.RegularizationListResponse{
+		Regularizations: response.ToRegularizationResponseList(regularizations),
+		Total:           len(regularizations),
+	}
+	SuccessResponse(c, http.StatusOK, "Regularizations retrieved successfully", listResp)
+}
+
+// GetPendingRegularizations retrieves the pending requests the authenticated user may review
+func (h *AttendanceRegularizationHandler) GetPendingRegularizations(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	regularizations, err := h.regularizationService.GetPendingRegularizations(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get pending regularizations: "+err.Error())
+		return
+	}
+
+	listResp := response.RegularizationListResponse{
+		Regularizations: response.ToRegularizationResponseList(regularizations),
+		Total:           len(regularizations),
+	}
+	SuccessResponse(c, http.StatusOK, "Pending regularizations retrieved successfully", listResp)
+}
+
+// GetRegularizationByID retrieves a regularization request by ID
+func (h *AttendanceRegularizationHandler) GetRegularizationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid regularization ID")
+		return
+	}
+
+	regularization, err := h.regularizationService.GetRegularizationByID(uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrRegularizationNotFound) {
+			NotFoundResponse(c, "Regularization not found")
+		} else {
+			InternalServerErrorResponse(c, "Failed to get regularization: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Regularization retrieved successfully", response.ToRegularizationResponse(regularization))
+}
+
+// ApproveRegularization approves a request and applies the corrected punches
+func (h *AttendanceRegularizationHandler) ApproveRegularization(c *gin.Context) {
+	h.review(c, h.regularizationService.ApproveRegularization, "approve", "Regularization approved successfully")
+}
+
+// RejectRegularization rejects a request
+func (h *AttendanceRegularizationHandler) RejectRegularization(c *gin.Context) {
+	h.review(c, h.regularizationService.RejectRegularization, "reject", "Regularization rejected successfully")
+}
+
+// CancelRegularization withdraws a pending request of the authenticated user
+func (h *AttendanceRegularizationHandler) CancelRegularization(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid regularization ID")
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	if err := h.regularizationService.CancelRegularization(uint(id), userID); err != nil {
+		h.handleReviewError(c, err, "cancel")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Regularization cancelled successfully", nil)
+}
+
+// review runs an approve or reject action on behalf of the authenticated user
+func (h *AttendanceRegularizationHandler) review(c *gin.Context, action func(id uint, approverID uint, note string) error, verb string, message string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid regularization ID")
+		return
+	}
+
+	var req request.RegularizationReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestResponse(c, "Invalid request data: "+err.Error())
+			return
+		}
+	}
+
+	approverID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	if err := action(uint(id), approverID, req.Note); err != nil {
+		h.handleReviewError(c, err, verb)
+		return
+	}
+
+	regularization, err := h.regularizationService.GetRegularizationByID(uint(id))
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get regularization: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, message, response.ToRegularizationResponse(regularization))
+}
+
+// handleReviewError maps review and cancellation errors to HTTP responses
+func (h *AttendanceRegularizationHandler) handleReviewError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrRegularizationNotFound):
+		NotFoundResponse(c, "Regularization not found")
+	case errors.Is(err, domain.ErrRegularizationNotPending):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Regularization request is no longer pending",
+		})
+	case errors.Is(err, domain.ErrAttendanceLocked):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Attendance of this day is locked by an approved timesheet",
+		})
+	case errors.Is(err, domain.ErrSelfApproval):
+		ForbiddenResponse(c, "You cannot review your own request")
+	case errors.Is(err, domain.ErrUnauthorized):
+		ForbiddenResponse(c, "You are not allowed to "+verb+" this request")
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+" regularization: "+err.Error())
+	}
+}
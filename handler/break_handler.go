@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"hrm/domain"
 	"hrm/handler/request"
 	"hrm/handler/response"
+	"hrm/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,7 +25,7 @@ func NewBreakHandler(breakService domain.BreakServiceInterface) *BreakHandler {
 	}
 }
 
-// AddBreak adds a new break to an attendance record
+// AddBreak adds a new break to an attendance record of the authenticated user
 func (h *BreakHandler) AddBreak(c *gin.Context) {
 	var req request.BreakRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -31,15 +33,34 @@ func (h *BreakHandler) AddBreak(c *gin.Context) {
 		return
 	}
 
-	breakItem, err := h.breakService.CreateBreak(req.AttendanceID, req.StartTime, req.Reason)
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	breakItem, err := h.breakService.CreateBreak(userID, req.AttendanceID, domain.Punch{ClientTime: req.StartTime, IPAddress: c.ClientIP()}, req.BreakTypeID, req.Reason)
 	if err != nil {
 		if err == domain.ErrAttendanceNotFound {
 			NotFoundResponse(c, "Attendance not found")
+		} else if err == domain.ErrUnauthorized {
+			ForbiddenResponse(c, "You can only add breaks to your own attendance")
+		} else if err == domain.ErrClockSkew {
+			BadRequestResponse(c, "Device clock is out of sync with server time")
+		} else if err == domain.ErrBreakTypeNotFound || err == domain.ErrBreakTypeInactive {
+			BadRequestResponse(c, err.Error())
+		} else if err == domain.ErrNotCheckedIn {
+			BadRequestResponse(c, "Breaks can only be taken while checked in")
 		} else if err == domain.ErrBreakInProgress {
 			c.JSON(http.StatusConflict, Response{
 				Success: false,
 				Message: "Break already in progress",
 			})
+		} else if err == domain.ErrAttendanceLocked {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
 		} else {
 			InternalServerErrorResponse(c, "Failed to add break: "+err.Error())
 		}
@@ -55,7 +76,7 @@ func (h *BreakHandler) AddBreak(c *gin.Context) {
 	SuccessResponse(c, http.StatusCreated, "Break added successfully", addBreakResp)
 }
 
-// EndBreak ends an existing break
+// EndBreak ends an existing break of the authenticated user
 func (h *BreakHandler) EndBreak(c *gin.Context) {
 	var req request.EndBreakRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -63,15 +84,30 @@ func (h *BreakHandler) EndBreak(c *gin.Context) {
 		return
 	}
 
-	err := h.breakService.EndBreak(req.BreakID, req.EndTime)
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	err := h.breakService.EndBreak(userID, req.BreakID, domain.Punch{ClientTime: req.EndTime, IPAddress: c.ClientIP()})
 	if err != nil {
 		if err == domain.ErrBreakNotFound {
 			NotFoundResponse(c, "Break not found")
+		} else if err == domain.ErrUnauthorized {
+			ForbiddenResponse(c, "You can only end breaks of your own attendance")
+		} else if err == domain.ErrClockSkew {
+			BadRequestResponse(c, "Device clock is out of sync with server time")
 		} else if err == domain.ErrBreakAlreadyEnded {
 			c.JSON(http.StatusConflict, Response{
 				Success: false,
 				Message: "Break already ended",
 			})
+		} else if err == domain.ErrAttendanceLocked {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
 		} else if err == domain.ErrInvalidBreakTime {
 			BadRequestResponse(c, "Invalid break time")
 		} else {
@@ -81,7 +117,7 @@ func (h *BreakHandler) EndBreak(c *gin.Context) {
 	}
 
 	// Get the updated break record
-	breakItem, err := h.breakService.GetBreakByID(req.BreakID)
+	breakItem, err := h.breakService.GetBreakByID(userID, req.BreakID)
 	if err != nil {
 		InternalServerErrorResponse(c, "Failed to get updated break: "+err.Error())
 		return
@@ -97,7 +133,110 @@ func (h *BreakHandler) EndBreak(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "Break ended successfully", endBreakResp)
 }
 
-// GetBreakByID retrieves a break record by ID
+// StartMyBreak starts a break in the attendance the authenticated user is checked in to
+func (h *BreakHandler) StartMyBreak(c *gin.Context) {
+	var req request.StartMyBreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	breakItem, err := h.breakService.StartMyBreak(userID, domain.Punch{ClientTime: req.StartTime, IPAddress: c.ClientIP()}, req.BreakTypeID, req.Reason)
+	if err != nil {
+		h.handleMyBreakError(c, err, "start break")
+		return
+	}
+
+	h.respondWithStatus(c, userID, breakItem, http.StatusCreated, "Break started successfully")
+}
+
+// EndMyBreak ends the break the authenticated user is currently on
+func (h *BreakHandler) EndMyBreak(c *gin.Context) {
+	var req request.EndMyBreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	breakItem, err := h.breakService.EndMyBreak(userID, domain.Punch{ClientTime: req.EndTime, IPAddress: c.ClientIP()})
+	if err != nil {
+		h.handleMyBreakError(c, err, "end break")
+		return
+	}
+
+	h.respondWithStatus(c, userID, breakItem, http.StatusOK, "Break ended successfully")
+}
+
+// GetMyBreakStatus reports whether the authenticated user is working, on a break or checked out
+func (h *BreakHandler) GetMyBreakStatus(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	status, err := h.breakService.GetBreakStatus(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get break status: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Break status retrieved successfully", response.ToBreakStatusResponse(status))
+}
+
+// respondWithStatus returns a break together with the caller's status after the change
+func (h *BreakHandler) respondWithStatus(c *gin.Context, userID uint, breakItem *domain.Break, statusCode int, message string) {
+	status, err := h.breakService.GetBreakStatus(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get break status: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, statusCode, message, response.MyBreakResponse{
+		Break:  h.convertToBreakResponse(*breakItem),
+		Status: response.ToBreakStatusResponse(status),
+	})
+}
+
+// handleMyBreakError maps errors of the caller's own break actions to HTTP responses
+func (h *BreakHandler) handleMyBreakError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, domain.ErrNotCheckedIn):
+		BadRequestResponse(c, "You are not checked in")
+	case errors.Is(err, domain.ErrNoActiveBreak):
+		BadRequestResponse(c, "You are not on a break")
+	case errors.Is(err, domain.ErrClockSkew):
+		BadRequestResponse(c, "Device clock is out of sync with server time")
+	case errors.Is(err, domain.ErrBreakTypeNotFound), errors.Is(err, domain.ErrBreakTypeInactive):
+		BadRequestResponse(c, err.Error())
+	case errors.Is(err, domain.ErrBreakInProgress):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Break already in progress",
+		})
+	case errors.Is(err, domain.ErrAttendanceLocked):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Attendance is locked by an approved timesheet",
+		})
+	default:
+		InternalServerErrorResponse(c, "Failed to "+action+": "+err.Error())
+	}
+}
+
+// GetBreakByID retrieves a break record of the authenticated user by ID
 func (h *BreakHandler) GetBreakByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
@@ -106,10 +245,18 @@ func (h *BreakHandler) GetBreakByID(c *gin.Context) {
 		return
 	}
 
-	breakItem, err := h.breakService.GetBreakByID(uint(id))
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	breakItem, err := h.breakService.GetBreakByID(userID, uint(id))
 	if err != nil {
 		if err == domain.ErrBreakNotFound {
 			NotFoundResponse(c, "Break not found")
+		} else if err == domain.ErrUnauthorized {
+			ForbiddenResponse(c, "You can only view breaks of your own attendance")
 		} else {
 			InternalServerErrorResponse(c, "Failed to get break: "+err.Error())
 		}
@@ -120,7 +267,7 @@ func (h *BreakHandler) GetBreakByID(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "Break retrieved successfully", breakResp)
 }
 
-// GetBreaksByAttendanceID retrieves all breaks for an attendance
+// GetBreaksByAttendanceID retrieves all breaks for an attendance of the authenticated user
 func (h *BreakHandler) GetBreaksByAttendanceID(c *gin.Context) {
 	attendanceIDStr := c.Param("attendance_id")
 	attendanceID, err := strconv.ParseUint(attendanceIDStr, 10, 32)
@@ -129,10 +276,18 @@ func (h *BreakHandler) GetBreaksByAttendanceID(c *gin.Context) {
 		return
 	}
 
-	breaks, err := h.breakService.GetBreaksByAttendanceID(uint(attendanceID))
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	breaks, err := h.breakService.GetBreaksByAttendanceID(userID, uint(attendanceID))
 	if err != nil {
 		if err == domain.ErrAttendanceNotFound {
 			NotFoundResponse(c, "Attendance not found")
+		} else if err == domain.ErrUnauthorized {
+			ForbiddenResponse(c, "You can only view breaks of your own attendance")
 		} else {
 			InternalServerErrorResponse(c, "Failed to get breaks: "+err.Error())
 		}
@@ -152,7 +307,7 @@ func (h *BreakHandler) GetBreaksByAttendanceID(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "Breaks retrieved successfully", listResp)
 }
 
-// GetAllBreaks retrieves all break records
+// GetAllBreaks retrieves the break records of all users
 func (h *BreakHandler) GetAllBreaks(c *gin.Context) {
 	breaks, err := h.breakService.GetAllBreaks()
 	if err != nil {
@@ -173,7 +328,7 @@ func (h *BreakHandler) GetAllBreaks(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "All breaks retrieved successfully", listResp)
 }
 
-// DeleteBreak deletes a break record
+// DeleteBreak deletes a break record of the authenticated user
 func (h *BreakHandler) DeleteBreak(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
@@ -182,10 +337,23 @@ func (h *BreakHandler) DeleteBreak(c *gin.Context) {
 		return
 	}
 
-	err = h.breakService.DeleteBreak(uint(id))
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	err = h.breakService.DeleteBreak(userID, uint(id))
 	if err != nil {
 		if err == domain.ErrBreakNotFound {
 			NotFoundResponse(c, "Break not found")
+		} else if err == domain.ErrUnauthorized {
+			ForbiddenResponse(c, "You can only delete breaks of your own attendance")
+		} else if err == domain.ErrAttendanceLocked {
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: "Attendance is locked by an approved timesheet",
+			})
 		} else {
 			InternalServerErrorResponse(c, "Failed to delete break: "+err.Error())
 		}
@@ -204,6 +372,12 @@ func (h *BreakHandler) convertToBreakResponse(breakItem domain.Break) response.B
 		EndTime:      breakItem.EndTime,
 		Duration:     breakItem.Duration,
 		Reason:       breakItem.Reason,
+		BreakTypeID:  breakItem.BreakTypeID,
+		IsPaid:       breakItem.IsPaid,
+		Flagged:      breakItem.Flagged,
+		FlagReason:   breakItem.FlagReason,
+		StartIP:      breakItem.StartIP,
+		EndIP:        breakItem.EndIP,
 		CreatedAt:    breakItem.CreatedAt,
 		UpdatedAt:    breakItem.UpdatedAt,
 	}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BreakTypeHandler handles HTTP requests related to break types
+type BreakTypeHandler struct {
+	breakTypeService domain.BreakTypeServiceInterface
+}
+
+// NewBreakTypeHandler creates a new instance of BreakTypeHandler
+func NewBreakTypeHandler(breakTypeService domain.BreakTypeServiceInterface) *BreakTypeHandler {
+	return &BreakTypeHandler{
+		breakTypeService: breakTypeService,
+	}
+}
+
+// CreateBreakType creates a new break type
+func (h *BreakTypeHandler) CreateBreakType(c *gin.Context) {
+	var req request.BreakTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	breakType := &domain.BreakType{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPaid:      req.IsPaid,
+		MaxDuration: req.MaxDuration,
+		MaxPerDay:   req.MaxPerDay,
+	}
+
+	if err := h.breakTypeService.CreateBreakType(breakType); err != nil {
+		h.handleError(c, err, "create break type")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Break type created successfully", response.ToBreakTypeResponse(breakType))
+}
+
+// GetBreakTypeByID retrieves a break type by ID
+func (h *BreakTypeHandler) GetBreakTypeByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid break type ID")
+		return
+	}
+
+	breakType, err := h.breakTypeService.GetBreakTypeByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "get break type")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Break type retrieved successfully", response.ToBreakTypeResponse(breakType))
+}
+
+// GetAllBreakTypes retrieves all break types
+func (h *BreakTypeHandler) GetAllBreakTypes(c *gin.Context) {
+	breakTypes, err := h.breakTypeService.GetAllBreakTypes()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get break types: "+err.Error())
+		return
+	}
+
+	breakTypeResponses := response.ToBreakTypeResponseList(breakTypes)
+	listResp := response.BreakTypeListResponse{
+		BreakTypes: breakTypeResponses,
+		Total:      len(breakTypeResponses),
+	}
+
+	SuccessResponse(c, http.StatusOK, "Break types retrieved successfully", listResp)
+}
+
+// UpdateBreakType updates an existing break type
+func (h *BreakTypeHandler) UpdateBreakType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid break type ID")
+		return
+	}
+
+	var req request.BreakTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	existingBreakType, err := h.breakTypeService.GetBreakTypeByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "update break type")
+		return
+	}
+
+	breakType := &domain.BreakType{
+		ID:          uint(id),
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPaid:      req.IsPaid,
+		MaxDuration: req.MaxDuration,
+		MaxPerDay:   req.MaxPerDay,
+		IsActive:    existingBreakType.IsActive,
+	}
+	if req.IsActive != nil {
+		breakType.IsActive = *req.IsActive
+	}
+
+	if err := h.breakTypeService.UpdateBreakType(breakType); err != nil {
+		h.handleError(c, err, "update break type")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Break type updated successfully", response.ToBreakTypeResponse(breakType))
+}
+
+// DeleteBreakType deletes a break type no break was recorded with
+func (h *BreakTypeHandler) DeleteBreakType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid break type ID")
+		return
+	}
+
+	if err := h.breakTypeService.DeleteBreakType(uint(id)); err != nil {
+		h.handleError(c, err, "delete break type")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Break type deleted successfully", nil)
+}
+
+// handleError maps break type errors to HTTP responses
+func (h *BreakTypeHandler) handleError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, domain.ErrBreakTypeNotFound):
+		NotFoundResponse(c, "Break type not found")
+	case errors.Is(err, domain.ErrBreakTypeCodeExists):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Break type code already exists",
+		})
+	case errors.Is(err, domain.ErrBreakTypeInUse):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Break type has recorded breaks; deactivate it instead",
+		})
+	case errors.Is(err, domain.ErrInvalidBreakTypeName), errors.Is(err, domain.ErrInvalidBreakTypeCode),
+		errors.Is(err, domain.ErrInvalidBreakLimit):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+action+": "+err.Error())
+	}
+}
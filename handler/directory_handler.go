@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DirectoryHandler handles HTTP requests for syncing users from the LDAP directory
+type DirectoryHandler struct {
+	directorySyncService domain.DirectorySyncServiceInterface
+}
+
+// NewDirectoryHandler creates a new instance of DirectoryHandler
+func NewDirectoryHandler(directorySyncService domain.DirectorySyncServiceInterface) *DirectoryHandler {
+	return &DirectoryHandler{
+		directorySyncService: directorySyncService,
+	}
+}
+
+// SyncDirectory syncs users and managers from the directory and returns what changed.
+// With dry_run=true it only reports the changes a sync would make.
+func (h *DirectoryHandler) SyncDirectory(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			BadRequestResponse(c, "Invalid dry_run value")
+			return
+		}
+		dryRun = parsed
+	}
+
+	report, err := h.directorySyncService.Sync(dryRun)
+	if err != nil {
+		directoryErrorResponse(c, err, "Failed to sync users from the directory")
+		return
+	}
+
+	message := "Users synced from the directory"
+	if report.DryRun {
+		message = "Directory sync checked; nothing was saved"
+	}
+	SuccessResponse(c, http.StatusOK, message, response.ToDirectorySyncResponse(report))
+}
+
+// directoryErrorResponse answers an unreachable directory with 503 and an empty one with 409.
+// Other errors are answered with 500 and the given message.
+func directoryErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrDirectoryUnavailable):
+		ErrorResponse(c, http.StatusServiceUnavailable, domain.ErrDirectoryUnavailable.Error())
+	case errors.Is(err, domain.ErrDirectoryEmpty):
+		ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		InternalServerErrorResponse(c, message)
+	}
+}
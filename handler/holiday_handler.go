@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HolidayHandler handles HTTP requests related to holiday operations
+type HolidayHandler struct {
+	holidayService domain.HolidayServiceInterface
+}
+
+// NewHolidayHandler creates a new instance of HolidayHandler
+func NewHolidayHandler(holidayService domain.HolidayServiceInterface) *HolidayHandler {
+	return &HolidayHandler{
+		holidayService: holidayService,
+	}
+}
+
+// CreateHoliday creates a new holiday
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	var req request.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	holiday := &domain.Holiday{
+		Name:       req.Name,
+		Date:       req.Date,
+		LocationID: req.LocationID,
+	}
+
+	if err := h.holidayService.CreateHoliday(holiday); err != nil {
+		h.handleError(c, err, "create")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Holiday created successfully", response.ToHolidayResponse(holiday))
+}
+
+// GetHolidayByID retrieves a holiday by ID
+func (h *HolidayHandler) GetHolidayByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid holiday ID")
+		return
+	}
+
+	holiday, err := h.holidayService.GetHolidayByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "get")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Holiday retrieved successfully", response.ToHolidayResponse(holiday))
+}
+
+// GetAllHolidays retrieves all holidays
+func (h *HolidayHandler) GetAllHolidays(c *gin.Context) {
+	holidays, err := h.holidayService.GetAllHolidays()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get holidays: "+err.Error())
+		return
+	}
+
+	holidayResponses := response.ToHolidayResponseList(holidays)
+	listResp := response.HolidayListResponse{
+		Holidays: holidayResponses,
+		Total:    len(holidayResponses),
+	}
+
+	SuccessResponse(c, http.StatusOK, "Holidays retrieved successfully", listResp)
+}
+
+// UpdateHoliday updates an existing holiday
+func (h *HolidayHandler) UpdateHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid holiday ID")
+		return
+	}
+
+	var req request.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	holiday := &domain.Holiday{
+		ID:         uint(id),
+		Name:       req.Name,
+		Date:       req.Date,
+		LocationID: req.LocationID,
+	}
+
+	if err := h.holidayService.UpdateHoliday(holiday); err != nil {
+		h.handleError(c, err, "update")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Holiday updated successfully", response.ToHolidayResponse(holiday))
+}
+
+// DeleteHoliday deletes a holiday
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid holiday ID")
+		return
+	}
+
+	if err := h.holidayService.DeleteHoliday(uint(id)); err != nil {
+		h.handleError(c, err, "delete")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Holiday deleted successfully", nil)
+}
+
+// handleError maps holiday errors to HTTP responses
+func (h *HolidayHandler) handleError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrHolidayNotFound):
+		NotFoundResponse(c, "Holiday not found")
+	case errors.Is(err, domain.ErrInvalidHolidayName), errors.Is(err, domain.ErrInvalidDate),
+		errors.Is(err, domain.ErrLocationNotFound):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+" holiday: "+err.Error())
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"hrm/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler handles HTTP requests for the public keys access tokens are signed with
+type JWKSHandler struct {
+	signingKeyService domain.SigningKeyServiceInterface
+}
+
+// NewJWKSHandler creates a new instance of JWKSHandler
+func NewJWKSHandler(signingKeyService domain.SigningKeyServiceInterface) *JWKSHandler {
+	return &JWKSHandler{
+		signingKeyService: signingKeyService,
+	}
+}
+
+// GetJWKS returns the JSON Web Key Set other services verify HRM access tokens with.
+// The set is served as is, without the usual response envelope, so standard JWT libraries can read it.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	jwks, err := h.signingKeyService.GetJWKS()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to load signing keys")
+		return
+	}
+
+	// Verifiers should still fetch the set again when they meet an unknown "kid"
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KioskHandler handles HTTP requests for managing shared kiosks and for the punches made on them
+type KioskHandler struct {
+	kioskService domain.KioskServiceInterface
+}
+
+// NewKioskHandler creates a new instance of KioskHandler
+func NewKioskHandler(kioskService domain.KioskServiceInterface) *KioskHandler {
+	return &KioskHandler{
+		kioskService: kioskService,
+	}
+}
+
+// RegisterKiosk registers a new kiosk and returns its device token once
+func (h *KioskHandler) RegisterKiosk(c *gin.Context) {
+	var req request.KioskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	kiosk := &domain.Kiosk{
+		Name:       req.Name,
+		LocationID: req.LocationID,
+	}
+
+	token, err := h.kioskService.RegisterKiosk(kiosk)
+	if err != nil {
+		h.handleError(c, err, "register kiosk")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Kiosk registered successfully; store the token now, it is not shown again", response.KioskTokenResponse{
+		Kiosk: response.ToKioskResponse(kiosk),
+		Token: token,
+	})
+}
+
+// GetKioskByID retrieves a kiosk by ID
+func (h *KioskHandler) GetKioskByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid kiosk ID")
+		return
+	}
+
+	kiosk, err := h.kioskService.GetKioskByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "get kiosk")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Kiosk retrieved successfully", response.ToKioskResponse(kiosk))
+}
+
+// GetAllKiosks retrieves all kiosks
+func (h *KioskHandler) GetAllKiosks(c *gin.Context) {
+	kiosks, err := h.kioskService.GetAllKiosks()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get kiosks: "+err.Error())
+		return
+	}
+
+	kioskResponses := response.ToKioskResponseList(kiosks)
+	listResp := response.KioskListResponse{
+		Kiosks: kioskResponses,
+		Total:  len(kioskResponses),
+	}
+
+	SuccessResponse(c, http.StatusOK, "Kiosks retrieved successfully", listResp)
+}
+
+// UpdateKiosk renames, moves, activates or deactivates a kiosk
+func (h *KioskHandler) UpdateKiosk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid kiosk ID")
+		return
+	}
+
+	var req request.KioskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	existingKiosk, err := h.kioskService.GetKioskByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "update kiosk")
+		return
+	}
+
+	kiosk := &domain.Kiosk{
+		ID:         uint(id),
+		Name:       req.Name,
+		LocationID: req.LocationID,
+		IsActive:   existingKiosk.IsActive,
+	}
+	if req.IsActive != nil {
+		kiosk.IsActive = *req.IsActive
+	}
+
+	if err := h.kioskService.UpdateKiosk(kiosk); err != nil {
+		h.handleError(c, err, "update kiosk")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Kiosk updated successfully", response.ToKioskResponse(kiosk))
+}
+
+// RotateKioskToken issues a new device token for a kiosk and invalidates the old one
+func (h *KioskHandler) RotateKioskToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid kiosk ID")
+		return
+	}
+
+	token, err := h.kioskService.RotateKioskToken(uint(id))
+	if err != nil {
+		h.handleError(c, err, "rotate kiosk token")
+		return
+	}
+
+	kiosk, err := h.kioskService.GetKioskByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "rotate kiosk token")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Kiosk token rotated successfully; store the token now, it is not shown again", response.KioskTokenResponse{
+		Kiosk: response.ToKioskResponse(kiosk),
+		Token: token,
+	})
+}
+
+// DeleteKiosk deletes a kiosk
+func (h *KioskHandler) DeleteKiosk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid kiosk ID")
+		return
+	}
+
+	if err := h.kioskService.DeleteKiosk(uint(id)); err != nil {
+		h.handleError(c, err, "delete kiosk")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Kiosk deleted successfully", nil)
+}
+
+// CheckIn checks in the employee identified at the kiosk
+func (h *KioskHandler) CheckIn(c *gin.Context) {
+	kiosk, req, ok := h.bindPunch(c)
+	if !ok {
+		return
+	}
+
+	user, attendance, err := h.kioskService.CheckIn(kiosk, kioskCredentials(req), kioskPunch(c, req))
+	if err != nil {
+		h.handlePunchError(c, err, "check in")
+		return
+	}
+
+	attendanceResp := response.ToAttendanceResponse(attendance)
+	SuccessResponse(c, http.StatusOK, "Check-in successful", response.KioskPunchResponse{
+		Employee:   response.ToKioskEmployeeResponse(user),
+		Attendance: &attendanceResp,
+	})
+}
+
+// CheckOut checks out the employee identified at the kiosk
+func (h *KioskHandler) CheckOut(c *gin.Context) {
+	kiosk, req, ok := h.bindPunch(c)
+	if !ok {
+		return
+	}
+
+	user, attendance, err := h.kioskService.CheckOut(kiosk, kioskCredentials(req), kioskPunch(c, req))
+	if err != nil {
+		h.handlePunchError(c, err, "check out")
+		return
+	}
+
+	attendanceResp := response.ToAttendanceResponse(attendance)
+	SuccessResponse(c, http.StatusOK, "Check-out successful", response.KioskPunchResponse{
+		Employee:   response.ToKioskEmployeeResponse(user),
+		Attendance: &attendanceResp,
+	})
+}
+
+// StartBreak starts a break for the employee identified at the kiosk
+func (h *KioskHandler) StartBreak(c *gin.Context) {
+	kiosk, req, ok := h.bindPunch(c)
+	if !ok {
+		return
+	}
+
+	user, breakItem, err := h.kioskService.StartBreak(kiosk, kioskCredentials(req), kioskPunch(c, req), req.BreakTypeID, req.Reason)
+	if err != nil {
+		h.handlePunchError(c, err, "start break")
+		return
+	}
+
+	breakResp := response.ToBreakResponse(breakItem)
+	SuccessResponse(c, http.StatusCreated, "Break started successfully", response.KioskPunchResponse{
+		Employee: response.ToKioskEmployeeResponse(user),
+		Break:    &breakResp,
+	})
+}
+
+// EndBreak ends the break of the employee identified at the kiosk
+func (h *KioskHandler) EndBreak(c *gin.Context) {
+	kiosk, req, ok := h.bindPunch(c)
+	if !ok {
+		return
+	}
+
+	user, breakItem, err := h.kioskService.EndBreak(kiosk, kioskCredentials(req), kioskPunch(c, req))
+	if err != nil {
+		h.handlePunchError(c, err, "end break")
+		return
+	}
+
+	breakResp := response.ToBreakResponse(breakItem)
+	SuccessResponse(c, http.StatusOK, "Break ended successfully", response.KioskPunchResponse{
+		Employee: response.ToKioskEmployeeResponse(user),
+		Break:    &breakResp,
+	})
+}
+
+// bindPunch reads the authenticated kiosk and the body of a kiosk punch request.
+// It writes the error response and returns false if the request cannot be used.
+func (h *KioskHandler) bindPunch(c *gin.Context) (*domain.Kiosk, *request.KioskPunchRequest, bool) {
+	kiosk, exists := GetKioskFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "Kiosk not authenticated")
+		return nil, nil, false
+	}
+
+	var req request.KioskPunchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return nil, nil, false
+	}
+
+	return kiosk, &req, true
+}
+
+// kioskCredentials converts the identification fields of a kiosk request to domain credentials
+func kioskCredentials(req *request.KioskPunchRequest) domain.KioskCredentials {
+	return domain.KioskCredentials{
+		EmployeeCode: req.EmployeeCode,
+		PIN:          req.PIN,
+		BadgeID:      req.BadgeID,
+	}
+}
+
+// kioskPunch builds the punch of a kiosk request; the kiosk itself is recorded by the service
+func kioskPunch(c *gin.Context, req *request.KioskPunchRequest) domain.Punch {
+	return domain.Punch{
+		ClientTime: req.Date,
+		IPAddress:  c.ClientIP(),
+	}
+}
+
+// handlePunchError maps errors of kiosk punches to HTTP responses
+func (h *KioskHandler) handlePunchError(c *gin.Context, err error, action string) {
+	if handlePunchPolicyError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrKioskCredentialsRequired):
+		BadRequestResponse(c, err.Error())
+	case errors.Is(err, domain.ErrInvalidKioskCredentials):
+		UnauthorizedResponse(c, "Invalid employee code, PIN or badge")
+	case errors.Is(err, domain.ErrKioskPINLocked):
+		ErrorResponseWithCode(c, http.StatusTooManyRequests, ErrorCodeKioskPINLocked, err.Error())
+	case errors.Is(err, domain.ErrUserInactive), errors.Is(err, domain.ErrKioskLocationMismatch):
+		ForbiddenResponse(c, err.Error())
+	case errors.Is(err, domain.ErrAlreadyCheckedIn), errors.Is(err, domain.ErrBreakInProgress),
+		errors.Is(err, domain.ErrAttendanceLocked):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrNotCheckedIn), errors.Is(err, domain.ErrNoActiveBreak),
+		errors.Is(err, domain.ErrBreakTypeNotFound), errors.Is(err, domain.ErrBreakTypeInactive):
+		BadRequestResponse(c, err.Error())
+	case errors.Is(err, domain.ErrClockSkew):
+		BadRequestResponse(c, "Kiosk clock is out of sync with server time")
+	default:
+		InternalServerErrorResponse(c, "Failed to "+action+": "+err.Error())
+	}
+}
+
+// handleError maps errors of kiosk management to HTTP responses
+func (h *KioskHandler) handleError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, domain.ErrKioskNotFound):
+		NotFoundResponse(c, "Kiosk not found")
+	case errors.Is(err, domain.ErrInvalidKioskName), errors.Is(err, domain.ErrLocationNotFound):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+action+": "+err.Error())
+	}
+}
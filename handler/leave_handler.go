@@ -37,7 +37,7 @@ func SetupLeaveRoutes(router *gin.Engine, leaveService domain.LeaveServiceInterf
 		leaveGroup.Use(middleware.JWTAuthMiddleware())
 		{
 			// Leave management
-			leaveGroup.POST("/", handler.CreateLeave)
+			leaveGroup.POST("/", middleware.RequireVerifiedEmail(), handler.CreateLeave)
 			leaveGroup.GET("/", handler.GetAllLeaves)
 			leaveGroup.GET("/pending", handler.GetPendingLeaves)
 			leaveGroup.GET("/:id", handler.GetLeaveByID)
@@ -45,8 +45,8 @@ func SetupLeaveRoutes(router *gin.Engine, leaveService domain.LeaveServiceInterf
 			leaveGroup.DELETE("/:id", handler.DeleteLeave)
 
 			// Leave approval/rejection
-			leaveGroup.POST("/:id/approve", handler.ApproveLeave)
-			leaveGroup.POST("/:id/reject", handler.RejectLeave)
+			leaveGroup.POST("/:id/approve", middleware.RequireVerifiedEmail(), handler.ApproveLeave)
+			leaveGroup.POST("/:id/reject", middleware.RequireVerifiedEmail(), handler.RejectLeave)
 			leaveGroup.POST("/:id/cancel", handler.CancelLeave)
 
 			// User-specific leaves
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocationHandler handles HTTP requests related to location operations
+type LocationHandler struct {
+	locationService domain.LocationServiceInterface
+}
+
+// NewLocationHandler creates a new instance of LocationHandler
+func NewLocationHandler(locationService domain.LocationServiceInterface) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+	}
+}
+
+// CreateLocation creates a new location
+func (h *LocationHandler) CreateLocation(c *gin.Context) {
+	var req request.LocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	location := &domain.Location{
+		Name:            req.Name,
+		Timezone:        req.Timezone,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		GeofenceRadius:  req.GeofenceRadius,
+		GeofenceMode:    req.GeofenceMode,
+		AllowedNetworks: strings.Join(req.AllowedNetworks, ","),
+	}
+
+	if err := h.locationService.CreateLocation(location); err != nil {
+		if isLocationValidationError(err) {
+			BadRequestResponse(c, err.Error())
+		} else {
+			InternalServerErrorResponse(c, "Failed to create location: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Location created successfully", response.ToLocationResponse(location))
+}
+
+// GetLocationByID retrieves a location by ID
+func (h *LocationHandler) GetLocationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid location ID")
+		return
+	}
+
+	location, err := h.locationService.GetLocationByID(uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrLocationNotFound) {
+			NotFoundResponse(c, "Location not found")
+		} else {
+			InternalServerErrorResponse(c, "Failed to get location: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Location retrieved successfully", response.ToLocationResponse(location))
+}
+
+// GetAllLocations retrieves all locations
+func (h *LocationHandler) GetAllLocations(c *gin.Context) {
+	locations, err := h.locationService.GetAllLocations()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get locations: "+err.Error())
+		return
+	}
+
+	locationResponses := response.ToLocationResponseList(locations)
+	listResp := response.LocationListResponse{
+		Locations: locationResponses,
+		Total:     len(locationResponses),
+	}
+
+	SuccessResponse(c, http.StatusOK, "Locations retrieved successfully", listResp)
+}
+
+// UpdateLocation updates an existing location
+func (h *LocationHandler) UpdateLocation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid location ID")
+		return
+	}
+
+	var req request.LocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	location := &domain.Location{
+		ID:              uint(id),
+		Name:            req.Name,
+		Timezone:        req.Timezone,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		GeofenceRadius:  req.GeofenceRadius,
+		GeofenceMode:    req.GeofenceMode,
+		AllowedNetworks: strings.Join(req.AllowedNetworks, ","),
+	}
+
+	if err := h.locationService.UpdateLocation(location); err != nil {
+		if errors.Is(err, domain.ErrLocationNotFound) {
+			NotFoundResponse(c, "Location not found")
+		} else if isLocationValidationError(err) {
+			BadRequestResponse(c, err.Error())
+		} else {
+			InternalServerErrorResponse(c, "Failed to update location: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Location updated successfully", response.ToLocationResponse(location))
+}
+
+// DeleteLocation deletes a location
+func (h *LocationHandler) DeleteLocation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid location ID")
+		return
+	}
+
+	if err := h.locationService.DeleteLocation(uint(id)); err != nil {
+		if errors.Is(err, domain.ErrLocationNotFound) {
+			NotFoundResponse(c, "Location not found")
+		} else {
+			InternalServerErrorResponse(c, "Failed to delete location: "+err.Error())
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Location deleted successfully", nil)
+}
+
+// isLocationValidationError reports whether the error is caused by invalid location input
+func isLocationValidationError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidLocationName) || errors.Is(err, domain.ErrInvalidTimezone) ||
+		errors.Is(err, domain.ErrInvalidCoordinates) || errors.Is(err, domain.ErrGeofenceCenter) ||
+		errors.Is(err, domain.ErrInvalidGeofence) || errors.Is(err, domain.ErrInvalidGeofenceMode) ||
+		errors.Is(err, domain.ErrInvalidNetwork)
+}
@@ -1,13 +1,24 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"hrm/domain"
+
 	"github.com/gin-gonic/gin"
 )
 
+// kioskContextKey is the Gin context key the authenticated kiosk is stored under
+const kioskContextKey = "kiosk"
+
 // Logger middleware for request logging
 func Logger() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
@@ -64,3 +75,147 @@ func Recovery() gin.HandlerFunc {
 		InternalServerErrorResponse(c, "Internal server error")
 	})
 }
+
+// KioskAuthMiddleware authenticates shared kiosk devices by their device token.
+// Kiosks send "Authorization: Bearer <device token>"; user JWTs are not accepted here.
+func KioskAuthMiddleware(kioskService domain.KioskServiceInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+		if authHeader == "" || token == authHeader {
+			UnauthorizedResponse(c, "Kiosk device token is required")
+			c.Abort()
+			return
+		}
+
+		kiosk, err := kioskService.AuthenticateKiosk(token)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidKioskToken):
+				UnauthorizedResponse(c, "Invalid kiosk device token")
+			case errors.Is(err, domain.ErrKioskInactive):
+				ForbiddenResponse(c, "Kiosk is deactivated")
+			default:
+				InternalServerErrorResponse(c, "Failed to authenticate kiosk")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(kioskContextKey, kiosk)
+		c.Next()
+	}
+}
+
+// GetKioskFromContext extracts the kiosk authenticated by KioskAuthMiddleware
+func GetKioskFromContext(c *gin.Context) (*domain.Kiosk, bool) {
+	value, exists := c.Get(kioskContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	kiosk, ok := value.(*domain.Kiosk)
+	return kiosk, ok
+}
+
+// SCIMAuthMiddleware authenticates SCIM clients by the bearer token configured for provisioning.
+// Clients send "Authorization: Bearer <token>"; user JWTs are not accepted here.
+func SCIMAuthMiddleware(scimService domain.SCIMServiceInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+		if authHeader == "" || token == authHeader {
+			scimError(c, http.StatusUnauthorized, "", "SCIM bearer token is required")
+			c.Abort()
+			return
+		}
+
+		if err := scimService.Authenticate(token); err != nil {
+			scimErrorResponse(c, err, "authenticate SCIM client")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// KioskRateLimit limits how many requests each kiosk may make per window.
+// It must run after KioskAuthMiddleware; a limit of 0 disables it.
+func KioskRateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	if limit <= 0 || window <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	limiter := newRateLimiter(limit, window)
+	return func(c *gin.Context) {
+		kiosk, ok := GetKioskFromContext(c)
+		if !ok {
+			UnauthorizedResponse(c, "Kiosk not authenticated")
+			c.Abort()
+			return
+		}
+
+		allowed, retryAfter := limiter.allow(strconv.FormatUint(uint64(kiosk.ID), 10), time.Now())
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			ErrorResponseWithCode(c, http.StatusTooManyRequests, ErrorCodeRateLimited, "Too many requests from this kiosk; try again shortly")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimiter counts requests per key in fixed time windows
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[string]*rateWindow
+}
+
+// rateWindow is the request count of one key in its current window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter creates a limiter allowing limit requests per key in each window
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// allow records a request for the key and reports whether it is within the limit.
+// When it is not, it also returns how long until the key's window resets.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current, exists := l.windows[key]
+	if !exists || now.Sub(current.start) >= l.window {
+		l.prune(now)
+		current = &rateWindow{start: now}
+		l.windows[key] = current
+	}
+
+	if current.count >= l.limit {
+		return false, current.start.Add(l.window).Sub(now)
+	}
+	current.count++
+	return true, 0
+}
+
+// prune drops windows that have expired so idle keys do not accumulate
+func (l *rateLimiter) prune(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"hrm/domain"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie binds a sign-in at the identity provider to the browser that started it
+const oidcStateCookie = "hrm_oidc_state"
+
+// OIDCHandler handles HTTP requests for single sign-on through an OpenID Connect provider
+type OIDCHandler struct {
+	oidcService       domain.OIDCServiceInterface
+	twoFactorService  domain.TwoFactorServiceInterface
+	authService       domain.AuthServiceInterface
+	attendanceService domain.AttendanceServiceInterface
+}
+
+// NewOIDCHandler creates a new instance of OIDCHandler
+func NewOIDCHandler(oidcService domain.OIDCServiceInterface, twoFactorService domain.TwoFactorServiceInterface, authService domain.AuthServiceInterface, attendanceService domain.AttendanceServiceInterface) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:       oidcService,
+		twoFactorService:  twoFactorService,
+		authService:       authService,
+		attendanceService: attendanceService,
+	}
+}
+
+// Login redirects the browser to the identity provider to sign in.
+// The state is also set as a cookie, so the callback is only accepted from the same browser.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	start, err := h.oidcService.StartLogin()
+	if err != nil {
+		switch err {
+		case domain.ErrOIDCLoginFailed:
+			c.JSON(http.StatusBadGateway, Response{
+				Success: false,
+				Message: "Identity provider is unavailable",
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to start sign-in")
+		}
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, start.State, 0, "/api/users/oidc", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, start.AuthorizationURL)
+}
+
+// Callback handles the identity provider redirecting back with an authorization code.
+// This method:
+// 1. Refuses callbacks reporting an error, or whose state does not match the browser's cookie
+// 2. Calls the business logic to redeem the code and find or provision the user
+// 3. Returns a two-factor challenge or the same response as a password sign-in
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	// Step 1: Check what the provider sent back against the browser's cookie
+	if providerError := c.Query("error"); providerError != "" {
+		UnauthorizedResponse(c, "Sign-in at the identity provider was not completed: "+providerError)
+		return
+	}
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		BadRequestResponse(c, "Missing state or code")
+		return
+	}
+	cookieState, err := c.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		UnauthorizedResponse(c, domain.ErrInvalidOIDCState.Error())
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, "/api/users/oidc", "", c.Request.TLS != nil, true)
+
+	// Step 2: Call business logic to complete the sign-in
+	user, err := h.oidcService.CompleteLogin(state, code, authClient(c))
+	if err != nil {
+		if signInBlockedResponse(c, err) {
+			return
+		}
+
+		switch err {
+		case domain.ErrInvalidOIDCState, domain.ErrOIDCLoginFailed:
+			UnauthorizedResponse(c, err.Error())
+		case domain.ErrOIDCEmailNotVerified, domain.ErrOIDCUserNotProvisioned:
+			ForbiddenResponse(c, err.Error())
+		case domain.ErrOIDCIdentityConflict:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		case domain.ErrUserInactive:
+			UnauthorizedResponse(c, "Account is deactivated")
+		default:
+			InternalServerErrorResponse(c, "Failed to sign in")
+		}
+		return
+	}
+
+	// Step 3: Accounts with two-factor authentication get a challenge instead of tokens
+	ticket, err := h.twoFactorService.BeginSignIn(user)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to sign in")
+		return
+	}
+	if ticket != nil {
+		SuccessResponse(c, http.StatusOK, "Two-factor authentication required", response.ToTwoFactorChallengeResponse(ticket))
+		return
+	}
+
+	respondSignedIn(c, h.authService, h.attendanceService, user, nil)
+}
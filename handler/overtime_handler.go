@@ -0,0 +1,323 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OvertimeHandler handles HTTP requests related to overtime operations
+type OvertimeHandler struct {
+	overtimeService domain.OvertimeServiceInterface
+}
+
+// NewOvertimeHandler creates a new instance of OvertimeHandler
+func NewOvertimeHandler(overtimeService domain.OvertimeServiceInterface) *OvertimeHandler {
+	return &OvertimeHandler{
+		overtimeService: overtimeService,
+	}
+}
+
+// GetLedger retrieves the overtime ledger for a pay period.
+// Query parameters: user_id (defaults to the authenticated user) and date (YYYY-MM-DD, defaults to today)
+func (h *OvertimeHandler) GetLedger(c *gin.Context) {
+	viewerID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	userID := viewerID
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		id, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			BadRequestResponse(c, "Invalid user ID")
+			return
+		}
+		userID = uint(id)
+	}
+
+	date := time.Now().UTC()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			BadRequestResponse(c, "Invalid date format, use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+
+	ledger, err := h.overtimeService.GetLedger(userID, viewerID, date)
+	if err != nil {
+		h.handleError(c, err, "get overtime ledger")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Overtime ledger retrieved successfully", response.ToOvertimeLedgerResponse(ledger))
+}
+
+// Recalculate recomputes the overtime of the authenticated user, or of a user they may review, for a date range
+func (h *OvertimeHandler) Recalculate(c *gin.Context) {
+	var req request.OvertimeRecalculateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	requesterID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+	userID := requesterID
+	if req.UserID != 0 {
+		userID = req.UserID
+	}
+
+	if err := h.overtimeService.RecalculateUserRange(userID, requesterID, req.StartDate, req.EndDate); err != nil {
+		h.handleError(c, err, "recalculate overtime")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Overtime recalculated successfully", nil)
+}
+
+// GetPendingEntries retrieves the unapproved overtime the authenticated user may review
+func (h *OvertimeHandler) GetPendingEntries(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	entries, err := h.overtimeService.GetPendingEntries(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get pending overtime: "+err.Error())
+		return
+	}
+
+	listResp := response.OvertimeEntryListResponse{
+		Entries: response.ToOvertimeEntryResponseList(entries),
+		Total:   len(entries),
+	}
+	SuccessResponse(c, http.StatusOK, "Pending overtime retrieved successfully", listResp)
+}
+
+// ApproveEntry approves recorded overtime
+func (h *OvertimeHandler) ApproveEntry(c *gin.Context) {
+	h.reviewEntry(c, h.overtimeService.ApproveEntry, "approve overtime", "Overtime approved successfully")
+}
+
+// RejectEntry rejects recorded overtime
+func (h *OvertimeHandler) RejectEntry(c *gin.Context) {
+	h.reviewEntry(c, h.overtimeService.RejectEntry, "reject overtime", "Overtime rejected successfully")
+}
+
+// RequestPreApproval asks for overtime approval in advance
+func (h *OvertimeHandler) RequestPreApproval(c *gin.Context) {
+	var req request.OvertimePreApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	overtimeRequest := &domain.OvertimeRequest{
+		Date:   req.Date,
+		Hours:  req.Hours,
+		Reason: req.Reason,
+	}
+
+	if err := h.overtimeService.RequestPreApproval(userID, overtimeRequest); err != nil {
+		h.handleError(c, err, "request overtime")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Overtime requested successfully", response.ToOvertimeRequestResponse(overtimeRequest))
+}
+
+// GetMyRequests retrieves the overtime requests of the authenticated user
+func (h *OvertimeHandler) GetMyRequests(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	requests, err := h.overtimeService.GetUserRequests(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get overtime requests: "+err.Error())
+		return
+	}
+
+	listResp := response.OvertimeRequestListResponse{
+		Requests: response.ToOvertimeRequestResponseList(requests),
+		Total:    len(requests),
+	}
+	SuccessResponse(c, http.StatusOK, "Overtime requests retrieved successfully", listResp)
+}
+
+// GetPendingRequests retrieves the pending requests the authenticated user may review
+func (h *OvertimeHandler) GetPendingRequests(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	requests, err := h.overtimeService.GetPendingRequests(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get pending overtime requests: "+err.Error())
+		return
+	}
+
+	listResp := response.OvertimeRequestListResponse{
+		Requests: response.ToOvertimeRequestResponseList(requests),
+		Total:    len(requests),
+	}
+	SuccessResponse(c, http.StatusOK, "Pending overtime requests retrieved successfully", listResp)
+}
+
+// ApproveRequest approves an overtime pre-approval request
+func (h *OvertimeHandler) ApproveRequest(c *gin.Context) {
+	h.reviewRequest(c, h.overtimeService.ApproveRequest, "approve overtime request", "Overtime request approved successfully")
+}
+
+// RejectRequest rejects an overtime pre-approval request
+func (h *OvertimeHandler) RejectRequest(c *gin.Context) {
+	h.reviewRequest(c, h.overtimeService.RejectRequest, "reject overtime request", "Overtime request rejected successfully")
+}
+
+// CancelRequest withdraws a pending overtime request of the authenticated user
+func (h *OvertimeHandler) CancelRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid overtime request ID")
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	if err := h.overtimeService.CancelRequest(uint(id), userID); err != nil {
+		h.handleError(c, err, "cancel overtime request")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Overtime request cancelled successfully", nil)
+}
+
+// reviewEntry runs an approve or reject action on a ledger entry
+func (h *OvertimeHandler) reviewEntry(c *gin.Context, action func(id uint, approverID uint, note string) error, verb string, message string) {
+	id, approverID, note, ok := h.bindReview(c, "Invalid overtime entry ID")
+	if !ok {
+		return
+	}
+
+	if err := action(id, approverID, note); err != nil {
+		h.handleError(c, err, verb)
+		return
+	}
+
+	entry, err := h.overtimeService.GetEntryByID(id)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get overtime entry: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, message, response.ToOvertimeEntryResponse(entry))
+}
+
+// reviewRequest runs an approve or reject action on a pre-approval request
+func (h *OvertimeHandler) reviewRequest(c *gin.Context, action func(id uint, approverID uint, note string) error, verb string, message string) {
+	id, approverID, note, ok := h.bindReview(c, "Invalid overtime request ID")
+	if !ok {
+		return
+	}
+
+	if err := action(id, approverID, note); err != nil {
+		h.handleError(c, err, verb)
+		return
+	}
+
+	overtimeRequest, err := h.overtimeService.GetRequestByID(id)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get overtime request: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, message, response.ToOvertimeRequestResponse(overtimeRequest))
+}
+
+// bindReview parses the ID, the optional review note and the authenticated approver
+func (h *OvertimeHandler) bindReview(c *gin.Context, invalidIDMessage string) (uint, uint, string, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, invalidIDMessage)
+		return 0, 0, "", false
+	}
+
+	var req request.OvertimeReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestResponse(c, "Invalid request data: "+err.Error())
+			return 0, 0, "", false
+		}
+	}
+
+	approverID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return 0, 0, "", false
+	}
+
+	return uint(id), approverID, req.Note, true
+}
+
+// handleError maps overtime errors to HTTP responses
+func (h *OvertimeHandler) handleError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrOvertimeEntryNotFound):
+		NotFoundResponse(c, "Overtime entry not found")
+	case errors.Is(err, domain.ErrOvertimeRequestNotFound):
+		NotFoundResponse(c, "Overtime request not found")
+	case errors.Is(err, domain.ErrUserNotFound):
+		NotFoundResponse(c, "User not found")
+	case errors.Is(err, domain.ErrOvertimeNotPending):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Overtime is no longer pending",
+		})
+	case errors.Is(err, domain.ErrOvertimeRequestAlreadyExists):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "An overtime request already exists for this date",
+		})
+	case errors.Is(err, domain.ErrSelfApproval):
+		ForbiddenResponse(c, "You cannot review your own overtime")
+	case errors.Is(err, domain.ErrUnauthorized):
+		ForbiddenResponse(c, "You are not allowed to "+verb)
+	case errors.Is(err, domain.ErrInvalidOvertimeHours), errors.Is(err, domain.ErrOvertimeReason),
+		errors.Is(err, domain.ErrOvertimeRequestInPast), errors.Is(err, domain.ErrInvalidDate),
+		errors.Is(err, domain.ErrInvalidDateRange), errors.Is(err, domain.ErrOvertimeRangeTooLong):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+": "+err.Error())
+	}
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectHandler handles HTTP requests related to projects and their utilization
+type ProjectHandler struct {
+	projectService   domain.ProjectServiceInterface
+	timeEntryService domain.TimeEntryServiceInterface
+}
+
+// NewProjectHandler creates a new instance of ProjectHandler
+func NewProjectHandler(projectService domain.ProjectServiceInterface, timeEntryService domain.TimeEntryServiceInterface) *ProjectHandler {
+	return &ProjectHandler{
+		projectService:   projectService,
+		timeEntryService: timeEntryService,
+	}
+}
+
+// CreateProject creates a new project
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var req request.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	project := &domain.Project{
+		Name:        req.Name,
+		Code:        req.Code,
+		Description: req.Description,
+	}
+
+	if err := h.projectService.CreateProject(project); err != nil {
+		h.handleError(c, err, "create project")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, "Project created successfully", response.ToProjectResponse(project))
+}
+
+// GetProjectByID retrieves a project by ID
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid project ID")
+		return
+	}
+
+	project, err := h.projectService.GetProjectByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "get project")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Project retrieved successfully", response.ToProjectResponse(project))
+}
+
+// GetAllProjects retrieves all projects
+func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
+	projects, err := h.projectService.GetAllProjects()
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get projects: "+err.Error())
+		return
+	}
+
+	projectResponses := response.ToProjectResponseList(projects)
+	listResp := response.ProjectListResponse{
+		Projects: projectResponses,
+		Total:    len(projectResponses),
+	}
+
+	SuccessResponse(c, http.StatusOK, "Projects retrieved successfully", listResp)
+}
+
+// UpdateProject updates an existing project
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid project ID")
+		return
+	}
+
+	var req request.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	existingProject, err := h.projectService.GetProjectByID(uint(id))
+	if err != nil {
+		h.handleError(c, err, "update project")
+		return
+	}
+
+	project := &domain.Project{
+		ID:          uint(id),
+		Name:        req.Name,
+		Code:        req.Code,
+		Description: req.Description,
+		IsActive:    existingProject.IsActive,
+	}
+	if req.IsActive != nil {
+		project.IsActive = *req.IsActive
+	}
+
+	if err := h.projectService.UpdateProject(project); err != nil {
+		h.handleError(c, err, "update project")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Project updated successfully", response.ToProjectResponse(project))
+}
+
+// DeleteProject deletes a project without booked time
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid project ID")
+		return
+	}
+
+	if err := h.projectService.DeleteProject(uint(id)); err != nil {
+		h.handleError(c, err, "delete project")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Project deleted successfully", nil)
+}
+
+// GetUtilization reports booked hours per project and per user.
+// Query parameters: start_date and end_date (YYYY-MM-DD), optional project_id and user_id
+func (h *ProjectHandler) GetUtilization(c *gin.Context) {
+	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	if err != nil {
+		BadRequestResponse(c, "Invalid start date format. Use YYYY-MM-DD")
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", c.Query("end_date"))
+	if err != nil {
+		BadRequestResponse(c, "Invalid end date format. Use YYYY-MM-DD")
+		return
+	}
+
+	var projectID, userID uint64
+	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
+		if projectID, err = strconv.ParseUint(projectIDStr, 10, 32); err != nil {
+			BadRequestResponse(c, "Invalid project ID")
+			return
+		}
+	}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		if userID, err = strconv.ParseUint(userIDStr, 10, 32); err != nil {
+			BadRequestResponse(c, "Invalid user ID")
+			return
+		}
+	}
+
+	report, err := h.timeEntryService.GetUtilization(startDate, endDate, uint(projectID), uint(userID))
+	if err != nil {
+		h.handleError(c, err, "get utilization")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Utilization retrieved successfully", response.ToUtilizationResponse(report))
+}
+
+// handleError maps project errors to HTTP responses
+func (h *ProjectHandler) handleError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrProjectNotFound):
+		NotFoundResponse(c, "Project not found")
+	case errors.Is(err, domain.ErrProjectCodeExists):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Project code already exists",
+		})
+	case errors.Is(err, domain.ErrProjectInUse):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Project has booked time; deactivate it instead",
+		})
+	case errors.Is(err, domain.ErrInvalidProjectName), errors.Is(err, domain.ErrInvalidProjectCode),
+		errors.Is(err, domain.ErrInvalidDateRange):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+": "+err.Error())
+	}
+}
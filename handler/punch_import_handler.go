@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"hrm/domain"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPunchLogSize limits the size of an uploaded punch log
+const maxPunchLogSize = 10 << 20
+
+// PunchImportHandler handles HTTP requests for importing time clock punch logs
+type PunchImportHandler struct {
+	punchImportService domain.PunchImportServiceInterface
+}
+
+// NewPunchImportHandler creates a new instance of PunchImportHandler
+func NewPunchImportHandler(punchImportService domain.PunchImportServiceInterface) *PunchImportHandler {
+	return &PunchImportHandler{
+		punchImportService: punchImportService,
+	}
+}
+
+// ImportPunchLog imports a CSV or JSON punch log and returns the import report.
+// The log is sent as the "file" field of a multipart form or as the raw request body.
+// Query parameters: format (csv or json, guessed from the file name or content type when omitted),
+// dry_run=true to only report what would be imported, and map[field]=column to name the column
+// holding a punch field, e.g. map[employee_code]=Badge.
+func (h *PunchImportHandler) ImportPunchLog(c *gin.Context) {
+	options := domain.PunchImportOptions{}
+	for field, column := range c.QueryMap("map") {
+		if err := options.Mapping.Set(field, column); err != nil {
+			BadRequestResponse(c, err.Error())
+			return
+		}
+	}
+	if dryRun := c.Query("dry_run"); dryRun != "" {
+		value, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			BadRequestResponse(c, "Invalid dry_run value")
+			return
+		}
+		options.DryRun = value
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPunchLogSize)
+
+	var log io.Reader = c.Request.Body
+	fileName := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			BadRequestResponse(c, "Punch log file is required: "+err.Error())
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			BadRequestResponse(c, "Failed to read punch log: "+err.Error())
+			return
+		}
+		defer file.Close()
+		log = file
+		fileName = fileHeader.Filename
+	}
+	options.Format = punchLogFormat(c, fileName)
+
+	report, err := h.punchImportService.ImportPunchLog(log, options)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	message := "Punch log imported"
+	if report.DryRun {
+		message = "Punch log checked; nothing was saved"
+	}
+	SuccessResponse(c, http.StatusOK, message, response.ToPunchImportResponse(report))
+}
+
+// punchLogFormat returns the format given in the query, else the one implied by the
+// file extension or content type, defaulting to CSV
+func punchLogFormat(c *gin.Context, fileName string) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	switch ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), "."); ext {
+	case domain.PunchLogFormatCSV, domain.PunchLogFormatJSON:
+		return ext
+	}
+	if strings.Contains(c.ContentType(), "json") {
+		return domain.PunchLogFormatJSON
+	}
+	return domain.PunchLogFormatCSV
+}
+
+// handleError maps errors of punch imports to HTTP responses
+func (h *PunchImportHandler) handleError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, domain.ErrInvalidPunchLog), errors.Is(err, domain.ErrUnsupportedPunchLogFormat):
+		BadRequestResponse(c, err.Error())
+	case errors.As(err, &maxBytesErr):
+		ErrorResponse(c, http.StatusRequestEntityTooLarge, "Punch log exceeds the 10 MB limit")
+	default:
+		InternalServerErrorResponse(c, "Failed to import punch log: "+err.Error())
+	}
+}
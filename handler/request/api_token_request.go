@@ -0,0 +1,18 @@
+package request
+
+import (
+	"time"
+)
+
+// APITokenRequest represents the request structure for creating a personal access token
+type APITokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"` // e.g. ["attendance:read", "leave:approve"]
+	ExpiresAt *time.Time `json:"expires_at"`                      // Omitted uses the default lifetime
+}
+
+// ServiceAccountRequest represents the request structure for creating a service account
+type ServiceAccountRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Role string `json:"role"` // Omitted creates an employee
+}
@@ -0,0 +1,18 @@
+package request
+
+import (
+	"time"
+)
+
+// RegularizationRequest represents the request structure for asking a correction of an attendance day
+type RegularizationRequest struct {
+	Date         time.Time `json:"date" binding:"required"`
+	CheckInTime  time.Time `json:"check_in_time" binding:"required"`
+	CheckOutTime time.Time `json:"check_out_time" binding:"required"`
+	Reason       string    `json:"reason" binding:"required"`
+}
+
+// RegularizationReviewRequest represents the request structure for approving or rejecting a correction
+type RegularizationReviewRequest struct {
+	Note string `json:"note"`
+}
@@ -9,14 +9,26 @@ type AttendanceRequest struct {
 	Date time.Time `json:"date" binding:"required"`
 }
 
-// CheckInRequest represents the request structure for check-in
+// CheckInRequest represents the request structure for check-in.
+// The server clock decides the check-in time; Date is the client's own timestamp
+// and is only used to detect clock drift. The device position is checked against
+// the geofence of the employee's location and stored for review.
 type CheckInRequest struct {
-	Date time.Time `json:"date" binding:"required"`
+	Date      *time.Time `json:"date"`
+	Latitude  *float64   `json:"latitude" binding:"omitempty,min=-90,max=90,required_with=Longitude"`
+	Longitude *float64   `json:"longitude" binding:"omitempty,min=-180,max=180,required_with=Latitude"`
+	Accuracy  *float64   `json:"accuracy" binding:"omitempty,gte=0"` // meters
 }
 
-// CheckOutRequest represents the request structure for check-out
+// CheckOutRequest represents the request structure for check-out.
+// The server clock decides the check-out time; Date is the client's own timestamp
+// and is only used to detect clock drift. The device position is checked against
+// the geofence of the employee's location and stored for review.
 type CheckOutRequest struct {
-	Date time.Time `json:"date" binding:"required"`
+	Date      *time.Time `json:"date"`
+	Latitude  *float64   `json:"latitude" binding:"omitempty,min=-90,max=90,required_with=Longitude"`
+	Longitude *float64   `json:"longitude" binding:"omitempty,min=-180,max=180,required_with=Latitude"`
+	Accuracy  *float64   `json:"accuracy" binding:"omitempty,gte=0"` // meters
 }
 
 // AttendanceRangeRequest represents the request structure for getting attendance range
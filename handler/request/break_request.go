@@ -4,17 +4,34 @@ import (
 	"time"
 )
 
-// BreakRequest represents the request structure for break operations
+// BreakRequest represents the request structure for break operations.
+// StartTime is the client's own timestamp; the break starts at server time.
 type BreakRequest struct {
-	AttendanceID uint      `json:"attendance_id" binding:"required"`
-	StartTime    time.Time `json:"start_time" binding:"required"`
-	Reason       string    `json:"reason"`
+	AttendanceID uint       `json:"attendance_id" binding:"required"`
+	StartTime    *time.Time `json:"start_time"`
+	BreakTypeID  *uint      `json:"break_type_id"` // Optional; breaks without a type are unpaid and unlimited
+	Reason       string     `json:"reason"`
 }
 
-// EndBreakRequest represents the request structure for ending a break
+// EndBreakRequest represents the request structure for ending a break.
+// EndTime is the client's own timestamp; the break ends at server time.
 type EndBreakRequest struct {
-	BreakID uint      `json:"break_id" binding:"required"`
-	EndTime time.Time `json:"end_time" binding:"required"`
+	BreakID uint       `json:"break_id" binding:"required"`
+	EndTime *time.Time `json:"end_time"`
+}
+
+// StartMyBreakRequest represents the request structure for starting a break in the caller's own attendance.
+// StartTime is the client's own timestamp; the break starts at server time.
+type StartMyBreakRequest struct {
+	StartTime   *time.Time `json:"start_time"`
+	BreakTypeID *uint      `json:"break_type_id"` // Optional; breaks without a type are unpaid and unlimited
+	Reason      string     `json:"reason"`
+}
+
+// EndMyBreakRequest represents the request structure for ending the caller's current break.
+// EndTime is the client's own timestamp; the break ends at server time.
+type EndMyBreakRequest struct {
+	EndTime *time.Time `json:"end_time"`
 }
 
 // BreakUpdateRequest represents the request structure for updating break details
@@ -0,0 +1,12 @@
+package request
+
+// BreakTypeRequest represents the request structure for creating or updating a break type
+type BreakTypeRequest struct {
+	Code        string `json:"code" binding:"required,max=20"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	IsPaid      bool   `json:"is_paid"`
+	MaxDuration int    `json:"max_duration" binding:"min=0"` // Minutes per break; 0 means no limit
+	MaxPerDay   int    `json:"max_per_day" binding:"min=0"`  // Breaks per attendance day; 0 means no limit
+	IsActive    *bool  `json:"is_active"`                    // Only used on update; omitted keeps the current value
+}
@@ -0,0 +1,12 @@
+package request
+
+import (
+	"time"
+)
+
+// HolidayRequest represents the request structure for creating or updating a holiday
+type HolidayRequest struct {
+	Name       string    `json:"name" binding:"required"`
+	Date       time.Time `json:"date" binding:"required"`
+	LocationID *uint     `json:"location_id"`
+}
@@ -0,0 +1,23 @@
+package request
+
+import (
+	"time"
+)
+
+// KioskRequest represents the request structure for registering or updating a kiosk
+type KioskRequest struct {
+	Name       string `json:"name" binding:"required,max=100"`
+	LocationID *uint  `json:"location_id"`
+	IsActive   *bool  `json:"is_active"` // Only used on update; omitted keeps the current value
+}
+
+// KioskPunchRequest represents the request structure for punches made on a shared kiosk.
+// The employee identifies with an employee code and PIN, or with a badge ID.
+type KioskPunchRequest struct {
+	EmployeeCode string     `json:"employee_code" binding:"max=32"`
+	PIN          string     `json:"pin" binding:"max=8"`
+	BadgeID      string     `json:"badge_id" binding:"max=64"`
+	Date         *time.Time `json:"date"`          // Kiosk clock, only used to detect drift
+	BreakTypeID  *uint      `json:"break_type_id"` // Only used when starting a break
+	Reason       string     `json:"reason"`        // Only used when starting a break
+}
@@ -0,0 +1,12 @@
+package request
+
+// LocationRequest represents the request structure for creating or updating a location
+type LocationRequest struct {
+	Name            string   `json:"name" binding:"required"`
+	Timezone        string   `json:"timezone" binding:"required"`
+	Latitude        *float64 `json:"latitude"`
+	Longitude       *float64 `json:"longitude"`
+	GeofenceRadius  float64  `json:"geofence_radius"`  // meters; 0 disables the geofence
+	GeofenceMode    string   `json:"geofence_mode"`    // "flag" or "reject"; empty uses GEOFENCE_REJECT
+	AllowedNetworks []string `json:"allowed_networks"` // CIDR ranges or IPs punches must come from; empty allows any
+}
@@ -0,0 +1,24 @@
+package request
+
+import (
+	"time"
+)
+
+// OvertimePreApprovalRequest represents the request structure for asking overtime approval in advance
+type OvertimePreApprovalRequest struct {
+	Date   time.Time `json:"date" binding:"required"`
+	Hours  float64   `json:"hours" binding:"required,gt=0"`
+	Reason string    `json:"reason" binding:"required"`
+}
+
+// OvertimeReviewRequest represents the request structure for approving or rejecting overtime
+type OvertimeReviewRequest struct {
+	Note string `json:"note"`
+}
+
+// OvertimeRecalculateRequest represents the request structure for recalculating a user's overtime
+type OvertimeRecalculateRequest struct {
+	UserID    uint      `json:"user_id"`
+	StartDate time.Time `json:"start_date" binding:"required"`
+	EndDate   time.Time `json:"end_date" binding:"required"`
+}
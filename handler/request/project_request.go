@@ -0,0 +1,30 @@
+package request
+
+import (
+	"time"
+)
+
+// ProjectRequest represents the request structure for creating or updating a project
+type ProjectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Code        string `json:"code" binding:"required,max=20"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"` // Only used on update; omitted keeps the current value
+}
+
+// TimeEntryRequest represents the request structure for booking hours on a project
+type TimeEntryRequest struct {
+	ProjectID uint      `json:"project_id" binding:"required"`
+	Date      time.Time `json:"date" binding:"required"`
+	Hours     float64   `json:"hours" binding:"required,gt=0"`
+	Task      string    `json:"task" binding:"max=200"`
+	Note      string    `json:"note"`
+}
+
+// UpdateTimeEntryRequest represents the request structure for changing a time entry
+type UpdateTimeEntryRequest struct {
+	ProjectID uint    `json:"project_id" binding:"required"`
+	Hours     float64 `json:"hours" binding:"required,gt=0"`
+	Task      string  `json:"task" binding:"max=200"`
+	Note      string  `json:"note"`
+}
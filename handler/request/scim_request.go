@@ -0,0 +1,9 @@
+package request
+
+import "hrm/domain"
+
+// SCIMPatchRequest represents the request model for a SCIM PATCH of a user or group
+type SCIMPatchRequest struct {
+	Schemas    []string                    `json:"schemas"`
+	Operations []domain.SCIMPatchOperation `json:"Operations" binding:"required,min=1"`
+}
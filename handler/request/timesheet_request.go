@@ -0,0 +1,28 @@
+package request
+
+import (
+	"time"
+)
+
+// TimesheetWeekRequest represents the request structure for selecting a timesheet week
+type TimesheetWeekRequest struct {
+	Week time.Time `json:"week" binding:"required"` // Any day of the week
+}
+
+// TimesheetNotesRequest represents the request structure for annotating a timesheet week
+type TimesheetNotesRequest struct {
+	Week     time.Time                 `json:"week" binding:"required"` // Any day of the week
+	Note     string                    `json:"note"`
+	DayNotes []TimesheetDayNoteRequest `json:"day_notes" binding:"dive"`
+}
+
+// TimesheetDayNoteRequest represents an employee's note on one day of the week
+type TimesheetDayNoteRequest struct {
+	Date time.Time `json:"date" binding:"required"`
+	Note string    `json:"note"`
+}
+
+// TimesheetReviewRequest represents the request structure for approving or returning a timesheet
+type TimesheetReviewRequest struct {
+	Note string `json:"note"`
+}
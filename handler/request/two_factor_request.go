@@ -0,0 +1,17 @@
+package request
+
+// TwoFactorSignInRequest represents the request model for the second step of signing in
+type TwoFactorSignInRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=32"` // TOTP code or recovery code
+}
+
+// TwoFactorChallengeRequest represents the request model for setting up 2FA during a sign-in that requires it
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+// TwoFactorCodeRequest represents the request model for confirming a change with a current code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
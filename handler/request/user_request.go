@@ -4,7 +4,7 @@ package request
 type SignUpRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"` // Checked against the password policy
 }
 
 // SignInRequest represents the request model for user authentication
@@ -13,11 +13,62 @@ type SignInRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-// UpdateUserRequest represents the request model for user updates
+// RefreshTokenRequest represents the request model for exchanging a refresh token for new tokens
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ForgotPasswordRequest represents the request model for asking for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents the request model for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"` // Checked against the password policy
+}
+
+// ChangePasswordRequest represents the request model for changing the signed-in user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"` // Checked against the password policy
+}
+
+// VerifyEmailRequest represents the request model for verifying an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdateUserRequest represents the request model for user profile updates.
+// The password is changed through the change-password and password reset endpoints.
 type UpdateUserRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Name       string `json:"name" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Timezone   string `json:"timezone"`
+	LocationID *uint  `json:"location_id"`
+	ManagerID  *uint  `json:"manager_id"`
+}
+
+// SetUserActiveRequest represents the request model for activating or deactivating a user
+type SetUserActiveRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// SetUserRoleRequest represents the request model for assigning a role to a user
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=employee admin security_admin"`
+}
+
+// ListSignInAttemptsRequest represents the request model for listing sign-in history.
+// The user, email and IP filters only apply to the security admin listing of all users.
+type ListSignInAttemptsRequest struct {
+	UserID    *uint  `form:"user_id"`
+	Email     string `form:"email"`
+	IPAddress string `form:"ip"`
+	Result    string `form:"result" binding:"omitempty,oneof=success invalid_credentials locked throttled inactive invalid_second_factor"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset    int    `form:"offset" binding:"omitempty,min=0"`
 }
 
 // ListUsersRequest represents the request model for listing users with pagination
@@ -35,3 +86,11 @@ type GetUserByIDRequest struct {
 type DeleteUserRequest struct {
 	ID uint `uri:"id" binding:"required"`
 }
+
+// SetKioskCredentialsRequest represents the request model for assigning a user's kiosk credentials.
+// Omitting pin or badge_id keeps the current value; an empty string removes it.
+type SetKioskCredentialsRequest struct {
+	EmployeeCode string  `json:"employee_code" binding:"required,max=32"`
+	PIN          *string `json:"pin"`
+	BadgeID      *string `json:"badge_id" binding:"omitempty,max=64"`
+}
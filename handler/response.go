@@ -6,9 +6,22 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Machine-readable error codes returned with errors that clients are expected to handle
+const (
+	ErrorCodeNetworkNotAllowed = "NETWORK_NOT_ALLOWED"
+	ErrorCodeOutsideGeofence   = "OUTSIDE_GEOFENCE"
+	ErrorCodeLocationRequired  = "LOCATION_REQUIRED"
+	ErrorCodeLocationImprecise = "LOCATION_IMPRECISE"
+	ErrorCodeRateLimited       = "RATE_LIMITED"
+	ErrorCodeAccountLocked     = "ACCOUNT_LOCKED"
+	ErrorCodeKioskPINLocked    = "KIOSK_PIN_LOCKED"
+	ErrorCodeWeakPassword      = "WEAK_PASSWORD"
+)
+
 // Response represents a standardized API response
 type Response struct {
 	Success bool        `json:"success"`
+	Code    string      `json:"code,omitempty"` // Set on errors that carry a machine-readable code
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 }
@@ -30,6 +43,15 @@ func ErrorResponse(c *gin.Context, statusCode int, message string) {
 	})
 }
 
+// ErrorResponseWithCode sends an error response carrying a machine-readable error code
+func ErrorResponseWithCode(c *gin.Context, statusCode int, code string, message string) {
+	c.JSON(statusCode, Response{
+		Success: false,
+		Code:    code,
+		Message: message,
+	})
+}
+
 // BadRequestResponse sends a 400 bad request response
 func BadRequestResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusBadRequest, message)
@@ -49,3 +71,8 @@ func NotFoundResponse(c *gin.Context, message string) {
 func UnauthorizedResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusUnauthorized, message)
 }
+
+// ForbiddenResponse sends a 403 forbidden response
+func ForbiddenResponse(c *gin.Context, message string) {
+	ErrorResponse(c, http.StatusForbidden, message)
+}
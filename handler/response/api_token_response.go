@@ -0,0 +1,98 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// APITokenResponse represents the response structure for personal access token data; the token itself is never included
+type APITokenResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Hint       string     `json:"hint"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	Active     bool       `json:"active"` // Neither revoked nor expired
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APITokenListResponse represents the response structure for a list of personal access tokens
+type APITokenListResponse struct {
+	Tokens []APITokenResponse `json:"tokens"`
+	Total  int                `json:"total"`
+}
+
+// CreatedAPITokenResponse represents a new personal access token together with the token, returned only on creation
+type CreatedAPITokenResponse struct {
+	APIToken APITokenResponse `json:"api_token"`
+	Token    string           `json:"token"`
+}
+
+// ServiceAccountResponse represents the response structure for service account data
+type ServiceAccountResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"` // Made up; service accounts cannot receive email or sign in
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ServiceAccountListResponse represents the response structure for a list of service accounts
+type ServiceAccountListResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+	Total           int                      `json:"total"`
+}
+
+// ToAPITokenResponse converts a domain APIToken to APITokenResponse
+func ToAPITokenResponse(token *domain.APIToken) APITokenResponse {
+	return APITokenResponse{
+		ID:         token.ID,
+		UserID:     token.UserID,
+		Name:       token.Name,
+		Hint:       token.Hint,
+		Scopes:     token.ScopeList(),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		RevokedAt:  token.RevokedAt,
+		Active:     token.IsUsableAt(time.Now().UTC()),
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// ToAPITokenResponseList converts a slice of domain APITokens to APITokenResponse slice
+func ToAPITokenResponseList(tokens []domain.APIToken) []APITokenResponse {
+	responses := make([]APITokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = ToAPITokenResponse(&tokens[i])
+	}
+	return responses
+}
+
+// ToServiceAccountResponse converts a service account's domain User to ServiceAccountResponse
+func ToServiceAccountResponse(user *domain.User) ServiceAccountResponse {
+	return ServiceAccountResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// ToServiceAccountResponseList converts a slice of service accounts to ServiceAccountResponse slice
+func ToServiceAccountResponseList(users []domain.User) []ServiceAccountResponse {
+	responses := make([]ServiceAccountResponse, len(users))
+	for i := range users {
+		responses[i] = ToServiceAccountResponse(&users[i])
+	}
+	return responses
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// RegularizationResponse represents the response structure for attendance regularization data
+type RegularizationResponse struct {
+	ID                    uint       `json:"id"`
+	UserID                uint       `json:"user_id"`
+	AttendanceID          *uint      `json:"attendance_id"`
+	Date                  time.Time  `json:"date"`
+	OriginalCheckInTime   *time.Time `json:"original_check_in_time"`
+	OriginalCheckOutTime  *time.Time `json:"original_check_out_time"`
+	RequestedCheckInTime  time.Time  `json:"requested_check_in_time"`
+	RequestedCheckOutTime time.Time  `json:"requested_check_out_time"`
+	Reason                string     `json:"reason"`
+	Status                string     `json:"status"` // "pending", "approved", "rejected", "cancelled"
+	ReviewedBy            *uint      `json:"reviewed_by"`
+	ReviewedAt            *time.Time `json:"reviewed_at"`
+	ReviewNote            string     `json:"review_note,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// RegularizationListResponse represents the response structure for a list of regularization requests
+type RegularizationListResponse struct {
+	Regularizations []RegularizationResponse `json:"regularizations"`
+	Total           int                      `json:"total"`
+}
+
+// ToRegularizationResponse converts a domain AttendanceRegularization to RegularizationResponse
+func ToRegularizationResponse(regularization *domain.AttendanceRegularization) RegularizationResponse {
+	return RegularizationResponse{
+		ID:                    regularization.ID,
+		UserID:                regularization.UserID,
+		AttendanceID:          regularization.AttendanceID,
+		Date:                  regularization.Date,
+		OriginalCheckInTime:   regularization.OriginalCheckInTime,
+		OriginalCheckOutTime:  regularization.OriginalCheckOutTime,
+		RequestedCheckInTime:  regularization.RequestedCheckInTime,
+		RequestedCheckOutTime: regularization.RequestedCheckOutTime,
+		Reason:                regularization.Reason,
+		Status:                string(regularization.Status),
+		ReviewedBy:            regularization.ReviewedBy,
+		ReviewedAt:            regularization.ReviewedAt,
+		ReviewNote:            regularization.ReviewNote,
+		CreatedAt:             regularization.CreatedAt,
+		UpdatedAt:             regularization.UpdatedAt,
+	}
+}
+
+// ToRegularizationResponseList converts a slice of domain AttendanceRegularizations to RegularizationResponse slice
+func ToRegularizationResponseList(regularizations []domain.AttendanceRegularization) []RegularizationResponse {
+	responses := make([]RegularizationResponse, len(regularizations))
+	for i := range regularizations {
+		responses[i] = ToRegularizationResponse(&regularizations[i])
+	}
+	return responses
+}
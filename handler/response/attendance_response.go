@@ -2,21 +2,55 @@ package response
 
 import (
 	"hrm/domain"
+	"math"
 	"time"
 )
 
 // AttendanceResponse represents the response structure for attendance data
 type AttendanceResponse struct {
-	ID             uint       `json:"id"`
-	UserID         uint       `json:"user_id"`
-	Date           time.Time  `json:"date"`
-	CheckInTime    *time.Time `json:"check_in_time"`
-	CheckOutTime   *time.Time `json:"check_out_time"`
-	TotalWorkHours float64    `json:"total_work_hours"`
-	Status         string     `json:"status"` // "present", "absent", "late", "early_leave", "completed"
+	ID                  uint       `json:"id"`
+	UserID              uint       `json:"user_id"`
+	Date                time.Time  `json:"date"`
+	CheckInTime         *time.Time `json:"check_in_time"`
+	CheckOutTime        *time.Time `json:"check_out_time"`
+	TotalWorkHours      float64    `json:"total_work_hours"`
+	BreakDeduction      float64    `json:"break_deduction"` // Minutes deducted for a missed mandatory break
+	Timezone            string     `json:"timezone"`
+	Status              string     `json:"status"` // "present", "absent", "on_leave", "late", "early_leave", "completed", "auto_closed"
+	Flagged             bool       `json:"flagged"`
+	FlagReason          string     `json:"flag_reason,omitempty"`
+	LockedByTimesheetID *uint      `json:"locked_by_timesheet_id,omitempty"`
 	//CreatedAt      time.Time       `json:"created_at"`
 	//UpdatedAt      time.Time       `json:"updated_at"`
-	Breaks []BreakResponse `json:"breaks"`
+	Breaks   []BreakResponse             `json:"breaks"`
+	Sessions []AttendanceSessionResponse `json:"sessions"`
+}
+
+// AttendanceSessionResponse represents one check-in/check-out pair of an attendance
+type AttendanceSessionResponse struct {
+	ID               uint                   `json:"id"`
+	CheckInTime      time.Time              `json:"check_in_time"`
+	CheckOutTime     *time.Time             `json:"check_out_time"`
+	Hours            float64                `json:"hours"`
+	Source           string                 `json:"source"`
+	SupersededByID   *uint                  `json:"superseded_by_id,omitempty"` // Regularization that replaced this session
+	AutoClosed       bool                   `json:"auto_closed"`
+	CheckInLocation  *PunchLocationResponse `json:"check_in_location,omitempty"`
+	CheckOutLocation *PunchLocationResponse `json:"check_out_location,omitempty"`
+	CheckInIP        string                 `json:"check_in_ip,omitempty"`
+	CheckOutIP       string                 `json:"check_out_ip,omitempty"`
+	CheckInKioskID   *uint                  `json:"check_in_kiosk_id,omitempty"`
+	CheckOutKioskID  *uint                  `json:"check_out_kiosk_id,omitempty"`
+	CheckInDevice    string                 `json:"check_in_device,omitempty"`
+	CheckOutDevice   string                 `json:"check_out_device,omitempty"`
+}
+
+// PunchLocationResponse represents the device position captured with a punch
+type PunchLocationResponse struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Accuracy  *float64 `json:"accuracy,omitempty"` // meters
+	Distance  *float64 `json:"distance,omitempty"` // meters from the geofence center
 }
 
 // AttendanceListResponse represents the response structure for a list of attendances
@@ -43,15 +77,64 @@ func ToAttendanceResponse(attendance *domain.Attendance) AttendanceResponse {
 	}
 
 	return AttendanceResponse{
-		ID:             attendance.ID,
-		UserID:         attendance.UserID,
-		Date:           attendance.Date,
-		CheckInTime:    attendance.CheckInTime,
-		CheckOutTime:   attendance.CheckOutTime,
-		TotalWorkHours: attendance.TotalWorkHours,
-		Status:         attendance.GetStatus(),
-		Breaks:         breaks,
+		ID:                  attendance.ID,
+		UserID:              attendance.UserID,
+		Date:                attendance.Date,
+		CheckInTime:         attendance.CheckInTime,
+		CheckOutTime:        attendance.CheckOutTime,
+		TotalWorkHours:      attendance.TotalWorkHours,
+		BreakDeduction:      attendance.BreakDeduction,
+		Timezone:            attendance.Timezone,
+		Status:              attendance.GetStatus(),
+		Flagged:             attendance.Flagged,
+		FlagReason:          attendance.FlagReason,
+		LockedByTimesheetID: attendance.LockedByTimesheetID,
+		Breaks:              breaks,
+		Sessions:            ToAttendanceSessionResponseList(attendance.Sessions),
+	}
+}
+
+// ToAttendanceSessionResponseList converts domain AttendanceSessions to AttendanceSessionResponse slice
+func ToAttendanceSessionResponseList(sessions []domain.AttendanceSession) []AttendanceSessionResponse {
+	responses := make([]AttendanceSessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = AttendanceSessionResponse{
+			ID:               session.ID,
+			CheckInTime:      session.CheckInTime,
+			CheckOutTime:     session.CheckOutTime,
+			Hours:            session.Duration().Hours(),
+			Source:           session.Source,
+			SupersededByID:   session.SupersededByID,
+			AutoClosed:       session.AutoClosed,
+			CheckInLocation:  ToPunchLocationResponse(session.CheckInLocation),
+			CheckOutLocation: ToPunchLocationResponse(session.CheckOutLocation),
+			CheckInIP:        session.CheckInIP,
+			CheckOutIP:       session.CheckOutIP,
+			CheckInKioskID:   session.CheckInKioskID,
+			CheckOutKioskID:  session.CheckOutKioskID,
+			CheckInDevice:    session.CheckInDevice,
+			CheckOutDevice:   session.CheckOutDevice,
+		}
+	}
+	return responses
+}
+
+// ToPunchLocationResponse converts a captured punch position, returning nil if none was captured
+func ToPunchLocationResponse(location domain.PunchLocation) *PunchLocationResponse {
+	if location.IsEmpty() {
+		return nil
+	}
+
+	response := &PunchLocationResponse{
+		Latitude:  *location.Latitude,
+		Longitude: *location.Longitude,
+		Accuracy:  location.Accuracy,
+	}
+	if location.Distance != nil {
+		distance := math.Round(*location.Distance)
+		response.Distance = &distance
 	}
+	return response
 }
 
 // ToAttendanceResponseList converts a slice of domain Attendances to AttendanceResponse slice
@@ -13,6 +13,12 @@ type BreakResponse struct {
 	EndTime      *time.Time `json:"end_time"`
 	Duration     float64    `json:"duration"`
 	Reason       string     `json:"reason"`
+	BreakTypeID  *uint      `json:"break_type_id"`
+	IsPaid       bool       `json:"is_paid"`
+	Flagged      bool       `json:"flagged"`
+	FlagReason   string     `json:"flag_reason,omitempty"`
+	StartIP      string     `json:"start_ip,omitempty"`
+	EndIP        string     `json:"end_ip,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -30,6 +36,21 @@ type BreakEndResponse struct {
 	Duration float64       `json:"duration"`
 }
 
+// BreakStatusResponse represents whether an employee is working or on a break right now
+type BreakStatusResponse struct {
+	State          string         `json:"state"` // "working", "on_break" or "checked_out"
+	AttendanceID   *uint          `json:"attendance_id"`
+	Since          *time.Time     `json:"since"`
+	ElapsedMinutes float64        `json:"elapsed_minutes"`
+	ActiveBreak    *BreakResponse `json:"active_break"`
+}
+
+// MyBreakResponse represents the response structure for starting or ending the caller's own break
+type MyBreakResponse struct {
+	Break  BreakResponse       `json:"break"`
+	Status BreakStatusResponse `json:"status"`
+}
+
 // BreakListResponse represents the response structure for a list of breaks
 type BreakListResponse struct {
 	Breaks []BreakResponse `json:"breaks"`
@@ -60,7 +81,30 @@ func ToBreakResponse(breakItem *domain.Break) BreakResponse {
 		EndTime:      breakItem.EndTime,
 		Duration:     breakItem.Duration,
 		Reason:       breakItem.Reason,
+		BreakTypeID:  breakItem.BreakTypeID,
+		IsPaid:       breakItem.IsPaid,
+		Flagged:      breakItem.Flagged,
+		FlagReason:   breakItem.FlagReason,
+		StartIP:      breakItem.StartIP,
+		EndIP:        breakItem.EndIP,
 		CreatedAt:    breakItem.CreatedAt,
 		UpdatedAt:    breakItem.UpdatedAt,
 	}
 }
+
+// ToBreakStatusResponse converts a domain BreakStatus to BreakStatusResponse
+func ToBreakStatusResponse(status *domain.BreakStatus) BreakStatusResponse {
+	statusResp := BreakStatusResponse{
+		State:          status.State,
+		Since:          status.Since,
+		ElapsedMinutes: status.Elapsed.Minutes(),
+	}
+	if status.Attendance != nil {
+		statusResp.AttendanceID = &status.Attendance.ID
+	}
+	if status.ActiveBreak != nil {
+		activeBreak := ToBreakResponse(status.ActiveBreak)
+		statusResp.ActiveBreak = &activeBreak
+	}
+	return statusResp
+}
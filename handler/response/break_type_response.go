@@ -0,0 +1,51 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// BreakTypeResponse represents the response structure for break type data
+type BreakTypeResponse struct {
+	ID          uint      `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsPaid      bool      `json:"is_paid"`
+	MaxDuration int       `json:"max_duration"`
+	MaxPerDay   int       `json:"max_per_day"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BreakTypeListResponse represents the response structure for a list of break types
+type BreakTypeListResponse struct {
+	BreakTypes []BreakTypeResponse `json:"break_types"`
+	Total      int                 `json:"total"`
+}
+
+// ToBreakTypeResponse converts a domain BreakType to BreakTypeResponse
+func ToBreakTypeResponse(breakType *domain.BreakType) BreakTypeResponse {
+	return BreakTypeResponse{
+		ID:          breakType.ID,
+		Code:        breakType.Code,
+		Name:        breakType.Name,
+		Description: breakType.Description,
+		IsPaid:      breakType.IsPaid,
+		MaxDuration: breakType.MaxDuration,
+		MaxPerDay:   breakType.MaxPerDay,
+		IsActive:    breakType.IsActive,
+		CreatedAt:   breakType.CreatedAt,
+		UpdatedAt:   breakType.UpdatedAt,
+	}
+}
+
+// ToBreakTypeResponseList converts a slice of domain BreakTypes to BreakTypeResponse slice
+func ToBreakTypeResponseList(breakTypes []domain.BreakType) []BreakTypeResponse {
+	responses := make([]BreakTypeResponse, len(breakTypes))
+	for i := range breakTypes {
+		responses[i] = ToBreakTypeResponse(&breakTypes[i])
+	}
+	return responses
+}
@@ -0,0 +1,78 @@
+package response
+
+import "hrm/domain"
+
+// DirectoryFieldChangeResponse represents one attribute a directory sync changes
+type DirectoryFieldChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DirectoryUserChangeResponse represents a user a directory sync creates, updates or deactivates
+type DirectoryUserChangeResponse struct {
+	UserID  uint                           `json:"user_id,omitempty"`
+	DN      string                         `json:"dn"`
+	Email   string                         `json:"email"`
+	Changes []DirectoryFieldChangeResponse `json:"changes"`
+}
+
+// DirectorySyncIssueResponse represents a directory entry that could not be synced
+type DirectorySyncIssueResponse struct {
+	DN      string `json:"dn"`
+	Message string `json:"message"`
+}
+
+// DirectorySyncResponse represents the report of a directory sync
+type DirectorySyncResponse struct {
+	DryRun      bool                          `json:"dry_run"`
+	Entries     int                           `json:"entries"`
+	Created     []DirectoryUserChangeResponse `json:"created"`
+	Updated     []DirectoryUserChangeResponse `json:"updated"`
+	Deactivated []DirectoryUserChangeResponse `json:"deactivated"`
+	Unchanged   int                           `json:"unchanged"`
+	Issues      []DirectorySyncIssueResponse  `json:"issues"`
+}
+
+// ToDirectorySyncResponse converts a domain DirectorySyncReport to DirectorySyncResponse
+func ToDirectorySyncResponse(report *domain.DirectorySyncReport) DirectorySyncResponse {
+	issues := make([]DirectorySyncIssueResponse, len(report.Issues))
+	for i, issue := range report.Issues {
+		issues[i] = DirectorySyncIssueResponse{
+			DN:      issue.DN,
+			Message: issue.Message,
+		}
+	}
+
+	return DirectorySyncResponse{
+		DryRun:      report.DryRun,
+		Entries:     report.Entries,
+		Created:     toDirectoryUserChangeResponses(report.Created),
+		Updated:     toDirectoryUserChangeResponses(report.Updated),
+		Deactivated: toDirectoryUserChangeResponses(report.Deactivated),
+		Unchanged:   report.Unchanged,
+		Issues:      issues,
+	}
+}
+
+// toDirectoryUserChangeResponses converts user changes, keeping empty lists as [] in JSON
+func toDirectoryUserChangeResponses(changes []domain.DirectoryUserChange) []DirectoryUserChangeResponse {
+	responses := make([]DirectoryUserChangeResponse, len(changes))
+	for i, change := range changes {
+		fields := make([]DirectoryFieldChangeResponse, len(change.Changes))
+		for j, field := range change.Changes {
+			fields[j] = DirectoryFieldChangeResponse{
+				Field: field.Field,
+				From:  field.From,
+				To:    field.To,
+			}
+		}
+		responses[i] = DirectoryUserChangeResponse{
+			UserID:  change.UserID,
+			DN:      change.DN,
+			Email:   change.Email,
+			Changes: fields,
+		}
+	}
+	return responses
+}
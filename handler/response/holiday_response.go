@@ -0,0 +1,43 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// HolidayResponse represents the response structure for holiday data
+type HolidayResponse struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	Date       time.Time `json:"date"`
+	LocationID *uint     `json:"location_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HolidayListResponse represents the response structure for a list of holidays
+type HolidayListResponse struct {
+	Holidays []HolidayResponse `json:"holidays"`
+	Total    int               `json:"total"`
+}
+
+// ToHolidayResponse converts a domain Holiday to HolidayResponse
+func ToHolidayResponse(holiday *domain.Holiday) HolidayResponse {
+	return HolidayResponse{
+		ID:         holiday.ID,
+		Name:       holiday.Name,
+		Date:       holiday.Date,
+		LocationID: holiday.LocationID,
+		CreatedAt:  holiday.CreatedAt,
+		UpdatedAt:  holiday.UpdatedAt,
+	}
+}
+
+// ToHolidayResponseList converts a slice of domain Holidays to HolidayResponse slice
+func ToHolidayResponseList(holidays []domain.Holiday) []HolidayResponse {
+	responses := make([]HolidayResponse, len(holidays))
+	for i := range holidays {
+		responses[i] = ToHolidayResponse(&holidays[i])
+	}
+	return responses
+}
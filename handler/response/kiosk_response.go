@@ -0,0 +1,93 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// KioskResponse represents the response structure for kiosk data
+type KioskResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	LocationID *uint      `json:"location_id"`
+	IsActive   bool       `json:"is_active"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// KioskListResponse represents the response structure for a list of kiosks
+type KioskListResponse struct {
+	Kiosks []KioskResponse `json:"kiosks"`
+	Total  int             `json:"total"`
+}
+
+// KioskTokenResponse represents a kiosk together with its device token, returned only on registration and rotation
+type KioskTokenResponse struct {
+	Kiosk KioskResponse `json:"kiosk"`
+	Token string        `json:"token"`
+}
+
+// KioskEmployeeResponse identifies the employee a kiosk punch was recorded for
+type KioskEmployeeResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// KioskCredentialsResponse represents the kiosk credentials of an employee, shown to admins only.
+// User responses leave out the employee code, as it is half of the credentials; the PIN and badge are never returned.
+type KioskCredentialsResponse struct {
+	UserID         uint       `json:"user_id"`
+	EmployeeCode   *string    `json:"employee_code"`
+	HasKioskPIN    bool       `json:"has_kiosk_pin"`
+	HasBadge       bool       `json:"has_badge"`
+	PINLockedUntil *time.Time `json:"pin_locked_until"` // Kiosks refuse the employee code until this time after too many wrong PINs
+}
+
+// KioskPunchResponse represents the result of a punch made on a kiosk
+type KioskPunchResponse struct {
+	Employee   KioskEmployeeResponse `json:"employee"`
+	Attendance *AttendanceResponse   `json:"attendance,omitempty"`
+	Break      *BreakResponse        `json:"break,omitempty"`
+}
+
+// ToKioskResponse converts a domain Kiosk to KioskResponse
+func ToKioskResponse(kiosk *domain.Kiosk) KioskResponse {
+	return KioskResponse{
+		ID:         kiosk.ID,
+		Name:       kiosk.Name,
+		LocationID: kiosk.LocationID,
+		IsActive:   kiosk.IsActive,
+		LastSeenAt: kiosk.LastSeenAt,
+		CreatedAt:  kiosk.CreatedAt,
+		UpdatedAt:  kiosk.UpdatedAt,
+	}
+}
+
+// ToKioskResponseList converts a slice of domain Kiosks to KioskResponse slice
+func ToKioskResponseList(kiosks []domain.Kiosk) []KioskResponse {
+	responses := make([]KioskResponse, len(kiosks))
+	for i := range kiosks {
+		responses[i] = ToKioskResponse(&kiosks[i])
+	}
+	return responses
+}
+
+// ToKioskEmployeeResponse converts the identified user to KioskEmployeeResponse
+func ToKioskEmployeeResponse(user *domain.User) KioskEmployeeResponse {
+	return KioskEmployeeResponse{
+		ID:   user.ID,
+		Name: user.Name,
+	}
+}
+
+// ToKioskCredentialsResponse converts the kiosk credentials of a user to KioskCredentialsResponse
+func ToKioskCredentialsResponse(user *domain.User) KioskCredentialsResponse {
+	return KioskCredentialsResponse{
+		UserID:         user.ID,
+		EmployeeCode:   user.EmployeeCode,
+		HasKioskPIN:    user.HasKioskPIN(),
+		HasBadge:       user.BadgeID != nil,
+		PINLockedUntil: user.KioskPINLockedUntil,
+	}
+}
@@ -0,0 +1,51 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// LocationResponse represents the response structure for location data
+type LocationResponse struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Timezone        string    `json:"timezone"`
+	Latitude        *float64  `json:"latitude"`
+	Longitude       *float64  `json:"longitude"`
+	GeofenceRadius  float64   `json:"geofence_radius"`
+	GeofenceMode    string    `json:"geofence_mode,omitempty"`
+	AllowedNetworks []string  `json:"allowed_networks"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// LocationListResponse represents the response structure for a list of locations
+type LocationListResponse struct {
+	Locations []LocationResponse `json:"locations"`
+	Total     int                `json:"total"`
+}
+
+// ToLocationResponse converts a domain Location to LocationResponse
+func ToLocationResponse(location *domain.Location) LocationResponse {
+	return LocationResponse{
+		ID:              location.ID,
+		Name:            location.Name,
+		Timezone:        location.Timezone,
+		Latitude:        location.Latitude,
+		Longitude:       location.Longitude,
+		GeofenceRadius:  location.GeofenceRadius,
+		GeofenceMode:    location.GeofenceMode,
+		AllowedNetworks: location.NetworkList(),
+		CreatedAt:       location.CreatedAt,
+		UpdatedAt:       location.UpdatedAt,
+	}
+}
+
+// ToLocationResponseList converts a slice of domain Locations to LocationResponse slice
+func ToLocationResponseList(locations []domain.Location) []LocationResponse {
+	responses := make([]LocationResponse, len(locations))
+	for i := range locations {
+		responses[i] = ToLocationResponse(&locations[i])
+	}
+	return responses
+}
@@ -0,0 +1,134 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// OvertimeEntryResponse represents the response structure for an overtime ledger entry
+type OvertimeEntryResponse struct {
+	ID            uint       `json:"id"`
+	UserID        uint       `json:"user_id"`
+	AttendanceID  uint       `json:"attendance_id"`
+	Date          time.Time  `json:"date"`
+	WorkedHours   float64    `json:"worked_hours"`
+	OvertimeHours float64    `json:"overtime_hours"`
+	Multiplier    float64    `json:"multiplier"`
+	WeightedHours float64    `json:"weighted_hours"`
+	Kind          string     `json:"kind"`   // "daily", "weekly", "weekend", "holiday"
+	Status        string     `json:"status"` // "pending", "approved", "rejected"
+	RequestID     *uint      `json:"request_id"`
+	ReviewedBy    *uint      `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+}
+
+// OvertimeEntryListResponse represents the response structure for a list of overtime entries
+type OvertimeEntryListResponse struct {
+	Entries []OvertimeEntryResponse `json:"entries"`
+	Total   int                     `json:"total"`
+}
+
+// OvertimeLedgerResponse represents the response structure for a user's overtime in one pay period
+type OvertimeLedgerResponse struct {
+	UserID                uint                    `json:"user_id"`
+	PeriodStart           time.Time               `json:"period_start"`
+	PeriodEnd             time.Time               `json:"period_end"`
+	TotalOvertimeHours    float64                 `json:"total_overtime_hours"`
+	TotalWeightedHours    float64                 `json:"total_weighted_hours"`
+	ApprovedOvertimeHours float64                 `json:"approved_overtime_hours"`
+	ApprovedWeightedHours float64                 `json:"approved_weighted_hours"`
+	PendingOvertimeHours  float64                 `json:"pending_overtime_hours"`
+	RejectedOvertimeHours float64                 `json:"rejected_overtime_hours"`
+	Entries               []OvertimeEntryResponse `json:"entries"`
+}
+
+// OvertimeRequestResponse represents the response structure for an overtime pre-approval request
+type OvertimeRequestResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Date       time.Time  `json:"date"`
+	Hours      float64    `json:"hours"`
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"` // "pending", "approved", "rejected", "cancelled"
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `json:"review_note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// OvertimeRequestListResponse represents the response structure for a list of overtime requests
+type OvertimeRequestListResponse struct {
+	Requests []OvertimeRequestResponse `json:"requests"`
+	Total    int                       `json:"total"`
+}
+
+// ToOvertimeEntryResponse converts a domain OvertimeEntry to OvertimeEntryResponse
+func ToOvertimeEntryResponse(entry *domain.OvertimeEntry) OvertimeEntryResponse {
+	return OvertimeEntryResponse{
+		ID:            entry.ID,
+		UserID:        entry.UserID,
+		AttendanceID:  entry.AttendanceID,
+		Date:          entry.Date,
+		WorkedHours:   entry.WorkedHours,
+		OvertimeHours: entry.OvertimeHours,
+		Multiplier:    entry.Multiplier,
+		WeightedHours: entry.WeightedHours,
+		Kind:          string(entry.Kind),
+		Status:        string(entry.Status),
+		RequestID:     entry.RequestID,
+		ReviewedBy:    entry.ReviewedBy,
+		ReviewedAt:    entry.ReviewedAt,
+		ReviewNote:    entry.ReviewNote,
+	}
+}
+
+// ToOvertimeEntryResponseList converts a slice of domain OvertimeEntries to OvertimeEntryResponse slice
+func ToOvertimeEntryResponseList(entries []domain.OvertimeEntry) []OvertimeEntryResponse {
+	responses := make([]OvertimeEntryResponse, len(entries))
+	for i := range entries {
+		responses[i] = ToOvertimeEntryResponse(&entries[i])
+	}
+	return responses
+}
+
+// ToOvertimeLedgerResponse converts a domain OvertimeLedger to OvertimeLedgerResponse
+func ToOvertimeLedgerResponse(ledger *domain.OvertimeLedger) OvertimeLedgerResponse {
+	return OvertimeLedgerResponse{
+		UserID:                ledger.UserID,
+		PeriodStart:           ledger.PeriodStart,
+		PeriodEnd:             ledger.PeriodEnd,
+		TotalOvertimeHours:    ledger.TotalOvertimeHours,
+		TotalWeightedHours:    ledger.TotalWeightedHours,
+		ApprovedOvertimeHours: ledger.ApprovedOvertimeHours,
+		ApprovedWeightedHours: ledger.ApprovedWeightedHours,
+		PendingOvertimeHours:  ledger.PendingOvertimeHours,
+		RejectedOvertimeHours: ledger.RejectedOvertimeHours,
+		Entries:               ToOvertimeEntryResponseList(ledger.Entries),
+	}
+}
+
+// ToOvertimeRequestResponse converts a domain OvertimeRequest to OvertimeRequestResponse
+func ToOvertimeRequestResponse(request *domain.OvertimeRequest) OvertimeRequestResponse {
+	return OvertimeRequestResponse{
+		ID:         request.ID,
+		UserID:     request.UserID,
+		Date:       request.Date,
+		Hours:      request.Hours,
+		Reason:     request.Reason,
+		Status:     string(request.Status),
+		ReviewedBy: request.ReviewedBy,
+		ReviewedAt: request.ReviewedAt,
+		ReviewNote: request.ReviewNote,
+		CreatedAt:  request.CreatedAt,
+	}
+}
+
+// ToOvertimeRequestResponseList converts a slice of domain OvertimeRequests to OvertimeRequestResponse slice
+func ToOvertimeRequestResponseList(requests []domain.OvertimeRequest) []OvertimeRequestResponse {
+	responses := make([]OvertimeRequestResponse, len(requests))
+	for i := range requests {
+		responses[i] = ToOvertimeRequestResponse(&requests[i])
+	}
+	return responses
+}
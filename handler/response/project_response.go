@@ -0,0 +1,161 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// ProjectResponse represents the response structure for project data
+type ProjectResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProjectListResponse represents the response structure for a list of projects
+type ProjectListResponse struct {
+	Projects []ProjectResponse `json:"projects"`
+	Total    int               `json:"total"`
+}
+
+// TimeEntryResponse represents the response structure for a project time entry
+type TimeEntryResponse struct {
+	ID           uint      `json:"id"`
+	UserID       uint      `json:"user_id"`
+	AttendanceID uint      `json:"attendance_id"`
+	ProjectID    uint      `json:"project_id"`
+	ProjectCode  string    `json:"project_code,omitempty"`
+	Date         time.Time `json:"date"`
+	Hours        float64   `json:"hours"`
+	Task         string    `json:"task"`
+	Note         string    `json:"note,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TimeEntryListResponse represents the response structure for a list of time entries
+type TimeEntryListResponse struct {
+	Entries    []TimeEntryResponse `json:"entries"`
+	Total      int                 `json:"total"`
+	TotalHours float64             `json:"total_hours"`
+}
+
+// UtilizationResponse represents the response structure for a project utilization report
+type UtilizationResponse struct {
+	StartDate      time.Time                    `json:"start_date"`
+	EndDate        time.Time                    `json:"end_date"`
+	WorkedHours    float64                      `json:"worked_hours"`
+	AllocatedHours float64                      `json:"allocated_hours"`
+	Projects       []ProjectUtilizationResponse `json:"projects"`
+	Users          []UserUtilizationResponse    `json:"users"`
+}
+
+// ProjectUtilizationResponse represents the hours booked on one project
+type ProjectUtilizationResponse struct {
+	ProjectID   uint                       `json:"project_id"`
+	ProjectName string                     `json:"project_name"`
+	ProjectCode string                     `json:"project_code"`
+	Hours       float64                    `json:"hours"`
+	Users       []ProjectUserHoursResponse `json:"users"`
+}
+
+// ProjectUserHoursResponse represents the hours one user booked on a project
+type ProjectUserHoursResponse struct {
+	UserID uint    `json:"user_id"`
+	Hours  float64 `json:"hours"`
+}
+
+// UserUtilizationResponse represents the worked and allocated hours of one user
+type UserUtilizationResponse struct {
+	UserID         uint    `json:"user_id"`
+	WorkedHours    float64 `json:"worked_hours"`
+	AllocatedHours float64 `json:"allocated_hours"`
+	Utilization    float64 `json:"utilization"` // Percentage of worked hours allocated to projects
+}
+
+// ToProjectResponse converts a domain Project to ProjectResponse
+func ToProjectResponse(project *domain.Project) ProjectResponse {
+	return ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Code:        project.Code,
+		Description: project.Description,
+		IsActive:    project.IsActive,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+// ToProjectResponseList converts a slice of domain Projects to ProjectResponse slice
+func ToProjectResponseList(projects []domain.Project) []ProjectResponse {
+	responses := make([]ProjectResponse, len(projects))
+	for i := range projects {
+		responses[i] = ToProjectResponse(&projects[i])
+	}
+	return responses
+}
+
+// ToTimeEntryResponse converts a domain TimeEntry to TimeEntryResponse
+func ToTimeEntryResponse(entry *domain.TimeEntry) TimeEntryResponse {
+	return TimeEntryResponse{
+		ID:           entry.ID,
+		UserID:       entry.UserID,
+		AttendanceID: entry.AttendanceID,
+		ProjectID:    entry.ProjectID,
+		ProjectCode:  entry.Project.Code,
+		Date:         entry.Date,
+		Hours:        entry.Hours,
+		Task:         entry.Task,
+		Note:         entry.Note,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+// ToTimeEntryResponseList converts a slice of domain TimeEntries to TimeEntryResponse slice
+func ToTimeEntryResponseList(entries []domain.TimeEntry) []TimeEntryResponse {
+	responses := make([]TimeEntryResponse, len(entries))
+	for i := range entries {
+		responses[i] = ToTimeEntryResponse(&entries[i])
+	}
+	return responses
+}
+
+// ToUtilizationResponse converts a domain UtilizationReport to UtilizationResponse
+func ToUtilizationResponse(report *domain.UtilizationReport) UtilizationResponse {
+	projects := make([]ProjectUtilizationResponse, len(report.Projects))
+	for i, project := range report.Projects {
+		users := make([]ProjectUserHoursResponse, len(project.Users))
+		for j, user := range project.Users {
+			users[j] = ProjectUserHoursResponse{UserID: user.UserID, Hours: user.Hours}
+		}
+		projects[i] = ProjectUtilizationResponse{
+			ProjectID:   project.ProjectID,
+			ProjectName: project.ProjectName,
+			ProjectCode: project.ProjectCode,
+			Hours:       project.Hours,
+			Users:       users,
+		}
+	}
+
+	users := make([]UserUtilizationResponse, len(report.Users))
+	for i, user := range report.Users {
+		users[i] = UserUtilizationResponse{
+			UserID:         user.UserID,
+			WorkedHours:    user.WorkedHours,
+			AllocatedHours: user.AllocatedHours,
+			Utilization:    user.Utilization,
+		}
+	}
+
+	return UtilizationResponse{
+		StartDate:      report.StartDate,
+		EndDate:        report.EndDate,
+		WorkedHours:    report.WorkedHours,
+		AllocatedHours: report.AllocatedHours,
+		Projects:       projects,
+		Users:          users,
+	}
+}
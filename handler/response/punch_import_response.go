@@ -0,0 +1,45 @@
+package response
+
+import "hrm/domain"
+
+// PunchImportIssueResponse represents a punch log row that could not be imported
+type PunchImportIssueResponse struct {
+	Line         int    `json:"line"`
+	EmployeeCode string `json:"employee_code,omitempty"`
+	Message      string `json:"message"`
+}
+
+// PunchImportResponse represents the report of a punch log import
+type PunchImportResponse struct {
+	DryRun          bool                       `json:"dry_run"`
+	Rows            int                        `json:"rows"`
+	Duplicates      int                        `json:"duplicates"`
+	SessionsCreated int                        `json:"sessions_created"`
+	SessionsClosed  int                        `json:"sessions_closed"`
+	SessionsOpened  int                        `json:"sessions_opened"`
+	AttendanceDays  int                        `json:"attendance_days"`
+	Issues          []PunchImportIssueResponse `json:"issues"`
+}
+
+// ToPunchImportResponse converts a domain PunchImportReport to PunchImportResponse
+func ToPunchImportResponse(report *domain.PunchImportReport) PunchImportResponse {
+	issues := make([]PunchImportIssueResponse, len(report.Issues))
+	for i, issue := range report.Issues {
+		issues[i] = PunchImportIssueResponse{
+			Line:         issue.Line,
+			EmployeeCode: issue.EmployeeCode,
+			Message:      issue.Message,
+		}
+	}
+
+	return PunchImportResponse{
+		DryRun:          report.DryRun,
+		Rows:            report.Rows,
+		Duplicates:      report.Duplicates,
+		SessionsCreated: report.SessionsCreated,
+		SessionsClosed:  report.SessionsClosed,
+		SessionsOpened:  report.SessionsOpened,
+		AttendanceDays:  report.AttendanceDays,
+		Issues:          issues,
+	}
+}
@@ -0,0 +1,145 @@
+package response
+
+import "hrm/domain"
+
+// SCIMListResponse represents a page of SCIM resources
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// SCIMErrorResponse represents a SCIM error; SCIM clients expect it instead of the usual error response
+type SCIMErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// SCIMSupported represents whether an optional SCIM feature is supported
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// SCIMFilterSupport represents the filtering support of the SCIM endpoint
+type SCIMFilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// SCIMBulkSupport represents the bulk operation support of the SCIM endpoint
+type SCIMBulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// SCIMAuthenticationScheme represents how SCIM clients authenticate
+type SCIMAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SCIMServiceProviderConfigResponse represents the features the SCIM endpoint supports
+type SCIMServiceProviderConfigResponse struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 SCIMSupported              `json:"patch"`
+	Bulk                  SCIMBulkSupport            `json:"bulk"`
+	Filter                SCIMFilterSupport          `json:"filter"`
+	ChangePassword        SCIMSupported              `json:"changePassword"`
+	Sort                  SCIMSupported              `json:"sort"`
+	ETag                  SCIMSupported              `json:"etag"`
+	AuthenticationSchemes []SCIMAuthenticationScheme `json:"authenticationSchemes"`
+}
+
+// SCIMSchemaExtensionResponse represents a schema extension of a SCIM resource type
+type SCIMSchemaExtensionResponse struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+// SCIMResourceTypeResponse represents a resource type the SCIM endpoint serves
+type SCIMResourceTypeResponse struct {
+	Schemas          []string                      `json:"schemas"`
+	ID               string                        `json:"id"`
+	Name             string                        `json:"name"`
+	Endpoint         string                        `json:"endpoint"`
+	Schema           string                        `json:"schema"`
+	SchemaExtensions []SCIMSchemaExtensionResponse `json:"schemaExtensions,omitempty"`
+}
+
+// ToSCIMUserListResponse converts a page of SCIM users to SCIMListResponse, keeping an empty page as [] in JSON
+func ToSCIMUserListResponse(users []domain.SCIMUser, total, startIndex int) SCIMListResponse {
+	if users == nil {
+		users = []domain.SCIMUser{}
+	}
+	return SCIMListResponse{
+		Schemas:      []string{domain.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(users),
+		Resources:    users,
+	}
+}
+
+// ToSCIMGroupListResponse converts a page of SCIM groups to SCIMListResponse, keeping an empty page as [] in JSON
+func ToSCIMGroupListResponse(groups []domain.SCIMGroup, total, startIndex int) SCIMListResponse {
+	if groups == nil {
+		groups = []domain.SCIMGroup{}
+	}
+	return SCIMListResponse{
+		Schemas:      []string{domain.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(groups),
+		Resources:    groups,
+	}
+}
+
+// ToSCIMServiceProviderConfigResponse describes the SCIM features HRM supports
+func ToSCIMServiceProviderConfigResponse() SCIMServiceProviderConfigResponse {
+	return SCIMServiceProviderConfigResponse{
+		Schemas:        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		Patch:          SCIMSupported{Supported: true},
+		Filter:         SCIMFilterSupport{Supported: true, MaxResults: domain.SCIMMaxCount},
+		ChangePassword: SCIMSupported{Supported: true},
+		AuthenticationSchemes: []SCIMAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer token",
+			Description: "The token configured in SCIM_TOKEN, sent as \"Authorization: Bearer <token>\"",
+		}},
+	}
+}
+
+// ToSCIMResourceTypesResponse describes the User and Group resource types
+func ToSCIMResourceTypesResponse() SCIMListResponse {
+	schemas := []string{"urn:ietf:params:scim:schemas:core:2.0:ResourceType"}
+	resourceTypes := []SCIMResourceTypeResponse{
+		{
+			Schemas:          schemas,
+			ID:               "User",
+			Name:             "User",
+			Endpoint:         "/Users",
+			Schema:           domain.SCIMSchemaUser,
+			SchemaExtensions: []SCIMSchemaExtensionResponse{{Schema: domain.SCIMSchemaEnterpriseUser}},
+		},
+		{
+			Schemas:  schemas,
+			ID:       "Group",
+			Name:     "Group",
+			Endpoint: "/Groups",
+			Schema:   domain.SCIMSchemaGroup,
+		},
+	}
+	return SCIMListResponse{
+		Schemas:      []string{domain.SCIMSchemaListResponse},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	}
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// TimesheetResponse represents the response structure for a weekly timesheet
+type TimesheetResponse struct {
+	ID                uint                   `json:"id"` // 0 while the week has never been saved
+	UserID            uint                   `json:"user_id"`
+	WeekStart         time.Time              `json:"week_start"`
+	WeekEnd           time.Time              `json:"week_end"`
+	Status            string                 `json:"status"` // "draft", "submitted", "approved", "returned"
+	EmployeeNote      string                 `json:"employee_note,omitempty"`
+	TotalWorkHours    float64                `json:"total_work_hours"`
+	TotalBreakMinutes float64                `json:"total_break_minutes"`
+	SubmittedAt       *time.Time             `json:"submitted_at"`
+	ReviewedBy        *uint                  `json:"reviewed_by"`
+	ReviewedAt        *time.Time             `json:"reviewed_at"`
+	ReviewNote        string                 `json:"review_note,omitempty"`
+	Days              []TimesheetDayResponse `json:"days,omitempty"`
+}
+
+// TimesheetDayResponse represents the aggregated figures of one day of a timesheet
+type TimesheetDayResponse struct {
+	Date         time.Time  `json:"date"`
+	AttendanceID *uint      `json:"attendance_id"`
+	Status       string     `json:"status,omitempty"`
+	CheckInTime  *time.Time `json:"check_in_time"`
+	CheckOutTime *time.Time `json:"check_out_time"`
+	WorkHours    float64    `json:"work_hours"`
+	BreakMinutes float64    `json:"break_minutes"`
+	BreakCount   int        `json:"break_count"`
+	Note         string     `json:"note,omitempty"`
+}
+
+// TimesheetListResponse represents the response structure for a list of timesheets
+type TimesheetListResponse struct {
+	Timesheets []TimesheetResponse `json:"timesheets"`
+	Total      int                 `json:"total"`
+}
+
+// ToTimesheetResponse converts a domain Timesheet to TimesheetResponse
+func ToTimesheetResponse(timesheet *domain.Timesheet) TimesheetResponse {
+	days := make([]TimesheetDayResponse, len(timesheet.Days))
+	for i, day := range timesheet.Days {
+		days[i] = TimesheetDayResponse{
+			Date:         day.Date,
+			AttendanceID: day.AttendanceID,
+			Status:       day.Status,
+			CheckInTime:  day.CheckInTime,
+			CheckOutTime: day.CheckOutTime,
+			WorkHours:    day.WorkHours,
+			BreakMinutes: day.BreakMinutes,
+			BreakCount:   day.BreakCount,
+			Note:         day.Note,
+		}
+	}
+
+	return TimesheetResponse{
+		ID:                timesheet.ID,
+		UserID:            timesheet.UserID,
+		WeekStart:         timesheet.WeekStart,
+		WeekEnd:           timesheet.WeekEnd,
+		Status:            string(timesheet.Status),
+		EmployeeNote:      timesheet.EmployeeNote,
+		TotalWorkHours:    timesheet.TotalWorkHours,
+		TotalBreakMinutes: timesheet.TotalBreakMinutes,
+		SubmittedAt:       timesheet.SubmittedAt,
+		ReviewedBy:        timesheet.ReviewedBy,
+		ReviewedAt:        timesheet.ReviewedAt,
+		ReviewNote:        timesheet.ReviewNote,
+		Days:              days,
+	}
+}
+
+// ToTimesheetResponseList converts a slice of domain Timesheets to TimesheetResponse slice
+func ToTimesheetResponseList(timesheets []domain.Timesheet) []TimesheetResponse {
+	responses := make([]TimesheetResponse, len(timesheets))
+	for i := range timesheets {
+		responses[i] = ToTimesheetResponse(&timesheets[i])
+	}
+	return responses
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// TwoFactorChallengeResponse is returned instead of tokens when a sign-in needs a second factor
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired  bool      `json:"two_factor_required"`
+	SetupRequired      bool      `json:"setup_required"` // Set up an authenticator app first via /signin/2fa/setup
+	ChallengeToken     string    `json:"challenge_token"`
+	ChallengeExpiresAt time.Time `json:"challenge_expires_at"`
+}
+
+// TOTPEnrollmentResponse represents what an authenticator app needs to be set up
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // Render as a QR code
+}
+
+// RecoveryCodesResponse represents newly issued recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorStatusResponse represents a user's two-factor authentication setup
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	ConfirmedAt            *time.Time `json:"confirmed_at"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+	Required               bool       `json:"required"`
+}
+
+// ToTwoFactorChallengeResponse converts a sign-in ticket to TwoFactorChallengeResponse
+func ToTwoFactorChallengeResponse(ticket *domain.TwoFactorTicket) TwoFactorChallengeResponse {
+	return TwoFactorChallengeResponse{
+		TwoFactorRequired:  true,
+		SetupRequired:      ticket.SetupRequired,
+		ChallengeToken:     ticket.ChallengeToken,
+		ChallengeExpiresAt: ticket.ExpiresAt,
+	}
+}
+
+// ToTOTPEnrollmentResponse converts a domain TOTPEnrollment to TOTPEnrollmentResponse
+func ToTOTPEnrollmentResponse(enrollment *domain.TOTPEnrollment) TOTPEnrollmentResponse {
+	return TOTPEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+// ToTwoFactorStatusResponse converts a domain TwoFactorStatus to TwoFactorStatusResponse
+func ToTwoFactorStatusResponse(status *domain.TwoFactorStatus) TwoFactorStatusResponse {
+	return TwoFactorStatusResponse{
+		Enabled:                status.Enabled,
+		ConfirmedAt:            status.ConfirmedAt,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+		Required:               status.Required,
+	}
+}
@@ -7,24 +7,65 @@ import (
 
 // UserResponse represents the response model for user data
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `json:"id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Timezone        string     `json:"timezone"`
+	LocationID      *uint      `json:"location_id"`
+	ManagerID       *uint      `json:"manager_id"`
+	IsActive        bool       `json:"is_active"`
+	DeprovisionedAt *time.Time `json:"deprovisioned_at"`
+	HasKioskPIN     bool       `json:"has_kiosk_pin"`
+	HasBadge        bool       `json:"has_badge"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TokenResponse represents the tokens issued at sign-in or refresh.
+// Token is the short-lived access token; RefreshToken renews it and is replaced on every use.
+type TokenResponse struct {
+	Token                 string    `json:"token"`
+	TokenExpiresAt        time.Time `json:"token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 // SignUpResponse represents the response model for user registration
 type SignUpResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User UserResponse `json:"user"`
+	TokenResponse
 }
 
 // SignInResponse represents the response model for user authentication
 type SignInResponse struct {
-	User            UserResponse         `json:"user"`
-	Token           string               `json:"token"`
+	User UserResponse `json:"user"`
+	TokenResponse
 	LastAttendances []AttendanceResponse `json:"last_attendances"`
+	RecoveryCodes   []string             `json:"recovery_codes,omitempty"` // Only when the sign-in completed a required 2FA setup
+}
+
+// AuthSessionResponse represents a device the user is signed in on
+type AuthSessionResponse struct {
+	ID         uint       `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Current    bool       `json:"current"` // The session the request was made with
+}
+
+// SignInAttemptResponse represents one entry of the sign-in history
+type SignInAttemptResponse struct {
+	ID        uint      `json:"id"`
+	UserID    *uint     `json:"user_id"`
+	Email     string    `json:"email"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Result    string    `json:"result"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // GetUserResponse represents the response model for getting a user
@@ -59,12 +100,48 @@ type ListUsersResponse struct {
 // ToUserResponse converts a domain User to UserResponse
 func ToUserResponse(user *domain.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		EmailVerified:   user.IsEmailVerified(),
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Timezone:        user.Timezone,
+		LocationID:      user.LocationID,
+		ManagerID:       user.ManagerID,
+		IsActive:        user.IsActive,
+		DeprovisionedAt: user.DeprovisionedAt,
+		HasKioskPIN:     user.HasKioskPIN(),
+		HasBadge:        user.BadgeID != nil,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
+
+// ToTokenResponse converts a domain TokenPair to TokenResponse
+func ToTokenResponse(tokens *domain.TokenPair) TokenResponse {
+	return TokenResponse{
+		Token:                 tokens.AccessToken,
+		TokenExpiresAt:        tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+// ToAuthSessionResponseList converts a user's sessions to AuthSessionResponse, marking the current one
+func ToAuthSessionResponseList(sessions []domain.AuthSession, currentSessionID uint) []AuthSessionResponse {
+	responses := make([]AuthSessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = AuthSessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		}
 	}
+	return responses
 }
 
 // ToUserResponseList converts a slice of domain Users to UserResponse slice
@@ -75,3 +152,46 @@ func ToUserResponseList(users []domain.User) []UserResponse {
 	}
 	return responses
 }
+
+// ToSignInAttemptResponseList converts sign-in attempts to SignInAttemptResponse
+func ToSignInAttemptResponseList(attempts []domain.SignInAttempt) []SignInAttemptResponse {
+	responses := make([]SignInAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		responses[i] = SignInAttemptResponse{
+			ID:        attempt.ID,
+			UserID:    attempt.UserID,
+			Email:     attempt.Email,
+			IPAddress: attempt.IPAddress,
+			UserAgent: attempt.UserAgent,
+			Result:    attempt.Result,
+			CreatedAt: attempt.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// PasswordPolicyResponse represents the rules new passwords have to follow
+type PasswordPolicyResponse struct {
+	MinLength             int  `json:"min_length"`
+	MaxLength             int  `json:"max_length"`
+	RequireUppercase      bool `json:"require_uppercase"`
+	RequireLowercase      bool `json:"require_lowercase"`
+	RequireDigit          bool `json:"require_digit"`
+	RequireSymbol         bool `json:"require_symbol"`
+	HistorySize           int  `json:"history_size"` // Number of recent passwords that cannot be reused
+	BlocksCommonPasswords bool `json:"blocks_common_passwords"`
+}
+
+// ToPasswordPolicyResponse converts a domain PasswordPolicy to PasswordPolicyResponse
+func ToPasswordPolicyResponse(policy domain.PasswordPolicy) PasswordPolicyResponse {
+	return PasswordPolicyResponse{
+		MinLength:             policy.MinLength,
+		MaxLength:             policy.MaxLength,
+		RequireUppercase:      policy.RequireUpper,
+		RequireLowercase:      policy.RequireLower,
+		RequireDigit:          policy.RequireDigit,
+		RequireSymbol:         policy.RequireSymbol,
+		HistorySize:           policy.HistorySize,
+		BlocksCommonPasswords: len(policy.Blocklist) > 0,
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAPITokenRoutes configures the routes for personal access tokens and service accounts
+func SetupAPITokenRoutes(router *gin.Engine, apiTokenService domain.APITokenServiceInterface) {
+	// Create API token handler
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenService)
+
+	// Personal access tokens of the current user (API tokens cannot manage tokens)
+	tokenGroup := router.Group("/api/users/me/tokens")
+	tokenGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		tokenGroup.GET("", apiTokenHandler.GetMyTokens)
+		tokenGroup.POST("", apiTokenHandler.CreateMyToken)
+		tokenGroup.DELETE("/:id", apiTokenHandler.RevokeMyToken)
+	}
+
+	// Service accounts and their tokens (requires admin)
+	serviceAccountGroup := router.Group("/api/service-accounts")
+	serviceAccountGroup.Use(middleware.JWTAuthMiddleware(), middleware.RequireRole(domain.RoleAdmin))
+	{
+		serviceAccountGroup.POST("", apiTokenHandler.CreateServiceAccount)
+		serviceAccountGroup.GET("", apiTokenHandler.GetServiceAccounts)
+		serviceAccountGroup.GET("/:id", apiTokenHandler.GetServiceAccount)
+		serviceAccountGroup.DELETE("/:id", apiTokenHandler.DeleteServiceAccount)
+		serviceAccountGroup.GET("/:id/tokens", apiTokenHandler.GetServiceAccountTokens)
+		serviceAccountGroup.POST("/:id/tokens", apiTokenHandler.CreateServiceAccountToken)
+		serviceAccountGroup.DELETE("/:id/tokens/:token_id", apiTokenHandler.RevokeServiceAccountToken)
+	}
+}
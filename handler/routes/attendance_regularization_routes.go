@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAttendanceRegularizationRoutes configures all attendance regularization routes
+func SetupAttendanceRegularizationRoutes(router *gin.Engine, regularizationService domain.AttendanceRegularizationServiceInterface) {
+	// Create regularization handler
+	regularizationHandler := handler.NewAttendanceRegularizationHandler(regularizationService)
+
+	// Regularization API group (all routes require authentication)
+	regularizationGroup := router.Group("/api/v1/attendance/regularizations")
+	regularizationGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		// Employee routes
+		regularizationGroup.POST("/", middleware.RequireVerifiedEmail(), regularizationHandler.RequestRegularization)
+		regularizationGroup.GET("/me", regularizationHandler.GetMyRegularizations)
+		regularizationGroup.POST("/:id/cancel", regularizationHandler.CancelRegularization)
+
+		// Manager routes
+		regularizationGroup.GET("/pending", regularizationHandler.GetPendingRegularizations)
+		regularizationGroup.POST("/:id/approve", middleware.RequireVerifiedEmail(), regularizationHandler.ApproveRegularization)
+		regularizationGroup.POST("/:id/reject", middleware.RequireVerifiedEmail(), regularizationHandler.RejectRegularization)
+
+		regularizationGroup.GET("/:id", regularizationHandler.GetRegularizationByID)
+	}
+}
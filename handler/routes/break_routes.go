@@ -13,15 +13,23 @@ func SetupBreakRoutes(router *gin.Engine, breakService domain.BreakServiceInterf
 	// Create break handler
 	breakHandler := handler.NewBreakHandler(breakService)
 
+	// Users manage the breaks of their own attendance; only admins list everyone's
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+
 	// Break API group
 	breakGroup := router.Group("/api/v1/breaks")
 	{
 		// Protected routes (require authentication)
 		breakGroup.Use(middleware.JWTAuthMiddleware())
 		{
+			// Self-service breaks in the caller's open attendance
+			breakGroup.POST("/me/start", breakHandler.StartMyBreak)
+			breakGroup.POST("/me/end", breakHandler.EndMyBreak)
+			breakGroup.GET("/me/status", breakHandler.GetMyBreakStatus)
+
 			// Break management
 			breakGroup.POST("/", breakHandler.AddBreak)
-			breakGroup.GET("/", breakHandler.GetAllBreaks)
+			breakGroup.GET("/", requireAdmin, breakHandler.GetAllBreaks)
 			breakGroup.GET("/:id", breakHandler.GetBreakByID)
 			breakGroup.PUT("/end", breakHandler.EndBreak)
 			breakGroup.DELETE("/:id", breakHandler.DeleteBreak)
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBreakTypeRoutes configures all break type routes
+func SetupBreakTypeRoutes(router *gin.Engine, breakTypeService domain.BreakTypeServiceInterface) {
+	// Create break type handler
+	breakTypeHandler := handler.NewBreakTypeHandler(breakTypeService)
+
+	// Only admins maintain break types and their limits
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+
+	// Break type API group (all routes require authentication)
+	breakTypeGroup := router.Group("/api/v1/break-types")
+	breakTypeGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		breakTypeGroup.POST("/", requireAdmin, breakTypeHandler.CreateBreakType)
+		breakTypeGroup.GET("/", breakTypeHandler.GetAllBreakTypes)
+		breakTypeGroup.GET("/:id", breakTypeHandler.GetBreakTypeByID)
+		breakTypeGroup.PUT("/:id", requireAdmin, breakTypeHandler.UpdateBreakType)
+		breakTypeGroup.DELETE("/:id", requireAdmin, breakTypeHandler.DeleteBreakType)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupDirectoryRoutes configures the route for syncing users from the LDAP directory
+func SetupDirectoryRoutes(router *gin.Engine, directorySyncService domain.DirectorySyncServiceInterface) {
+	// Create directory handler
+	directoryHandler := handler.NewDirectoryHandler(directorySyncService)
+
+	// Directory sync (requires admin)
+	router.POST("/api/users/directory/sync", middleware.JWTAuthMiddleware(), middleware.RequireRole(domain.RoleAdmin), directoryHandler.SyncDirectory)
+}
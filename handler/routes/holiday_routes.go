@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupHolidayRoutes configures all holiday-related routes
+func SetupHolidayRoutes(router *gin.Engine, holidayService domain.HolidayServiceInterface) {
+	// Create holiday handler
+	holidayHandler := handler.NewHolidayHandler(holidayService)
+
+	// Only admins maintain the holiday calendar
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+
+	// Holiday API group
+	holidayGroup := router.Group("/api/v1/holidays")
+	{
+		// Protected routes (require authentication)
+		holidayGroup.Use(middleware.JWTAuthMiddleware())
+		{
+			holidayGroup.POST("/", requireAdmin, holidayHandler.CreateHoliday)
+			holidayGroup.GET("/", holidayHandler.GetAllHolidays)
+			holidayGroup.GET("/:id", holidayHandler.GetHolidayByID)
+			holidayGroup.PUT("/:id", requireAdmin, holidayHandler.UpdateHoliday)
+			holidayGroup.DELETE("/:id", requireAdmin, holidayHandler.DeleteHoliday)
+		}
+	}
+}
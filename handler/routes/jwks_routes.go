@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupJWKSRoutes configures the public route serving the access token verification keys
+func SetupJWKSRoutes(router *gin.Engine, signingKeyService domain.SigningKeyServiceInterface) {
+	// Create JWKS handler
+	jwksHandler := handler.NewJWKSHandler(signingKeyService)
+
+	// Well-known JWKS location (no authentication, the keys are public)
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
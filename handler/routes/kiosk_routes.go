@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"time"
+
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupKioskRoutes configures kiosk management routes and the punch routes used by kiosk devices
+func SetupKioskRoutes(router *gin.Engine, kioskService domain.KioskServiceInterface, rateLimit int, rateWindow time.Duration) {
+	// Create kiosk handler
+	kioskHandler := handler.NewKioskHandler(kioskService)
+
+	// Kiosk management API group (all routes require an admin)
+	kioskGroup := router.Group("/api/v1/kiosks")
+	kioskGroup.Use(middleware.JWTAuthMiddleware(), middleware.RequireRole(domain.RoleAdmin))
+	{
+		kioskGroup.POST("/", kioskHandler.RegisterKiosk)
+		kioskGroup.GET("/", kioskHandler.GetAllKiosks)
+		kioskGroup.GET("/:id", kioskHandler.GetKioskByID)
+		kioskGroup.PUT("/:id", kioskHandler.UpdateKiosk)
+		kioskGroup.POST("/:id/rotate-token", kioskHandler.RotateKioskToken)
+		kioskGroup.DELETE("/:id", kioskHandler.DeleteKiosk)
+	}
+
+	// Kiosk device API group (authenticated by device token, rate limited per kiosk)
+	deviceGroup := router.Group("/api/v1/kiosk")
+	deviceGroup.Use(handler.KioskAuthMiddleware(kioskService), handler.KioskRateLimit(rateLimit, rateWindow))
+	{
+		deviceGroup.POST("/checkin", kioskHandler.CheckIn)
+		deviceGroup.POST("/checkout", kioskHandler.CheckOut)
+		deviceGroup.POST("/breaks/start", kioskHandler.StartBreak)
+		deviceGroup.POST("/breaks/end", kioskHandler.EndBreak)
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupLocationRoutes configures all location-related routes
+func SetupLocationRoutes(router *gin.Engine, locationService domain.LocationServiceInterface) {
+	// Create location handler
+	locationHandler := handler.NewLocationHandler(locationService)
+
+	// Only admins maintain locations, which carry the geofences and network rules of punches
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+
+	// Location API group
+	locationGroup := router.Group("/api/v1/locations")
+	{
+		// Protected routes (require authentication)
+		locationGroup.Use(middleware.JWTAuthMiddleware())
+		{
+			locationGroup.POST("/", requireAdmin, locationHandler.CreateLocation)
+			locationGroup.GET("/", locationHandler.GetAllLocations)
+			locationGroup.GET("/:id", locationHandler.GetLocationByID)
+			locationGroup.PUT("/:id", requireAdmin, locationHandler.UpdateLocation)
+			locationGroup.DELETE("/:id", requireAdmin, locationHandler.DeleteLocation)
+		}
+	}
+}
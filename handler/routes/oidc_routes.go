@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOIDCRoutes configures the OpenID Connect single sign-on routes under /api/users/oidc
+func SetupOIDCRoutes(router *gin.Engine, oidcService domain.OIDCServiceInterface, twoFactorService domain.TwoFactorServiceInterface, authService domain.AuthServiceInterface, attendanceService domain.AttendanceServiceInterface) {
+	// Create OIDC handler
+	oidcHandler := handler.NewOIDCHandler(oidcService, twoFactorService, authService, attendanceService)
+
+	// Browser redirects to and from the identity provider (no authentication, this is how users sign in)
+	oidc := router.Group("/api/users/oidc")
+	{
+		oidc.GET("/login", oidcHandler.Login)
+		oidc.GET("/callback", oidcHandler.Callback)
+	}
+}
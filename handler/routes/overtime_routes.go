@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOvertimeRoutes configures all overtime-related routes
+func SetupOvertimeRoutes(router *gin.Engine, overtimeService domain.OvertimeServiceInterface) {
+	// Create overtime handler
+	overtimeHandler := handler.NewOvertimeHandler(overtimeService)
+
+	// Overtime API group (all routes require authentication)
+	overtimeGroup := router.Group("/api/v1/overtime")
+	overtimeGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		// Ledger per pay period
+		overtimeGroup.GET("/ledger", overtimeHandler.GetLedger)
+		overtimeGroup.POST("/recalculate", overtimeHandler.Recalculate)
+
+		// Post-facto approval of recorded overtime
+		overtimeGroup.GET("/entries/pending", overtimeHandler.GetPendingEntries)
+		overtimeGroup.POST("/entries/:id/approve", middleware.RequireVerifiedEmail(), overtimeHandler.ApproveEntry)
+		overtimeGroup.POST("/entries/:id/reject", middleware.RequireVerifiedEmail(), overtimeHandler.RejectEntry)
+
+		// Pre-approval requests
+		overtimeGroup.POST("/requests", middleware.RequireVerifiedEmail(), overtimeHandler.RequestPreApproval)
+		overtimeGroup.GET("/requests/me", overtimeHandler.GetMyRequests)
+		overtimeGroup.GET("/requests/pending", overtimeHandler.GetPendingRequests)
+		overtimeGroup.POST("/requests/:id/approve", middleware.RequireVerifiedEmail(), overtimeHandler.ApproveRequest)
+		overtimeGroup.POST("/requests/:id/reject", middleware.RequireVerifiedEmail(), overtimeHandler.RejectRequest)
+		overtimeGroup.POST("/requests/:id/cancel", overtimeHandler.CancelRequest)
+	}
+}
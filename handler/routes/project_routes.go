@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupProjectRoutes configures all project and time entry routes
+func SetupProjectRoutes(router *gin.Engine, projectService domain.ProjectServiceInterface, timeEntryService domain.TimeEntryServiceInterface) {
+	// Create handlers
+	projectHandler := handler.NewProjectHandler(projectService, timeEntryService)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService)
+
+	// Only admins maintain projects; everyone can book time on them
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+
+	// Project API group (all routes require authentication)
+	projectGroup := router.Group("/api/v1/projects")
+	projectGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		projectGroup.POST("/", requireAdmin, projectHandler.CreateProject)
+		projectGroup.GET("/", projectHandler.GetAllProjects)
+		projectGroup.GET("/utilization", projectHandler.GetUtilization)
+		projectGroup.GET("/:id", projectHandler.GetProjectByID)
+		projectGroup.PUT("/:id", requireAdmin, projectHandler.UpdateProject)
+		projectGroup.DELETE("/:id", requireAdmin, projectHandler.DeleteProject)
+	}
+
+	// Time entry API group (all routes require authentication)
+	timeEntryGroup := router.Group("/api/v1/time-entries")
+	timeEntryGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		timeEntryGroup.POST("/", timeEntryHandler.LogTime)
+		timeEntryGroup.GET("/me", timeEntryHandler.GetMyTimeEntries)
+		timeEntryGroup.PUT("/:id", timeEntryHandler.UpdateTimeEntry)
+		timeEntryGroup.DELETE("/:id", timeEntryHandler.DeleteTimeEntry)
+	}
+}
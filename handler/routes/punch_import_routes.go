@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPunchImportRoutes configures the route for importing time clock punch logs
+func SetupPunchImportRoutes(router *gin.Engine, punchImportService domain.PunchImportServiceInterface) {
+	// Create punch import handler
+	punchImportHandler := handler.NewPunchImportHandler(punchImportService)
+
+	// Punch import API group (all routes require an admin)
+	importGroup := router.Group("/api/v1/attendance/imports")
+	importGroup.Use(middleware.JWTAuthMiddleware(), middleware.RequireRole(domain.RoleAdmin))
+	{
+		importGroup.POST("/", punchImportHandler.ImportPunchLog)
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSCIMRoutes configures the SCIM 2.0 routes identity providers provision users and role groups with
+func SetupSCIMRoutes(router *gin.Engine, scimService domain.SCIMServiceInterface) {
+	// Create SCIM handler
+	scimHandler := handler.NewSCIMHandler(scimService)
+
+	// SCIM API group (authenticated by the SCIM bearer token)
+	scimGroup := router.Group("/scim/v2")
+	scimGroup.Use(handler.SCIMAuthMiddleware(scimService))
+	{
+		// Discovery
+		scimGroup.GET("/ServiceProviderConfig", scimHandler.GetServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", scimHandler.GetResourceTypes)
+
+		// Users
+		scimGroup.GET("/Users", scimHandler.ListUsers)
+		scimGroup.POST("/Users", scimHandler.CreateUser)
+		scimGroup.GET("/Users/:id", scimHandler.GetUser)
+		scimGroup.PUT("/Users/:id", scimHandler.ReplaceUser)
+		scimGroup.PATCH("/Users/:id", scimHandler.PatchUser)
+		scimGroup.DELETE("/Users/:id", scimHandler.DeleteUser)
+
+		// Groups (HRM's admin and security_admin roles)
+		scimGroup.GET("/Groups", scimHandler.ListGroups)
+		scimGroup.POST("/Groups", scimHandler.CreateGroup)
+		scimGroup.GET("/Groups/:id", scimHandler.GetGroup)
+		scimGroup.PUT("/Groups/:id", scimHandler.ReplaceGroup)
+		scimGroup.PATCH("/Groups/:id", scimHandler.PatchGroup)
+		scimGroup.DELETE("/Groups/:id", scimHandler.DeleteGroup)
+	}
+}
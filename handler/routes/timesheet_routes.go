@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTimesheetRoutes configures all timesheet-related routes
+func SetupTimesheetRoutes(router *gin.Engine, timesheetService domain.TimesheetServiceInterface) {
+	// Create timesheet handler
+	timesheetHandler := handler.NewTimesheetHandler(timesheetService)
+
+	// Timesheet API group (all routes require authentication)
+	timesheetGroup := router.Group("/api/v1/timesheets")
+	timesheetGroup.Use(middleware.JWTAuthMiddleware())
+	{
+		// Employee routes
+		timesheetGroup.GET("/me", timesheetHandler.GetMyWeek)
+		timesheetGroup.GET("/me/history", timesheetHandler.GetMyTimesheets)
+		timesheetGroup.PUT("/me/notes", timesheetHandler.AnnotateMyWeek)
+		timesheetGroup.POST("/me/submit", middleware.RequireVerifiedEmail(), timesheetHandler.SubmitMyWeek)
+
+		// Manager routes
+		timesheetGroup.GET("/pending", timesheetHandler.GetPendingTimesheets)
+		timesheetGroup.GET("/user/:user_id", timesheetHandler.GetUserWeek)
+		timesheetGroup.GET("/:id", timesheetHandler.GetTimesheet)
+		timesheetGroup.POST("/:id/approve", middleware.RequireVerifiedEmail(), timesheetHandler.ApproveTimesheet)
+		timesheetGroup.POST("/:id/return", middleware.RequireVerifiedEmail(), timesheetHandler.ReturnTimesheet)
+	}
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"hrm/domain"
+	"hrm/handler"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTwoFactorRoutes configures the TOTP two-factor authentication routes under /api/users
+func SetupTwoFactorRoutes(router *gin.Engine, twoFactorService domain.TwoFactorServiceInterface, authService domain.AuthServiceInterface, attendanceService domain.AttendanceServiceInterface) {
+	// Create two-factor handler
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService, authService, attendanceService)
+
+	users := router.Group("/api/users")
+	{
+		// Second sign-in step (authenticated by the challenge token from POST /api/users/signin)
+		users.POST("/signin/2fa", twoFactorHandler.CompleteSignIn)
+		users.POST("/signin/2fa/setup", twoFactorHandler.SetupForSignIn)
+
+		// Self-service enrollment (requires JWT)
+		me := users.Group("/me/2fa", middleware.JWTAuthMiddleware())
+		{
+			me.GET("", twoFactorHandler.GetMyStatus)
+			me.POST("/setup", twoFactorHandler.StartMyEnrollment)
+			me.POST("/confirm", twoFactorHandler.ConfirmMyEnrollment)
+			me.POST("/recovery-codes", twoFactorHandler.RegenerateMyRecoveryCodes)
+			me.POST("/disable", twoFactorHandler.DisableMine)
+		}
+
+		// Reset for users who lost their authenticator app (requires security admin)
+		users.DELETE("/:id/2fa", middleware.JWTAuthMiddleware(), middleware.RequireRole(domain.RoleAdmin, domain.RoleSecurityAdmin), twoFactorHandler.ResetUser)
+	}
+}
@@ -8,8 +8,8 @@ import (
 )
 
 // SetupUserRoutes configures all user-related routes
-func SetupUserRoutes(router *gin.Engine, userService domain.UserServiceInterface, attendanceService domain.AttendanceServiceInterface) {
-	handler.SetupUserRoutes(router, userService, attendanceService)
+func SetupUserRoutes(router *gin.Engine, userService domain.UserServiceInterface, authService domain.AuthServiceInterface, accountService domain.AccountServiceInterface, twoFactorService domain.TwoFactorServiceInterface, attendanceService domain.AttendanceServiceInterface) {
+	handler.SetupUserRoutes(router, userService, authService, accountService, twoFactorService, attendanceService)
 }
 
 // SetupHealthRoutes configures health check routes
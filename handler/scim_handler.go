@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SCIMHandler handles SCIM 2.0 requests from identity providers provisioning users and role groups.
+// Responses use the SCIM formats rather than the usual response envelope.
+type SCIMHandler struct {
+	scimService domain.SCIMServiceInterface
+}
+
+// NewSCIMHandler creates a new instance of SCIMHandler
+func NewSCIMHandler(scimService domain.SCIMServiceInterface) *SCIMHandler {
+	return &SCIMHandler{
+		scimService: scimService,
+	}
+}
+
+// GetServiceProviderConfig describes the SCIM features HRM supports
+func (h *SCIMHandler) GetServiceProviderConfig(c *gin.Context) {
+	scimJSON(c, http.StatusOK, response.ToSCIMServiceProviderConfigResponse())
+}
+
+// GetResourceTypes describes the User and Group resource types
+func (h *SCIMHandler) GetResourceTypes(c *gin.Context) {
+	scimJSON(c, http.StatusOK, response.ToSCIMResourceTypesResponse())
+}
+
+// ListUsers lists users, optionally filtered with the filter parameter and paged with startIndex and count
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	query, ok := scimListQuery(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.scimService.ListUsers(query)
+	if err != nil {
+		scimErrorResponse(c, err, "list users")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, response.ToSCIMUserListResponse(users, total, query.StartIndex))
+}
+
+// GetUser retrieves a user by SCIM ID
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	user, err := h.scimService.GetUser(c.Param("id"))
+	if err != nil {
+		scimErrorResponse(c, err, "get user")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, user)
+}
+
+// CreateUser provisions a new user
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	var resource domain.SCIMUser
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request data: "+err.Error())
+		return
+	}
+
+	user, err := h.scimService.CreateUser(&resource)
+	if err != nil {
+		scimErrorResponse(c, err, "create user")
+		return
+	}
+
+	c.Header("Location", user.Meta.Location)
+	scimJSON(c, http.StatusCreated, user)
+}
+
+// ReplaceUser replaces the attributes of a user
+func (h *SCIMHandler) ReplaceUser(c *gin.Context) {
+	var resource domain.SCIMUser
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request data: "+err.Error())
+		return
+	}
+
+	user, err := h.scimService.ReplaceUser(c.Param("id"), &resource)
+	if err != nil {
+		scimErrorResponse(c, err, "replace user")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, user)
+}
+
+// PatchUser applies PATCH operations to a user, e.g. setting active to false to deactivate them
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	var req request.SCIMPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request data: "+err.Error())
+		return
+	}
+
+	user, err := h.scimService.PatchUser(c.Param("id"), req.Operations)
+	if err != nil {
+		scimErrorResponse(c, err, "patch user")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, user)
+}
+
+// DeleteUser removes a user
+func (h *SCIMHandler) DeleteUser(c *gin.Context) {
+	if err := h.scimService.DeleteUser(c.Param("id")); err != nil {
+		scimErrorResponse(c, err, "delete user")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroups lists the role groups, optionally filtered with the filter parameter and paged with startIndex and count
+func (h *SCIMHandler) ListGroups(c *gin.Context) {
+	query, ok := scimListQuery(c)
+	if !ok {
+		return
+	}
+
+	groups, total, err := h.scimService.ListGroups(query)
+	if err != nil {
+		scimErrorResponse(c, err, "list groups")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, response.ToSCIMGroupListResponse(groups, total, query.StartIndex))
+}
+
+// GetGroup retrieves a role group by SCIM ID
+func (h *SCIMHandler) GetGroup(c *gin.Context) {
+	group, err := h.scimService.GetGroup(c.Param("id"))
+	if err != nil {
+		scimErrorResponse(c, err, "get group")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, group)
+}
+
+// CreateGroup refuses to create groups; the groups are HRM's fixed roles
+func (h *SCIMHandler) CreateGroup(c *gin.Context) {
+	scimErrorResponse(c, domain.ErrSCIMGroupImmutable, "create group")
+}
+
+// ReplaceGroup replaces the members of a role group
+func (h *SCIMHandler) ReplaceGroup(c *gin.Context) {
+	var resource domain.SCIMGroup
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request data: "+err.Error())
+		return
+	}
+
+	group, err := h.scimService.ReplaceGroup(c.Param("id"), &resource)
+	if err != nil {
+		scimErrorResponse(c, err, "replace group")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, group)
+}
+
+// PatchGroup adds or removes members of a role group
+func (h *SCIMHandler) PatchGroup(c *gin.Context) {
+	var req request.SCIMPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request data: "+err.Error())
+		return
+	}
+
+	group, err := h.scimService.PatchGroup(c.Param("id"), req.Operations)
+	if err != nil {
+		scimErrorResponse(c, err, "patch group")
+		return
+	}
+
+	scimJSON(c, http.StatusOK, group)
+}
+
+// DeleteGroup refuses to delete groups; the groups are HRM's fixed roles
+func (h *SCIMHandler) DeleteGroup(c *gin.Context) {
+	scimErrorResponse(c, domain.ErrSCIMGroupImmutable, "delete group")
+}
+
+// scimListQuery reads the filter, startIndex and count parameters of a list request.
+// Out of range values are clamped; values that are not numbers are answered with 400.
+func scimListQuery(c *gin.Context) (domain.SCIMListQuery, bool) {
+	query := domain.SCIMListQuery{
+		Filter:     c.Query("filter"),
+		StartIndex: 1,
+		Count:      domain.SCIMDefaultCount,
+	}
+
+	if value := c.Query("startIndex"); value != "" {
+		startIndex, err := strconv.Atoi(value)
+		if err != nil {
+			scimError(c, http.StatusBadRequest, "invalidValue", "Invalid startIndex")
+			return query, false
+		}
+		query.StartIndex = max(startIndex, 1)
+	}
+	if value := c.Query("count"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			scimError(c, http.StatusBadRequest, "invalidValue", "Invalid count")
+			return query, false
+		}
+		query.Count = min(max(count, 0), domain.SCIMMaxCount)
+	}
+
+	return query, true
+}
+
+// scimErrorResponse maps errors of SCIM provisioning to SCIM error responses
+func scimErrorResponse(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSCIMToken):
+		scimError(c, http.StatusUnauthorized, "", err.Error())
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrSCIMGroupNotFound):
+		scimError(c, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		scimError(c, http.StatusConflict, "uniqueness", "A user with this userName already exists")
+	case errors.Is(err, domain.ErrSCIMInvalidFilter):
+		scimError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, domain.ErrSCIMInvalidPath):
+		scimError(c, http.StatusBadRequest, "invalidPath", err.Error())
+	case errors.Is(err, domain.ErrSCIMNoTarget):
+		scimError(c, http.StatusBadRequest, "noTarget", err.Error())
+	case errors.Is(err, domain.ErrSCIMInvalidPatch):
+		scimError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	case errors.Is(err, domain.ErrSCIMGroupImmutable):
+		scimError(c, http.StatusBadRequest, "mutability", err.Error())
+	case errors.Is(err, domain.ErrSCIMInvalidValue),
+		errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrInvalidPassword),
+		errors.Is(err, domain.ErrPasswordReused),
+		errors.Is(err, domain.ErrInvalidTimezone),
+		errors.Is(err, domain.ErrInvalidManager),
+		errors.Is(err, domain.ErrManagerNotFound):
+		scimError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		scimError(c, http.StatusInternalServerError, "", "Failed to "+action+": "+err.Error())
+	}
+}
+
+// scimError sends a SCIM error response
+func scimError(c *gin.Context, statusCode int, scimType, detail string) {
+	scimJSON(c, statusCode, response.SCIMErrorResponse{
+		Schemas:  []string{domain.SCIMSchemaError},
+		Status:   strconv.Itoa(statusCode),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// scimJSON sends a SCIM response with the SCIM media type
+func scimJSON(c *gin.Context, statusCode int, body interface{}) {
+	c.Header("Content-Type", "application/scim+json; charset=utf-8")
+	c.JSON(statusCode, body)
+}
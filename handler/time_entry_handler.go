@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeEntryHandler handles HTTP requests related to booking time on projects
+type TimeEntryHandler struct {
+	timeEntryService domain.TimeEntryServiceInterface
+}
+
+// NewTimeEntryHandler creates a new instance of TimeEntryHandler
+func NewTimeEntryHandler(timeEntryService domain.TimeEntryServiceInterface) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService: timeEntryService,
+	}
+}
+
+// LogTime books hours of the authenticated user's attendance day on a project
+func (h *TimeEntryHandler) LogTime(c *gin.Context) {
+	var req request.TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	entry := &domain.TimeEntry{
+		ProjectID: req.ProjectID,
+		Date:      req.Date,
+		Hours:     req.Hours,
+		Task:      req.Task,
+		Note:      req.Note,
+	}
+
+	if err := h.timeEntryService.LogTime(userID, entry); err != nil {
+		h.handleError(c, err, "log time")
+		return
+	}
+
+	h.respondWithEntry(c, http.StatusCreated, entry.ID, "Time logged successfully")
+}
+
+// GetMyTimeEntries retrieves the time entries of the authenticated user.
+// Query parameters: start_date and end_date (YYYY-MM-DD)
+func (h *TimeEntryHandler) GetMyTimeEntries(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	if err != nil {
+		BadRequestResponse(c, "Invalid start date format. Use YYYY-MM-DD")
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", c.Query("end_date"))
+	if err != nil {
+		BadRequestResponse(c, "Invalid end date format. Use YYYY-MM-DD")
+		return
+	}
+
+	entries, err := h.timeEntryService.GetUserTimeEntries(userID, startDate, endDate)
+	if err != nil {
+		h.handleError(c, err, "get time entries")
+		return
+	}
+
+	totalHours := 0.0
+	for _, entry := range entries {
+		totalHours += entry.Hours
+	}
+
+	listResp := response.TimeEntryListResponse{
+		Entries:    response.ToTimeEntryResponseList(entries),
+		Total:      len(entries),
+		TotalHours: totalHours,
+	}
+	SuccessResponse(c, http.StatusOK, "Time entries retrieved successfully", listResp)
+}
+
+// UpdateTimeEntry changes one of the authenticated user's time entries
+func (h *TimeEntryHandler) UpdateTimeEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid time entry ID")
+		return
+	}
+
+	var req request.UpdateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	entry := &domain.TimeEntry{
+		ID:        uint(id),
+		ProjectID: req.ProjectID,
+		Hours:     req.Hours,
+		Task:      req.Task,
+		Note:      req.Note,
+	}
+
+	if err := h.timeEntryService.UpdateTimeEntry(userID, entry); err != nil {
+		h.handleError(c, err, "update time entry")
+		return
+	}
+
+	h.respondWithEntry(c, http.StatusOK, entry.ID, "Time entry updated successfully")
+}
+
+// DeleteTimeEntry removes one of the authenticated user's time entries
+func (h *TimeEntryHandler) DeleteTimeEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid time entry ID")
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	if err := h.timeEntryService.DeleteTimeEntry(uint(id), userID); err != nil {
+		h.handleError(c, err, "delete time entry")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Time entry deleted successfully", nil)
+}
+
+// respondWithEntry reloads a time entry with its project and writes it to the response
+func (h *TimeEntryHandler) respondWithEntry(c *gin.Context, status int, id uint, message string) {
+	entry, err := h.timeEntryService.GetTimeEntryByID(id)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get time entry: "+err.Error())
+		return
+	}
+
+	SuccessResponse(c, status, message, response.ToTimeEntryResponse(entry))
+}
+
+// handleError maps time entry errors to HTTP responses
+func (h *TimeEntryHandler) handleError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrTimeEntryNotFound):
+		NotFoundResponse(c, "Time entry not found")
+	case errors.Is(err, domain.ErrProjectNotFound):
+		NotFoundResponse(c, "Project not found")
+	case errors.Is(err, domain.ErrAttendanceNotFound):
+		NotFoundResponse(c, "No attendance found for this day")
+	case errors.Is(err, domain.ErrUnauthorized):
+		ForbiddenResponse(c, "You can only change your own time entries")
+	case errors.Is(err, domain.ErrAttendanceLocked):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Attendance of this day is locked by an approved timesheet",
+		})
+	case errors.Is(err, domain.ErrTimeEntryExceedsWorkHours), errors.Is(err, domain.ErrProjectInactive),
+		errors.Is(err, domain.ErrInvalidTimeEntryHours), errors.Is(err, domain.ErrInvalidTimeEntryProject),
+		errors.Is(err, domain.ErrInvalidDateRange):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+": "+err.Error())
+	}
+}
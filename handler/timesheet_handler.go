@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimesheetHandler handles HTTP requests related to weekly timesheets
+type TimesheetHandler struct {
+	timesheetService domain.TimesheetServiceInterface
+}
+
+// NewTimesheetHandler creates a new instance of TimesheetHandler
+func NewTimesheetHandler(timesheetService domain.TimesheetServiceInterface) *TimesheetHandler {
+	return &TimesheetHandler{
+		timesheetService: timesheetService,
+	}
+}
+
+// GetMyWeek retrieves the timesheet of the authenticated user.
+// Query parameter: week (any day of the week, YYYY-MM-DD, defaults to today)
+func (h *TimesheetHandler) GetMyWeek(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	h.getWeek(c, userID, userID)
+}
+
+// GetUserWeek retrieves the timesheet of another user for their manager.
+// Query parameter: week (any day of the week, YYYY-MM-DD, defaults to today)
+func (h *TimesheetHandler) GetUserWeek(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	viewerID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	h.getWeek(c, uint(userID), viewerID)
+}
+
+// GetMyTimesheets retrieves the stored timesheets of the authenticated user
+func (h *TimesheetHandler) GetMyTimesheets(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	timesheets, err := h.timesheetService.GetUserTimesheets(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get timesheets: "+err.Error())
+		return
+	}
+
+	listResp := response.TimesheetListResponse{
+		Timesheets: response.ToTimesheetResponseList(timesheets),
+		Total:      len(timesheets),
+	}
+	SuccessResponse(c, http.StatusOK, "Timesheets retrieved successfully", listResp)
+}
+
+// AnnotateMyWeek stores the notes of the authenticated user on a week
+func (h *TimesheetHandler) AnnotateMyWeek(c *gin.Context) {
+	var req request.TimesheetNotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	dayNotes := make([]domain.TimesheetDayNote, len(req.DayNotes))
+	for i, dayNote := range req.DayNotes {
+		dayNotes[i] = domain.TimesheetDayNote{Date: dayNote.Date, Note: dayNote.Note}
+	}
+
+	timesheet, err := h.timesheetService.Annotate(userID, req.Week, req.Note, dayNotes)
+	if err != nil {
+		h.handleError(c, err, "annotate timesheet")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Timesheet notes saved successfully", response.ToTimesheetResponse(timesheet))
+}
+
+// SubmitMyWeek submits the timesheet of the authenticated user for approval
+func (h *TimesheetHandler) SubmitMyWeek(c *gin.Context) {
+	var req request.TimesheetWeekRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	timesheet, err := h.timesheetService.Submit(userID, req.Week)
+	if err != nil {
+		h.handleError(c, err, "submit timesheet")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Timesheet submitted successfully", response.ToTimesheetResponse(timesheet))
+}
+
+// GetPendingTimesheets retrieves the submitted timesheets the authenticated user may review
+func (h *TimesheetHandler) GetPendingTimesheets(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	timesheets, err := h.timesheetService.GetPendingTimesheets(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get pending timesheets: "+err.Error())
+		return
+	}
+
+	listResp := response.TimesheetListResponse{
+		Timesheets: response.ToTimesheetResponseList(timesheets),
+		Total:      len(timesheets),
+	}
+	SuccessResponse(c, http.StatusOK, "Pending timesheets retrieved successfully", listResp)
+}
+
+// GetTimesheet retrieves a timesheet by ID for its owner or their manager
+func (h *TimesheetHandler) GetTimesheet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid timesheet ID")
+		return
+	}
+
+	viewerID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	timesheet, err := h.timesheetService.GetTimesheetByID(uint(id), viewerID)
+	if err != nil {
+		h.handleError(c, err, "view this timesheet")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Timesheet retrieved successfully", response.ToTimesheetResponse(timesheet))
+}
+
+// ApproveTimesheet approves a submitted timesheet and locks its attendance
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	h.review(c, h.timesheetService.Approve, "approve timesheet", "Timesheet approved successfully")
+}
+
+// ReturnTimesheet sends a submitted timesheet back to the employee
+func (h *TimesheetHandler) ReturnTimesheet(c *gin.Context) {
+	h.review(c, h.timesheetService.Return, "return timesheet", "Timesheet returned successfully")
+}
+
+// getWeek parses the week query parameter and responds with the timesheet of that week
+func (h *TimesheetHandler) getWeek(c *gin.Context, userID uint, viewerID uint) {
+	week := time.Now().UTC()
+	if weekStr := c.Query("week"); weekStr != "" {
+		parsed, err := time.Parse("2006-01-02", weekStr)
+		if err != nil {
+			BadRequestResponse(c, "Invalid week format, use YYYY-MM-DD")
+			return
+		}
+		week = parsed
+	}
+
+	timesheet, err := h.timesheetService.GetWeek(userID, viewerID, week)
+	if err != nil {
+		h.handleError(c, err, "view this timesheet")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Timesheet retrieved successfully", response.ToTimesheetResponse(timesheet))
+}
+
+// review runs an approve or return action on a submitted timesheet
+func (h *TimesheetHandler) review(c *gin.Context, action func(id uint, approverID uint, note string) (*domain.Timesheet, error), verb string, message string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		BadRequestResponse(c, "Invalid timesheet ID")
+		return
+	}
+
+	var req request.TimesheetReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestResponse(c, "Invalid request data: "+err.Error())
+			return
+		}
+	}
+
+	approverID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User ID not found in token")
+		return
+	}
+
+	timesheet, err := action(uint(id), approverID, req.Note)
+	if err != nil {
+		h.handleError(c, err, verb)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, message, response.ToTimesheetResponse(timesheet))
+}
+
+// handleError maps timesheet errors to HTTP responses
+func (h *TimesheetHandler) handleError(c *gin.Context, err error, verb string) {
+	switch {
+	case errors.Is(err, domain.ErrTimesheetNotFound):
+		NotFoundResponse(c, "Timesheet not found")
+	case errors.Is(err, domain.ErrUserNotFound):
+		NotFoundResponse(c, "User not found")
+	case errors.Is(err, domain.ErrTimesheetNotEditable):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Timesheet has already been submitted or approved",
+		})
+	case errors.Is(err, domain.ErrTimesheetNotSubmitted):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Timesheet is not awaiting approval",
+		})
+	case errors.Is(err, domain.ErrTimesheetOpenSession):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Message: "Check out of every day of the week before submitting",
+		})
+	case errors.Is(err, domain.ErrSelfApproval):
+		ForbiddenResponse(c, "You cannot review your own timesheet")
+	case errors.Is(err, domain.ErrUnauthorized):
+		ForbiddenResponse(c, "You are not allowed to "+verb)
+	case errors.Is(err, domain.ErrTimesheetWeekNotEnded), errors.Is(err, domain.ErrInvalidTimesheetDay):
+		BadRequestResponse(c, err.Error())
+	default:
+		InternalServerErrorResponse(c, "Failed to "+verb+": "+err.Error())
+	}
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"net/http"
+
+	"hrm/domain"
+	"hrm/handler/request"
+	"hrm/handler/response"
+	"hrm/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorHandler handles HTTP requests related to TOTP two-factor authentication:
+// the second step of signing in and managing the authenticator app enrollment
+type TwoFactorHandler struct {
+	twoFactorService  domain.TwoFactorServiceInterface
+	authService       domain.AuthServiceInterface
+	attendanceService domain.AttendanceServiceInterface
+}
+
+// NewTwoFactorHandler creates a new instance of TwoFactorHandler
+func NewTwoFactorHandler(twoFactorService domain.TwoFactorServiceInterface, authService domain.AuthServiceInterface, attendanceService domain.AttendanceServiceInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService:  twoFactorService,
+		authService:       authService,
+		attendanceService: attendanceService,
+	}
+}
+
+// CompleteSignIn handles the second step of signing in: a challenge token from POST /api/users/signin
+// together with a TOTP or recovery code. It returns the same response as a sign-in without 2FA.
+func (h *TwoFactorHandler) CompleteSignIn(c *gin.Context) {
+	var req request.TwoFactorSignInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	result, err := h.twoFactorService.CompleteSignIn(req.ChallengeToken, req.Code, authClient(c))
+	if err != nil {
+		if signInBlockedResponse(c, err) {
+			return
+		}
+
+		switch err {
+		case domain.ErrInvalidTwoFactorCode, domain.ErrInvalidTwoFactorChallenge:
+			UnauthorizedResponse(c, err.Error())
+		case domain.ErrUserInactive:
+			UnauthorizedResponse(c, "Account is deactivated")
+		case domain.ErrTOTPCredentialNotFound:
+			BadRequestResponse(c, "Set up an authenticator app first")
+		default:
+			InternalServerErrorResponse(c, "Failed to sign in")
+		}
+		return
+	}
+
+	respondSignedIn(c, h.authService, h.attendanceService, result.User, result.RecoveryCodes)
+}
+
+// SetupForSignIn handles requests to set up an authenticator app during a sign-in whose
+// challenge requires it. The code from the app then completes the sign-in.
+func (h *TwoFactorHandler) SetupForSignIn(c *gin.Context) {
+	var req request.TwoFactorChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	enrollment, err := h.twoFactorService.StartChallengeSetup(req.ChallengeToken)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidTwoFactorChallenge:
+			UnauthorizedResponse(c, err.Error())
+		case domain.ErrTwoFactorSetupNotRequired, domain.ErrTwoFactorAlreadyEnabled:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to start two-factor setup")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Add the secret to your authenticator app", response.ToTOTPEnrollmentResponse(enrollment))
+}
+
+// GetMyStatus handles requests for the authenticated user's two-factor authentication setup
+func (h *TwoFactorHandler) GetMyStatus(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	status, err := h.twoFactorService.GetStatus(userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			NotFoundResponse(c, "User not found")
+			return
+		}
+		InternalServerErrorResponse(c, "Failed to get two-factor status")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Two-factor status retrieved successfully", response.ToTwoFactorStatusResponse(status))
+}
+
+// StartMyEnrollment handles requests to add an authenticator app. 2FA is enabled once a code
+// from the app is confirmed; starting again replaces an unconfirmed secret.
+func (h *TwoFactorHandler) StartMyEnrollment(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	enrollment, err := h.twoFactorService.StartEnrollment(userID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrTwoFactorAlreadyEnabled:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to start two-factor setup")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Add the secret to your authenticator app", response.ToTOTPEnrollmentResponse(enrollment))
+}
+
+// ConfirmMyEnrollment handles requests to enable 2FA with a first code from the authenticator app.
+// The recovery codes in the response are only shown this once.
+func (h *TwoFactorHandler) ConfirmMyEnrollment(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req request.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	codes, err := h.twoFactorService.ConfirmEnrollment(userID, req.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidTwoFactorCode, domain.ErrTOTPCredentialNotFound:
+			BadRequestResponse(c, err.Error())
+		case domain.ErrTwoFactorAlreadyEnabled:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to enable two-factor authentication")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Two-factor authentication enabled", response.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// RegenerateMyRecoveryCodes handles requests to replace all recovery codes, confirmed with a current code
+func (h *TwoFactorHandler) RegenerateMyRecoveryCodes(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req request.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(userID, req.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidTwoFactorCode, domain.ErrTwoFactorNotEnabled:
+			BadRequestResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to generate recovery codes")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Recovery codes generated", response.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMine handles requests to turn 2FA off, confirmed with a current code
+func (h *TwoFactorHandler) DisableMine(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req request.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := h.twoFactorService.Disable(userID, req.Code); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrInvalidTwoFactorCode, domain.ErrTwoFactorNotEnabled:
+			BadRequestResponse(c, err.Error())
+		case domain.ErrTwoFactorRequired:
+			c.JSON(http.StatusForbidden, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to disable two-factor authentication")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// ResetUser handles requests to remove a user's 2FA setup, e.g. after they lost their authenticator app
+// and recovery codes
+func (h *TwoFactorHandler) ResetUser(c *gin.Context) {
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.twoFactorService.Reset(uriReq.ID); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrTwoFactorNotEnabled:
+			BadRequestResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to reset two-factor authentication")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Two-factor authentication reset", nil)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"hrm/domain"
 	"hrm/handler/request"
@@ -20,15 +24,21 @@ import (
 // - Handling HTTP-specific errors
 type UserHandler struct {
 	userService       domain.UserServiceInterface       // Dependency on user business logic
+	authService       domain.AuthServiceInterface       // Dependency on sign-in session business logic
+	accountService    domain.AccountServiceInterface    // Dependency on password reset and email verification business logic
+	twoFactorService  domain.TwoFactorServiceInterface  // Dependency on two-factor authentication business logic
 	attendanceService domain.AttendanceServiceInterface // Dependency on attendance business logic
 }
 
 // NewUserHandler creates a new UserHandler instance.
 // This function acts as a constructor and ensures proper dependency injection.
 // It takes a user service interface, making it easy to test with mock services.
-func NewUserHandler(userService domain.UserServiceInterface, attendanceService domain.AttendanceServiceInterface) *UserHandler {
+func NewUserHandler(userService domain.UserServiceInterface, authService domain.AuthServiceInterface, accountService domain.AccountServiceInterface, twoFactorService domain.TwoFactorServiceInterface, attendanceService domain.AttendanceServiceInterface) *UserHandler {
 	return &UserHandler{
 		userService:       userService,
+		authService:       authService,
+		accountService:    accountService,
+		twoFactorService:  twoFactorService,
 		attendanceService: attendanceService,
 	}
 }
@@ -37,24 +47,57 @@ func NewUserHandler(userService domain.UserServiceInterface, attendanceService d
 // This function configures the routing for all user operations including:
 // - User registration (POST /api/users/signup)
 // - User authentication (POST /api/users/signin)
+// - Token refresh (POST /api/users/refresh)
+// - Sign-out of the current device or all devices (POST /api/users/logout, /api/users/logout-all) - requires JWT
+// - Password reset (POST /api/users/password/forgot, /api/users/password/reset)
+// - Password policy (GET /api/users/password/policy)
+// - Password change (PUT /api/users/me/password) - requires JWT
+// - Email verification (POST /api/users/email/verify, /api/users/me/verification-email - requires JWT)
 // - Current user profile (GET /api/users/me) - requires JWT
+// - Signed-in devices (GET /api/users/me/sessions) - requires JWT
 // - User retrieval (GET /api/users/:id)
-// - User updates (PUT /api/users/:id)
-// - User deletion (DELETE /api/users/:id)
+// - User updates (PUT /api/users/:id) - requires an admin
+// - User deletion (DELETE /api/users/:id) - requires an admin
+// - User activation (PUT /api/users/:id/active) - requires an admin
+// - Kiosk credentials (GET, PUT /api/users/:id/kiosk-credentials) - requires an admin
+// - Own sign-in history (GET /api/users/me/sign-ins) - requires JWT
+// - Sign-in history of all or one user (GET /api/users/sign-ins, /api/users/:id/sign-ins) - requires a security admin
+// - Account unlock (POST /api/users/:id/unlock) - requires a security admin
+// - Role assignment (PUT /api/users/:id/role) - requires an admin
 // - User listing (GET /api/users)
-func SetupUserRoutes(router *gin.Engine, userService domain.UserServiceInterface, attendanceService domain.AttendanceServiceInterface) {
-	handler := NewUserHandler(userService, attendanceService)
+func SetupUserRoutes(router *gin.Engine, userService domain.UserServiceInterface, authService domain.AuthServiceInterface, accountService domain.AccountServiceInterface, twoFactorService domain.TwoFactorServiceInterface, attendanceService domain.AttendanceServiceInterface) {
+	handler := NewUserHandler(userService, authService, accountService, twoFactorService, attendanceService)
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+	requireSecurityAdmin := middleware.RequireRole(domain.RoleAdmin, domain.RoleSecurityAdmin)
 
 	// Group all user routes under /api/users
 	users := router.Group("/api/users")
 	{
-		users.POST("/signup", handler.SignUp)                                    // Register new user
-		users.POST("/signin", handler.SignIn)                                    // Authenticate user
-		users.GET("/me", middleware.JWTAuthMiddleware(), handler.GetCurrentUser) // Get current user (requires JWT)
-		users.GET("/:id", handler.GetUserByID)                                   // Get user by ID
-		users.PUT("/:id", handler.UpdateUser)                                    // Update user
-		users.DELETE("/:id", handler.DeleteUser)                                 // Delete user
-		users.GET("/", handler.ListUsers)                                        // List users with pagination
+		users.POST("/signup", handler.SignUp)                                                                          // Register new user
+		users.POST("/signin", handler.SignIn)                                                                          // Authenticate user
+		users.POST("/refresh", handler.RefreshToken)                                                                   // Exchange a refresh token for new tokens
+		users.POST("/logout", middleware.JWTAuthMiddleware(), handler.Logout)                                          // Sign out the current device (requires JWT)
+		users.POST("/logout-all", middleware.JWTAuthMiddleware(), handler.LogoutAll)                                   // Sign out all devices (requires JWT)
+		users.POST("/password/forgot", handler.ForgotPassword)                                                         // Mail a password reset link
+		users.POST("/password/reset", handler.ResetPassword)                                                           // Set a new password with a reset token
+		users.GET("/password/policy", handler.GetPasswordPolicy)                                                       // Rules for new passwords
+		users.PUT("/me/password", middleware.JWTAuthMiddleware(), handler.ChangePassword)                              // Change the password (requires JWT)
+		users.POST("/email/verify", handler.VerifyEmail)                                                               // Verify the email address with a mailed token
+		users.POST("/me/verification-email", middleware.JWTAuthMiddleware(), handler.SendVerificationEmail)            // Mail a new verification link (requires JWT)
+		users.GET("/me", middleware.JWTAuthMiddleware(), handler.GetCurrentUser)                                       // Get current user (requires JWT)
+		users.GET("/me/sessions", middleware.JWTAuthMiddleware(), handler.GetMySessions)                               // List signed-in devices (requires JWT)
+		users.GET("/me/sign-ins", middleware.JWTAuthMiddleware(), handler.GetMySignInHistory)                          // Own sign-in history (requires JWT)
+		users.GET("/sign-ins", middleware.JWTAuthMiddleware(), requireSecurityAdmin, handler.ListSignInAttempts)       // Sign-in history of all users (requires security admin)
+		users.GET("/:id", handler.GetUserByID)                                                                         // Get user by ID
+		users.PUT("/:id", middleware.JWTAuthMiddleware(), requireAdmin, handler.UpdateUser)                            // Update user (requires admin)
+		users.DELETE("/:id", middleware.JWTAuthMiddleware(), requireAdmin, handler.DeleteUser)                         // Delete user (requires admin)
+		users.PUT("/:id/active", middleware.JWTAuthMiddleware(), requireAdmin, handler.SetUserActive)                  // Activate or deactivate user (requires admin)
+		users.GET("/:id/kiosk-credentials", middleware.JWTAuthMiddleware(), requireAdmin, handler.GetKioskCredentials) // Get kiosk code and PIN lockout (requires admin)
+		users.PUT("/:id/kiosk-credentials", middleware.JWTAuthMiddleware(), requireAdmin, handler.SetKioskCredentials) // Assign kiosk code, PIN and badge (requires admin)
+		users.GET("/:id/sign-ins", middleware.JWTAuthMiddleware(), requireSecurityAdmin, handler.GetUserSignInHistory) // Sign-in history of a user (requires security admin)
+		users.POST("/:id/unlock", middleware.JWTAuthMiddleware(), requireSecurityAdmin, handler.UnlockUser)            // Lift a sign-in or kiosk PIN lockout (requires security admin)
+		users.PUT("/:id/role", middleware.JWTAuthMiddleware(), requireAdmin, handler.SetUserRole)                      // Assign a role (requires admin)
+		users.GET("/", handler.ListUsers)                                                                              // List users with pagination
 	}
 }
 
@@ -63,8 +106,9 @@ func SetupUserRoutes(router *gin.Engine, userService domain.UserServiceInterface
 // 1. Parses and validates the JSON request body
 // 2. Converts the request to a domain User object
 // 3. Calls the business logic to create the user
-// 4. Generates a JWT token for the newly created user
-// 5. Returns appropriate HTTP response with user data and token
+// 4. Mails a link to verify the email address
+// 5. Returns a two-factor challenge if the new user's role requires 2FA
+// 6. Otherwise starts a session and returns user data and tokens
 func (h *UserHandler) SignUp(c *gin.Context) {
 	// Step 1: Parse and validate the JSON request body
 	var req request.SignUpRequest
@@ -82,6 +126,10 @@ func (h *UserHandler) SignUp(c *gin.Context) {
 
 	// Step 3: Call business logic to create user
 	if err := h.userService.SignUp(user); err != nil {
+		if passwordPolicyResponse(c, err) {
+			return
+		}
+
 		// Handle different types of business errors
 		switch err {
 		case domain.ErrUserAlreadyExists:
@@ -94,18 +142,32 @@ func (h *UserHandler) SignUp(c *gin.Context) {
 		return
 	}
 
-	// Step 4: Generate JWT token for the newly created user
-	token, err := h.userService.GenerateJWTToken(user)
+	// Step 4: Mail a verification link; the user can ask for another one if this fails
+	if err := h.accountService.SendEmailVerification(user.ID); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	// Step 5: Users whose role requires 2FA set it up before they get tokens
+	ticket, err := h.twoFactorService.BeginSignIn(user)
 	if err != nil {
-		InternalServerErrorResponse(c, "Failed to generate authentication token")
+		InternalServerErrorResponse(c, "Failed to create user")
+		return
+	}
+	if ticket != nil {
+		SuccessResponse(c, http.StatusCreated, "User created, two-factor authentication setup required", response.ToTwoFactorChallengeResponse(ticket))
 		return
 	}
 
-	// Step 5: Return success response with user data and token
+	// Step 6: Start a session and return success response with user data and tokens
+	tokens, err := h.authService.IssueTokens(user, authClient(c))
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to generate authentication token")
+		return
+	}
 	userResponse := response.ToUserResponse(user)
 	signUpResponse := response.SignUpResponse{
-		User:  userResponse,
-		Token: token,
+		User:          userResponse,
+		TokenResponse: response.ToTokenResponse(tokens),
 	}
 	SuccessResponse(c, http.StatusCreated, "User created successfully", signUpResponse)
 }
@@ -114,8 +176,8 @@ func (h *UserHandler) SignUp(c *gin.Context) {
 // This method:
 // 1. Parses and validates the JSON request body
 // 2. Calls the business logic to authenticate the user
-// 3. Generates a JWT token for the authenticated user
-// 4. Returns appropriate HTTP response with user data and token
+// 3. Returns a two-factor challenge for accounts that use or must set up 2FA
+// 4. Otherwise starts a session and returns user data and tokens
 func (h *UserHandler) SignIn(c *gin.Context) {
 	// Step 1: Parse and validate the JSON request body
 	var req request.SignInRequest
@@ -125,45 +187,361 @@ func (h *UserHandler) SignIn(c *gin.Context) {
 	}
 
 	// Step 2: Call business logic to authenticate user
-	user, err := h.userService.SignIn(req.Email, req.Password)
+	user, err := h.userService.SignIn(req.Email, req.Password, authClient(c))
 	if err != nil {
+		if signInBlockedResponse(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrDirectoryUnavailable) {
+			directoryErrorResponse(c, err, "Failed to sign in")
+			return
+		}
+
 		// Handle authentication errors
 		switch err {
 		case domain.ErrInvalidCredentials:
 			UnauthorizedResponse(c, "Invalid email or password")
+		case domain.ErrUserInactive:
+			UnauthorizedResponse(c, "Account is deactivated")
 		default:
 			InternalServerErrorResponse(c, "Failed to sign in")
 		}
 		return
 	}
 
-	// Step 3: Generate JWT token for the authenticated user
-	token, err := h.userService.GenerateJWTToken(user)
+	// Step 3: Accounts with two-factor authentication get a challenge instead of tokens
+	ticket, err := h.twoFactorService.BeginSignIn(user)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to sign in")
+		return
+	}
+	if ticket != nil {
+		SuccessResponse(c, http.StatusOK, "Two-factor authentication required", response.ToTwoFactorChallengeResponse(ticket))
+		return
+	}
+
+	// Step 4: Start a session and return the tokens
+	respondSignedIn(c, h.authService, h.attendanceService, user, nil)
+}
+
+// signInBlockedResponse answers a locked account or throttled attempt with 429 and when to try again.
+// It returns false for other errors.
+func signInBlockedResponse(c *gin.Context, err error) bool {
+	var blocked *domain.SignInBlockedError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+
+	retryAfter := int(time.Until(blocked.RetryAt).Seconds()) + 1
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	code := ErrorCodeRateLimited
+	if errors.Is(err, domain.ErrAccountLocked) {
+		code = ErrorCodeAccountLocked
+	}
+	ErrorResponseWithCode(c, http.StatusTooManyRequests, code, blocked.Error())
+	return true
+}
+
+// respondSignedIn starts a session for a fully authenticated user and returns the tokens
+// together with the user's last 6 attendance records
+func respondSignedIn(c *gin.Context, authService domain.AuthServiceInterface, attendanceService domain.AttendanceServiceInterface, user *domain.User, recoveryCodes []string) {
+	// Step 1: Start a session and issue tokens for the authenticated user
+	tokens, err := authService.IssueTokens(user, authClient(c))
 	if err != nil {
 		InternalServerErrorResponse(c, "Failed to generate authentication token")
 		return
 	}
 
-	// Step 4: Get last 6 attendance records for the user
+	// Step 2: Get last 6 attendance records for the user
 	var lastAttendances []response.AttendanceResponse
-	if h.attendanceService != nil {
-		attendances, err := h.attendanceService.GetLastNAttendanceByUserID(user.ID, 6)
+	if attendanceService != nil {
+		attendances, err := attendanceService.GetLastNAttendanceByUserID(user.ID, 6)
 		if err == nil {
 			lastAttendances = response.ToAttendanceResponseList(attendances)
 		}
 		// If there's an error fetching attendance, we continue with empty list
 	}
 
-	// Step 5: Return success response with user data, token, and last attendance records
+	// Step 3: Return success response with user data, tokens, and last attendance records
 	userResponse := response.ToUserResponse(user)
 	signInResponse := response.SignInResponse{
 		User:            userResponse,
-		Token:           token,
+		TokenResponse:   response.ToTokenResponse(tokens),
 		LastAttendances: lastAttendances,
+		RecoveryCodes:   recoveryCodes,
 	}
 	SuccessResponse(c, http.StatusOK, "Sign in successful", signInResponse)
 }
 
+// RefreshToken handles requests to exchange a refresh token for a new access and refresh token.
+// This method:
+// 1. Parses and validates the JSON request body
+// 2. Calls the business logic to rotate the refresh token
+// 3. Returns appropriate HTTP response with the new tokens
+//
+// Each refresh token can be used once; presenting a used one again signs out its session.
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	// Step 1: Parse and validate the JSON request body
+	var req request.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	// Step 2: Call business logic to rotate the refresh token
+	tokens, err := h.authService.RefreshTokens(req.RefreshToken, authClient(c))
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidRefreshToken, domain.ErrRefreshTokenReused:
+			UnauthorizedResponse(c, err.Error())
+		case domain.ErrUserInactive:
+			UnauthorizedResponse(c, "Account is deactivated")
+		default:
+			InternalServerErrorResponse(c, "Failed to refresh token")
+		}
+		return
+	}
+
+	// Step 3: Return success response with the new tokens
+	SuccessResponse(c, http.StatusOK, "Token refreshed successfully", response.ToTokenResponse(tokens))
+}
+
+// Logout handles requests to sign out the device the request was made with.
+// The session's access and refresh tokens stop working immediately.
+//
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) Logout(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	sessionID, exists := middleware.GetSessionIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "Session not found in token")
+		return
+	}
+
+	if err := h.authService.Logout(userID, sessionID); err != nil {
+		switch err {
+		case domain.ErrAuthSessionNotFound:
+			NotFoundResponse(c, "Session not found")
+		default:
+			InternalServerErrorResponse(c, "Failed to sign out")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Signed out successfully", nil)
+}
+
+// LogoutAll handles requests to sign out all devices of the authenticated user, including this one.
+//
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if err := h.authService.LogoutAll(userID); err != nil {
+		InternalServerErrorResponse(c, "Failed to sign out all devices")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Signed out of all devices successfully", nil)
+}
+
+// GetMySessions handles requests to list the devices the authenticated user is signed in on.
+//
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) GetMySessions(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	sessionID, _ := middleware.GetSessionIDFromContext(c)
+
+	sessions, err := h.authService.GetActiveSessions(userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get sessions")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Sessions retrieved successfully", response.ToAuthSessionResponseList(sessions, sessionID))
+}
+
+// ForgotPassword handles requests to mail a password reset link.
+// The response is the same whether or not the email is registered, so it cannot be used to find accounts.
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := h.accountService.RequestPasswordReset(req.Email); err != nil {
+		InternalServerErrorResponse(c, "Failed to send password reset email")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword handles requests to set a new password with a token from a password reset email.
+// All sessions of the user are signed out afterwards.
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := h.accountService.ResetPassword(req.Token, req.Password); err != nil {
+		if passwordPolicyResponse(c, err) {
+			return
+		}
+
+		switch err {
+		case domain.ErrInvalidAccountToken, domain.ErrInvalidPassword:
+			BadRequestResponse(c, err.Error())
+		case domain.ErrPasswordManagedByDirectory:
+			ForbiddenResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to reset password")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Password reset successfully, please sign in with the new password", nil)
+}
+
+// ChangePassword handles requests to change the authenticated user's password.
+// This method:
+// 1. Parses and validates the JSON request body
+// 2. Calls the business logic to check the current password and save the new one
+// 3. Returns appropriate HTTP response
+//
+// Other devices are signed out; the session the request is made with stays signed in.
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	sessionID, _ := middleware.GetSessionIDFromContext(c)
+
+	// Step 1: Parse and validate the JSON request body
+	var req request.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	// Step 2: Call business logic to change the password
+	if err := h.userService.ChangePassword(userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		if signInBlockedResponse(c, err) || passwordPolicyResponse(c, err) {
+			return
+		}
+
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrIncorrectPassword:
+			BadRequestResponse(c, err.Error())
+		case domain.ErrPasswordManagedByDirectory:
+			ForbiddenResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to change password")
+		}
+		return
+	}
+
+	// Step 3: Return success response
+	SuccessResponse(c, http.StatusOK, "Password changed successfully, other devices have been signed out", nil)
+}
+
+// GetPasswordPolicy handles requests for the rules new passwords have to follow,
+// so clients can show them before the user picks a password
+func (h *UserHandler) GetPasswordPolicy(c *gin.Context) {
+	SuccessResponse(c, http.StatusOK, "Password policy retrieved successfully", response.ToPasswordPolicyResponse(h.userService.GetPasswordPolicy()))
+}
+
+// passwordPolicyResponse answers a new password the policy refuses with 400 and a WEAK_PASSWORD code.
+// It returns false for other errors.
+func passwordPolicyResponse(c *gin.Context, err error) bool {
+	var policyErr *domain.PasswordPolicyError
+	if !errors.As(err, &policyErr) && !errors.Is(err, domain.ErrPasswordReused) {
+		return false
+	}
+
+	ErrorResponseWithCode(c, http.StatusBadRequest, ErrorCodeWeakPassword, err.Error())
+	return true
+}
+
+// VerifyEmail handles requests to verify an email address with a token from a verification email
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	user, err := h.accountService.VerifyEmail(req.Token)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidAccountToken:
+			BadRequestResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to verify email")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Email verified successfully", response.ToUserResponse(user))
+}
+
+// SendVerificationEmail handles requests to mail a new verification link to the authenticated user.
+//
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) SendVerificationEmail(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	if err := h.accountService.SendEmailVerification(userID); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrEmailAlreadyVerified:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		case domain.ErrAccountTokenRecentlySent:
+			ErrorResponseWithCode(c, http.StatusTooManyRequests, ErrorCodeRateLimited, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to send verification email")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Verification email sent", nil)
+}
+
+// authClient describes the device a request comes from, for the session it signs in to
+func authClient(c *gin.Context) domain.AuthClient {
+	return domain.AuthClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 // GetCurrentUser handles requests to get the current authenticated user's profile.
 // This method:
 // 1. Extracts the authenticated user ID from the JWT token (via middleware)
@@ -251,10 +629,12 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	// Step 3: Convert request to domain User object
 	user := &domain.User{
-		ID:       uriReq.ID,
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
+		ID:         uriReq.ID,
+		Name:       req.Name,
+		Email:      req.Email,
+		Timezone:   req.Timezone,
+		LocationID: req.LocationID,
+		ManagerID:  req.ManagerID,
 	}
 
 	// Step 4: Call business logic to update user
@@ -263,7 +643,8 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		switch err {
 		case domain.ErrUserNotFound:
 			NotFoundResponse(c, "User not found")
-		case domain.ErrInvalidEmail, domain.ErrInvalidPassword, domain.ErrInvalidName:
+		case domain.ErrInvalidEmail, domain.ErrInvalidName, domain.ErrInvalidTimezone, domain.ErrLocationNotFound,
+			domain.ErrInvalidManager, domain.ErrManagerNotFound:
 			BadRequestResponse(c, err.Error())
 		default:
 			InternalServerErrorResponse(c, "Failed to update user")
@@ -305,6 +686,244 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, "User deleted successfully", nil)
 }
 
+// SetUserActive handles requests to activate or deactivate a user.
+// This method:
+// 1. Parses and validates the URL parameter (user ID) and the JSON request body
+// 2. Calls the business logic to change the user's status
+// 3. Returns appropriate HTTP response
+func (h *UserHandler) SetUserActive(c *gin.Context) {
+	// Step 1: Parse and validate the URL parameter and request body
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	var req request.SetUserActiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	// Step 2: Call business logic to change the user's status
+	if err := h.userService.SetUserActive(uriReq.ID, *req.IsActive); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		default:
+			InternalServerErrorResponse(c, "Failed to update user status")
+		}
+		return
+	}
+
+	// Step 3: Return success response
+	message := "User deactivated successfully"
+	if *req.IsActive {
+		message = "User activated successfully"
+	}
+	SuccessResponse(c, http.StatusOK, message, nil)
+}
+
+// SetKioskCredentials handles requests to assign the employee code, PIN and badge a user punches with at shared kiosks.
+// This method:
+// 1. Parses and validates the URL parameter (user ID) and the JSON request body
+// 2. Calls the business logic to store the credentials
+// 3. Returns appropriate HTTP response with the updated credentials
+func (h *UserHandler) SetKioskCredentials(c *gin.Context) {
+	// Step 1: Parse and validate the URL parameter and request body
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	var req request.SetKioskCredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	// Step 2: Call business logic to store the credentials
+	user, err := h.userService.SetKioskCredentials(uriReq.ID, req.EmployeeCode, req.PIN, req.BadgeID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrInvalidEmployeeCode, domain.ErrInvalidKioskPIN:
+			BadRequestResponse(c, err.Error())
+		case domain.ErrEmployeeCodeExists, domain.ErrBadgeIDExists:
+			c.JSON(http.StatusConflict, Response{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			InternalServerErrorResponse(c, "Failed to update kiosk credentials")
+		}
+		return
+	}
+
+	// Step 3: Return success response with the updated credentials
+	SuccessResponse(c, http.StatusOK, "Kiosk credentials updated successfully", response.ToKioskCredentialsResponse(user))
+}
+
+// GetKioskCredentials handles requests to show the employee code of a user and whether it is locked at kiosks.
+// This method:
+// 1. Parses and validates the URL parameter (user ID)
+// 2. Calls the business logic to retrieve the user
+// 3. Returns appropriate HTTP response with the kiosk credentials
+func (h *UserHandler) GetKioskCredentials(c *gin.Context) {
+	// Step 1: Parse and validate the URL parameter
+	var req request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	// Step 2: Call business logic to retrieve the user
+	user, err := h.userService.GetUserByID(req.ID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		default:
+			InternalServerErrorResponse(c, "Failed to get kiosk credentials")
+		}
+		return
+	}
+
+	// Step 3: Return success response with the credentials
+	SuccessResponse(c, http.StatusOK, "Kiosk credentials retrieved successfully", response.ToKioskCredentialsResponse(user))
+}
+
+// SetUserRole handles requests to assign a role to a user.
+// This method:
+// 1. Parses and validates the URL parameter (user ID) and the JSON request body
+// 2. Calls the business logic to assign the role
+// 3. Returns appropriate HTTP response with the updated user data
+func (h *UserHandler) SetUserRole(c *gin.Context) {
+	// Step 1: Parse and validate the URL parameter and request body
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	var req request.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestResponse(c, "Invalid request data: "+err.Error())
+		return
+	}
+
+	// Step 2: Call business logic to assign the role
+	user, err := h.userService.SetUserRole(uriReq.ID, req.Role)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		case domain.ErrInvalidRole:
+			BadRequestResponse(c, err.Error())
+		default:
+			InternalServerErrorResponse(c, "Failed to update user role")
+		}
+		return
+	}
+
+	// Step 3: Return success response with updated user data
+	SuccessResponse(c, http.StatusOK, "User role updated successfully", response.ToUserResponse(user))
+}
+
+// UnlockUser handles requests to lift a sign-in lockout before it expires.
+// The user's failed attempts are forgotten as well.
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.userService.UnlockUser(uriReq.ID); err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			NotFoundResponse(c, "User not found")
+		default:
+			InternalServerErrorResponse(c, "Failed to unlock user")
+		}
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "User unlocked successfully", nil)
+}
+
+// GetMySignInHistory handles requests to list the authenticated user's sign-in attempts, newest first.
+//
+// This endpoint requires JWT authentication via middleware.
+func (h *UserHandler) GetMySignInHistory(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req request.ListSignInAttemptsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestResponse(c, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	h.respondWithSignInAttempts(c, domain.SignInAttemptFilter{UserID: &userID, Result: req.Result}, req)
+}
+
+// GetUserSignInHistory handles requests from security admins to list a user's sign-in attempts, newest first
+func (h *UserHandler) GetUserSignInHistory(c *gin.Context) {
+	var uriReq request.GetUserByIDRequest
+	if err := c.ShouldBindUri(&uriReq); err != nil {
+		BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	var req request.ListSignInAttemptsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestResponse(c, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	h.respondWithSignInAttempts(c, domain.SignInAttemptFilter{UserID: &uriReq.ID, Result: req.Result}, req)
+}
+
+// ListSignInAttempts handles requests from security admins to search the sign-in history of all users.
+// Query parameters: user_id, email, ip and result narrow the list; attempts with unknown emails are included.
+func (h *UserHandler) ListSignInAttempts(c *gin.Context) {
+	var req request.ListSignInAttemptsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestResponse(c, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	h.respondWithSignInAttempts(c, domain.SignInAttemptFilter{
+		UserID:    req.UserID,
+		Email:     req.Email,
+		IPAddress: req.IPAddress,
+		Result:    req.Result,
+	}, req)
+}
+
+// respondWithSignInAttempts applies the pagination of the request to the filter and sends the matching attempts
+func (h *UserHandler) respondWithSignInAttempts(c *gin.Context, filter domain.SignInAttemptFilter, req request.ListSignInAttemptsRequest) {
+	filter.Limit = req.Limit
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 50
+	}
+	filter.Offset = req.Offset
+
+	attempts, err := h.userService.ListSignInAttempts(filter)
+	if err != nil {
+		InternalServerErrorResponse(c, "Failed to get sign-in history")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, "Sign-in history retrieved successfully", response.ToSignInAttemptResponseList(attempts))
+}
+
 // ListUsers handles requests to retrieve a paginated list of users.
 // This method:
 // 1. Parses and validates query parameters (limit, offset)
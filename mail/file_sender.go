@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+// FileSender implements the MailSenderInterface by writing each email to its own .eml file.
+// It is meant for local development and tests of the mail flows without an SMTP server.
+type FileSender struct {
+	dir  string
+	from string
+}
+
+// NewFileSender creates a new instance of FileSender writing to the given directory
+func NewFileSender(dir, from string) domain.MailSenderInterface {
+	return &FileSender{dir: dir, from: from}
+}
+
+// Send writes the email to a file named after the time it was sent and its recipient
+func (s *FileSender) Send(message domain.MailMessage) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(message.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), recipient)
+	return os.WriteFile(filepath.Join(s.dir, name), formatMessage(s.from, message), 0o600)
+}
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"log"
+
+	"hrm/domain"
+)
+
+// LogSender implements the MailSenderInterface by writing emails to the application log.
+// It is meant for local development, where links in the emails can be copied from the log.
+type LogSender struct{}
+
+// NewLogSender creates a new instance of LogSender
+func NewLogSender() domain.MailSenderInterface {
+	return &LogSender{}
+}
+
+// Send writes the email to the log instead of delivering it
+func (s *LogSender) Send(message domain.MailMessage) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
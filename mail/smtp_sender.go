@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+
+	"hrm/domain"
+)
+
+// SMTPSender implements the MailSenderInterface by delivering emails through an SMTP server.
+// The connection is upgraded with STARTTLS when the server offers it.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a new instance of SMTPSender.
+// Authentication is skipped when no username is given.
+func NewSMTPSender(host, port, username, password, from string) domain.MailSenderInterface {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send delivers the email to its recipient
+func (s *SMTPSender) Send(message domain.MailMessage) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", s.from, err)
+	}
+	return smtp.SendMail(s.addr, s.auth, from.Address, []string{message.To}, formatMessage(s.from, message))
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"hrm/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APITokenAuthenticator resolves the API token sent with a request and the user it acts as
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(token, clientIP string) (*domain.APIToken, *domain.User, error)
+}
+
+// apiTokenAuthenticator lets JWTAuthMiddleware accept API tokens once registered
+var apiTokenAuthenticator APITokenAuthenticator
+
+// SetAPITokenAuthenticator registers the check of API tokens. Without one, only JWT access tokens are accepted.
+func SetAPITokenAuthenticator(authenticator APITokenAuthenticator) {
+	apiTokenAuthenticator = authenticator
+}
+
+// scopeRule grants API tokens access to the routes under a path prefix
+type scopeRule struct {
+	prefix  string
+	read    string // Scope GET requests need
+	write   string // Scope other requests need
+	approve string // Scope approving, rejecting and returning requests needs; empty uses the write scope
+}
+
+// scopeRules lists the routes API tokens may use, most specific prefix first.
+// Routes no rule covers, such as account security, role and token management, refuse API tokens.
+var scopeRules = []scopeRule{
+	{"/api/v1/attendance/regularizations", domain.ScopeAttendanceRead, domain.ScopeAttendanceWrite, domain.ScopeAttendanceApprove},
+	{"/api/v1/attendance", domain.ScopeAttendanceRead, domain.ScopeAttendanceWrite, ""},
+	{"/api/v1/breaks", domain.ScopeAttendanceRead, domain.ScopeAttendanceWrite, ""},
+	{"/api/leaves", domain.ScopeLeaveRead, domain.ScopeLeaveWrite, domain.ScopeLeaveApprove},
+	{"/api/v1/overtime", domain.ScopeOvertimeRead, domain.ScopeOvertimeWrite, domain.ScopeOvertimeApprove},
+	{"/api/v1/timesheets", domain.ScopeTimesheetRead, domain.ScopeTimesheetWrite, domain.ScopeTimesheetApprove},
+	{"/api/v1/projects", domain.ScopeProjectsRead, domain.ScopeProjectsWrite, ""},
+	{"/api/v1/time-entries", domain.ScopeProjectsRead, domain.ScopeProjectsWrite, ""},
+	{"/api/v1/locations", domain.ScopeSettingsRead, domain.ScopeSettingsWrite, ""},
+	{"/api/v1/holidays", domain.ScopeSettingsRead, domain.ScopeSettingsWrite, ""},
+	{"/api/v1/break-types", domain.ScopeSettingsRead, domain.ScopeSettingsWrite, ""},
+}
+
+// scopeRoutes grants API tokens access to single routes, keyed by method and route path
+var scopeRoutes = map[string]string{
+	"GET /api/users/me":                  domain.ScopeProfileRead,
+	"POST /api/v1/attendance/user/range": domain.ScopeAttendanceRead, // A query sent as a body
+}
+
+// reviewActions are the last path segments of routes that review other users' requests
+var reviewActions = []string{"approve", "reject", "return"}
+
+// routeScope returns the scope an API token needs for a route, or false if the route refuses API tokens
+func routeScope(method, routePath string) (string, bool) {
+	if scope, ok := scopeRoutes[method+" "+routePath]; ok {
+		return scope, true
+	}
+
+	path := strings.TrimSuffix(routePath, "/")
+	for _, rule := range scopeRules {
+		if path != rule.prefix && !strings.HasPrefix(path, rule.prefix+"/") {
+			continue
+		}
+		if method == http.MethodGet {
+			return rule.read, true
+		}
+		action := path[strings.LastIndex(path, "/")+1:]
+		for _, review := range reviewActions {
+			if action == review && rule.approve != "" {
+				return rule.approve, true
+			}
+		}
+		return rule.write, true
+	}
+	return "", false
+}
+
+// authenticateAPIToken authenticates a request made with an API token instead of a JWT access token.
+// The token acts as its user, but only on routes that accept one of its scopes.
+func authenticateAPIToken(c *gin.Context, token string) {
+	// Step 1: Resolve the token and its user
+	if apiTokenAuthenticator == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Invalid or expired token",
+		})
+		c.Abort()
+		return
+	}
+	apiToken, user, err := apiTokenAuthenticator.AuthenticateAPIToken(token, c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidAPIToken):
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Invalid, expired or revoked API token",
+			})
+		case errors.Is(err, domain.ErrUserInactive):
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "User account is deactivated",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to verify API token",
+			})
+		}
+		c.Abort()
+		return
+	}
+
+	// Step 2: Check that the route accepts the token's scopes
+	scope, ok := routeScope(c.Request.Method, c.FullPath())
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"code":    "API_TOKEN_NOT_ALLOWED",
+			"error":   "This endpoint cannot be used with API tokens",
+		})
+		c.Abort()
+		return
+	}
+	if !apiToken.HasScope(scope) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"code":    "INSUFFICIENT_SCOPE",
+			"error":   "The API token lacks the " + scope + " scope",
+		})
+		c.Abort()
+		return
+	}
+
+	// Step 3: Set user information in the context
+	c.Set("user_id", user.ID)
+	c.Set("user_email", user.Email)
+	c.Set("api_token_id", apiToken.ID)
+
+	c.Next()
+}
+
+// GetAPITokenIDFromContext extracts the ID of the API token a request was authenticated with from the Gin context.
+// It returns false for requests made with a JWT access token.
+func GetAPITokenIDFromContext(c *gin.Context) (uint, bool) {
+	tokenID, exists := c.Get("api_token_id")
+	if !exists {
+		return 0, false
+	}
+
+	id, ok := tokenID.(uint)
+	return id, ok
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailVerifier reports whether a user has verified their email address
+type EmailVerifier interface {
+	IsEmailVerified(userID uint) (bool, error)
+}
+
+// emailVerifier guards the routes that require a verified email once registered
+var emailVerifier EmailVerifier
+
+// SetEmailVerifier registers the check behind RequireVerifiedEmail. Without one, the middleware lets every request through.
+func SetEmailVerifier(verifier EmailVerifier) {
+	emailVerifier = verifier
+}
+
+// RequireVerifiedEmail rejects requests of users who have not verified their email address yet.
+// It must run after JWTAuthMiddleware.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if emailVerifier == nil {
+			c.Next()
+			return
+		}
+
+		userID, exists := GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		verified, err := emailVerifier.IsEmailVerified(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to check email verification",
+			})
+			c.Abort()
+			return
+		}
+		if !verified {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"code":    "EMAIL_NOT_VERIFIED",
+				"error":   "Please verify your email address first",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,27 +1,58 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"strings"
 
+	"hrm/domain"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // JWTClaims represents the claims in a JWT token
 type JWTClaims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID uint   `json:"sid"` // Sign-in session the access token was issued for
 	jwt.RegisteredClaims
 }
 
+// SessionValidator checks that the sign-in session an access token was issued for is still active
+type SessionValidator interface {
+	ValidateSession(userID, sessionID uint) error
+}
+
+// sessionValidator is consulted on every authenticated request once registered
+var sessionValidator SessionValidator
+
+// SetSessionValidator registers the check that rejects access tokens of revoked sessions
+func SetSessionValidator(validator SessionValidator) {
+	sessionValidator = validator
+}
+
+// KeyResolver returns the key that verifies a token signed with the given key ID and algorithm
+type KeyResolver interface {
+	VerificationKey(kid, algorithm string) (interface{}, error)
+}
+
+// keyResolver verifies token signatures once registered; without one, tokens are verified with JWT_SECRET
+var keyResolver KeyResolver
+
+// SetKeyResolver registers the lookup of token verification keys, which allows RS256 and EdDSA tokens
+func SetKeyResolver(resolver KeyResolver) {
+	keyResolver = resolver
+}
+
 // JWTAuthMiddleware validates JWT tokens and extracts user information
 // This middleware:
-// 1. Extracts the JWT token from the Authorization header
+// 1. Extracts the JWT token from the Authorization header; API tokens are checked by authenticateAPIToken instead
 // 2. Validates the token signature and expiration
 // 3. Extracts user information from the token
-// 4. Sets user information in the context for handlers to use
+// 4. Rejects tokens whose session has been revoked
+// 5. Sets user information in the context for handlers to use
 func JWTAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Step 1: Get the Authorization header
@@ -45,15 +76,14 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if strings.HasPrefix(tokenString, domain.APITokenPrefix) {
+			authenticateAPIToken(c, tokenString)
+			return
+		}
 
 		// Step 3: Parse and validate the JWT token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate the signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, verificationKey,
+			jwt.WithValidMethods([]string{domain.SigningAlgHS256, domain.SigningAlgRS256, domain.SigningAlgEdDSA}))
 
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -75,14 +105,53 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Step 5: Set user information in the context
+		// Step 5: Reject tokens of sessions that were signed out or revoked
+		if sessionValidator != nil {
+			err := domain.ErrAuthSessionRevoked
+			if claims.SessionID != 0 {
+				err = sessionValidator.ValidateSession(claims.UserID, claims.SessionID)
+			}
+			if errors.Is(err, domain.ErrAuthSessionRevoked) || errors.Is(err, domain.ErrAuthSessionNotFound) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"success": false,
+					"error":   "Session has been revoked, please sign in again",
+				})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"success": false,
+					"error":   "Failed to verify session",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// Step 6: Set user information in the context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
 }
 
+// verificationKey picks the key a token is verified with from its "kid" and "alg" headers
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	if keyResolver == nil {
+		// Validate the signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	return keyResolver.VerificationKey(kid, token.Method.Alg())
+}
+
 // GetUserIDFromContext extracts the user ID from the Gin context
 // This helper function is used by handlers to get the authenticated user's ID
 func GetUserIDFromContext(c *gin.Context) (uint, bool) {
@@ -106,3 +175,15 @@ func GetUserEmailFromContext(c *gin.Context) (string, bool) {
 	email, ok := userEmail.(string)
 	return email, ok
 }
+
+// GetSessionIDFromContext extracts the sign-in session ID of the access token from the Gin context
+// This helper function is used by handlers that act on the current session, such as logout
+func GetSessionIDFromContext(c *gin.Context) (uint, bool) {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		return 0, false
+	}
+
+	id, ok := sessionID.(uint)
+	return id, ok && id != 0
+}
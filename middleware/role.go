@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleResolver returns the role of a user
+type RoleResolver interface {
+	GetUserRole(userID uint) (string, error)
+}
+
+// roleResolver looks up the role of the authenticated user for RequireRole
+var roleResolver RoleResolver
+
+// SetRoleResolver registers the role lookup behind RequireRole. Without one, RequireRole refuses every request.
+func SetRoleResolver(resolver RoleResolver) {
+	roleResolver = resolver
+}
+
+// RequireRole only lets users with one of the given roles through.
+// The role is read on every request, so role changes take effect immediately.
+// It must run after JWTAuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		if roleResolver == nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error":   "You do not have permission to perform this action",
+			})
+			c.Abort()
+			return
+		}
+
+		role, err := roleResolver.GetUserRole(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to check permissions",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Set("user_role", role)
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "You do not have permission to perform this action",
+		})
+		c.Abort()
+	}
+}
@@ -0,0 +1,317 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hrm/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// httpTimeout bounds every request to the identity provider
+	httpTimeout = 10 * time.Second
+
+	// maxResponseBytes caps how much of a provider response is read
+	maxResponseBytes = 1 << 20
+
+	// clockSkew is the leeway allowed when checking ID token times
+	clockSkew = time.Minute
+)
+
+// idTokenAlgorithms are the ID token signature algorithms accepted; "none" and HMAC never are
+var idTokenAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}
+
+// providerMetadata is the part of the discovery document HRM needs (OpenID Connect Discovery 1.0)
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// tokenResponse is the token endpoint's answer to an authorization code grant
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Provider implements the OIDCProviderInterface for a standards compliant OpenID Connect provider.
+// Endpoints are discovered from the issuer on first use, so the application starts even while the provider is down.
+type Provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	groupsClaim  string
+	httpClient   *http.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata
+	keys     *keySet
+}
+
+// NewProvider creates a new instance of Provider.
+// Without a client secret the client is treated as public and authenticates with PKCE alone.
+func NewProvider(issuer, clientID, clientSecret, redirectURL string, scopes []string, groupsClaim string) domain.OIDCProviderInterface {
+	httpClient := &http.Client{Timeout: httpTimeout}
+	return &Provider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		groupsClaim:  groupsClaim,
+		httpClient:   httpClient,
+		keys:         &keySet{httpClient: httpClient},
+	}
+}
+
+// AuthorizationURL returns the provider URL that starts an authorization code flow with PKCE (S256)
+func (p *Provider) AuthorizationURL(state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", strings.Join(p.scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the claims of the verified ID token.
+// Claims missing from the ID token are taken from the userinfo endpoint when the provider has one.
+func (p *Provider) Exchange(code, codeVerifier, nonce string) (*domain.OIDCClaims, error) {
+	metadata, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Redeem the code together with the PKCE verifier
+	tokens, err := p.redeemCode(metadata, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Verify the ID token's signature, issuer, audience, expiry and nonce
+	claims, err := p.verifyIDToken(metadata, tokens.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Fill in what the ID token left out from the userinfo endpoint
+	if (claims.Email == "" || claims.Groups == nil) && metadata.UserinfoEndpoint != "" && tokens.AccessToken != "" {
+		if err := p.mergeUserinfo(metadata, tokens.AccessToken, claims); err != nil {
+			return nil, err
+		}
+	}
+
+	return claims, nil
+}
+
+// discover fetches and caches the provider's discovery document
+func (p *Provider) discover() (*providerMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var metadata providerMetadata
+	if err := fetchJSON(p.httpClient, p.issuer+"/.well-known/openid-configuration", "", &metadata); err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", metadata.Issuer, p.issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing the authorization, token or JWKS endpoint")
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// redeemCode calls the token endpoint with the authorization code grant
+func (p *Provider) redeemCode(metadata *providerMetadata, code, codeVerifier string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.clientID)
+
+	req, err := http.NewRequest(http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		// client_secret_basic; both halves are form encoded first (RFC 6749 section 2.3.1)
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tokens tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("token endpoint returned %s with an unreadable body: %w", resp.Status, err)
+	}
+	if tokens.Error != "" {
+		return nil, fmt.Errorf("token endpoint refused the code: %s %s", tokens.Error, tokens.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token endpoint returned no ID token")
+	}
+
+	return &tokens, nil
+}
+
+// verifyIDToken checks the ID token and extracts the claims HRM uses
+func (p *Provider) verifyIDToken(metadata *providerMetadata, idToken, nonce string) (*domain.OIDCClaims, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.publicKey(metadata.JWKSURI, kid)
+	}
+
+	token, err := jwt.Parse(idToken, keyFunc,
+		jwt.WithValidMethods(idTokenAlgorithms),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid ID token claims")
+	}
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("ID token nonce does not match the sign-in")
+	}
+	if azp, ok := mapClaims["azp"].(string); ok && azp != p.clientID {
+		return nil, fmt.Errorf("ID token was issued to %q", azp)
+	}
+
+	claims := p.claimsFrom(mapClaims)
+	if claims.Subject == "" {
+		return nil, errors.New("ID token has no subject")
+	}
+	claims.Issuer = metadata.Issuer
+	return claims, nil
+}
+
+// mergeUserinfo adds the claims the ID token did not carry from the userinfo endpoint
+func (p *Provider) mergeUserinfo(metadata *providerMetadata, accessToken string, claims *domain.OIDCClaims) error {
+	var raw map[string]interface{}
+	if err := fetchJSON(p.httpClient, metadata.UserinfoEndpoint, accessToken, &raw); err != nil {
+		return fmt.Errorf("userinfo request failed: %w", err)
+	}
+
+	userinfo := p.claimsFrom(raw)
+	if userinfo.Subject != claims.Subject {
+		return errors.New("userinfo is for a different subject than the ID token")
+	}
+
+	if claims.Email == "" {
+		claims.Email = userinfo.Email
+		claims.EmailVerified = userinfo.EmailVerified
+	}
+	if claims.Name == "" {
+		claims.Name = userinfo.Name
+	}
+	if claims.Groups == nil {
+		claims.Groups = userinfo.Groups
+	}
+	return nil
+}
+
+// claimsFrom reads the standard claims and the configured groups claim
+func (p *Provider) claimsFrom(raw map[string]interface{}) *domain.OIDCClaims {
+	claims := &domain.OIDCClaims{}
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.Name, _ = raw["name"].(string)
+
+	// Some providers send email_verified as a string
+	switch verified := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = verified
+	case string:
+		claims.EmailVerified = verified == "true"
+	}
+
+	// A single group may come as a plain string
+	switch groups := raw[p.groupsClaim].(type) {
+	case []interface{}:
+		claims.Groups = []string{}
+		for _, group := range groups {
+			if name, ok := group.(string); ok {
+				claims.Groups = append(claims.Groups, name)
+			}
+		}
+	case string:
+		claims.Groups = []string{groups}
+	}
+
+	return claims
+}
+
+// fetchJSON fetches a JSON document and decodes it into out, with a bearer token when one is given
+func fetchJSON(httpClient *http.Client, target, bearerToken string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", target, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(out)
+}
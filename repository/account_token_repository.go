@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountTokenRepository implements the AccountTokenRepositoryInterface
+// This struct handles all database operations related to password reset and email verification tokens
+type AccountTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountTokenRepository creates a new instance of AccountTokenRepository
+func NewAccountTokenRepository(db *gorm.DB) domain.AccountTokenRepositoryInterface {
+	return &AccountTokenRepository{db: db}
+}
+
+// Create saves a new account token to the database
+func (r *AccountTokenRepository) Create(token *domain.AccountToken) error {
+	// Validate token data before saving
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamp
+	token.CreatedAt = time.Now().UTC()
+
+	// Save to database
+	return r.db.Create(token).Error
+}
+
+// GetByTokenHash retrieves the account token with the given hash
+func (r *AccountTokenRepository) GetByTokenHash(tokenHash string) (*domain.AccountToken, error) {
+	var token domain.AccountToken
+
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAccountTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetLatest retrieves the most recently created token of a user for a purpose
+func (r *AccountTokenRepository) GetLatest(userID uint, purpose string) (*domain.AccountToken, error) {
+	var token domain.AccountToken
+
+	err := r.db.Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC, id DESC").
+		First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAccountTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Update modifies an existing account token in the database
+func (r *AccountTokenRepository) Update(token *domain.AccountToken) error {
+	// Validate token data before updating
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Save(token)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAccountTokenNotFound
+	}
+
+	return nil
+}
+
+// InvalidateByUserID marks every unused token of a user for a purpose as used,
+// so only the token sent last keeps working
+func (r *AccountTokenRepository) InvalidateByUserID(userID uint, purpose string) error {
+	return r.db.Model(&domain.AccountToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now().UTC()).Error
+}
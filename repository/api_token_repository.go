@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APITokenRepository implements the APITokenRepositoryInterface
+// This struct handles all database operations related to personal access tokens
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAPITokenRepository creates a new instance of APITokenRepository
+func NewAPITokenRepository(db *gorm.DB) domain.APITokenRepositoryInterface {
+	return &APITokenRepository{db: db}
+}
+
+// Create saves a new API token to the database
+func (r *APITokenRepository) Create(token *domain.APIToken) error {
+	// Validate token data before saving
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	token.CreatedAt = now
+	token.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(token).Error
+}
+
+// GetByID retrieves an API token by its ID
+func (r *APITokenRepository) GetByID(id uint) (*domain.APIToken, error) {
+	var token domain.APIToken
+
+	err := r.db.First(&token, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetByTokenHash retrieves the API token with the given hash
+func (r *APITokenRepository) GetByTokenHash(tokenHash string) (*domain.APIToken, error) {
+	var token domain.APIToken
+
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetByUserID retrieves all tokens of a user, newest first
+func (r *APITokenRepository) GetByUserID(userID uint) ([]domain.APIToken, error) {
+	var tokens []domain.APIToken
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Update modifies an existing API token in the database
+func (r *APITokenRepository) Update(token *domain.APIToken) error {
+	// Validate token data before updating
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	token.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(token)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+// RecordUse stores when and from where a token was last used.
+// Only these columns are written, so a revocation made meanwhile is kept.
+func (r *APITokenRepository) RecordUse(id uint, usedAt time.Time, ipAddress string) error {
+	return r.db.Model(&domain.APIToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ipAddress,
+		}).Error
+}
+
+// RevokeAllByUserID revokes every token of a user that is not revoked yet
+func (r *APITokenRepository) RevokeAllByUserID(userID uint) error {
+	now := time.Now().UTC()
+	return r.db.Model(&domain.APIToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).Error
+}
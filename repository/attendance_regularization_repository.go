@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceRegularizationRepository implements the AttendanceRegularizationRepositoryInterface
+// This struct handles all database operations related to attendance correction requests
+type AttendanceRegularizationRepository struct {
+	db *gorm.DB
+}
+
+// NewAttendanceRegularizationRepository creates a new instance of AttendanceRegularizationRepository
+func NewAttendanceRegularizationRepository(db *gorm.DB) domain.AttendanceRegularizationRepositoryInterface {
+	return &AttendanceRegularizationRepository{db: db}
+}
+
+// Create saves a new regularization request to the database
+func (r *AttendanceRegularizationRepository) Create(regularization *domain.AttendanceRegularization) error {
+	// Validate regularization data before saving
+	if err := regularization.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	regularization.CreatedAt = now
+	regularization.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(regularization).Error
+}
+
+// GetByID retrieves a regularization request by its ID
+func (r *AttendanceRegularizationRepository) GetByID(id uint) (*domain.AttendanceRegularization, error) {
+	var regularization domain.AttendanceRegularization
+
+	err := r.db.First(&regularization, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrRegularizationNotFound
+		}
+		return nil, err
+	}
+
+	return &regularization, nil
+}
+
+// GetByUserID retrieves all regularization requests of a user, newest first
+func (r *AttendanceRegularizationRepository) GetByUserID(userID uint) ([]domain.AttendanceRegularization, error) {
+	var regularizations []domain.AttendanceRegularization
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&regularizations).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return regularizations, nil
+}
+
+// GetByStatus retrieves all regularization requests with a specific status, oldest first
+func (r *AttendanceRegularizationRepository) GetByStatus(status domain.RegularizationStatus) ([]domain.AttendanceRegularization, error) {
+	var regularizations []domain.AttendanceRegularization
+
+	err := r.db.Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&regularizations).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return regularizations, nil
+}
+
+// GetPendingByUserIDAndDate retrieves the pending regularization request of a user for a specific date
+func (r *AttendanceRegularizationRepository) GetPendingByUserIDAndDate(userID uint, date time.Time) (*domain.AttendanceRegularization, error) {
+	var regularization domain.AttendanceRegularization
+
+	err := r.db.Where("user_id = ? AND date = ? AND status = ?", userID, date, domain.RegularizationStatusPending).
+		First(&regularization).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrRegularizationNotFound
+		}
+		return nil, err
+	}
+
+	return &regularization, nil
+}
+
+// Update modifies an existing regularization request
+func (r *AttendanceRegularizationRepository) Update(regularization *domain.AttendanceRegularization) error {
+	// Validate regularization data before updating
+	if err := regularization.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	regularization.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(regularization)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrRegularizationNotFound
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AttendanceRepository implements the AttendanceRepositoryInterface
@@ -26,7 +27,7 @@ func (r *AttendanceRepository) Create(attendance *domain.Attendance) error {
 	}
 
 	// Set timestamps
-	now := time.Now()
+	now := time.Now().UTC()
 	attendance.CreatedAt = now
 	attendance.UpdatedAt = now
 
@@ -68,6 +69,32 @@ func (r *AttendanceRepository) GetByUserID(userID uint, date time.Time) (*domain
 	return &attendance, nil
 }
 
+// GetOpenByUserID retrieves the most recent attendance a user has checked in to but not yet checked out of
+func (r *AttendanceRepository) GetOpenByUserID(userID uint) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+
+	err := r.db.Where("user_id = ? AND check_in_time IS NOT NULL AND check_out_time IS NULL", userID).
+		Order("date DESC").
+		First(&attendance).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAttendanceNotFound
+		}
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
+// GetAllOpen retrieves every attendance that has been checked in to but not yet checked out of
+func (r *AttendanceRepository) GetAllOpen() ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	err := r.db.Where("check_in_time IS NOT NULL AND check_out_time IS NULL").
+		Order("date ASC").
+		Find(&attendances).Error
+	return attendances, err
+}
+
 // GetByUserIDAndDateRange retrieves attendance records for a user within a date range
 func (r *AttendanceRepository) GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]domain.Attendance, error) {
 	var attendances []domain.Attendance
@@ -110,10 +137,10 @@ func (r *AttendanceRepository) Update(attendance *domain.Attendance) error {
 	}
 
 	// Update timestamp
-	attendance.UpdatedAt = time.Now()
+	attendance.UpdatedAt = time.Now().UTC()
 
-	// Update in database
-	result := r.db.Save(attendance)
+	// Update in database; breaks and sessions are persisted through their own repositories
+	result := r.db.Omit(clause.Associations).Save(attendance)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -151,11 +178,15 @@ func (r *AttendanceRepository) GetAll() ([]domain.Attendance, error) {
 	return attendances, nil
 }
 
-// GetWithBreaks retrieves an attendance record with its associated breaks
+// GetWithBreaks retrieves an attendance record with its associated breaks and sessions
 func (r *AttendanceRepository) GetWithBreaks(id uint) (*domain.Attendance, error) {
 	var attendance domain.Attendance
 
-	err := r.db.Preload("Breaks").First(&attendance, id).Error
+	err := r.db.Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_time ASC")
+	}).Preload("Sessions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("check_in_time ASC")
+	}).First(&attendance, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrAttendanceNotFound
@@ -172,6 +203,7 @@ func (r *AttendanceRepository) GetLastNByUserID(userID uint, limit int) ([]domai
 
 	err := r.db.Where("user_id = ?", userID).
 		Preload("Breaks").
+		Preload("Sessions").
 		Order("date DESC").
 		Limit(limit).
 		Find(&attendances).Error
@@ -182,3 +214,11 @@ func (r *AttendanceRepository) GetLastNByUserID(userID uint, limit int) ([]domai
 
 	return attendances, nil
 }
+
+// LockByUserIDAndDateRange marks the attendance records of a user between two dates (inclusive)
+// as locked by the given timesheet
+func (r *AttendanceRepository) LockByUserIDAndDateRange(userID uint, startDate, endDate time.Time, timesheetID uint) error {
+	return r.db.Model(&domain.Attendance{}).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Update("locked_by_timesheet_id", timesheetID).Error
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceSessionRepository implements the AttendanceSessionRepositoryInterface
+// This struct handles all database operations related to attendance sessions
+type AttendanceSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewAttendanceSessionRepository creates a new instance of AttendanceSessionRepository
+func NewAttendanceSessionRepository(db *gorm.DB) domain.AttendanceSessionRepositoryInterface {
+	return &AttendanceSessionRepository{db: db}
+}
+
+// Create saves a new session to the database
+func (r *AttendanceSessionRepository) Create(session *domain.AttendanceSession) error {
+	// Validate session data before saving
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(session).Error
+}
+
+// GetByID retrieves a session by its ID
+func (r *AttendanceSessionRepository) GetByID(id uint) (*domain.AttendanceSession, error) {
+	var session domain.AttendanceSession
+
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetByAttendanceID retrieves all sessions of an attendance in chronological order
+func (r *AttendanceSessionRepository) GetByAttendanceID(attendanceID uint) ([]domain.AttendanceSession, error) {
+	var sessions []domain.AttendanceSession
+
+	err := r.db.Where("attendance_id = ?", attendanceID).
+		Order("check_in_time ASC").
+		Find(&sessions).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetOpenByAttendanceID retrieves the session of an attendance that has not been checked out yet
+func (r *AttendanceSessionRepository) GetOpenByAttendanceID(attendanceID uint) (*domain.AttendanceSession, error) {
+	var session domain.AttendanceSession
+
+	err := r.db.Where("attendance_id = ? AND check_out_time IS NULL", attendanceID).
+		Order("check_in_time DESC").
+		First(&session).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Update modifies an existing session
+func (r *AttendanceSessionRepository) Update(session *domain.AttendanceSession) error {
+	// Validate session data before updating
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	session.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(session)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a session from the database
+func (r *AttendanceSessionRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.AttendanceSession{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuthSessionRepository implements the AuthSessionRepositoryInterface
+// This struct handles all database operations related to sign-in sessions
+type AuthSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthSessionRepository creates a new instance of AuthSessionRepository
+func NewAuthSessionRepository(db *gorm.DB) domain.AuthSessionRepositoryInterface {
+	return &AuthSessionRepository{db: db}
+}
+
+// Create saves a new session to the database
+func (r *AuthSessionRepository) Create(session *domain.AuthSession) error {
+	// Validate session data before saving
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(session).Error
+}
+
+// GetByID retrieves a session by its ID
+func (r *AuthSessionRepository) GetByID(id uint) (*domain.AuthSession, error) {
+	var session domain.AuthSession
+
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAuthSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetByRefreshTokenHash retrieves the session holding a refresh token
+func (r *AuthSessionRepository) GetByRefreshTokenHash(tokenHash string) (*domain.AuthSession, error) {
+	return r.getBy("refresh_token_hash = ?", tokenHash)
+}
+
+// GetByPreviousTokenHash retrieves the session whose last rotation replaced a refresh token
+func (r *AuthSessionRepository) GetByPreviousTokenHash(tokenHash string) (*domain.AuthSession, error) {
+	return r.getBy("previous_token_hash = ?", tokenHash)
+}
+
+// GetActiveByUserID retrieves the sessions of a user that are neither revoked nor expired, most recent first
+func (r *AuthSessionRepository) GetActiveByUserID(userID uint) ([]domain.AuthSession, error) {
+	var sessions []domain.AuthSession
+
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now().UTC()).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Update modifies an existing session
+func (r *AuthSessionRepository) Update(session *domain.AuthSession) error {
+	// Validate session data before updating
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	session.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(session)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrAuthSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllByUserID revokes every session of a user that is not revoked yet
+func (r *AuthSessionRepository) RevokeAllByUserID(userID uint, reason string) error {
+	now := time.Now().UTC()
+	return r.db.Model(&domain.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+			"updated_at":     now,
+		}).Error
+}
+
+// RevokeOthersByUserID revokes every active session of a user except the given one,
+// e.g. to sign out other devices while keeping the one the password was changed on
+func (r *AuthSessionRepository) RevokeOthersByUserID(userID, keepSessionID uint, reason string) error {
+	now := time.Now().UTC()
+	return r.db.Model(&domain.AuthSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepSessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+			"updated_at":     now,
+		}).Error
+}
+
+// getBy retrieves the first session matching a condition
+func (r *AuthSessionRepository) getBy(query string, args ...interface{}) (*domain.AuthSession, error) {
+	var session domain.AuthSession
+
+	err := r.db.Where(query, args...).First(&session).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAuthSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
@@ -26,7 +26,7 @@ func (r *BreakRepository) Create(breakItem *domain.Break) error {
 	}
 
 	// Set timestamps
-	now := time.Now()
+	now := time.Now().UTC()
 	breakItem.CreatedAt = now
 	breakItem.UpdatedAt = now
 
@@ -72,7 +72,7 @@ func (r *BreakRepository) Update(breakItem *domain.Break) error {
 	}
 
 	// Update timestamp
-	breakItem.UpdatedAt = time.Now()
+	breakItem.UpdatedAt = time.Now().UTC()
 
 	// Update in database
 	result := r.db.Save(breakItem)
@@ -130,6 +130,14 @@ func (r *BreakRepository) GetActiveBreakByAttendanceID(attendanceID uint) (*doma
 	return &breakItem, nil
 }
 
+// CountByBreakTypeID returns the number of breaks recorded with a break type
+func (r *BreakRepository) CountByBreakTypeID(breakTypeID uint) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&domain.Break{}).Where("break_type_id = ?", breakTypeID).Count(&count).Error
+	return count, err
+}
+
 // GetBreaksByDateRange retrieves breaks within a date range
 func (r *BreakRepository) GetBreaksByDateRange(startDate, endDate time.Time) ([]domain.Break, error) {
 	var breaks []domain.Break
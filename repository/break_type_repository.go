@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BreakTypeRepository implements the BreakTypeRepositoryInterface
+// This struct handles all database operations related to break types
+type BreakTypeRepository struct {
+	db *gorm.DB
+}
+
+// NewBreakTypeRepository creates a new instance of BreakTypeRepository
+func NewBreakTypeRepository(db *gorm.DB) domain.BreakTypeRepositoryInterface {
+	return &BreakTypeRepository{db: db}
+}
+
+// Create saves a new break type to the database
+func (r *BreakTypeRepository) Create(breakType *domain.BreakType) error {
+	// Validate break type data before saving
+	if err := breakType.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	breakType.CreatedAt = now
+	breakType.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(breakType).Error
+}
+
+// GetByID retrieves a break type by its ID
+func (r *BreakTypeRepository) GetByID(id uint) (*domain.BreakType, error) {
+	var breakType domain.BreakType
+
+	err := r.db.First(&breakType, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBreakTypeNotFound
+		}
+		return nil, err
+	}
+
+	return &breakType, nil
+}
+
+// GetByCode retrieves a break type by its unique code
+func (r *BreakTypeRepository) GetByCode(code string) (*domain.BreakType, error) {
+	var breakType domain.BreakType
+
+	err := r.db.Where("code = ?", code).First(&breakType).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBreakTypeNotFound
+		}
+		return nil, err
+	}
+
+	return &breakType, nil
+}
+
+// GetAll retrieves all break types ordered by name
+func (r *BreakTypeRepository) GetAll() ([]domain.BreakType, error) {
+	var breakTypes []domain.BreakType
+
+	err := r.db.Order("name ASC").Find(&breakTypes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return breakTypes, nil
+}
+
+// Update modifies an existing break type
+func (r *BreakTypeRepository) Update(breakType *domain.BreakType) error {
+	// Validate break type data before updating
+	if err := breakType.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	breakType.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(breakType)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrBreakTypeNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a break type from the database
+func (r *BreakTypeRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.BreakType{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrBreakTypeNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HolidayRepository implements the HolidayRepositoryInterface
+// This struct handles all database operations related to holidays
+type HolidayRepository struct {
+	db *gorm.DB
+}
+
+// NewHolidayRepository creates a new instance of HolidayRepository
+func NewHolidayRepository(db *gorm.DB) domain.HolidayRepositoryInterface {
+	return &HolidayRepository{db: db}
+}
+
+// Create saves a new holiday to the database
+func (r *HolidayRepository) Create(holiday *domain.Holiday) error {
+	// Validate holiday data before saving
+	if err := holiday.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	holiday.CreatedAt = now
+	holiday.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(holiday).Error
+}
+
+// GetByID retrieves a holiday by its ID
+func (r *HolidayRepository) GetByID(id uint) (*domain.Holiday, error) {
+	var holiday domain.Holiday
+
+	err := r.db.First(&holiday, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrHolidayNotFound
+		}
+		return nil, err
+	}
+
+	return &holiday, nil
+}
+
+// GetAll retrieves all holidays ordered by date
+func (r *HolidayRepository) GetAll() ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+
+	err := r.db.Order("date ASC").Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+// GetByDateRange retrieves holidays between two dates (inclusive)
+func (r *HolidayRepository) GetByDateRange(startDate, endDate time.Time) ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+
+	err := r.db.Where("date >= ? AND date <= ?", startDate, endDate).
+		Order("date ASC").
+		Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+// Update modifies an existing holiday
+func (r *HolidayRepository) Update(holiday *domain.Holiday) error {
+	// Validate holiday data before updating
+	if err := holiday.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	holiday.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(holiday)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrHolidayNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a holiday from the database
+func (r *HolidayRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Holiday{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrHolidayNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// KioskRepository implements the KioskRepositoryInterface
+// This struct handles all database operations related to kiosk devices
+type KioskRepository struct {
+	db *gorm.DB
+}
+
+// NewKioskRepository creates a new instance of KioskRepository
+func NewKioskRepository(db *gorm.DB) domain.KioskRepositoryInterface {
+	return &KioskRepository{db: db}
+}
+
+// Create saves a new kiosk to the database
+func (r *KioskRepository) Create(kiosk *domain.Kiosk) error {
+	// Validate kiosk data before saving
+	if err := kiosk.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	kiosk.CreatedAt = now
+	kiosk.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(kiosk).Error
+}
+
+// GetByID retrieves a kiosk by its ID
+func (r *KioskRepository) GetByID(id uint) (*domain.Kiosk, error) {
+	var kiosk domain.Kiosk
+
+	err := r.db.First(&kiosk, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrKioskNotFound
+		}
+		return nil, err
+	}
+
+	return &kiosk, nil
+}
+
+// GetByTokenHash retrieves the kiosk a device token belongs to
+func (r *KioskRepository) GetByTokenHash(tokenHash string) (*domain.Kiosk, error) {
+	var kiosk domain.Kiosk
+
+	err := r.db.Where("token_hash = ?", tokenHash).First(&kiosk).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrKioskNotFound
+		}
+		return nil, err
+	}
+
+	return &kiosk, nil
+}
+
+// GetAll retrieves all kiosks ordered by name
+func (r *KioskRepository) GetAll() ([]domain.Kiosk, error) {
+	var kiosks []domain.Kiosk
+
+	err := r.db.Order("name ASC").Find(&kiosks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return kiosks, nil
+}
+
+// Update modifies an existing kiosk
+func (r *KioskRepository) Update(kiosk *domain.Kiosk) error {
+	// Validate kiosk data before updating
+	if err := kiosk.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	kiosk.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(kiosk)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrKioskNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a kiosk from the database
+func (r *KioskRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Kiosk{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrKioskNotFound
+	}
+
+	return nil
+}
@@ -62,6 +62,17 @@ func (r *LeaveRepositoryImpl) GetByUserIDAndDateRange(userID uint, startDate, en
 	return leaves, nil
 }
 
+// GetApprovedOnDate retrieves all approved leaves that cover a specific date
+func (r *LeaveRepositoryImpl) GetApprovedOnDate(date time.Time) ([]domain.Leave, error) {
+	var leaves []domain.Leave
+	if err := r.db.Where("status = ? AND start_date <= ? AND end_date >= ?", domain.LeaveStatusApproved, date, date).
+		Find(&leaves).Error; err != nil {
+		log.Printf("Error getting approved leaves on date: %v", err)
+		return nil, err
+	}
+	return leaves, nil
+}
+
 // GetByStatus retrieves all leaves with a specific status
 func (r *LeaveRepositoryImpl) GetByStatus(status domain.LeaveStatus) ([]domain.Leave, error) {
 	var leaves []domain.Leave
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LocationRepository implements the LocationRepositoryInterface
+// This struct handles all database operations related to locations
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+// NewLocationRepository creates a new instance of LocationRepository
+func NewLocationRepository(db *gorm.DB) domain.LocationRepositoryInterface {
+	return &LocationRepository{db: db}
+}
+
+// Create saves a new location to the database
+func (r *LocationRepository) Create(location *domain.Location) error {
+	// Validate location data before saving
+	if err := location.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	location.CreatedAt = now
+	location.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(location).Error
+}
+
+// GetByID retrieves a location by its ID
+func (r *LocationRepository) GetByID(id uint) (*domain.Location, error) {
+	var location domain.Location
+
+	err := r.db.First(&location, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLocationNotFound
+		}
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+// GetAll retrieves all locations ordered by name
+func (r *LocationRepository) GetAll() ([]domain.Location, error) {
+	var locations []domain.Location
+
+	err := r.db.Order("name ASC").Find(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// Update modifies an existing location
+func (r *LocationRepository) Update(location *domain.Location) error {
+	// Validate location data before updating
+	if err := location.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	location.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(location)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrLocationNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a location from the database
+func (r *LocationRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Location{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrLocationNotFound
+	}
+
+	return nil
+}
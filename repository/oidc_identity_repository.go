@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OIDCIdentityRepository implements the OIDCIdentityRepositoryInterface
+// This struct handles all database operations related to links between identity provider accounts and users
+type OIDCIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewOIDCIdentityRepository creates a new instance of OIDCIdentityRepository
+func NewOIDCIdentityRepository(db *gorm.DB) domain.OIDCIdentityRepositoryInterface {
+	return &OIDCIdentityRepository{db: db}
+}
+
+// Create saves a new identity link to the database
+func (r *OIDCIdentityRepository) Create(identity *domain.OIDCIdentity) error {
+	// Validate identity data before saving
+	if err := identity.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	identity.CreatedAt = now
+	identity.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(identity).Error
+}
+
+// GetBySubject retrieves the identity link of the given provider account
+func (r *OIDCIdentityRepository) GetBySubject(issuer, subject string) (*domain.OIDCIdentity, error) {
+	var identity domain.OIDCIdentity
+
+	err := r.db.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOIDCIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// GetByUserID retrieves the identity link a user has at the given provider
+func (r *OIDCIdentityRepository) GetByUserID(issuer string, userID uint) (*domain.OIDCIdentity, error) {
+	var identity domain.OIDCIdentity
+
+	err := r.db.Where("issuer = ? AND user_id = ?", issuer, userID).First(&identity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOIDCIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// Update modifies an existing identity link in the database
+func (r *OIDCIdentityRepository) Update(identity *domain.OIDCIdentity) error {
+	// Validate identity data before updating
+	if err := identity.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	identity.UpdatedAt = time.Now().UTC()
+
+	result := r.db.Save(identity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrOIDCIdentityNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OIDCLoginStateRepository implements the OIDCLoginStateRepositoryInterface
+// This struct handles all database operations related to pending OIDC sign-ins
+type OIDCLoginStateRepository struct {
+	db *gorm.DB
+}
+
+// NewOIDCLoginStateRepository creates a new instance of OIDCLoginStateRepository
+func NewOIDCLoginStateRepository(db *gorm.DB) domain.OIDCLoginStateRepositoryInterface {
+	return &OIDCLoginStateRepository{db: db}
+}
+
+// Create saves a new login state to the database
+func (r *OIDCLoginStateRepository) Create(state *domain.OIDCLoginState) error {
+	// Validate login state data before saving
+	if err := state.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamp
+	state.CreatedAt = time.Now().UTC()
+
+	// Save to database
+	return r.db.Create(state).Error
+}
+
+// GetByStateHash retrieves the login state with the given state hash
+func (r *OIDCLoginStateRepository) GetByStateHash(stateHash string) (*domain.OIDCLoginState, error) {
+	var state domain.OIDCLoginState
+
+	err := r.db.Where("state_hash = ?", stateHash).First(&state).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOIDCLoginStateNotFound
+		}
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// Update modifies an existing login state in the database
+func (r *OIDCLoginStateRepository) Update(state *domain.OIDCLoginState) error {
+	// Validate login state data before updating
+	if err := state.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Save(state)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrOIDCLoginStateNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired removes login states that expired before the given time and returns how many were removed
+func (r *OIDCLoginStateRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&domain.OIDCLoginState{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OvertimeEntryRepository implements the OvertimeEntryRepositoryInterface
+// This struct handles all database operations related to the overtime ledger
+type OvertimeEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewOvertimeEntryRepository creates a new instance of OvertimeEntryRepository
+func NewOvertimeEntryRepository(db *gorm.DB) domain.OvertimeEntryRepositoryInterface {
+	return &OvertimeEntryRepository{db: db}
+}
+
+// Create saves a new overtime entry to the database
+func (r *OvertimeEntryRepository) Create(entry *domain.OvertimeEntry) error {
+	// Set timestamps
+	now := time.Now().UTC()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(entry).Error
+}
+
+// GetByID retrieves an overtime entry by its ID
+func (r *OvertimeEntryRepository) GetByID(id uint) (*domain.OvertimeEntry, error) {
+	var entry domain.OvertimeEntry
+
+	err := r.db.First(&entry, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOvertimeEntryNotFound
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetByUserIDAndDateRange retrieves the overtime entries of a user between two dates (inclusive)
+func (r *OvertimeEntryRepository) GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]domain.OvertimeEntry, error) {
+	var entries []domain.OvertimeEntry
+
+	err := r.db.Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Order("date ASC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetByStatus retrieves all overtime entries with a specific status, oldest first
+func (r *OvertimeEntryRepository) GetByStatus(status domain.OvertimeStatus) ([]domain.OvertimeEntry, error) {
+	var entries []domain.OvertimeEntry
+
+	err := r.db.Where("status = ?", status).
+		Order("date ASC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Update modifies an existing overtime entry
+func (r *OvertimeEntryRepository) Update(entry *domain.OvertimeEntry) error {
+	// Update timestamp
+	entry.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(entry)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOvertimeEntryNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an overtime entry from the database
+func (r *OvertimeEntryRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.OvertimeEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOvertimeEntryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OvertimeRequestRepository implements the OvertimeRequestRepositoryInterface
+// This struct handles all database operations related to overtime pre-approval requests
+type OvertimeRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewOvertimeRequestRepository creates a new instance of OvertimeRequestRepository
+func NewOvertimeRequestRepository(db *gorm.DB) domain.OvertimeRequestRepositoryInterface {
+	return &OvertimeRequestRepository{db: db}
+}
+
+// Create saves a new overtime request to the database
+func (r *OvertimeRequestRepository) Create(request *domain.OvertimeRequest) error {
+	// Validate request data before saving
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	request.CreatedAt = now
+	request.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(request).Error
+}
+
+// GetByID retrieves an overtime request by its ID
+func (r *OvertimeRequestRepository) GetByID(id uint) (*domain.OvertimeRequest, error) {
+	var request domain.OvertimeRequest
+
+	err := r.db.First(&request, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOvertimeRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// GetByUserID retrieves all overtime requests of a user, newest first
+func (r *OvertimeRequestRepository) GetByUserID(userID uint) ([]domain.OvertimeRequest, error) {
+	var requests []domain.OvertimeRequest
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("date DESC").
+		Find(&requests).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetByStatus retrieves all overtime requests with a specific status, oldest first
+func (r *OvertimeRequestRepository) GetByStatus(status domain.OvertimeStatus) ([]domain.OvertimeRequest, error) {
+	var requests []domain.OvertimeRequest
+
+	err := r.db.Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&requests).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetApprovedByUserIDAndDateRange retrieves the approved requests of a user between two dates (inclusive)
+func (r *OvertimeRequestRepository) GetApprovedByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]domain.OvertimeRequest, error) {
+	var requests []domain.OvertimeRequest
+
+	err := r.db.Where("user_id = ? AND status = ? AND date >= ? AND date <= ?",
+		userID, domain.OvertimeStatusApproved, startDate, endDate).
+		Order("date ASC").
+		Find(&requests).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetOpenByUserIDAndDate retrieves the pending or approved request of a user for a date
+func (r *OvertimeRequestRepository) GetOpenByUserIDAndDate(userID uint, date time.Time) (*domain.OvertimeRequest, error) {
+	var request domain.OvertimeRequest
+
+	err := r.db.Where("user_id = ? AND date = ? AND status IN ?", userID, date,
+		[]domain.OvertimeStatus{domain.OvertimeStatusPending, domain.OvertimeStatusApproved}).
+		First(&request).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOvertimeRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// Update modifies an existing overtime request
+func (r *OvertimeRequestRepository) Update(request *domain.OvertimeRequest) error {
+	// Validate request data before updating
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	request.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(request)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOvertimeRequestNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository implements the PasswordHistoryRepositoryInterface
+// This struct handles all database operations related to the hashes of users' previous passwords
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository creates a new instance of PasswordHistoryRepository
+func NewPasswordHistoryRepository(db *gorm.DB) domain.PasswordHistoryRepositoryInterface {
+	return &PasswordHistoryRepository{db: db}
+}
+
+// Create saves a replaced password hash to the database
+func (r *PasswordHistoryRepository) Create(entry *domain.PasswordHistory) error {
+	// Validate entry data before saving
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamp
+	entry.CreatedAt = time.Now().UTC()
+
+	// Save to database
+	return r.db.Create(entry).Error
+}
+
+// ListRecent retrieves a user's most recently replaced passwords, newest first
+func (r *PasswordHistoryRepository) ListRecent(userID uint, limit int) ([]domain.PasswordHistory, error) {
+	var entries []domain.PasswordHistory
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+
+	return entries, err
+}
+
+// DeleteAllButRecent removes a user's replaced passwords except the most recent ones
+func (r *PasswordHistoryRepository) DeleteAllButRecent(userID uint, keep int) error {
+	var keepIDs []uint
+	if keep > 0 {
+		if err := r.db.Model(&domain.PasswordHistory{}).
+			Where("user_id = ?", userID).
+			Order("created_at DESC, id DESC").
+			Limit(keep).
+			Pluck("id", &keepIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	query := r.db.Where("user_id = ?", userID)
+	if len(keepIDs) > 0 {
+		query = query.Where("id NOT IN ?", keepIDs)
+	}
+	return query.Delete(&domain.PasswordHistory{}).Error
+}
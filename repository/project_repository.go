@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProjectRepository implements the ProjectRepositoryInterface
+// This struct handles all database operations related to projects
+type ProjectRepository struct {
+	db *gorm.DB
+}
+
+// NewProjectRepository creates a new instance of ProjectRepository
+func NewProjectRepository(db *gorm.DB) domain.ProjectRepositoryInterface {
+	return &ProjectRepository{db: db}
+}
+
+// Create saves a new project to the database
+func (r *ProjectRepository) Create(project *domain.Project) error {
+	// Validate project data before saving
+	if err := project.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(project).Error
+}
+
+// GetByID retrieves a project by its ID
+func (r *ProjectRepository) GetByID(id uint) (*domain.Project, error) {
+	var project domain.Project
+
+	err := r.db.First(&project, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetByCode retrieves a project by its unique code
+func (r *ProjectRepository) GetByCode(code string) (*domain.Project, error) {
+	var project domain.Project
+
+	err := r.db.Where("code = ?", code).First(&project).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetAll retrieves all projects ordered by name
+func (r *ProjectRepository) GetAll() ([]domain.Project, error) {
+	var projects []domain.Project
+
+	err := r.db.Order("name ASC").Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Update modifies an existing project
+func (r *ProjectRepository) Update(project *domain.Project) error {
+	// Validate project data before updating
+	if err := project.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	project.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Save(project)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a project from the database
+func (r *ProjectRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.Project{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
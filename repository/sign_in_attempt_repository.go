@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SignInAttemptRepository implements the SignInAttemptRepositoryInterface
+// This struct handles all database operations related to the sign-in history
+type SignInAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewSignInAttemptRepository creates a new instance of SignInAttemptRepository
+func NewSignInAttemptRepository(db *gorm.DB) domain.SignInAttemptRepositoryInterface {
+	return &SignInAttemptRepository{db: db}
+}
+
+// Create saves a new sign-in attempt to the database
+func (r *SignInAttemptRepository) Create(attempt *domain.SignInAttempt) error {
+	// Validate attempt data before saving
+	if err := attempt.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamp
+	attempt.CreatedAt = time.Now().UTC()
+
+	// Save to database
+	return r.db.Create(attempt).Error
+}
+
+// List retrieves the sign-in attempts matching the filter, newest first
+func (r *SignInAttemptRepository) List(filter domain.SignInAttemptFilter) ([]domain.SignInAttempt, error) {
+	var attempts []domain.SignInAttempt
+
+	query := r.db.Model(&domain.SignInAttempt{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.Result != "" {
+		query = query.Where("result = ?", filter.Result)
+	}
+
+	err := query.Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&attempts).Error
+
+	return attempts, err
+}
+
+// CountFailuresByIP counts the failed sign-in attempts made from an IP address since the given time
+func (r *SignInAttemptRepository) CountFailuresByIP(ipAddress string, since time.Time) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&domain.SignInAttempt{}).
+		Where("ip_address = ? AND result = ? AND created_at >= ?", ipAddress, domain.SignInResultInvalidCredentials, since).
+		Count(&count).Error
+
+	return count, err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SigningKeyRepository implements the SigningKeyRepositoryInterface
+// This struct handles all database operations related to access token signing keys
+type SigningKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewSigningKeyRepository creates a new instance of SigningKeyRepository
+func NewSigningKeyRepository(db *gorm.DB) domain.SigningKeyRepositoryInterface {
+	return &SigningKeyRepository{db: db}
+}
+
+// Create saves a new signing key to the database
+func (r *SigningKeyRepository) Create(key *domain.SigningKey) error {
+	// Validate key data before saving
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+
+	// Save to database
+	return r.db.Create(key).Error
+}
+
+// GetVerifiable retrieves the keys whose tokens are still accepted, newest first
+func (r *SigningKeyRepository) GetVerifiable(now time.Time) ([]domain.SigningKey, error) {
+	var keys []domain.SigningKey
+
+	err := r.db.Where("verify_until IS NULL OR verify_until > ?", now).
+		Order("created_at DESC, id DESC").
+		Find(&keys).Error
+
+	return keys, err
+}
+
+// Update modifies an existing signing key in the database
+func (r *SigningKeyRepository) Update(key *domain.SigningKey) error {
+	// Validate key data before updating
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	// Set updated timestamp
+	key.UpdatedAt = time.Now().UTC()
+
+	result := r.db.Save(key)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSigningKeyNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired removes retired keys no token signed with them can still be valid for
+func (r *SigningKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("verify_until IS NOT NULL AND verify_until <= ?", now).Delete(&domain.SigningKey{})
+	return result.RowsAffected, result.Error
+}
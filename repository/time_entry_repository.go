@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TimeEntryRepository implements the TimeEntryRepositoryInterface
+// This struct handles all database operations related to project time entries
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewTimeEntryRepository creates a new instance of TimeEntryRepository
+func NewTimeEntryRepository(db *gorm.DB) domain.TimeEntryRepositoryInterface {
+	return &TimeEntryRepository{db: db}
+}
+
+// Create saves a new time entry to the database
+func (r *TimeEntryRepository) Create(entry *domain.TimeEntry) error {
+	// Validate time entry data before saving
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	// Save to database
+	return r.db.Omit(clause.Associations).Create(entry).Error
+}
+
+// GetByID retrieves a time entry with its project by its ID
+func (r *TimeEntryRepository) GetByID(id uint) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+
+	err := r.db.Preload("Project").First(&entry, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTimeEntryNotFound
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetByAttendanceID retrieves all time entries of an attendance day
+func (r *TimeEntryRepository) GetByAttendanceID(attendanceID uint) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+
+	err := r.db.Where("attendance_id = ?", attendanceID).
+		Order("id ASC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetByUserIDAndDateRange retrieves the time entries of a user between two dates (inclusive)
+func (r *TimeEntryRepository) GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+
+	err := r.db.Preload("Project").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Order("date ASC, id ASC").
+		Find(&entries).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetByDateRange retrieves time entries between two dates (inclusive), optionally for one project or user
+func (r *TimeEntryRepository) GetByDateRange(startDate, endDate time.Time, projectID uint, userID uint) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+
+	query := r.db.Preload("Project").Where("date >= ? AND date <= ?", startDate, endDate)
+	if projectID != 0 {
+		query = query.Where("project_id = ?", projectID)
+	}
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	err := query.Order("date ASC, id ASC").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CountByProjectID returns the number of time entries booked on a project
+func (r *TimeEntryRepository) CountByProjectID(projectID uint) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&domain.TimeEntry{}).Where("project_id = ?", projectID).Count(&count).Error
+	return count, err
+}
+
+// Update modifies an existing time entry
+func (r *TimeEntryRepository) Update(entry *domain.TimeEntry) error {
+	// Validate time entry data before updating
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	// Update timestamp
+	entry.UpdatedAt = time.Now().UTC()
+
+	// Update in database
+	result := r.db.Omit(clause.Associations).Save(entry)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrTimeEntryNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a time entry from the database
+func (r *TimeEntryRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.TimeEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrTimeEntryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TimesheetRepository implements the TimesheetRepositoryInterface
+// This struct handles all database operations related to weekly timesheets
+type TimesheetRepository struct {
+	db *gorm.DB
+}
+
+// NewTimesheetRepository creates a new instance of TimesheetRepository
+func NewTimesheetRepository(db *gorm.DB) domain.TimesheetRepositoryInterface {
+	return &TimesheetRepository{db: db}
+}
+
+// Create saves a new timesheet to the database
+func (r *TimesheetRepository) Create(timesheet *domain.Timesheet) error {
+	// Set timestamps
+	now := time.Now().UTC()
+	timesheet.CreatedAt = now
+	timesheet.UpdatedAt = now
+
+	// Save to database; day notes are stored through ReplaceDayNotes
+	return r.db.Omit(clause.Associations).Create(timesheet).Error
+}
+
+// GetByID retrieves a timesheet with its day notes by its ID
+func (r *TimesheetRepository) GetByID(id uint) (*domain.Timesheet, error) {
+	var timesheet domain.Timesheet
+
+	err := r.db.Preload("DayNotes").First(&timesheet, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTimesheetNotFound
+		}
+		return nil, err
+	}
+
+	return &timesheet, nil
+}
+
+// GetByUserIDAndWeek retrieves the timesheet of a user for the week starting on the given day
+func (r *TimesheetRepository) GetByUserIDAndWeek(userID uint, weekStart time.Time) (*domain.Timesheet, error) {
+	var timesheet domain.Timesheet
+
+	err := r.db.Preload("DayNotes").
+		Where("user_id = ? AND week_start = ?", userID, weekStart).
+		First(&timesheet).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTimesheetNotFound
+		}
+		return nil, err
+	}
+
+	return &timesheet, nil
+}
+
+// GetApprovedByUserIDAndDate retrieves the approved timesheet of a user whose week contains the given day
+func (r *TimesheetRepository) GetApprovedByUserIDAndDate(userID uint, date time.Time) (*domain.Timesheet, error) {
+	var timesheet domain.Timesheet
+
+	err := r.db.Where("user_id = ? AND status = ? AND week_start <= ? AND week_end >= ?",
+		userID, domain.TimesheetStatusApproved, date, date).
+		First(&timesheet).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTimesheetNotFound
+		}
+		return nil, err
+	}
+
+	return &timesheet, nil
+}
+
+// GetByUserID retrieves all stored timesheets of a user, newest week first
+func (r *TimesheetRepository) GetByUserID(userID uint) ([]domain.Timesheet, error) {
+	var timesheets []domain.Timesheet
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("week_start DESC").
+		Find(&timesheets).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return timesheets, nil
+}
+
+// GetByStatus retrieves all timesheets with a specific status, oldest week first
+func (r *TimesheetRepository) GetByStatus(status domain.TimesheetStatus) ([]domain.Timesheet, error) {
+	var timesheets []domain.Timesheet
+
+	err := r.db.Where("status = ?", status).
+		Order("week_start ASC").
+		Find(&timesheets).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return timesheets, nil
+}
+
+// Update modifies an existing timesheet
+func (r *TimesheetRepository) Update(timesheet *domain.Timesheet) error {
+	// Update timestamp
+	timesheet.UpdatedAt = time.Now().UTC()
+
+	// Update in database; day notes are stored through ReplaceDayNotes
+	result := r.db.Omit(clause.Associations).Save(timesheet)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrTimesheetNotFound
+	}
+
+	return nil
+}
+
+// ReplaceDayNotes swaps the day notes of a timesheet for the given set
+func (r *TimesheetRepository) ReplaceDayNotes(timesheetID uint, notes []domain.TimesheetDayNote) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("timesheet_id = ?", timesheetID).Delete(&domain.TimesheetDayNote{}).Error; err != nil {
+			return err
+		}
+		if len(notes) == 0 {
+			return nil
+		}
+
+		for i := range notes {
+			notes[i].ID = 0
+			notes[i].TimesheetID = timesheetID
+		}
+		return tx.Create(&notes).Error
+	})
+}
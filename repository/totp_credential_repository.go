@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TOTPCredentialRepository implements the TOTPCredentialRepositoryInterface
+// This struct handles all database operations related to TOTP enrollments and their recovery codes
+type TOTPCredentialRepository struct {
+	db *gorm.DB
+}
+
+// NewTOTPCredentialRepository creates a new instance of TOTPCredentialRepository
+func NewTOTPCredentialRepository(db *gorm.DB) domain.TOTPCredentialRepositoryInterface {
+	return &TOTPCredentialRepository{db: db}
+}
+
+// GetByUserID retrieves the TOTP enrollment of a user
+func (r *TOTPCredentialRepository) GetByUserID(userID uint) (*domain.TOTPCredential, error) {
+	var credential domain.TOTPCredential
+
+	err := r.db.Where("user_id = ?", userID).First(&credential).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTOTPCredentialNotFound
+		}
+		return nil, err
+	}
+
+	return &credential, nil
+}
+
+// Save creates the TOTP enrollment of a user or updates the existing one
+func (r *TOTPCredentialRepository) Save(credential *domain.TOTPCredential) error {
+	// Validate credential data before saving
+	if err := credential.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamps
+	now := time.Now().UTC()
+	if credential.ID == 0 {
+		credential.CreatedAt = now
+	}
+	credential.UpdatedAt = now
+
+	return r.db.Save(credential).Error
+}
+
+// DeleteByUserID removes the TOTP enrollment of a user together with their recovery codes
+func (r *TOTPCredentialRepository) DeleteByUserID(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&domain.TOTPCredential{}).Error
+	})
+}
+
+// ReplaceRecoveryCodes removes all recovery codes of a user and saves the given ones
+func (r *TOTPCredentialRepository) ReplaceRecoveryCodes(userID uint, codes []domain.RecoveryCode) error {
+	now := time.Now().UTC()
+	for i := range codes {
+		codes[i].UserID = userID
+		codes[i].CreatedAt = now
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// GetUnusedRecoveryCodes retrieves the recovery codes of a user that can still be used
+func (r *TOTPCredentialRepository) GetUnusedRecoveryCodes(userID uint) ([]domain.RecoveryCode, error) {
+	var codes []domain.RecoveryCode
+
+	err := r.db.Where("user_id = ? AND used_at IS NULL", userID).
+		Order("id ASC").
+		Find(&codes).Error
+
+	return codes, err
+}
+
+// UseRecoveryCode marks a recovery code as used. It fails if the code was used concurrently.
+func (r *TOTPCredentialRepository) UseRecoveryCode(id uint, usedAt time.Time) error {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"hrm/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TwoFactorChallengeRepository implements the TwoFactorChallengeRepositoryInterface
+// This struct handles all database operations related to pending two-factor sign-in challenges
+type TwoFactorChallengeRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorChallengeRepository creates a new instance of TwoFactorChallengeRepository
+func NewTwoFactorChallengeRepository(db *gorm.DB) domain.TwoFactorChallengeRepositoryInterface {
+	return &TwoFactorChallengeRepository{db: db}
+}
+
+// Create saves a new challenge to the database
+func (r *TwoFactorChallengeRepository) Create(challenge *domain.TwoFactorChallenge) error {
+	// Validate challenge data before saving
+	if err := challenge.Validate(); err != nil {
+		return err
+	}
+
+	// Set timestamp
+	challenge.CreatedAt = time.Now().UTC()
+
+	// Save to database
+	return r.db.Create(challenge).Error
+}
+
+// GetByTokenHash retrieves the challenge with the given token hash
+func (r *TwoFactorChallengeRepository) GetByTokenHash(tokenHash string) (*domain.TwoFactorChallenge, error) {
+	var challenge domain.TwoFactorChallenge
+
+	err := r.db.Where("token_hash = ?", tokenHash).First(&challenge).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTwoFactorChallengeNotFound
+		}
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+// Update modifies an existing challenge in the database
+func (r *TwoFactorChallengeRepository) Update(challenge *domain.TwoFactorChallenge) error {
+	// Validate challenge data before updating
+	if err := challenge.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Save(challenge)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTwoFactorChallengeNotFound
+	}
+
+	return nil
+}
@@ -117,3 +117,110 @@ func (r *UserRepositoryImpl) List(limit, offset int) ([]domain.User, error) {
 
 	return users, nil
 }
+
+// ListActive retrieves all active users from the database, leaving out service accounts.
+// This method is used by background jobs that process every employee.
+func (r *UserRepositoryImpl) ListActive() ([]domain.User, error) {
+	var users []domain.User
+
+	if err := r.db.Where("is_active = ? AND is_service_account = ?", true, false).Order("id ASC").Find(&users).Error; err != nil {
+		// Log the error for debugging purposes
+		log.Printf("Error listing active users: %v", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetByEmployeeCode retrieves a user by the employee code they enter at shared kiosks.
+// If no user has the code, it returns domain.ErrUserNotFound.
+func (r *UserRepositoryImpl) GetByEmployeeCode(code string) (*domain.User, error) {
+	var user domain.User
+
+	if err := r.db.Where("employee_code = ?", code).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserNotFound
+		}
+		// Log other database errors
+		log.Printf("Error getting user by employee code: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetByBadgeID retrieves a user by the badge they scan at shared kiosks.
+// If no user has the badge, it returns domain.ErrUserNotFound.
+func (r *UserRepositoryImpl) GetByBadgeID(badgeID string) (*domain.User, error) {
+	var user domain.User
+
+	if err := r.db.Where("badge_id = ?", badgeID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserNotFound
+		}
+		// Log other database errors
+		log.Printf("Error getting user by badge ID: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetByDirectoryDN retrieves the user synced from the given LDAP entry.
+// If no user has the DN, it returns domain.ErrUserNotFound.
+func (r *UserRepositoryImpl) GetByDirectoryDN(dn string) (*domain.User, error) {
+	var user domain.User
+
+	if err := r.db.Where("directory_dn = ?", dn).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserNotFound
+		}
+		// Log other database errors
+		log.Printf("Error getting user by directory DN: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// ListFromDirectory retrieves all users synced from the LDAP directory.
+// This method is used by the directory sync to find users that left the directory.
+func (r *UserRepositoryImpl) ListFromDirectory() ([]domain.User, error) {
+	var users []domain.User
+
+	if err := r.db.Where("directory_dn IS NOT NULL").Order("id ASC").Find(&users).Error; err != nil {
+		// Log the error for debugging purposes
+		log.Printf("Error listing directory users: %v", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// ListAll retrieves every user, active or not, ordered by ID.
+// This method is used by SCIM clients, which filter and page through all users.
+func (r *UserRepositoryImpl) ListAll() ([]domain.User, error) {
+	var users []domain.User
+
+	if err := r.db.Order("id ASC").Find(&users).Error; err != nil {
+		// Log the error for debugging purposes
+		log.Printf("Error listing all users: %v", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// ListServiceAccounts retrieves all service accounts ordered by name.
+// Service accounts are users that integrations authenticate as with API tokens.
+func (r *UserRepositoryImpl) ListServiceAccounts() ([]domain.User, error) {
+	var users []domain.User
+
+	if err := r.db.Where("is_service_account = ?", true).Order("name ASC, id ASC").Find(&users).Error; err != nil {
+		// Log the error for debugging purposes
+		log.Printf("Error listing service accounts: %v", err)
+		return nil, err
+	}
+
+	return users, nil
+}
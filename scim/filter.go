@@ -0,0 +1,409 @@
+package scim
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+// Filter is a parsed SCIM filter expression (RFC 7644, section 3.4.2.2)
+type Filter interface {
+	// Matches reports whether a resource, in the generic form returned by ToMap, satisfies the filter
+	Matches(resource map[string]interface{}) bool
+}
+
+// ParseFilter parses a SCIM filter such as `userName eq "alice@example.com" and active eq true`.
+// Errors wrap domain.ErrSCIMInvalidFilter.
+func ParseFilter(input string) (Filter, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrSCIMInvalidFilter, err)
+	}
+
+	p := &parser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", tok)
+	}
+	return filter, nil
+}
+
+// compareOperators are the operators comparing an attribute with a value; "pr" takes no value
+var compareOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// String describes the token in error messages
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return strconv.Quote(t.text)
+}
+
+// tokenize splits a filter into words, JSON strings, parentheses and brackets
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		switch c := input[i]; c {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, text: "("})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, text: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "["})
+			i++
+		case ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for end < len(input) && input[end] != '"' {
+				if input[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(input) {
+				return nil, fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			var text string
+			if err := json.Unmarshal([]byte(input[i:end+1]), &text); err != nil {
+				return nil, fmt.Errorf("invalid string at position %d", i+1)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text})
+			i = end + 1
+		default:
+			start := i
+			for i < len(input) && !strings.ContainsRune(" \t\r\n()[]\"", rune(input[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[start:i]})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+// parser is a recursive descent parser over filter tokens; "and" binds tighter than "or"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if tok := p.next(); tok.kind != kind {
+		return p.errorf("expected %s, found %s", what, tok)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", domain.ErrSCIMInvalidFilter, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseTerm parses a parenthesized filter, a negation, a value path or an attribute expression
+func (p *parser) parseTerm() (Filter, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokenOpenParen:
+		return p.parseGroup()
+	case tok.kind == tokenWord && strings.EqualFold(tok.text, "not"):
+		if err := p.expect(tokenOpenParen, `"(" after not`); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{inner: inner}, nil
+	case tok.kind != tokenWord:
+		return nil, p.errorf("expected an attribute, found %s", tok)
+	}
+
+	path, ok := parseAttributePath(tok.text)
+	if !ok {
+		return nil, p.errorf("invalid attribute %q", tok.text)
+	}
+
+	// Value path: emails[type eq "work"]
+	if p.peek().kind == tokenOpenBracket {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, `"]"`); err != nil {
+			return nil, err
+		}
+		return valuePathFilter{path: path, inner: inner}, nil
+	}
+
+	opTok := p.next()
+	op := strings.ToLower(opTok.text)
+	if opTok.kind != tokenWord || (op != "pr" && !compareOperators[op]) {
+		return nil, p.errorf("expected an operator after %q, found %s", tok.text, opTok)
+	}
+	if op == "pr" {
+		return presentFilter{path: path}, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return compareFilter{path: path, op: op, value: value}, nil
+}
+
+// parseGroup parses the rest of a parenthesized filter after its "("
+func (p *parser) parseGroup() (Filter, error) {
+	inner, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenCloseParen, `")"`); err != nil {
+		return nil, err
+	}
+	return inner, nil
+}
+
+// parseValue parses a comparison value: a string, true, false, null or a number
+func (p *parser) parseValue() (interface{}, error) {
+	tok := p.next()
+	if tok.kind == tokenString {
+		return tok.text, nil
+	}
+	if tok.kind == tokenWord {
+		switch strings.ToLower(tok.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		if number, err := strconv.ParseFloat(tok.text, 64); err == nil {
+			return number, nil
+		}
+	}
+	return nil, p.errorf("expected a value, found %s", tok)
+}
+
+type orFilter struct{ left, right Filter }
+
+func (f orFilter) Matches(resource map[string]interface{}) bool {
+	return f.left.Matches(resource) || f.right.Matches(resource)
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f andFilter) Matches(resource map[string]interface{}) bool {
+	return f.left.Matches(resource) && f.right.Matches(resource)
+}
+
+type notFilter struct{ inner Filter }
+
+func (f notFilter) Matches(resource map[string]interface{}) bool {
+	return !f.inner.Matches(resource)
+}
+
+// valuePathFilter matches resources with a value of a multi-valued attribute satisfying the inner filter
+type valuePathFilter struct {
+	path  attributePath
+	inner Filter
+}
+
+func (f valuePathFilter) Matches(resource map[string]interface{}) bool {
+	for _, object := range f.path.objects(resource) {
+		if f.inner.Matches(object) {
+			return true
+		}
+	}
+	return false
+}
+
+// presentFilter matches resources that have a non-empty value for the attribute
+type presentFilter struct {
+	path attributePath
+}
+
+func (f presentFilter) Matches(resource map[string]interface{}) bool {
+	if f.path.sub == "" {
+		container, key := f.path.locate(resource, false)
+		return container != nil && !isEmpty(container[key])
+	}
+	for _, value := range f.path.values(resource) {
+		if !isEmpty(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareFilter compares the values of an attribute with a literal.
+// Strings compare case-insensitively, and strings holding RFC 3339 times compare as times.
+type compareFilter struct {
+	path  attributePath
+	op    string
+	value interface{}
+}
+
+func (f compareFilter) Matches(resource map[string]interface{}) bool {
+	values := f.path.values(resource)
+	if f.value == nil {
+		// "eq null" matches missing attributes and "ne null" present ones
+		return (len(values) == 0) == (f.op == "eq")
+	}
+	if f.op == "ne" {
+		return !compareFilter{path: f.path, op: "eq", value: f.value}.Matches(resource)
+	}
+	for _, value := range values {
+		if compareValue(value, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValue applies a comparison operator other than "ne" to an attribute value and a literal
+func compareValue(attribute interface{}, op string, literal interface{}) bool {
+	switch want := literal.(type) {
+	case bool:
+		got, ok := attribute.(bool)
+		return ok && op == "eq" && got == want
+	case float64:
+		got, ok := attribute.(float64)
+		return ok && ordered(cmp.Compare(got, want), op)
+	case string:
+		got, ok := attribute.(string)
+		if !ok {
+			return false
+		}
+		lowerGot, lowerWant := strings.ToLower(got), strings.ToLower(want)
+		switch op {
+		case "co":
+			return strings.Contains(lowerGot, lowerWant)
+		case "sw":
+			return strings.HasPrefix(lowerGot, lowerWant)
+		case "ew":
+			return strings.HasSuffix(lowerGot, lowerWant)
+		}
+		gotTime, gotErr := time.Parse(time.RFC3339Nano, got)
+		wantTime, wantErr := time.Parse(time.RFC3339Nano, want)
+		if gotErr == nil && wantErr == nil {
+			return ordered(gotTime.Compare(wantTime), op)
+		}
+		return ordered(strings.Compare(lowerGot, lowerWant), op)
+	}
+	return false
+}
+
+// ordered applies an ordering operator to the result of a comparison
+func ordered(result int, op string) bool {
+	switch op {
+	case "eq":
+		return result == 0
+	case "gt":
+		return result > 0
+	case "ge":
+		return result >= 0
+	case "lt":
+		return result < 0
+	case "le":
+		return result <= 0
+	}
+	return false
+}
+
+// isEmpty reports whether a value counts as absent: null, an empty string, list or object
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
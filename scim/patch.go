@@ -0,0 +1,270 @@
+package scim
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"hrm/domain"
+)
+
+// PATCH operation types; clients send them in any case
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// patchPath is the target of a PATCH operation, e.g. "name.givenName" or `emails[type eq "work"].value`
+type patchPath struct {
+	attributePath
+	filter Filter // Selects values of a multi-valued attribute; nil targets the whole attribute
+}
+
+// parsePatchPath parses the path of a PATCH operation; errors wrap domain.ErrSCIMInvalidPath
+// or, for a bad value filter, domain.ErrSCIMInvalidFilter
+func parsePatchPath(text string) (patchPath, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return patchPath{}, fmt.Errorf("%w: %v", domain.ErrSCIMInvalidPath, err)
+	}
+	invalid := fmt.Errorf("%w: %q", domain.ErrSCIMInvalidPath, text)
+
+	p := &parser{tokens: tokens}
+	tok := p.next()
+	if tok.kind != tokenWord {
+		return patchPath{}, invalid
+	}
+	attribute, ok := parseAttributePath(tok.text)
+	if !ok {
+		return patchPath{}, invalid
+	}
+	path := patchPath{attributePath: attribute}
+
+	if p.peek().kind == tokenOpenBracket {
+		if path.sub != "" {
+			return patchPath{}, invalid
+		}
+		p.next()
+		if path.filter, err = p.parseOr(); err != nil {
+			return patchPath{}, err
+		}
+		if p.next().kind != tokenCloseBracket {
+			return patchPath{}, invalid
+		}
+		if tok := p.peek(); tok.kind == tokenWord && strings.HasPrefix(tok.text, ".") {
+			p.next()
+			path.sub = tok.text[1:]
+			if !validAttributeName(path.sub) {
+				return patchPath{}, invalid
+			}
+		}
+	}
+
+	if p.peek().kind != tokenEOF {
+		return patchPath{}, invalid
+	}
+	return path, nil
+}
+
+// ApplyPatch applies PATCH operations (RFC 7644, section 3.5.2) to a resource in the generic form returned by ToMap.
+// Attribute names match case-insensitively. Operations without a path apply each attribute of their value,
+// which may itself be a path such as "name.givenName". The caller decides which of the changed attributes it takes over.
+func ApplyPatch(resource map[string]interface{}, operations []domain.SCIMPatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != opAdd && op != opReplace && op != opRemove {
+			return fmt.Errorf("%w: unknown op %q", domain.ErrSCIMInvalidPatch, operation.Op)
+		}
+
+		if operation.Path != "" {
+			path, err := parsePatchPath(operation.Path)
+			if err != nil {
+				return err
+			}
+			if err := applyOperation(resource, op, path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if op == opRemove {
+			return fmt.Errorf("%w: remove needs a path", domain.ErrSCIMNoTarget)
+		}
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: %s without a path needs an object value", domain.ErrSCIMInvalidPatch, op)
+		}
+		// Sorted so that a complex attribute is applied before paths into it
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			path, err := parsePatchPath(name)
+			if err != nil {
+				return err
+			}
+			if err := applyOperation(resource, op, path, values[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyOperation applies one add, replace or remove to the attribute, sub-attribute or values the path selects
+func applyOperation(resource map[string]interface{}, op string, path patchPath, value interface{}) error {
+	container, key := path.locate(resource, op != opRemove)
+	if container == nil {
+		return nil
+	}
+
+	if path.filter == nil {
+		if path.sub == "" {
+			return setAttribute(container, key, op, value)
+		}
+
+		// Sub-attribute of a complex attribute, or of every value of a multi-valued one
+		switch current := container[key].(type) {
+		case map[string]interface{}:
+			return setAttribute(current, findKey(current, path.sub), op, value)
+		case []interface{}:
+			for _, item := range current {
+				if object, ok := item.(map[string]interface{}); ok {
+					if err := setAttribute(object, findKey(object, path.sub), op, value); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		case nil:
+			if op == opRemove {
+				return nil
+			}
+			object := map[string]interface{}{}
+			container[key] = object
+			return setAttribute(object, path.sub, op, value)
+		default:
+			return fmt.Errorf("%w: %q has no sub-attributes", domain.ErrSCIMInvalidPath, path.attribute)
+		}
+	}
+
+	// Values of a multi-valued attribute selected by the filter
+	matched := 0
+	var kept []interface{}
+	for _, item := range asList(container[key]) {
+		object, ok := item.(map[string]interface{})
+		if !ok || !path.filter.Matches(object) {
+			kept = append(kept, item)
+			continue
+		}
+		matched++
+
+		switch {
+		case path.sub != "":
+			if err := setAttribute(object, findKey(object, path.sub), op, value); err != nil {
+				return err
+			}
+		case op == opRemove:
+			continue
+		default:
+			replacement, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%w: values of %q must be objects", domain.ErrSCIMInvalidValue, path.attribute)
+			}
+			if op == opReplace {
+				object = map[string]interface{}{}
+			}
+			merge(object, replacement)
+			item = object
+		}
+		kept = append(kept, item)
+	}
+
+	// Removing values that are already gone is not an error, so retried removals succeed
+	if matched == 0 && op != opRemove {
+		return fmt.Errorf("%w: no value of %q matches the filter", domain.ErrSCIMNoTarget, path.attribute)
+	}
+	container[key] = kept
+	return nil
+}
+
+// setAttribute applies an operation to one attribute of an object.
+// Adding to a multi-valued attribute appends the new values and adding to or replacing a complex one
+// keeps the sub-attributes not given; removing with a value removes just those values.
+func setAttribute(object map[string]interface{}, key, op string, value interface{}) error {
+	current := object[key]
+	switch op {
+	case opRemove:
+		if list, ok := current.([]interface{}); ok && value != nil {
+			object[key] = removeValues(list, asList(value))
+		} else {
+			delete(object, key)
+		}
+	case opAdd:
+		if list, ok := current.([]interface{}); ok {
+			object[key] = appendValues(list, asList(value))
+			return nil
+		}
+		fallthrough
+	case opReplace:
+		currentObject, currentIsObject := current.(map[string]interface{})
+		newObject, newIsObject := value.(map[string]interface{})
+		if currentIsObject && newIsObject {
+			merge(currentObject, newObject)
+			return nil
+		}
+		object[key] = value
+	}
+	return nil
+}
+
+// merge sets the attributes of src on dst, matching names case-insensitively
+func merge(dst, src map[string]interface{}) {
+	for name, value := range src {
+		dst[findKey(dst, name)] = value
+	}
+}
+
+// appendValues adds the values that are not in the list yet
+func appendValues(list, values []interface{}) []interface{} {
+	for _, value := range values {
+		if indexOfValue(list, value) < 0 {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// removeValues returns the list without the given values
+func removeValues(list, values []interface{}) []interface{} {
+	var kept []interface{}
+	for _, item := range list {
+		if indexOfValue(values, item) < 0 {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// indexOfValue finds a value in a list; complex values are the same if their "value" sub-attributes are
+func indexOfValue(list []interface{}, value interface{}) int {
+	for i, item := range list {
+		itemObject, itemIsObject := item.(map[string]interface{})
+		valueObject, valueIsObject := value.(map[string]interface{})
+		if itemIsObject && valueIsObject {
+			itemValue, valueValue := itemObject[findKey(itemObject, "value")], valueObject[findKey(valueObject, "value")]
+			if itemValue != nil && reflect.DeepEqual(itemValue, valueValue) {
+				return i
+			}
+			continue
+		}
+		if reflect.DeepEqual(item, value) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,182 @@
+// Package scim implements the protocol parts of SCIM 2.0 (RFC 7643 and RFC 7644) that do not depend on
+// how HRM stores users: filters and PATCH operations, applied to resources in their generic JSON form.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hrm/domain"
+)
+
+// ToMap converts a resource to the generic form filters and patches work on
+func ToMap(resource interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// FromMap decodes a resource from its generic form.
+// Attributes of the wrong type are reported as domain.ErrSCIMInvalidValue.
+func FromMap(values map[string]interface{}, resource interface{}) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, resource); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSCIMInvalidValue, err)
+	}
+	return nil
+}
+
+// extensionSchemas are the schema extensions whose URN refers to the whole extension in paths
+var extensionSchemas = []string{domain.SCIMSchemaEnterpriseUser}
+
+// coreSchemas are the schemas whose URN may prefix top-level attributes in paths
+var coreSchemas = []string{domain.SCIMSchemaUser, domain.SCIMSchemaGroup}
+
+// attributePath refers to an attribute such as "userName", "name.givenName" or
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager"
+type attributePath struct {
+	schema    string // Extension schema URN holding the attribute; empty for top-level attributes
+	attribute string
+	sub       string // Sub-attribute of a complex attribute; empty for the whole attribute
+}
+
+// parseAttributePath parses an attribute path and reports whether it is valid
+func parseAttributePath(text string) (attributePath, bool) {
+	var path attributePath
+	if len(text) > 4 && strings.EqualFold(text[:4], "urn:") {
+		for _, schema := range extensionSchemas {
+			if strings.EqualFold(text, schema) {
+				return attributePath{attribute: schema}, true
+			}
+		}
+		colon := strings.LastIndex(text, ":")
+		path.schema, text = text[:colon], text[colon+1:]
+		for _, schema := range coreSchemas {
+			if strings.EqualFold(path.schema, schema) {
+				path.schema = ""
+			}
+		}
+	}
+
+	path.attribute, path.sub, _ = strings.Cut(text, ".")
+	if !validAttributeName(path.attribute) || (path.sub != "" && !validAttributeName(path.sub)) {
+		return attributePath{}, false
+	}
+	return path, true
+}
+
+// validAttributeName checks an attribute name: a letter (or "$" for "$ref") followed by letters, digits, "-" and "_"
+func validAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i == 0 && c == '$':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-' || c == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// locate returns the object holding the attribute and the attribute's key in it.
+// A missing extension object is added when create is set; otherwise the object is nil.
+func (p attributePath) locate(resource map[string]interface{}, create bool) (map[string]interface{}, string) {
+	container := resource
+	if p.schema != "" {
+		key := findKey(resource, p.schema)
+		extension, ok := resource[key].(map[string]interface{})
+		if !ok {
+			if !create {
+				return nil, ""
+			}
+			extension = map[string]interface{}{}
+			resource[key] = extension
+		}
+		container = extension
+	}
+	return container, findKey(container, p.attribute)
+}
+
+// values returns the values the path refers to, looking through multi-valued attributes.
+// Complex values without a sub-attribute are represented by their "value" sub-attribute.
+func (p attributePath) values(resource map[string]interface{}) []interface{} {
+	container, key := p.locate(resource, false)
+	if container == nil || container[key] == nil {
+		return nil
+	}
+
+	var values []interface{}
+	for _, item := range asList(container[key]) {
+		object, isObject := item.(map[string]interface{})
+		sub := p.sub
+		if !isObject {
+			if sub == "" {
+				values = append(values, item)
+			}
+			continue
+		}
+		if sub == "" {
+			sub = "value"
+		}
+		if value := object[findKey(object, sub)]; value != nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// objects returns the complex values of the attribute the path refers to
+func (p attributePath) objects(resource map[string]interface{}) []map[string]interface{} {
+	container, key := p.locate(resource, false)
+	if container == nil {
+		return nil
+	}
+
+	var objects []map[string]interface{}
+	for _, item := range asList(container[key]) {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// findKey returns the key an attribute has in the object, matching names case-insensitively.
+// The name itself is returned for attributes the object does not have yet.
+func findKey(object map[string]interface{}, name string) string {
+	if _, ok := object[name]; ok {
+		return name
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
+
+// asList returns the values of a multi-valued attribute, or a single value as a list of one
+func asList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
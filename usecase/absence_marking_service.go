@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"log"
+	"time"
+)
+
+// AbsenceMarkingService implements the AbsenceMarkingServiceInterface
+// This struct contains the logic of the job that records days employees did not attend
+type AbsenceMarkingService struct {
+	attendanceRepo domain.AttendanceRepositoryInterface
+	timesheetRepo  domain.TimesheetRepositoryInterface
+	userRepo       domain.UserRepositoryInterface
+	leaveRepo      domain.LeaveRepositoryInterface
+	holidayRepo    domain.HolidayRepositoryInterface
+	locationRepo   domain.LocationRepositoryInterface
+	policy         domain.AttendancePolicy
+}
+
+// NewAbsenceMarkingService creates a new instance of AbsenceMarkingService
+func NewAbsenceMarkingService(
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	timesheetRepo domain.TimesheetRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	leaveRepo domain.LeaveRepositoryInterface,
+	holidayRepo domain.HolidayRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	policy domain.AttendancePolicy,
+) domain.AbsenceMarkingServiceInterface {
+	return &AbsenceMarkingService{
+		attendanceRepo: attendanceRepo,
+		timesheetRepo:  timesheetRepo,
+		userRepo:       userRepo,
+		leaveRepo:      leaveRepo,
+		holidayRepo:    holidayRepo,
+		locationRepo:   locationRepo,
+		policy:         policy,
+	}
+}
+
+// MarkAbsences creates attendance rows for active users who never checked in on a past working day.
+// Each user's days are taken in their own time zone, so a day is only processed once it has ended
+// for that user. Weekends and holidays are skipped; days covered by an approved leave are recorded
+// as "on_leave" instead of "absent". The job is idempotent: days that already have an attendance
+// are left untouched. It returns the number of rows created.
+func (s *AbsenceMarkingService) MarkAbsences(now time.Time) (int, error) {
+	users, err := s.userRepo.ListActive()
+	if err != nil {
+		return 0, err
+	}
+
+	lookback := s.policy.AbsenceLookbackDays
+	if lookback < 1 {
+		lookback = 1
+	}
+
+	calendar := newAbsenceCalendar(s.holidayRepo, s.leaveRepo)
+	created := 0
+	for i := range users {
+		user := &users[i]
+		loc := userTimeLocation(user, s.locationRepo, s.policy)
+		today := domain.WorkDay(now, loc)
+		joined := domain.WorkDay(user.CreatedAt, loc)
+
+		for offset := lookback; offset >= 1; offset-- {
+			day := today.AddDate(0, 0, -offset)
+			if day.Before(joined) {
+				continue
+			}
+
+			ok, err := s.markDay(user, day, loc, calendar)
+			if err != nil {
+				// Keep going so one broken record does not block the others
+				log.Printf("Absence marking for user %d on %s failed: %v", user.ID, day.Format("2006-01-02"), err)
+				continue
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+
+	return created, nil
+}
+
+// markDay records an absence or leave for a single user and day if no attendance exists
+func (s *AbsenceMarkingService) markDay(user *domain.User, day time.Time, loc *time.Location, calendar *absenceCalendar) (bool, error) {
+	if s.policy.IsWeekend(day) {
+		return false, nil
+	}
+
+	isHoliday, err := calendar.isHoliday(day, user.LocationID)
+	if err != nil || isHoliday {
+		return false, err
+	}
+
+	// Leave days that already have an attendance alone
+	if _, err := s.attendanceRepo.GetByUserID(user.ID, day); err == nil {
+		return false, nil
+	} else if !errors.Is(err, domain.ErrAttendanceNotFound) {
+		return false, err
+	}
+
+	// Weeks with an approved timesheet are not changed anymore
+	if err := checkWeekUnlocked(s.timesheetRepo, user.ID, day); err != nil {
+		if errors.Is(err, domain.ErrAttendanceLocked) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	status := domain.AttendanceStatusAbsent
+	onLeave, err := calendar.isOnLeave(day, user.ID)
+	if err != nil {
+		return false, err
+	}
+	if onLeave {
+		status = domain.AttendanceStatusOnLeave
+	}
+
+	attendance := &domain.Attendance{
+		UserID:   user.ID,
+		Date:     day,
+		Timezone: loc.String(),
+		Status:   status,
+	}
+	if err := s.attendanceRepo.Create(attendance); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// absenceCalendar caches holidays and approved leaves per day for a single job run
+type absenceCalendar struct {
+	holidayRepo domain.HolidayRepositoryInterface
+	leaveRepo   domain.LeaveRepositoryInterface
+	holidays    map[time.Time][]domain.Holiday
+	leaves      map[time.Time]map[uint]bool
+}
+
+// newAbsenceCalendar creates an empty calendar backed by the given repositories
+func newAbsenceCalendar(holidayRepo domain.HolidayRepositoryInterface, leaveRepo domain.LeaveRepositoryInterface) *absenceCalendar {
+	return &absenceCalendar{
+		holidayRepo: holidayRepo,
+		leaveRepo:   leaveRepo,
+		holidays:    make(map[time.Time][]domain.Holiday),
+		leaves:      make(map[time.Time]map[uint]bool),
+	}
+}
+
+// isHoliday returns true if a holiday on the given day applies to the location
+func (c *absenceCalendar) isHoliday(day time.Time, locationID *uint) (bool, error) {
+	holidays, ok := c.holidays[day]
+	if !ok {
+		var err error
+		holidays, err = c.holidayRepo.GetByDateRange(day, day)
+		if err != nil {
+			return false, err
+		}
+		c.holidays[day] = holidays
+	}
+
+	for i := range holidays {
+		if holidays[i].AppliesTo(locationID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isOnLeave returns true if the user has an approved leave covering the given day
+func (c *absenceCalendar) isOnLeave(day time.Time, userID uint) (bool, error) {
+	onLeave, ok := c.leaves[day]
+	if !ok {
+		leaves, err := c.leaveRepo.GetApprovedOnDate(day)
+		if err != nil {
+			return false, err
+		}
+		onLeave = make(map[uint]bool, len(leaves))
+		for _, leave := range leaves {
+			onLeave[leave.UserID] = true
+		}
+		c.leaves[day] = onLeave
+	}
+
+	return onLeave[userID], nil
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+const (
+	// Prefixes make the mailed tokens recognizable
+	passwordResetTokenPrefix     = "hrmpr_"
+	emailVerificationTokenPrefix = "hrmev_"
+
+	// accountTokenResendDelay is how long a user has to wait before another email of the same kind is sent
+	accountTokenResendDelay = time.Minute
+)
+
+// AccountService implements the AccountServiceInterface
+// This struct contains the business logic for proving ownership of an email address:
+// resetting a forgotten password and verifying the address
+type AccountService struct {
+	tokenRepo       domain.AccountTokenRepositoryInterface
+	userRepo        domain.UserRepositoryInterface
+	sessionRepo     domain.AuthSessionRepositoryInterface
+	mailSender      domain.MailSenderInterface
+	appBaseURL      string
+	resetTTL        time.Duration
+	verificationTTL time.Duration
+	passwords       passwordManager
+	directoryLogin  bool // Directory users sign in with their directory password, which HRM cannot reset
+}
+
+// NewAccountService creates a new instance of AccountService.
+// Links in the emails point to the web app at appBaseURL.
+// With directoryLogin, users synced from the LDAP directory cannot reset their password in HRM.
+func NewAccountService(
+	tokenRepo domain.AccountTokenRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	sessionRepo domain.AuthSessionRepositoryInterface,
+	passwordHistoryRepo domain.PasswordHistoryRepositoryInterface,
+	mailSender domain.MailSenderInterface,
+	appBaseURL string,
+	resetTTL time.Duration,
+	verificationTTL time.Duration,
+	passwordPolicy domain.PasswordPolicy,
+	directoryLogin bool,
+) domain.AccountServiceInterface {
+	return &AccountService{
+		tokenRepo:       tokenRepo,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		mailSender:      mailSender,
+		appBaseURL:      strings.TrimRight(appBaseURL, "/"),
+		resetTTL:        resetTTL,
+		verificationTTL: verificationTTL,
+		passwords:       passwordManager{policy: passwordPolicy, historyRepo: passwordHistoryRepo},
+		directoryLogin:  directoryLogin,
+	}
+}
+
+// RequestPasswordReset mails a password reset link to the user with the given email.
+// Unknown and deactivated addresses and repeated requests succeed without sending anything,
+// so the response does not reveal which addresses are registered.
+func (s *AccountService) RequestPasswordReset(email string) error {
+	now := time.Now().UTC()
+
+	user, err := s.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		log.Printf("Password reset requested for deactivated user %d; no email sent", user.ID)
+		return nil
+	}
+	if s.directoryLogin && user.IsFromDirectory() {
+		log.Printf("Password reset requested for directory user %d; no email sent", user.ID)
+		return nil
+	}
+	if user.IsServiceAccount {
+		log.Printf("Password reset requested for service account %d; no email sent", user.ID)
+		return nil
+	}
+
+	recent, err := s.recentlySent(user.ID, domain.AccountTokenPasswordReset, now)
+	if err != nil || recent {
+		return err
+	}
+
+	token, err := s.issueToken(user, domain.AccountTokenPasswordReset, passwordResetTokenPrefix, now.Add(s.resetTTL))
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(domain.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your HRM password",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"Someone asked to reset the password of your HRM account. To choose a new password, open this link:\n\n"+
+			"%s\n\n"+
+			"The link can be used once and expires in %s. If you did not ask for a new password, you can ignore this email.\n",
+			user.Name, s.link("/reset-password", token), formatTTL(s.resetTTL)),
+	})
+}
+
+// ResetPassword sets a new password with a token from a password reset email.
+// This method performs the following business operations:
+//  1. Checks the token and the new password against the password policy and the user's recent passwords;
+//     a refused password leaves the token usable
+//  2. Uses up the token
+//  3. Saves the new password, remembers the old one and lifts a sign-in lockout; the email address counts
+//     as verified since the user received the link
+//  4. Invalidates other reset links and signs the user out everywhere
+func (s *AccountService) ResetPassword(token, newPassword string) error {
+	now := time.Now().UTC()
+
+	// Step 1: Check the token and the password
+	accountToken, user, err := s.checkToken(token, domain.AccountTokenPasswordReset, now)
+	if err != nil {
+		return err
+	}
+	if s.directoryLogin && user.IsFromDirectory() {
+		return domain.ErrPasswordManagedByDirectory
+	}
+	hashedPassword, err := s.passwords.hashNewPassword(user, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Step 2: Use up the token
+	accountToken.UsedAt = &now
+	if err := s.tokenRepo.Update(accountToken); err != nil {
+		return err
+	}
+
+	// Step 3: Save the new password
+	oldHash := user.Password
+	user.Password = hashedPassword
+	user.ResetFailedSignIns()
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	if err := s.passwords.rememberPassword(user.ID, oldHash); err != nil {
+		return err
+	}
+
+	// Step 4: Nothing issued before the reset may keep working
+	if err := s.tokenRepo.InvalidateByUserID(user.ID, domain.AccountTokenPasswordReset); err != nil {
+		return err
+	}
+	return s.sessionRepo.RevokeAllByUserID(user.ID, domain.SessionRevokedPasswordChanged)
+}
+
+// SendEmailVerification mails a link to verify the user's current email address
+func (s *AccountService) SendEmailVerification(userID uint) error {
+	now := time.Now().UTC()
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	recent, err := s.recentlySent(user.ID, domain.AccountTokenEmailVerification, now)
+	if err != nil {
+		return err
+	}
+	if recent {
+		return domain.ErrAccountTokenRecentlySent
+	}
+
+	token, err := s.issueToken(user, domain.AccountTokenEmailVerification, emailVerificationTokenPrefix, now.Add(s.verificationTTL))
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(domain.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address for HRM",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"Please confirm that %s is your email address by opening this link:\n\n"+
+			"%s\n\n"+
+			"The link expires in %s.\n",
+			user.Name, user.Email, s.link("/verify-email", token), formatTTL(s.verificationTTL)),
+	})
+}
+
+// VerifyEmail marks the user's email address as verified with a token from a verification email
+func (s *AccountService) VerifyEmail(token string) (*domain.User, error) {
+	now := time.Now().UTC()
+
+	user, err := s.useToken(token, domain.AccountTokenEmailVerification, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Sanitize()
+	return user, nil
+}
+
+// IsEmailVerified reports whether a user has verified their email address
+func (s *AccountService) IsEmailVerified(userID uint) (bool, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsEmailVerified(), nil
+}
+
+// issueToken creates a new token for the user, replacing the unused ones of the same purpose
+func (s *AccountService) issueToken(user *domain.User, purpose, prefix string, expiresAt time.Time) (string, error) {
+	token, err := generateOpaqueToken(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.InvalidateByUserID(user.ID, purpose); err != nil {
+		return "", err
+	}
+	if err := s.tokenRepo.Create(&domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashOpaqueToken(token),
+		Email:     user.Email,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// useToken checks a mailed token and marks it used, returning its user
+func (s *AccountService) useToken(token, purpose string, now time.Time) (*domain.User, error) {
+	accountToken, user, err := s.checkToken(token, purpose, now)
+	if err != nil {
+		return nil, err
+	}
+
+	accountToken.UsedAt = &now
+	if err := s.tokenRepo.Update(accountToken); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkToken looks up a mailed token and its user without using it up.
+// Tokens sent to an address the user no longer has are refused.
+func (s *AccountService) checkToken(token, purpose string, now time.Time) (*domain.AccountToken, *domain.User, error) {
+	accountToken, err := s.tokenRepo.GetByTokenHash(hashOpaqueToken(strings.TrimSpace(token)))
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountTokenNotFound) {
+			return nil, nil, domain.ErrInvalidAccountToken
+		}
+		return nil, nil, err
+	}
+	if accountToken.Purpose != purpose || !accountToken.IsUsableAt(now) {
+		return nil, nil, domain.ErrInvalidAccountToken
+	}
+
+	user, err := s.userRepo.GetByID(accountToken.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, domain.ErrInvalidAccountToken
+		}
+		return nil, nil, err
+	}
+	if !strings.EqualFold(user.Email, accountToken.Email) {
+		return nil, nil, domain.ErrInvalidAccountToken
+	}
+
+	return accountToken, user, nil
+}
+
+// recentlySent reports whether an email of the given purpose was sent to the user moments ago
+func (s *AccountService) recentlySent(userID uint, purpose string, now time.Time) (bool, error) {
+	latest, err := s.tokenRepo.GetLatest(userID, purpose)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountTokenNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return now.Sub(latest.CreatedAt) < accountTokenResendDelay, nil
+}
+
+// link builds the web app URL a mailed token is opened with
+func (s *AccountService) link(path, token string) string {
+	return s.appBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// formatTTL renders a token lifetime for an email, e.g. "48 hours" or "1 hour 30 minutes"
+func formatTTL(ttl time.Duration) string {
+	ttl = ttl.Round(time.Minute)
+	hours := int(ttl / time.Hour)
+	minutes := int(ttl % time.Hour / time.Minute)
+
+	switch {
+	case hours == 0:
+		return pluralize(minutes, "minute")
+	case minutes == 0:
+		return pluralize(hours, "hour")
+	default:
+		return pluralize(hours, "hour") + " " + pluralize(minutes, "minute")
+	}
+}
+
+// pluralize renders a count with its unit, e.g. "1 hour" or "2 hours"
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
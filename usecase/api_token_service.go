@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// apiTokenHintLength is how much of a token is kept in the clear to recognize it, e.g. "hrm_pat_3f9a1c"
+const apiTokenHintLength = len(domain.APITokenPrefix) + 6
+
+// serviceAccountEmailDomain holds the made-up email addresses of service accounts.
+// The .invalid top-level domain never resolves, so nothing can be mailed to them.
+const serviceAccountEmailDomain = "service-accounts.invalid"
+
+// APITokenService implements the APITokenServiceInterface
+// This struct contains the business logic for personal access tokens and the service accounts integrations use
+type APITokenService struct {
+	tokenRepo   domain.APITokenRepositoryInterface
+	userRepo    domain.UserRepositoryInterface
+	tokenTTL    time.Duration // Lifetime of tokens created without an expiry
+	maxTokenTTL time.Duration // Longest lifetime a token can be created with
+}
+
+// NewAPITokenService creates a new instance of APITokenService
+func NewAPITokenService(
+	tokenRepo domain.APITokenRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	tokenTTL time.Duration,
+	maxTokenTTL time.Duration,
+) domain.APITokenServiceInterface {
+	return &APITokenService{
+		tokenRepo:   tokenRepo,
+		userRepo:    userRepo,
+		tokenTTL:    tokenTTL,
+		maxTokenTTL: maxTokenTTL,
+	}
+}
+
+// CreateToken issues a token for a user.
+// This method performs the following business operations:
+// 1. Checks that the user exists and is active
+// 2. Normalizes the scopes and checks the expiry against the maximum lifetime
+// 3. Generates the token and saves its hash
+// The token is only returned here; it cannot be retrieved later.
+func (s *APITokenService) CreateToken(userID uint, name string, scopes []string, expiresAt *time.Time) (*domain.APIToken, string, error) {
+	// Step 1: Tokens act as their user, who has to be able to act
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !user.IsActive {
+		return nil, "", domain.ErrUserInactive
+	}
+
+	// Step 2: Normalize the scopes and pick the expiry
+	var granted []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope != "" && !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	now := time.Now().UTC()
+	expiry := now.Add(s.tokenTTL)
+	if expiresAt != nil {
+		expiry = expiresAt.UTC()
+		if !expiry.After(now) || expiry.Sub(now) > s.maxTokenTTL {
+			return nil, "", domain.ErrInvalidAPITokenExpiry
+		}
+	}
+
+	// Step 3: Generate the token and save its hash
+	token, err := generateOpaqueToken(domain.APITokenPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	apiToken := &domain.APIToken{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		TokenHash: hashOpaqueToken(token),
+		Hint:      token[:apiTokenHintLength],
+		Scopes:    strings.Join(granted, " "),
+		ExpiresAt: expiry,
+	}
+	if err := s.tokenRepo.Create(apiToken); err != nil {
+		return nil, "", err
+	}
+
+	log.Printf("API token %d (%s) created for user %d with scopes %s", apiToken.ID, apiToken.Name, userID, apiToken.Scopes)
+	return apiToken, token, nil
+}
+
+// ListTokens retrieves a user's tokens, newest first
+func (s *APITokenService) ListTokens(userID uint) ([]domain.APIToken, error) {
+	return s.tokenRepo.GetByUserID(userID)
+}
+
+// RevokeToken revokes one of a user's tokens; it stops working immediately.
+// Tokens of other users are reported as not found.
+func (s *APITokenService) RevokeToken(userID, tokenID uint) error {
+	apiToken, err := s.tokenRepo.GetByID(tokenID)
+	if err != nil {
+		return err
+	}
+	if apiToken.UserID != userID {
+		return domain.ErrAPITokenNotFound
+	}
+	if apiToken.IsRevoked() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	apiToken.RevokedAt = &now
+	if err := s.tokenRepo.Update(apiToken); err != nil {
+		return err
+	}
+
+	log.Printf("API token %d (%s) of user %d revoked", apiToken.ID, apiToken.Name, userID)
+	return nil
+}
+
+// AuthenticateAPIToken resolves the usable token sent with a request and its active user,
+// and records when and from where the token was last used
+func (s *APITokenService) AuthenticateAPIToken(token, clientIP string) (*domain.APIToken, *domain.User, error) {
+	if !strings.HasPrefix(token, domain.APITokenPrefix) {
+		return nil, nil, domain.ErrInvalidAPIToken
+	}
+
+	apiToken, err := s.tokenRepo.GetByTokenHash(hashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPITokenNotFound) {
+			return nil, nil, domain.ErrInvalidAPIToken
+		}
+		return nil, nil, err
+	}
+	now := time.Now().UTC()
+	if !apiToken.IsUsableAt(now) {
+		return nil, nil, domain.ErrInvalidAPIToken
+	}
+
+	// Tokens of deleted users stop working; those of deactivated users until they are re-activated
+	user, err := s.userRepo.GetByID(apiToken.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, domain.ErrInvalidAPIToken
+		}
+		return nil, nil, err
+	}
+	if !user.IsActive {
+		return nil, nil, domain.ErrUserInactive
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= domain.APITokenLastUsedInterval || apiToken.LastUsedIP != clientIP {
+		if err := s.tokenRepo.RecordUse(apiToken.ID, now, clientIP); err != nil {
+			return nil, nil, err
+		}
+		apiToken.LastUsedAt = &now
+		apiToken.LastUsedIP = clientIP
+	}
+
+	user.Sanitize()
+	return apiToken, user, nil
+}
+
+// CreateServiceAccount creates an active user for an integration.
+// Service accounts have a made-up email address and a password nobody knows, so they cannot sign in;
+// they act through the API tokens created for them. The address counts as verified so that
+// tokens with approve scopes work when verified emails are required.
+func (s *APITokenService) CreateServiceAccount(name, role string) (*domain.User, error) {
+	if role == "" {
+		role = domain.RoleEmployee
+	}
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	email, err := serviceAccountEmail(name)
+	if err != nil {
+		return nil, err
+	}
+	password, err := unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	user := &domain.User{
+		Name:             strings.TrimSpace(name),
+		Email:            email,
+		Password:         password,
+		IsActive:         true,
+		Role:             role,
+		IsServiceAccount: true,
+		EmailVerifiedAt:  &now,
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Service account %d (%s) created with role %s", user.ID, user.Name, user.Role)
+	user.Sanitize()
+	return user, nil
+}
+
+// ListServiceAccounts retrieves all service accounts
+func (s *APITokenService) ListServiceAccounts() ([]domain.User, error) {
+	users, err := s.userRepo.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		users[i].Sanitize()
+	}
+	return users, nil
+}
+
+// GetServiceAccount retrieves a service account by user ID; other users are reported as not found
+func (s *APITokenService) GetServiceAccount(id uint) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		return nil, err
+	}
+	if !user.IsServiceAccount {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+
+	user.Sanitize()
+	return user, nil
+}
+
+// DeleteServiceAccount revokes a service account's tokens and deletes it
+func (s *APITokenService) DeleteServiceAccount(id uint) error {
+	if _, err := s.GetServiceAccount(id); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllByUserID(id); err != nil {
+		return err
+	}
+	if err := s.userRepo.Delete(id); err != nil {
+		return err
+	}
+
+	log.Printf("Service account %d deleted", id)
+	return nil
+}
+
+// serviceAccountEmail makes up a unique email address for a service account from its name,
+// e.g. "payroll-export.3f9a1c2b@service-accounts.invalid"
+func serviceAccountEmail(name string) (string, error) {
+	var slug strings.Builder
+	for _, c := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			slug.WriteRune(c)
+		case slug.Len() > 0 && !strings.HasSuffix(slug.String(), "-"):
+			slug.WriteByte('-')
+		}
+		if slug.Len() >= 40 {
+			break
+		}
+	}
+	local := strings.Trim(slug.String(), "-")
+	if local == "" {
+		local = "service"
+	}
+
+	suffix, err := generateOpaqueToken("")
+	if err != nil {
+		return "", err
+	}
+	return local + "." + suffix[:8] + "@" + serviceAccountEmailDomain, nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"hrm/domain"
+	"log"
+	"time"
+)
+
+// AttendanceAutoCloseService implements the AttendanceAutoCloseServiceInterface
+// This struct contains the logic of the job that closes check-outs employees forgot
+type AttendanceAutoCloseService struct {
+	attendanceRepo domain.AttendanceRepositoryInterface
+	sessionRepo    domain.AttendanceSessionRepositoryInterface
+	breakRepo      domain.BreakRepositoryInterface
+	policy         domain.AttendancePolicy
+}
+
+// NewAttendanceAutoCloseService creates a new instance of AttendanceAutoCloseService
+func NewAttendanceAutoCloseService(
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	sessionRepo domain.AttendanceSessionRepositoryInterface,
+	breakRepo domain.BreakRepositoryInterface,
+	policy domain.AttendancePolicy,
+) domain.AttendanceAutoCloseServiceInterface {
+	return &AttendanceAutoCloseService{
+		attendanceRepo: attendanceRepo,
+		sessionRepo:    sessionRepo,
+		breakRepo:      breakRepo,
+		policy:         policy,
+	}
+}
+
+// CloseForgottenCheckOuts closes every open attendance whose shift ended more than the
+// configured cutoff ago. The check-out is set to the shift end (or, for sessions started after
+// the shift end, to the check-in plus the maximum session length), any break still running is
+// ended at the same instant and the attendance is marked "auto_closed" and flagged for review.
+// It returns the number of attendances that were closed.
+func (s *AttendanceAutoCloseService) CloseForgottenCheckOuts(now time.Time) (int, error) {
+	open, err := s.attendanceRepo.GetAllOpen()
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, candidate := range open {
+		ok, err := s.closeAttendance(candidate.ID, now)
+		if err != nil {
+			// Keep going so one broken record does not block the others
+			log.Printf("Auto-close of attendance %d failed: %v", candidate.ID, err)
+			continue
+		}
+		if ok {
+			closed++
+		}
+	}
+
+	return closed, nil
+}
+
+// closeAttendance closes a single open attendance if its cutoff has passed
+func (s *AttendanceAutoCloseService) closeAttendance(attendanceID uint, now time.Time) (bool, error) {
+	attendance, err := s.attendanceRepo.GetWithBreaks(attendanceID)
+	if err != nil {
+		return false, err
+	}
+	// Days of an approved timesheet are left as they were approved
+	if !attendance.HasOpenSession() || attendance.IsLocked() {
+		return false, nil
+	}
+
+	// Find the open session; records from before sessions existed only have the check-in
+	var session *domain.AttendanceSession
+	for i := range attendance.Sessions {
+		if attendance.Sessions[i].IsOpen() && !attendance.Sessions[i].IsSuperseded() {
+			session = &attendance.Sessions[i]
+		}
+	}
+	checkIn := *attendance.CheckInTime
+	if session != nil {
+		checkIn = session.CheckInTime
+	}
+
+	// Close at the shift end of the work day. Sessions started after it, such as evening overtime,
+	// are given the maximum session length instead, so their hours are not silently lost.
+	loc := s.policy.DefaultLocation()
+	if attendance.Timezone != "" {
+		if zone, err := time.LoadLocation(attendance.Timezone); err == nil {
+			loc = zone
+		}
+	}
+	closeAt := s.policy.ShiftEndOn(attendance.Date, loc)
+	afterShift := closeAt.Before(checkIn)
+	if afterShift {
+		closeAt = checkIn.Add(s.policy.MaxSessionLength)
+	}
+	if now.Before(closeAt.Add(s.policy.AutoCloseCutoff)) {
+		return false, nil
+	}
+
+	if session != nil {
+		session.CheckOutTime = &closeAt
+		session.AutoClosed = true
+		if err := s.sessionRepo.Update(session); err != nil {
+			return false, err
+		}
+	} else {
+		session = &domain.AttendanceSession{
+			AttendanceID: attendance.ID,
+			CheckInTime:  checkIn,
+			CheckOutTime: &closeAt,
+			Source:       domain.SessionSourcePunch,
+			AutoClosed:   true,
+		}
+		if err := s.sessionRepo.Create(session); err != nil {
+			return false, err
+		}
+	}
+
+	// End a break that was left running
+	if err := endActiveBreak(s.breakRepo, attendance.ID, closeAt, "", "ended automatically with the attendance"); err != nil {
+		return false, err
+	}
+
+	attendance.CheckOutTime = &closeAt
+	attendance.Status = domain.AttendanceStatusAutoClosed
+	if afterShift {
+		attendance.Flag("auto-closed: no check-out recorded for a session started after the shift end, closed after the maximum session length")
+	} else {
+		attendance.Flag("auto-closed: no check-out recorded")
+	}
+
+	if err := recalculateWorkHours(s.attendanceRepo, attendance, s.policy); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+func TestCloseForgottenCheckOuts(t *testing.T) {
+	policy := domain.AttendancePolicy{
+		ShiftEnd:         18 * time.Hour,
+		AutoCloseCutoff:  4 * time.Hour,
+		MaxSessionLength: 12 * time.Hour,
+	}
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		checkIn      time.Time
+		now          time.Time
+		wantClosed   bool
+		wantCheckOut time.Time
+		wantHours    float64
+	}{
+		{
+			name:         "closed at the shift end",
+			checkIn:      day.Add(9 * time.Hour),
+			now:          day.Add(22 * time.Hour),
+			wantClosed:   true,
+			wantCheckOut: day.Add(18 * time.Hour),
+			wantHours:    9,
+		},
+		{
+			name:    "left open before the cutoff",
+			checkIn: day.Add(9 * time.Hour),
+			now:     day.Add(21 * time.Hour),
+		},
+		{
+			name:         "started after the shift end gets the maximum session length",
+			checkIn:      day.Add(20 * time.Hour),
+			now:          day.Add(36 * time.Hour),
+			wantClosed:   true,
+			wantCheckOut: day.Add(32 * time.Hour),
+			wantHours:    12,
+		},
+		{
+			name:    "started after the shift end and still within its length",
+			checkIn: day.Add(20 * time.Hour),
+			now:     day.Add(30 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIn := tt.checkIn
+			attendanceRepo := &fakeAttendanceRepository{attendances: []domain.Attendance{{
+				ID:          1,
+				UserID:      1,
+				Date:        day,
+				Timezone:    "UTC",
+				CheckInTime: &checkIn,
+				Sessions:    []domain.AttendanceSession{{ID: 1, AttendanceID: 1, CheckInTime: checkIn}},
+			}}}
+			service := NewAttendanceAutoCloseService(attendanceRepo, &fakeSessionRepository{attendances: attendanceRepo}, &fakeBreakRepository{}, policy)
+
+			closed, err := service.CloseForgottenCheckOuts(tt.now)
+			if err != nil {
+				t.Fatalf("CloseForgottenCheckOuts: %v", err)
+			}
+			attendance := attendanceRepo.attendances[0]
+			if !tt.wantClosed {
+				if closed != 0 || attendance.CheckOutTime != nil {
+					t.Fatalf("closed = %d, check-out = %v, want the attendance left open", closed, attendance.CheckOutTime)
+				}
+				return
+			}
+
+			if closed != 1 || attendance.CheckOutTime == nil {
+				t.Fatalf("closed = %d, want the attendance closed", closed)
+			}
+			if !attendance.CheckOutTime.Equal(tt.wantCheckOut) {
+				t.Fatalf("check-out = %v, want %v", attendance.CheckOutTime, tt.wantCheckOut)
+			}
+			if attendance.TotalWorkHours != tt.wantHours {
+				t.Fatalf("TotalWorkHours = %v, want %v", attendance.TotalWorkHours, tt.wantHours)
+			}
+			if attendance.Status != domain.AttendanceStatusAutoClosed || !attendance.Flagged {
+				t.Fatalf("status = %q, flagged = %v, want an auto-closed, flagged attendance", attendance.Status, attendance.Flagged)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"time"
+)
+
+// AttendanceRegularizationService implements the AttendanceRegularizationServiceInterface
+// This struct contains the business logic for attendance correction requests
+type AttendanceRegularizationService struct {
+	regularizationRepo domain.AttendanceRegularizationRepositoryInterface
+	attendanceRepo     domain.AttendanceRepositoryInterface
+	sessionRepo        domain.AttendanceSessionRepositoryInterface
+	breakRepo          domain.BreakRepositoryInterface
+	timesheetRepo      domain.TimesheetRepositoryInterface
+	userRepo           domain.UserRepositoryInterface
+	locationRepo       domain.LocationRepositoryInterface
+	policy             domain.AttendancePolicy
+}
+
+// NewAttendanceRegularizationService creates a new instance of AttendanceRegularizationService
+func NewAttendanceRegularizationService(
+	regularizationRepo domain.AttendanceRegularizationRepositoryInterface,
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	sessionRepo domain.AttendanceSessionRepositoryInterface,
+	breakRepo domain.BreakRepositoryInterface,
+	timesheetRepo domain.TimesheetRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	policy domain.AttendancePolicy,
+) domain.AttendanceRegularizationServiceInterface {
+	return &AttendanceRegularizationService{
+		regularizationRepo: regularizationRepo,
+		attendanceRepo:     attendanceRepo,
+		sessionRepo:        sessionRepo,
+		breakRepo:          breakRepo,
+		timesheetRepo:      timesheetRepo,
+		userRepo:           userRepo,
+		locationRepo:       locationRepo,
+		policy:             policy,
+	}
+}
+
+// RequestRegularization records an employee's proposed check-in/check-out times for a day.
+// The punches currently on the attendance are captured so they are preserved after approval.
+func (s *AttendanceRegularizationService) RequestRegularization(userID uint, regularization *domain.AttendanceRegularization) error {
+	// Check if user exists
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	regularization.UserID = userID
+	regularization.Status = domain.RegularizationStatusPending
+	regularization.Date = domain.DateOnly(regularization.Date)
+	regularization.RequestedCheckInTime = regularization.RequestedCheckInTime.UTC()
+	regularization.RequestedCheckOutTime = regularization.RequestedCheckOutTime.UTC()
+
+	if err := regularization.Validate(); err != nil {
+		return err
+	}
+
+	// The corrected punches must lie in the past and start on the requested work day
+	loc := userTimeLocation(user, s.locationRepo, s.policy)
+	if regularization.RequestedCheckOutTime.After(time.Now().UTC()) ||
+		!domain.WorkDay(regularization.RequestedCheckInTime, loc).Equal(regularization.Date) {
+		return domain.ErrInvalidRegularizationTimes
+	}
+
+	// Only one open request per day
+	if _, err := s.regularizationRepo.GetPendingByUserIDAndDate(userID, regularization.Date); err == nil {
+		return domain.ErrRegularizationAlreadyExists
+	} else if !errors.Is(err, domain.ErrRegularizationNotFound) {
+		return err
+	}
+
+	// Capture the original punches, if the day has an attendance record
+	attendance, err := s.attendanceRepo.GetByUserID(userID, regularization.Date)
+	if err != nil && !errors.Is(err, domain.ErrAttendanceNotFound) {
+		return err
+	}
+	if attendance != nil {
+		regularization.AttendanceID = &attendance.ID
+		regularization.OriginalCheckInTime = attendance.CheckInTime
+		regularization.OriginalCheckOutTime = attendance.CheckOutTime
+	}
+
+	return s.regularizationRepo.Create(regularization)
+}
+
+// GetRegularizationByID retrieves a regularization request by its ID
+func (s *AttendanceRegularizationService) GetRegularizationByID(id uint) (*domain.AttendanceRegularization, error) {
+	return s.regularizationRepo.GetByID(id)
+}
+
+// GetUserRegularizations retrieves all regularization requests of a user
+func (s *AttendanceRegularizationService) GetUserRegularizations(userID uint) ([]domain.AttendanceRegularization, error) {
+	return s.regularizationRepo.GetByUserID(userID)
+}
+
+// GetPendingRegularizations retrieves the pending requests the given approver is allowed to review
+func (s *AttendanceRegularizationService) GetPendingRegularizations(approverID uint) ([]domain.AttendanceRegularization, error) {
+	pending, err := s.regularizationRepo.GetByStatus(domain.RegularizationStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := newReviewerCache(s.userRepo)
+	reviewable := make([]domain.AttendanceRegularization, 0, len(pending))
+	for _, regularization := range pending {
+		if reviewers.canReview(regularization.UserID, approverID) {
+			reviewable = append(reviewable, regularization)
+		}
+	}
+
+	return reviewable, nil
+}
+
+// ApproveRegularization approves a request and applies the corrected punches to the attendance.
+// Existing sessions are marked as superseded rather than deleted, and any break still
+// in progress is ended at the corrected check-out time.
+func (s *AttendanceRegularizationService) ApproveRegularization(id uint, approverID uint, note string) error {
+	regularization, err := s.getReviewable(id, approverID)
+	if err != nil {
+		return err
+	}
+
+	attendance, err := s.applyCorrection(regularization)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	regularization.AttendanceID = &attendance.ID
+	regularization.Status = domain.RegularizationStatusApproved
+	regularization.ReviewedBy = &approverID
+	regularization.ReviewedAt = &now
+	regularization.ReviewNote = note
+
+	return s.regularizationRepo.Update(regularization)
+}
+
+// RejectRegularization rejects a request without touching the attendance
+func (s *AttendanceRegularizationService) RejectRegularization(id uint, approverID uint, note string) error {
+	regularization, err := s.getReviewable(id, approverID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	regularization.Status = domain.RegularizationStatusRejected
+	regularization.ReviewedBy = &approverID
+	regularization.ReviewedAt = &now
+	regularization.ReviewNote = note
+
+	return s.regularizationRepo.Update(regularization)
+}
+
+// CancelRegularization withdraws a pending request; only its owner may cancel it
+func (s *AttendanceRegularizationService) CancelRegularization(id uint, userID uint) error {
+	regularization, err := s.regularizationRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if regularization.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+	if !regularization.IsPending() {
+		return domain.ErrRegularizationNotPending
+	}
+
+	regularization.Status = domain.RegularizationStatusCancelled
+	return s.regularizationRepo.Update(regularization)
+}
+
+// getReviewable loads a pending request and checks that the approver may review it
+func (s *AttendanceRegularizationService) getReviewable(id uint, approverID uint) (*domain.AttendanceRegularization, error) {
+	regularization, err := s.regularizationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !regularization.IsPending() {
+		return nil, domain.ErrRegularizationNotPending
+	}
+
+	if regularization.UserID == approverID {
+		return nil, domain.ErrSelfApproval
+	}
+
+	user, err := s.userRepo.GetByID(regularization.UserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if !canReviewUser(s.userRepo, user, approverID) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return regularization, nil
+}
+
+// applyCorrection writes the requested punches to the attendance of the regularized day
+func (s *AttendanceRegularizationService) applyCorrection(regularization *domain.AttendanceRegularization) (*domain.Attendance, error) {
+	// Find the attendance of the day, creating it if the employee never checked in
+	attendance, err := s.attendanceRepo.GetByUserID(regularization.UserID, regularization.Date)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAttendanceNotFound) {
+			return nil, err
+		}
+		if err := checkWeekUnlocked(s.timesheetRepo, regularization.UserID, regularization.Date); err != nil {
+			return nil, err
+		}
+
+		user, err := s.userRepo.GetByID(regularization.UserID)
+		if err != nil {
+			return nil, domain.ErrUserNotFound
+		}
+
+		attendance = &domain.Attendance{
+			UserID:   regularization.UserID,
+			Date:     regularization.Date,
+			Timezone: userTimeLocation(user, s.locationRepo, s.policy).String(),
+			Status:   "present",
+		}
+		if err := s.attendanceRepo.Create(attendance); err != nil {
+			return nil, err
+		}
+	}
+	if attendance.IsLocked() {
+		return nil, domain.ErrAttendanceLocked
+	}
+
+	// Keep the original sessions for audit but stop counting them
+	sessions, err := s.sessionRepo.GetByAttendanceID(attendance.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		if sessions[i].IsSuperseded() {
+			continue
+		}
+		sessions[i].SupersededByID = &regularization.ID
+		if err := s.sessionRepo.Update(&sessions[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Record the corrected punches as a new session
+	checkIn := regularization.RequestedCheckInTime
+	checkOut := regularization.RequestedCheckOutTime
+	corrected := &domain.AttendanceSession{
+		AttendanceID: attendance.ID,
+		CheckInTime:  checkIn,
+		CheckOutTime: &checkOut,
+		Source:       domain.SessionSourceRegularization,
+	}
+	if err := s.sessionRepo.Create(corrected); err != nil {
+		return nil, err
+	}
+
+	// End a break that was left running at the corrected check-out time
+	if err := endActiveBreak(s.breakRepo, attendance.ID, checkOut, "", ""); err != nil {
+		return nil, err
+	}
+
+	attendance.CheckInTime = &checkIn
+	attendance.CheckOutTime = &checkOut
+	attendance.Status = "completed" // The corrected punches replace any auto-closed check-out
+
+	if err := recalculateWorkHours(s.attendanceRepo, attendance, s.policy); err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
@@ -10,39 +10,61 @@ import (
 // This struct contains all the business logic for attendance operations
 type AttendanceService struct {
 	attendanceRepo domain.AttendanceRepositoryInterface
+	sessionRepo    domain.AttendanceSessionRepositoryInterface
+	breakRepo      domain.BreakRepositoryInterface
+	timesheetRepo  domain.TimesheetRepositoryInterface
 	userRepo       domain.UserRepositoryInterface
+	locationRepo   domain.LocationRepositoryInterface
+	policy         domain.AttendancePolicy
 }
 
 // NewAttendanceService creates a new instance of AttendanceService
 func NewAttendanceService(
 	attendanceRepo domain.AttendanceRepositoryInterface,
+	sessionRepo domain.AttendanceSessionRepositoryInterface,
+	breakRepo domain.BreakRepositoryInterface,
+	timesheetRepo domain.TimesheetRepositoryInterface,
 	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	policy domain.AttendancePolicy,
 ) domain.AttendanceServiceInterface {
 	return &AttendanceService{
 		attendanceRepo: attendanceRepo,
+		sessionRepo:    sessionRepo,
+		breakRepo:      breakRepo,
+		timesheetRepo:  timesheetRepo,
 		userRepo:       userRepo,
+		locationRepo:   locationRepo,
+		policy:         policy,
 	}
 }
 
 // CreateAttendance creates a new attendance record for a user on a specific date
 func (attendanceService *AttendanceService) CreateAttendance(userID uint, date time.Time) (*domain.Attendance, error) {
 	// Check if user exists
-	_, err := attendanceService.userRepo.GetByID(userID)
+	user, err := attendanceService.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
 
+	// Attendance dates are calendar days, so drop any time of day sent by the client
+	date = domain.DateOnly(date)
+
 	// Check if attendance already exists for this user and date
 	existingAttendance, err := attendanceService.attendanceRepo.GetByUserID(userID, date)
 	if err == nil && existingAttendance != nil {
 		return existingAttendance, nil // Return existing attendance
 	}
+	if err := checkWeekUnlocked(attendanceService.timesheetRepo, userID, date); err != nil {
+		return nil, err
+	}
 
 	// Create new attendance record
 	attendance := &domain.Attendance{
-		UserID: userID,
-		Date:   date,
-		Status: "absent",
+		UserID:   userID,
+		Date:     date,
+		Timezone: userTimeLocation(user, attendanceService.locationRepo, attendanceService.policy).String(),
+		Status:   "absent",
 	}
 
 	if err := attendanceService.attendanceRepo.Create(attendance); err != nil {
@@ -52,23 +74,59 @@ func (attendanceService *AttendanceService) CreateAttendance(userID uint, date t
 	return attendance, nil
 }
 
-// CheckIn records the check-in time for a user on a specific date
-func (attendanceService *AttendanceService) CheckIn(userID uint, date time.Time) (*domain.Attendance, error) {
+// CheckIn starts a new work session for a user.
+// The check-in instant is taken from the server clock and the work day is derived
+// in the employee's time zone; the client timestamp is only checked for drift.
+// Several sessions per day are allowed as long as the previous one was checked out.
+func (attendanceService *AttendanceService) CheckIn(userID uint, punch domain.Punch) (*domain.Attendance, error) {
 	// Check if user exists
-	_, err := attendanceService.userRepo.GetByID(userID)
+	user, err := attendanceService.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
 
+	// Verify the client clock against the server clock
+	now := time.Now().UTC()
+	flagReason, err := attendanceService.policy.CheckClientTime(punch.ClientTime, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the source network and device position against the rules of the employee's location
+	workLocation := userWorkLocation(user, attendanceService.locationRepo)
+	if err := attendanceService.policy.CheckNetwork(workLocation, punch); err != nil {
+		return nil, err
+	}
+	position, geofenceReason, err := attendanceService.policy.CheckGeofence(workLocation, punch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only one session can be open at a time, including one left open on an earlier day
+	if _, err := attendanceService.attendanceRepo.GetOpenByUserID(userID); err == nil {
+		return nil, domain.ErrAlreadyCheckedIn
+	} else if !errors.Is(err, domain.ErrAttendanceNotFound) {
+		return nil, err
+	}
+
+	// Derive the work day in the employee's time zone
+	loc := userTimeLocation(user, attendanceService.locationRepo, attendanceService.policy)
+	date := domain.WorkDay(now, loc)
+
 	// Get or create attendance record
 	attendance, err := attendanceService.attendanceRepo.GetByUserID(userID, date)
 	if err != nil {
 		if errors.Is(err, domain.ErrAttendanceNotFound) {
+			if err := checkWeekUnlocked(attendanceService.timesheetRepo, userID, date); err != nil {
+				return nil, err
+			}
+
 			// Create new attendance record
 			attendance = &domain.Attendance{
-				UserID: userID,
-				Date:   date,
-				Status: "present",
+				UserID:   userID,
+				Date:     date,
+				Timezone: loc.String(),
+				Status:   "present",
 			}
 			if err := attendanceService.attendanceRepo.Create(attendance); err != nil {
 				return nil, err
@@ -77,67 +135,138 @@ func (attendanceService *AttendanceService) CheckIn(userID uint, date time.Time)
 			return nil, err
 		}
 	}
+	if attendance.IsLocked() {
+		return nil, domain.ErrAttendanceLocked
+	}
 
-	// Check if already checked in
-	if attendance.IsCheckedIn() {
-		return nil, domain.ErrAlreadyCheckedIn
+	// Open a new session
+	session := &domain.AttendanceSession{
+		AttendanceID:    attendance.ID,
+		CheckInTime:     now,
+		Source:          domain.SessionSourcePunch,
+		CheckInLocation: position,
+		CheckInIP:       punch.IPAddress,
+		CheckInKioskID:  punch.KioskID,
+	}
+	if punch.KioskID != nil {
+		session.Source = domain.SessionSourceKiosk
+	}
+	if err := attendanceService.sessionRepo.Create(session); err != nil {
+		return nil, err
 	}
 
-	// Set check-in time
-	now := time.Now()
-	attendance.CheckInTime = &now
+	// The first check-in of the day is kept; the day is open again until the next check-out
+	if attendance.CheckInTime == nil {
+		attendance.CheckInTime = &now
+	}
+	attendance.CheckOutTime = nil
+	attendance.Status = "present"
+	if flagReason != "" {
+		attendance.Flag("check-in: " + flagReason)
+	}
+	if geofenceReason != "" {
+		attendance.Flag("check-in: " + geofenceReason)
+	}
 
-	// Update attendance record
-	if err := attendanceService.attendanceRepo.Update(attendance); err != nil {
+	// Recalculate work hours and update attendance record
+	if err := recalculateWorkHours(attendanceService.attendanceRepo, attendance, attendanceService.policy); err != nil {
 		return nil, err
 	}
 
 	return attendance, nil
 }
 
-// CheckOut records the check-out time for a user on a specific date
-func (attendanceService *AttendanceService) CheckOut(userID uint, date time.Time) (*domain.Attendance, error) {
+// CheckOut closes the open work session of a user.
+// The open attendance is looked up regardless of date so that shifts crossing
+// midnight in the employee's time zone can still be closed.
+func (attendanceService *AttendanceService) CheckOut(userID uint, punch domain.Punch) (*domain.Attendance, error) {
 	// Check if user exists
-	_, err := attendanceService.userRepo.GetByID(userID)
+	user, err := attendanceService.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
 
-	// Get attendance record
-	attendance, err := attendanceService.attendanceRepo.GetByUserID(userID, date)
+	// Verify the client clock against the server clock
+	now := time.Now().UTC()
+	flagReason, err := attendanceService.policy.CheckClientTime(punch.ClientTime, now)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if already checked out
-	if attendance.IsCheckedOut() {
-		return nil, domain.ErrAlreadyCheckedOut
+	// Verify the source network and device position against the rules of the employee's location
+	workLocation := userWorkLocation(user, attendanceService.locationRepo)
+	if err := attendanceService.policy.CheckNetwork(workLocation, punch); err != nil {
+		return nil, err
+	}
+	position, geofenceReason, err := attendanceService.policy.CheckGeofence(workLocation, punch)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if checked in
-	if !attendance.IsCheckedIn() {
-		return nil, domain.ErrNotCheckedIn
+	// Get the open attendance record
+	attendance, err := attendanceService.attendanceRepo.GetOpenByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAttendanceNotFound) {
+			return nil, domain.ErrNotCheckedIn
+		}
+		return nil, err
+	}
+	if attendance.IsLocked() {
+		return nil, domain.ErrAttendanceLocked
+	}
+
+	// Close the open session
+	session, err := attendanceService.sessionRepo.GetOpenByAttendanceID(attendance.ID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, err
+		}
+		// Records checked in before sessions existed have no session row yet
+		session = &domain.AttendanceSession{
+			AttendanceID: attendance.ID,
+			CheckInTime:  *attendance.CheckInTime,
+			Source:       domain.SessionSourcePunch,
+		}
+	}
+	session.CheckOutTime = &now
+	session.CheckOutLocation = position
+	session.CheckOutIP = punch.IPAddress
+	session.CheckOutKioskID = punch.KioskID
+	if session.ID == 0 {
+		err = attendanceService.sessionRepo.Create(session)
+	} else {
+		err = attendanceService.sessionRepo.Update(session)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A break cannot outlast the session it was taken in
+	if err := endActiveBreak(attendanceService.breakRepo, attendance.ID, now, punch.IPAddress, ""); err != nil {
+		return nil, err
 	}
 
 	// Set check-out time
-	now := time.Now()
 	attendance.CheckOutTime = &now
 	attendance.Status = attendance.GetStatus()
+	if flagReason != "" {
+		attendance.Flag("check-out: " + flagReason)
+	}
+	if geofenceReason != "" {
+		attendance.Flag("check-out: " + geofenceReason)
+	}
 
-	// Calculate work hours
-	attendance.CalculateWorkHours()
-
-	// Update attendance record
-	if err := attendanceService.attendanceRepo.Update(attendance); err != nil {
+	// Recalculate work hours across all sessions and update attendance record
+	if err := recalculateWorkHours(attendanceService.attendanceRepo, attendance, attendanceService.policy); err != nil {
 		return nil, err
 	}
 
 	return attendance, nil
 }
 
-// GetAttendanceByID retrieves an attendance record by its ID
+// GetAttendanceByID retrieves an attendance record by its ID, including its breaks and sessions
 func (attendanceService *AttendanceService) GetAttendanceByID(id uint) (*domain.Attendance, error) {
-	return attendanceService.attendanceRepo.GetByID(id)
+	return attendanceService.attendanceRepo.GetWithBreaks(id)
 }
 
 // GetUserAttendance retrieves attendance record for a user on a specific date
@@ -148,7 +277,13 @@ func (attendanceService *AttendanceService) GetUserAttendance(userID uint, date
 		return nil, domain.ErrUserNotFound
 	}
 
-	return attendanceService.attendanceRepo.GetByUserID(userID, date)
+	attendance, err := attendanceService.attendanceRepo.GetByUserID(userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include breaks and sessions in the result
+	return attendanceService.attendanceRepo.GetWithBreaks(attendance.ID)
 }
 
 // GetUserAttendanceRange retrieves attendance records for a user within a date range
@@ -174,6 +309,9 @@ func (attendanceService *AttendanceService) UpdateAttendance(attendance *domain.
 	if err != nil {
 		return err
 	}
+	if existingAttendance.IsLocked() {
+		return domain.ErrAttendanceLocked
+	}
 
 	// Preserve existing check-in/out times if not provided
 	if attendance.CheckInTime == nil {
@@ -183,21 +321,27 @@ func (attendanceService *AttendanceService) UpdateAttendance(attendance *domain.
 		attendance.CheckOutTime = existingAttendance.CheckOutTime
 	}
 
-	// Recalculate work hours
-	attendance.CalculateWorkHours()
-
-	return attendanceService.attendanceRepo.Update(attendance)
+	// Recalculate work hours against the recorded breaks and save
+	return recalculateWorkHours(attendanceService.attendanceRepo, attendance, attendanceService.policy)
 }
 
 // DeleteAttendance removes an attendance record
 func (attendanceService *AttendanceService) DeleteAttendance(id uint) error {
+	// Check if attendance exists
+	attendance, err := attendanceService.attendanceRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if attendance.IsLocked() {
+		return domain.ErrAttendanceLocked
+	}
+
 	return attendanceService.attendanceRepo.Delete(id)
 }
 
 // CalculateWorkHours calculates and updates the work hours for an attendance record
 func (attendanceService *AttendanceService) CalculateWorkHours(attendance *domain.Attendance) error {
-	attendance.CalculateWorkHours()
-	return attendanceService.attendanceRepo.Update(attendance)
+	return recalculateWorkHours(attendanceService.attendanceRepo, attendance, attendanceService.policy)
 }
 
 // GetLastNAttendanceByUserID retrieves the last N attendance records for a user
@@ -210,3 +354,19 @@ func (attendanceService *AttendanceService) GetLastNAttendanceByUserID(userID ui
 
 	return attendanceService.attendanceRepo.GetLastNByUserID(userID, limit)
 }
+
+// recalculateWorkHours reloads the breaks and sessions of an attendance, recalculates
+// its total work hours under the policy's mandatory break rules and saves it.
+// The passed attendance is updated in place.
+func recalculateWorkHours(attendanceRepo domain.AttendanceRepositoryInterface, attendance *domain.Attendance, policy domain.AttendancePolicy) error {
+	detailed, err := attendanceRepo.GetWithBreaks(attendance.ID)
+	if err != nil {
+		return err
+	}
+
+	attendance.Breaks = detailed.Breaks
+	attendance.Sessions = detailed.Sessions
+	attendance.CalculateWorkHours(policy.MandatoryBreaks)
+
+	return attendanceRepo.Update(attendance)
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+// fakeAttendanceRepository keeps attendance records in memory; methods the tests do not use are left unimplemented
+type fakeAttendanceRepository struct {
+	domain.AttendanceRepositoryInterface
+	attendances []domain.Attendance
+	created     int
+}
+
+func (r *fakeAttendanceRepository) GetOpenByUserID(userID uint) (*domain.Attendance, error) {
+	for i := range r.attendances {
+		attendance := r.attendances[i]
+		if attendance.UserID == userID && attendance.HasOpenSession() {
+			return &attendance, nil
+		}
+	}
+	return nil, domain.ErrAttendanceNotFound
+}
+
+func (r *fakeAttendanceRepository) GetAllOpen() ([]domain.Attendance, error) {
+	var open []domain.Attendance
+	for _, attendance := range r.attendances {
+		if attendance.HasOpenSession() {
+			open = append(open, attendance)
+		}
+	}
+	return open, nil
+}
+
+func (r *fakeAttendanceRepository) GetByUserID(userID uint, date time.Time) (*domain.Attendance, error) {
+	for i := range r.attendances {
+		attendance := r.attendances[i]
+		if attendance.UserID == userID && attendance.Date.Equal(date) {
+			return &attendance, nil
+		}
+	}
+	return nil, domain.ErrAttendanceNotFound
+}
+
+func (r *fakeAttendanceRepository) GetByUserIDAndDateRange(userID uint, startDate, endDate time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	for _, attendance := range r.attendances {
+		if attendance.UserID == userID && !attendance.Date.Before(startDate) && !attendance.Date.After(endDate) {
+			attendances = append(attendances, attendance)
+		}
+	}
+	return attendances, nil
+}
+
+func (r *fakeAttendanceRepository) Create(attendance *domain.Attendance) error {
+	r.created++
+	return errors.New("unexpected create")
+}
+
+func (r *fakeAttendanceRepository) GetWithBreaks(id uint) (*domain.Attendance, error) {
+	for i := range r.attendances {
+		if r.attendances[i].ID == id {
+			attendance := r.attendances[i]
+			return &attendance, nil
+		}
+	}
+	return nil, domain.ErrAttendanceNotFound
+}
+
+func (r *fakeAttendanceRepository) Update(attendance *domain.Attendance) error {
+	for i := range r.attendances {
+		if r.attendances[i].ID == attendance.ID {
+			r.attendances[i] = *attendance
+			return nil
+		}
+	}
+	return domain.ErrAttendanceNotFound
+}
+
+// fakeSessionRepository stores sessions on the attendance records of a fakeAttendanceRepository
+type fakeSessionRepository struct {
+	domain.AttendanceSessionRepositoryInterface
+	attendances *fakeAttendanceRepository
+}
+
+func (r *fakeSessionRepository) GetByAttendanceID(attendanceID uint) ([]domain.AttendanceSession, error) {
+	for _, attendance := range r.attendances.attendances {
+		if attendance.ID == attendanceID {
+			return attendance.Sessions, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) Update(session *domain.AttendanceSession) error {
+	for i := range r.attendances.attendances {
+		sessions := r.attendances.attendances[i].Sessions
+		for j := range sessions {
+			if sessions[j].ID == session.ID {
+				sessions[j] = *session
+				return nil
+			}
+		}
+	}
+	return domain.ErrSessionNotFound
+}
+
+// fakeBreakRepository has no breaks
+type fakeBreakRepository struct {
+	domain.BreakRepositoryInterface
+}
+
+func (r *fakeBreakRepository) GetActiveBreakByAttendanceID(attendanceID uint) (*domain.Break, error) {
+	return nil, domain.ErrBreakNotFound
+}
+
+func TestCheckInRefusesWhileASessionOfAnEarlierDayIsOpen(t *testing.T) {
+	checkIn := time.Now().UTC().AddDate(0, 0, -2)
+	attendanceRepo := &fakeAttendanceRepository{attendances: []domain.Attendance{
+		{ID: 1, UserID: 1, Date: domain.DateOnly(checkIn), CheckInTime: &checkIn},
+	}}
+	userRepo := &fakeUserRepository{users: map[uint]domain.User{1: {ID: 1, Name: "Alice", Email: "alice@example.com"}}}
+	service := NewAttendanceService(attendanceRepo, nil, nil, nil, userRepo, nil, domain.AttendancePolicy{})
+
+	_, err := service.CheckIn(1, domain.Punch{})
+	if !errors.Is(err, domain.ErrAlreadyCheckedIn) {
+		t.Fatalf("err = %v, want ErrAlreadyCheckedIn", err)
+	}
+	if attendanceRepo.created != 0 {
+		t.Fatal("CheckIn created an attendance while another one was open")
+	}
+}
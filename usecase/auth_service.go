@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"hrm/domain"
+)
+
+// refreshTokenPrefix makes refresh tokens recognizable, e.g. in logs or secret scanners
+const refreshTokenPrefix = "hrmrt_"
+
+// AuthService implements the AuthServiceInterface
+// This struct contains the business logic for sign-in sessions: short-lived access tokens,
+// rotating refresh tokens and their revocation
+type AuthService struct {
+	sessionRepo     domain.AuthSessionRepositoryInterface
+	userRepo        domain.UserRepositoryInterface
+	keyService      domain.SigningKeyServiceInterface
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewAuthService creates a new instance of AuthService
+func NewAuthService(
+	sessionRepo domain.AuthSessionRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	keyService domain.SigningKeyServiceInterface,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) domain.AuthServiceInterface {
+	return &AuthService{
+		sessionRepo:     sessionRepo,
+		userRepo:        userRepo,
+		keyService:      keyService,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// IssueTokens starts a new session for a signed-in user and returns its first token pair
+func (s *AuthService) IssueTokens(user *domain.User, client domain.AuthClient) (*domain.TokenPair, error) {
+	now := time.Now().UTC()
+
+	refreshToken, err := generateOpaqueToken(refreshTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.AuthSession{
+		UserID:           user.ID,
+		RefreshTokenHash: hashOpaqueToken(refreshToken),
+		ExpiresAt:        now.Add(s.refreshTokenTTL),
+		LastUsedAt:       &now,
+	}
+	applyAuthClient(session, client)
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return s.tokenPair(user, session, refreshToken, now)
+}
+
+// RefreshTokens exchanges a refresh token for a new token pair.
+// This method performs the following business operations:
+// 1. Finds the session holding the refresh token
+// 2. Revokes the session if the token was already rotated, since it must have been copied
+// 3. Rejects expired and revoked sessions and deactivated users
+// 4. Replaces the refresh token and extends the session
+func (s *AuthService) RefreshTokens(refreshToken string, client domain.AuthClient) (*domain.TokenPair, error) {
+	now := time.Now().UTC()
+	tokenHash := hashOpaqueToken(refreshToken)
+
+	// Step 1: Find the session holding the refresh token
+	session, err := s.sessionRepo.GetByRefreshTokenHash(tokenHash)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAuthSessionNotFound) {
+			return nil, err
+		}
+
+		// Step 2: A rotated token is only presented again when someone else holds a copy
+		reused, err := s.sessionRepo.GetByPreviousTokenHash(tokenHash)
+		if err != nil {
+			if errors.Is(err, domain.ErrAuthSessionNotFound) {
+				return nil, domain.ErrInvalidRefreshToken
+			}
+			return nil, err
+		}
+		if reused.IsRevoked() {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		reused.Revoke(domain.SessionRevokedTokenReuse, now)
+		if err := s.sessionRepo.Update(reused); err != nil {
+			return nil, err
+		}
+		log.Printf("Refresh token reuse detected for session %d of user %d; session revoked", reused.ID, reused.UserID)
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	// Step 3: Reject expired and revoked sessions and deactivated users
+	if !session.IsActiveAt(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	user, err := s.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		session.Revoke(domain.SessionRevokedUserDeactivated, now)
+		if err := s.sessionRepo.Update(session); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrUserInactive
+	}
+
+	// Step 4: Rotate the refresh token
+	newRefreshToken, err := generateOpaqueToken(refreshTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+	session.PreviousTokenHash = tokenHash
+	session.RefreshTokenHash = hashOpaqueToken(newRefreshToken)
+	session.ExpiresAt = now.Add(s.refreshTokenTTL)
+	session.LastUsedAt = &now
+	applyAuthClient(session, client)
+	if err := s.sessionRepo.Update(session); err != nil {
+		return nil, err
+	}
+
+	return s.tokenPair(user, session, newRefreshToken, now)
+}
+
+// Logout revokes one session of a user, usually the one the request was made with
+func (s *AuthService) Logout(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return domain.ErrAuthSessionNotFound
+	}
+
+	session.Revoke(domain.SessionRevokedLogout, time.Now().UTC())
+	return s.sessionRepo.Update(session)
+}
+
+// LogoutAll revokes every session of a user, signing them out on all devices
+func (s *AuthService) LogoutAll(userID uint) error {
+	return s.sessionRepo.RevokeAllByUserID(userID, domain.SessionRevokedLogoutAll)
+}
+
+// GetActiveSessions retrieves the devices a user is currently signed in on
+func (s *AuthService) GetActiveSessions(userID uint) ([]domain.AuthSession, error) {
+	return s.sessionRepo.GetActiveByUserID(userID)
+}
+
+// ValidateSession checks that the session an access token was issued for still belongs
+// to the user and has been neither revoked nor expired
+func (s *AuthService) ValidateSession(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || !session.IsActiveAt(time.Now().UTC()) {
+		return domain.ErrAuthSessionRevoked
+	}
+	return nil
+}
+
+// tokenPair signs an access token for a session and pairs it with the session's refresh token
+func (s *AuthService) tokenPair(user *domain.User, session *domain.AuthSession, refreshToken string, now time.Time) (*domain.TokenPair, error) {
+	expiresAt := now.Add(s.accessTokenTTL)
+	accessToken, err := s.signAccessToken(user, session.ID, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+		SessionID:             session.ID,
+	}, nil
+}
+
+// signAccessToken creates a JWT access token for a user's session.
+// The token includes:
+// - User ID
+// - User email
+// - Session ID, checked against revocation on every request
+// - Token expiration time
+// - Token issuance time
+func (s *AuthService) signAccessToken(user *domain.User, sessionID uint, issuedAt, expiresAt time.Time) (string, error) {
+	claims := map[string]interface{}{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"sid":     sessionID,
+		"exp":     expiresAt.Unix(),
+		"iat":     issuedAt.Unix(),
+	}
+
+	tokenString, err := s.keyService.SignToken(claims)
+	if err != nil {
+		log.Printf("Error generating JWT token: %v", err)
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// applyAuthClient records the device a session was last used from
+func applyAuthClient(session *domain.AuthSession, client domain.AuthClient) {
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	session.UserAgent = userAgent
+	session.IPAddress = client.IPAddress
+}
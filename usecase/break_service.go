@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	"hrm/domain"
 	"time"
 )
@@ -9,27 +10,52 @@ import (
 // This struct contains all the business logic for break operations
 type BreakService struct {
 	breakRepo      domain.BreakRepositoryInterface
+	breakTypeRepo  domain.BreakTypeRepositoryInterface
 	attendanceRepo domain.AttendanceRepositoryInterface
+	policy         domain.AttendancePolicy
 }
 
 // NewBreakService creates a new instance of BreakService
 func NewBreakService(
 	breakRepo domain.BreakRepositoryInterface,
+	breakTypeRepo domain.BreakTypeRepositoryInterface,
 	attendanceRepo domain.AttendanceRepositoryInterface,
+	policy domain.AttendancePolicy,
 ) domain.BreakServiceInterface {
 	return &BreakService{
 		breakRepo:      breakRepo,
+		breakTypeRepo:  breakTypeRepo,
 		attendanceRepo: attendanceRepo,
+		policy:         policy,
 	}
 }
 
-// CreateBreak creates a new break record for an attendance
-func (s *BreakService) CreateBreak(attendanceID uint, startTime time.Time, reason string) (*domain.Break, error) {
+// CreateBreak creates a new break record for an attendance.
+// The break starts at the current server time; the client timestamp is only checked for drift.
+// A break with a type takes over whether it is paid and is flagged when it exceeds the daily allowance.
+// Breaks can only be taken while checked in, and only in the user's own attendance.
+func (s *BreakService) CreateBreak(userID uint, attendanceID uint, punch domain.Punch, breakTypeID *uint, reason string) (*domain.Break, error) {
 	// Check if attendance exists
 	attendance, err := s.attendanceRepo.GetByID(attendanceID)
 	if err != nil {
 		return nil, err
 	}
+	if attendance.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	if attendance.IsLocked() {
+		return nil, domain.ErrAttendanceLocked
+	}
+	if !attendance.HasOpenSession() {
+		return nil, domain.ErrNotCheckedIn
+	}
+
+	// Verify the client clock against the server clock
+	startTime := time.Now().UTC()
+	flagReason, err := s.policy.CheckClientTime(punch.ClientTime, startTime)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if there's already an active break for this attendance
 	activeBreak, err := s.breakRepo.GetActiveBreakByAttendanceID(attendanceID)
@@ -42,6 +68,31 @@ func (s *BreakService) CreateBreak(attendanceID uint, startTime time.Time, reaso
 		AttendanceID: attendanceID,
 		StartTime:    startTime,
 		Reason:       reason,
+		StartIP:      punch.IPAddress,
+	}
+	if flagReason != "" {
+		breakItem.Flag("break start: " + flagReason)
+	}
+
+	// Apply the rules of the break type
+	if breakTypeID != nil {
+		breakType, err := s.breakTypeRepo.GetByID(*breakTypeID)
+		if err != nil {
+			return nil, err
+		}
+		if !breakType.IsActive {
+			return nil, domain.ErrBreakTypeInactive
+		}
+		breakItem.BreakTypeID = &breakType.ID
+		breakItem.IsPaid = breakType.IsPaid
+
+		taken, err := s.countBreaksOfType(attendanceID, breakType.ID)
+		if err != nil {
+			return nil, err
+		}
+		if violation := breakType.CheckCount(taken); violation != "" {
+			breakItem.Flag(violation)
+		}
 	}
 
 	if err := s.breakRepo.Create(breakItem); err != nil {
@@ -49,47 +100,68 @@ func (s *BreakService) CreateBreak(attendanceID uint, startTime time.Time, reaso
 	}
 
 	// Recalculate work hours for the attendance
-	attendance.CalculateWorkHours()
-	if err := s.attendanceRepo.Update(attendance); err != nil {
+	if err := recalculateWorkHours(s.attendanceRepo, attendance, s.policy); err != nil {
 		return nil, err
 	}
 
 	return breakItem, nil
 }
 
-// GetBreakByID retrieves a break record by its ID
-func (s *BreakService) GetBreakByID(id uint) (*domain.Break, error) {
-	return s.breakRepo.GetByID(id)
+// GetBreakByID retrieves a break record of one of the user's own attendance records by its ID
+func (s *BreakService) GetBreakByID(userID uint, id uint) (*domain.Break, error) {
+	return s.ownBreak(userID, id)
 }
 
-// GetBreaksByAttendanceID retrieves all breaks for a specific attendance
-func (s *BreakService) GetBreaksByAttendanceID(attendanceID uint) ([]domain.Break, error) {
-	// Check if attendance exists
-	_, err := s.attendanceRepo.GetByID(attendanceID)
-	if err != nil {
+// GetBreaksByAttendanceID retrieves all breaks for one of the user's own attendance records
+func (s *BreakService) GetBreaksByAttendanceID(userID uint, attendanceID uint) ([]domain.Break, error) {
+	// Check if attendance exists and belongs to the user
+	if err := s.checkOwner(userID, attendanceID); err != nil {
 		return nil, err
 	}
 
 	return s.breakRepo.GetByAttendanceID(attendanceID)
 }
 
-// GetAllBreaks retrieves all break records
+// GetAllBreaks retrieves all break records of all users
 func (s *BreakService) GetAllBreaks() ([]domain.Break, error) {
 	return s.breakRepo.GetAll()
 }
 
-// UpdateBreak modifies an existing break record
-func (s *BreakService) UpdateBreak(breakItem *domain.Break) error {
-	// Check if break exists
-	existingBreak, err := s.breakRepo.GetByID(breakItem.ID)
+// UpdateBreak modifies an existing break record of one of the user's own attendance records.
+// The break must stay within one of the attendance's sessions.
+func (s *BreakService) UpdateBreak(userID uint, breakItem *domain.Break) error {
+	// Check if break exists and belongs to the user
+	existingBreak, err := s.ownBreak(userID, breakItem.ID)
 	if err != nil {
 		return err
 	}
+	if err := s.checkUnlocked(existingBreak.AttendanceID); err != nil {
+		return err
+	}
 
 	// Preserve existing end time if not provided
 	if breakItem.EndTime == nil {
 		breakItem.EndTime = existingBreak.EndTime
 	}
+	if breakItem.EndTime != nil && breakItem.EndTime.Before(breakItem.StartTime) {
+		return domain.ErrInvalidBreakTime
+	}
+
+	// Check the break against the check-in/check-out window
+	breakItem.AttendanceID = existingBreak.AttendanceID
+	attendance, err := s.attendanceRepo.GetWithBreaks(existingBreak.AttendanceID)
+	if err != nil {
+		return err
+	}
+	if !attendance.CoversBreak(breakItem) {
+		return domain.ErrBreakOutsideSession
+	}
+
+	// Keep the recorded punch sources and the type the break was taken as
+	breakItem.StartIP = existingBreak.StartIP
+	breakItem.EndIP = existingBreak.EndIP
+	breakItem.BreakTypeID = existingBreak.BreakTypeID
+	breakItem.IsPaid = existingBreak.IsPaid
 
 	// Recalculate duration
 	breakItem.CalculateDuration()
@@ -100,24 +172,21 @@ func (s *BreakService) UpdateBreak(breakItem *domain.Break) error {
 	}
 
 	// Recalculate work hours for the attendance
-	attendance, err := s.attendanceRepo.GetByID(breakItem.AttendanceID)
-	if err != nil {
-		return err
-	}
-
-	attendance.CalculateWorkHours()
-	return s.attendanceRepo.Update(attendance)
+	return recalculateWorkHours(s.attendanceRepo, attendance, s.policy)
 }
 
-// DeleteBreak removes a break record
-func (s *BreakService) DeleteBreak(id uint) error {
+// DeleteBreak removes a break record of one of the user's own attendance records
+func (s *BreakService) DeleteBreak(userID uint, id uint) error {
 	// Get break to find attendance ID
-	breakItem, err := s.breakRepo.GetByID(id)
+	breakItem, err := s.ownBreak(userID, id)
 	if err != nil {
 		return err
 	}
 
 	attendanceID := breakItem.AttendanceID
+	if err := s.checkUnlocked(attendanceID); err != nil {
+		return err
+	}
 
 	// Delete break record
 	if err := s.breakRepo.Delete(id); err != nil {
@@ -130,14 +199,14 @@ func (s *BreakService) DeleteBreak(id uint) error {
 		return err
 	}
 
-	attendance.CalculateWorkHours()
-	return s.attendanceRepo.Update(attendance)
+	return recalculateWorkHours(s.attendanceRepo, attendance, s.policy)
 }
 
-// EndBreak ends an existing break and calculates its duration
-func (s *BreakService) EndBreak(breakID uint, endTime time.Time) error {
+// EndBreak ends an existing break of one of the user's own attendance records
+// at the current server time and calculates its duration
+func (s *BreakService) EndBreak(userID uint, breakID uint, punch domain.Punch) error {
 	// Get break record
-	breakItem, err := s.breakRepo.GetByID(breakID)
+	breakItem, err := s.ownBreak(userID, breakID)
 	if err != nil {
 		return err
 	}
@@ -146,6 +215,19 @@ func (s *BreakService) EndBreak(breakID uint, endTime time.Time) error {
 	if breakItem.IsEnded() {
 		return domain.ErrBreakAlreadyEnded
 	}
+	if err := s.checkUnlocked(breakItem.AttendanceID); err != nil {
+		return err
+	}
+
+	// Verify the client clock against the server clock
+	endTime := time.Now().UTC()
+	flagReason, err := s.policy.CheckClientTime(punch.ClientTime, endTime)
+	if err != nil {
+		return err
+	}
+	if flagReason != "" {
+		breakItem.Flag("break end: " + flagReason)
+	}
 
 	// Validate end time is after start time
 	if endTime.Before(breakItem.StartTime) {
@@ -154,9 +236,13 @@ func (s *BreakService) EndBreak(breakID uint, endTime time.Time) error {
 
 	// Set end time
 	breakItem.EndTime = &endTime
+	breakItem.EndIP = punch.IPAddress
 
-	// Calculate duration
+	// Calculate duration and check it against the break type
 	breakItem.CalculateDuration()
+	if err := s.checkDuration(breakItem); err != nil {
+		return err
+	}
 
 	// Update break record
 	if err := s.breakRepo.Update(breakItem); err != nil {
@@ -169,8 +255,7 @@ func (s *BreakService) EndBreak(breakID uint, endTime time.Time) error {
 		return err
 	}
 
-	attendance.CalculateWorkHours()
-	return s.attendanceRepo.Update(attendance)
+	return recalculateWorkHours(s.attendanceRepo, attendance, s.policy)
 }
 
 // CalculateBreakDuration calculates and updates the duration for a break record
@@ -178,3 +263,162 @@ func (s *BreakService) CalculateBreakDuration(breakItem *domain.Break) error {
 	breakItem.CalculateDuration()
 	return s.breakRepo.Update(breakItem)
 }
+
+// StartMyBreak starts a break in the attendance the user is currently checked in to
+func (s *BreakService) StartMyBreak(userID uint, punch domain.Punch, breakTypeID *uint, reason string) (*domain.Break, error) {
+	attendance, err := s.openAttendance(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateBreak(userID, attendance.ID, punch, breakTypeID, reason)
+}
+
+// EndMyBreak ends the break the user is currently on
+func (s *BreakService) EndMyBreak(userID uint, punch domain.Punch) (*domain.Break, error) {
+	attendance, err := s.openAttendance(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeBreak, err := s.breakRepo.GetActiveBreakByAttendanceID(attendance.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBreakNotFound) {
+			return nil, domain.ErrNoActiveBreak
+		}
+		return nil, err
+	}
+
+	if err := s.EndBreak(userID, activeBreak.ID, punch); err != nil {
+		return nil, err
+	}
+
+	return s.breakRepo.GetByID(activeBreak.ID)
+}
+
+// GetBreakStatus reports whether the user is working, on a break or checked out, and for how long
+func (s *BreakService) GetBreakStatus(userID uint) (*domain.BreakStatus, error) {
+	attendance, err := s.openAttendance(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotCheckedIn) {
+			return &domain.BreakStatus{State: domain.BreakStateCheckedOut}, nil
+		}
+		return nil, err
+	}
+
+	detailed, err := s.attendanceRepo.GetWithBreaks(attendance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BreakStatusAt(detailed, time.Now().UTC()), nil
+}
+
+// openAttendance returns the attendance of a user that currently has an open session.
+// Shifts that crossed midnight are found on the day they started.
+func (s *BreakService) openAttendance(userID uint) (*domain.Attendance, error) {
+	attendance, err := s.attendanceRepo.GetOpenByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAttendanceNotFound) {
+			return nil, domain.ErrNotCheckedIn
+		}
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// countBreaksOfType returns how many breaks of a type an attendance already has
+func (s *BreakService) countBreaksOfType(attendanceID, breakTypeID uint) (int, error) {
+	breaks, err := s.breakRepo.GetByAttendanceID(attendanceID)
+	if err != nil {
+		return 0, err
+	}
+
+	taken := 0
+	for _, breakItem := range breaks {
+		if breakItem.BreakTypeID != nil && *breakItem.BreakTypeID == breakTypeID {
+			taken++
+		}
+	}
+	return taken, nil
+}
+
+// checkDuration flags an ended break that lasted longer than its type allows.
+// Breaks whose type was deleted are not checked.
+func (s *BreakService) checkDuration(breakItem *domain.Break) error {
+	if breakItem.BreakTypeID == nil {
+		return nil
+	}
+
+	breakType, err := s.breakTypeRepo.GetByID(*breakItem.BreakTypeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBreakTypeNotFound) {
+			return nil
+		}
+		return err
+	}
+	if violation := breakType.CheckDuration(breakItem); violation != "" {
+		breakItem.Flag(violation)
+	}
+	return nil
+}
+
+// ownBreak returns a break if it belongs to one of the user's own attendance records
+func (s *BreakService) ownBreak(userID, breakID uint) (*domain.Break, error) {
+	breakItem, err := s.breakRepo.GetByID(breakID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(userID, breakItem.AttendanceID); err != nil {
+		return nil, err
+	}
+	return breakItem, nil
+}
+
+// checkOwner rejects access to the breaks of another user's attendance
+func (s *BreakService) checkOwner(userID, attendanceID uint) error {
+	attendance, err := s.attendanceRepo.GetByID(attendanceID)
+	if err != nil {
+		return err
+	}
+	if attendance.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// checkUnlocked rejects changes to breaks of an attendance locked by an approved timesheet
+func (s *BreakService) checkUnlocked(attendanceID uint) error {
+	attendance, err := s.attendanceRepo.GetByID(attendanceID)
+	if err != nil {
+		return err
+	}
+	if attendance.IsLocked() {
+		return domain.ErrAttendanceLocked
+	}
+	return nil
+}
+
+// endActiveBreak ends the break still running on an attendance, if any, at the given time.
+// The end is never placed before the start of the break. A non-empty flag reason
+// marks the ended break for review.
+func endActiveBreak(breakRepo domain.BreakRepositoryInterface, attendanceID uint, endTime time.Time, ip, flagReason string) error {
+	activeBreak, err := breakRepo.GetActiveBreakByAttendanceID(attendanceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBreakNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if endTime.Before(activeBreak.StartTime) {
+		endTime = activeBreak.StartTime
+	}
+	activeBreak.EndTime = &endTime
+	activeBreak.EndIP = ip
+	activeBreak.CalculateDuration()
+	if flagReason != "" {
+		activeBreak.Flag(flagReason)
+	}
+	return breakRepo.Update(activeBreak)
+}
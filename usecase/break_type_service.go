@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"strings"
+)
+
+// BreakTypeService implements the BreakTypeServiceInterface
+// This struct contains all the business logic for break type operations
+type BreakTypeService struct {
+	breakTypeRepo domain.BreakTypeRepositoryInterface
+	breakRepo     domain.BreakRepositoryInterface
+}
+
+// NewBreakTypeService creates a new instance of BreakTypeService
+func NewBreakTypeService(
+	breakTypeRepo domain.BreakTypeRepositoryInterface,
+	breakRepo domain.BreakRepositoryInterface,
+) domain.BreakTypeServiceInterface {
+	return &BreakTypeService{
+		breakTypeRepo: breakTypeRepo,
+		breakRepo:     breakRepo,
+	}
+}
+
+// CreateBreakType creates a new, active break type
+func (s *BreakTypeService) CreateBreakType(breakType *domain.BreakType) error {
+	breakType.Code = strings.ToLower(strings.TrimSpace(breakType.Code))
+	breakType.IsActive = true
+
+	if err := s.checkCodeAvailable(breakType.Code, 0); err != nil {
+		return err
+	}
+
+	return s.breakTypeRepo.Create(breakType)
+}
+
+// GetBreakTypeByID retrieves a break type by its ID
+func (s *BreakTypeService) GetBreakTypeByID(id uint) (*domain.BreakType, error) {
+	return s.breakTypeRepo.GetByID(id)
+}
+
+// GetAllBreakTypes retrieves all break types
+func (s *BreakTypeService) GetAllBreakTypes() ([]domain.BreakType, error) {
+	return s.breakTypeRepo.GetAll()
+}
+
+// UpdateBreakType modifies an existing break type.
+// Breaks already taken keep the paid flag they were started with.
+func (s *BreakTypeService) UpdateBreakType(breakType *domain.BreakType) error {
+	// Check if break type exists
+	existingBreakType, err := s.breakTypeRepo.GetByID(breakType.ID)
+	if err != nil {
+		return err
+	}
+
+	breakType.Code = strings.ToLower(strings.TrimSpace(breakType.Code))
+	if err := s.checkCodeAvailable(breakType.Code, breakType.ID); err != nil {
+		return err
+	}
+
+	// Preserve the original creation time
+	breakType.CreatedAt = existingBreakType.CreatedAt
+
+	return s.breakTypeRepo.Update(breakType)
+}
+
+// DeleteBreakType removes a break type that no break was recorded with
+func (s *BreakTypeService) DeleteBreakType(id uint) error {
+	count, err := s.breakRepo.CountByBreakTypeID(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrBreakTypeInUse
+	}
+
+	return s.breakTypeRepo.Delete(id)
+}
+
+// checkCodeAvailable verifies that no other break type uses the code
+func (s *BreakTypeService) checkCodeAvailable(code string, breakTypeID uint) error {
+	if code == "" {
+		return nil
+	}
+
+	existing, err := s.breakTypeRepo.GetByCode(code)
+	if err != nil {
+		if errors.Is(err, domain.ErrBreakTypeNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != breakTypeID {
+		return domain.ErrBreakTypeCodeExists
+	}
+
+	return nil
+}
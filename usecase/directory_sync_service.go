@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+// DirectorySyncService implements the DirectorySyncServiceInterface
+// This struct contains the business logic for keeping users and their managers in step with the LDAP directory
+type DirectorySyncService struct {
+	directory       domain.DirectoryInterface
+	userRepo        domain.UserRepositoryInterface
+	authSessionRepo domain.AuthSessionRepositoryInterface
+}
+
+// NewDirectorySyncService creates a new instance of DirectorySyncService
+func NewDirectorySyncService(
+	directory domain.DirectoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	authSessionRepo domain.AuthSessionRepositoryInterface,
+) domain.DirectorySyncServiceInterface {
+	return &DirectorySyncService{
+		directory:       directory,
+		userRepo:        userRepo,
+		authSessionRepo: authSessionRepo,
+	}
+}
+
+// directorySyncUser is a user as the sync is going to leave it
+type directorySyncUser struct {
+	user    *domain.User
+	entry   domain.DirectoryEntry
+	created bool
+	disable bool // The entry was disabled in the directory, so the user's sessions are revoked
+	change  domain.DirectoryUserChange
+}
+
+// Sync creates, updates and deactivates users to match the directory.
+// This method performs the following business operations:
+//  1. Reads all directory entries; an empty result is refused so a misconfigured filter cannot deactivate everyone
+//  2. Matches each entry to the user synced from it, else to the user with the same email, else creates a user
+//  3. Updates name, email and active status from the entry
+//  4. Sets managers from the entries' manager DNs once all users are known
+//  5. Deactivates directory users whose entry is gone and signs them out
+//
+// A dry run reports the same changes without saving anything. Local accounts that were never synced are left alone.
+func (s *DirectorySyncService) Sync(dryRun bool) (*domain.DirectorySyncReport, error) {
+	// Step 1: Read the directory
+	entries, err := s.directory.ListEntries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, domain.ErrDirectoryEmpty
+	}
+	report := &domain.DirectorySyncReport{DryRun: dryRun, Entries: len(entries)}
+
+	existing, err := s.userRepo.ListFromDirectory()
+	if err != nil {
+		return nil, err
+	}
+	byDN := make(map[string]*domain.User, len(existing))
+	byID := make(map[uint]*domain.User, len(existing))
+	for i := range existing {
+		byDN[*existing[i].DirectoryDN] = &existing[i]
+		byID[existing[i].ID] = &existing[i]
+	}
+
+	// Steps 2 and 3: Match, create and update users
+	var synced []*directorySyncUser
+	syncedByDN := make(map[string]*directorySyncUser, len(entries))
+	for _, entry := range entries {
+		if entry.DN == "" || entry.Email == "" {
+			report.AddIssue(entry.DN, "entry has no email address")
+			continue
+		}
+		if syncedByDN[entry.DN] != nil {
+			report.AddIssue(entry.DN, "entry was returned twice")
+			continue
+		}
+
+		item, err := s.syncEntry(entry, byDN, dryRun)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserAlreadyExists) {
+				report.AddIssue(entry.DN, "%s is used by a user synced from another entry", entry.Email)
+				continue
+			}
+			return nil, err
+		}
+		synced = append(synced, item)
+		syncedByDN[entry.DN] = item
+		if item.user.ID != 0 {
+			byID[item.user.ID] = item.user
+		}
+	}
+
+	// Step 4: Set managers now that every entry has a user
+	for _, item := range synced {
+		s.syncManager(item, syncedByDN, byID, report)
+	}
+
+	for _, item := range synced {
+		switch {
+		case item.created:
+			report.Created = append(report.Created, item.change)
+		case len(item.change.Changes) > 0:
+			report.Updated = append(report.Updated, item.change)
+		default:
+			report.Unchanged++
+			continue
+		}
+		if dryRun {
+			continue
+		}
+		if err := s.userRepo.Update(item.user); err != nil {
+			return nil, err
+		}
+		if item.disable {
+			if err := s.authSessionRepo.RevokeAllByUserID(item.user.ID, domain.SessionRevokedUserDeactivated); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Step 5: Deactivate users whose entry is gone
+	for i := range existing {
+		user := &existing[i]
+		if syncedByDN[*user.DirectoryDN] != nil || !user.IsActive {
+			continue
+		}
+		report.Deactivated = append(report.Deactivated, domain.DirectoryUserChange{
+			UserID:  user.ID,
+			DN:      *user.DirectoryDN,
+			Email:   user.Email,
+			Changes: []domain.DirectoryFieldChange{{Field: domain.DirectoryFieldActive, From: "true", To: "false"}},
+		})
+		if dryRun {
+			continue
+		}
+		user.IsActive = false
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+		if err := s.authSessionRepo.RevokeAllByUserID(user.ID, domain.SessionRevokedUserDeactivated); err != nil {
+			return nil, err
+		}
+	}
+
+	if !dryRun {
+		log.Printf("Directory sync: %d created, %d updated, %d deactivated, %d unchanged, %d issue(s)",
+			len(report.Created), len(report.Updated), len(report.Deactivated), report.Unchanged, len(report.Issues))
+	}
+	return report, nil
+}
+
+// syncEntry finds or creates the user of a directory entry and applies the entry's attributes.
+// New users are saved right away, so that managers can refer to them; a dry run leaves them unsaved with ID 0.
+func (s *DirectorySyncService) syncEntry(entry domain.DirectoryEntry, byDN map[string]*domain.User, dryRun bool) (*directorySyncUser, error) {
+	item := &directorySyncUser{entry: entry, change: domain.DirectoryUserChange{DN: entry.DN, Email: entry.Email}}
+
+	user := byDN[entry.DN]
+	if user == nil {
+		// A local account with the same email is taken over by the directory
+		existing, err := s.userRepo.GetByEmail(entry.Email)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		if existing != nil && existing.IsFromDirectory() {
+			return nil, domain.ErrUserAlreadyExists
+		}
+		user = existing
+	}
+
+	if user == nil {
+		newUser, err := newDirectoryUser(entry)
+		if err != nil {
+			return nil, err
+		}
+		if !dryRun {
+			if err := s.userRepo.Create(newUser); err != nil {
+				return nil, err
+			}
+		}
+		item.user = newUser
+		item.created = true
+		item.change.UserID = newUser.ID
+		item.change.Changes = []domain.DirectoryFieldChange{
+			{Field: domain.DirectoryFieldName, To: newUser.Name},
+			{Field: domain.DirectoryFieldEmail, To: newUser.Email},
+			{Field: domain.DirectoryFieldActive, To: strconv.FormatBool(newUser.IsActive)},
+		}
+		return item, nil
+	}
+
+	item.user = user
+	item.change.UserID = user.ID
+	if user.DirectoryDN == nil || *user.DirectoryDN != entry.DN {
+		from := ""
+		if user.DirectoryDN != nil {
+			from = *user.DirectoryDN
+		}
+		item.record(domain.DirectoryFieldDN, from, entry.DN)
+		dn := entry.DN
+		user.DirectoryDN = &dn
+	}
+	if name := directoryUserName(entry); user.Name != name {
+		item.record(domain.DirectoryFieldName, user.Name, name)
+		user.Name = name
+	}
+	if user.Email != entry.Email {
+		item.record(domain.DirectoryFieldEmail, user.Email, entry.Email)
+		user.Email = entry.Email
+		// The directory vouches for the new address
+		now := time.Now().UTC()
+		user.EmailVerifiedAt = &now
+	}
+	if user.IsActive == entry.Disabled {
+		item.record(domain.DirectoryFieldActive, strconv.FormatBool(user.IsActive), strconv.FormatBool(!entry.Disabled))
+		user.IsActive = !entry.Disabled
+		item.disable = entry.Disabled
+	}
+	return item, nil
+}
+
+// syncManager points the user's manager at the user synced from the entry's manager DN.
+// Managers outside the synced entries are reported and the current manager is kept.
+func (s *DirectorySyncService) syncManager(
+	item *directorySyncUser,
+	syncedByDN map[string]*directorySyncUser,
+	byID map[uint]*domain.User,
+	report *domain.DirectorySyncReport,
+) {
+	current := s.managerLabel(item.user.ManagerID, byID)
+
+	if item.entry.ManagerDN == "" {
+		if item.user.ManagerID != nil {
+			item.record(domain.DirectoryFieldManager, current, "")
+			item.user.ManagerID = nil
+		}
+		return
+	}
+
+	manager := syncedByDN[item.entry.ManagerDN]
+	if manager == nil {
+		report.AddIssue(item.entry.DN, "manager %s is not a synced directory user; manager left unchanged", item.entry.ManagerDN)
+		return
+	}
+	if manager == item {
+		report.AddIssue(item.entry.DN, "entry is its own manager; manager left unchanged")
+		return
+	}
+
+	// Managers a dry run would create have no ID yet and are compared by email
+	if manager.user.ID != 0 && item.user.ManagerID != nil && *item.user.ManagerID == manager.user.ID {
+		return
+	}
+	item.record(domain.DirectoryFieldManager, current, manager.user.Email)
+	if manager.user.ID != 0 {
+		managerID := manager.user.ID
+		item.user.ManagerID = &managerID
+	}
+}
+
+// managerLabel returns how a manager is shown in the report: their email, or their ID if they are not loaded
+func (s *DirectorySyncService) managerLabel(managerID *uint, byID map[uint]*domain.User) string {
+	if managerID == nil {
+		return ""
+	}
+	if manager, ok := byID[*managerID]; ok {
+		return manager.Email
+	}
+	if manager, err := s.userRepo.GetByID(*managerID); err == nil {
+		byID[manager.ID] = manager
+		return manager.Email
+	}
+	return fmt.Sprintf("user %d", *managerID)
+}
+
+// record adds a field change to the report entry of the user
+func (i *directorySyncUser) record(field, from, to string) {
+	i.change.Changes = append(i.change.Changes, domain.DirectoryFieldChange{Field: field, From: from, To: to})
+}
+
+// newDirectoryUser builds the account of a directory entry. Directory users sign in with their
+// directory password, so the account gets an unusable one; the directory vouches for the email.
+func newDirectoryUser(entry domain.DirectoryEntry) (*domain.User, error) {
+	hashedPassword, err := unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	dn := entry.DN
+	return &domain.User{
+		Name:            directoryUserName(entry),
+		Email:           entry.Email,
+		Password:        hashedPassword,
+		IsActive:        !entry.Disabled,
+		Role:            domain.RoleEmployee,
+		EmailVerifiedAt: &now,
+		DirectoryDN:     &dn,
+	}, nil
+}
+
+// directoryUserName returns the entry's name, or the local part of its email if it has none
+func directoryUserName(entry domain.DirectoryEntry) string {
+	if entry.Name != "" {
+		return entry.Name
+	}
+	return strings.SplitN(entry.Email, "@", 2)[0]
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"hrm/domain"
+	"time"
+)
+
+// HolidayService implements the HolidayServiceInterface
+// This struct contains all the business logic for holiday operations
+type HolidayService struct {
+	holidayRepo  domain.HolidayRepositoryInterface
+	locationRepo domain.LocationRepositoryInterface
+}
+
+// NewHolidayService creates a new instance of HolidayService
+func NewHolidayService(holidayRepo domain.HolidayRepositoryInterface, locationRepo domain.LocationRepositoryInterface) domain.HolidayServiceInterface {
+	return &HolidayService{
+		holidayRepo:  holidayRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// CreateHoliday creates a new holiday
+func (s *HolidayService) CreateHoliday(holiday *domain.Holiday) error {
+	holiday.Date = domain.DateOnly(holiday.Date)
+
+	// Check that the location exists
+	if holiday.LocationID != nil {
+		if _, err := s.locationRepo.GetByID(*holiday.LocationID); err != nil {
+			return err
+		}
+	}
+
+	return s.holidayRepo.Create(holiday)
+}
+
+// GetHolidayByID retrieves a holiday by its ID
+func (s *HolidayService) GetHolidayByID(id uint) (*domain.Holiday, error) {
+	return s.holidayRepo.GetByID(id)
+}
+
+// GetAllHolidays retrieves all holidays
+func (s *HolidayService) GetAllHolidays() ([]domain.Holiday, error) {
+	return s.holidayRepo.GetAll()
+}
+
+// GetHolidaysByDateRange retrieves holidays between two dates (inclusive)
+func (s *HolidayService) GetHolidaysByDateRange(startDate, endDate time.Time) ([]domain.Holiday, error) {
+	return s.holidayRepo.GetByDateRange(domain.DateOnly(startDate), domain.DateOnly(endDate))
+}
+
+// UpdateHoliday modifies an existing holiday
+func (s *HolidayService) UpdateHoliday(holiday *domain.Holiday) error {
+	// Check if holiday exists
+	existingHoliday, err := s.holidayRepo.GetByID(holiday.ID)
+	if err != nil {
+		return err
+	}
+
+	// Check that the location exists
+	if holiday.LocationID != nil {
+		if _, err := s.locationRepo.GetByID(*holiday.LocationID); err != nil {
+			return err
+		}
+	}
+
+	// Preserve the original creation time
+	holiday.CreatedAt = existingHoliday.CreatedAt
+	holiday.Date = domain.DateOnly(holiday.Date)
+
+	return s.holidayRepo.Update(holiday)
+}
+
+// DeleteHoliday removes a holiday
+func (s *HolidayService) DeleteHoliday(id uint) error {
+	return s.holidayRepo.Delete(id)
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// kioskTokenPrefix makes kiosk device tokens recognizable and distinct from user JWTs
+const kioskTokenPrefix = "kiosk_"
+
+// kioskSeenInterval limits how often a kiosk's last-seen time is written
+const kioskSeenInterval = time.Minute
+
+// KioskService implements the KioskServiceInterface
+// This struct contains the business logic for shared kiosk devices and the punches made on them
+type KioskService struct {
+	kioskRepo         domain.KioskRepositoryInterface
+	userRepo          domain.UserRepositoryInterface
+	locationRepo      domain.LocationRepositoryInterface
+	attendanceService domain.AttendanceServiceInterface
+	breakService      domain.BreakServiceInterface
+	pinPolicy         domain.KioskPINPolicy
+}
+
+// NewKioskService creates a new instance of KioskService
+func NewKioskService(
+	kioskRepo domain.KioskRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	attendanceService domain.AttendanceServiceInterface,
+	breakService domain.BreakServiceInterface,
+	pinPolicy domain.KioskPINPolicy,
+) domain.KioskServiceInterface {
+	return &KioskService{
+		kioskRepo:         kioskRepo,
+		userRepo:          userRepo,
+		locationRepo:      locationRepo,
+		attendanceService: attendanceService,
+		breakService:      breakService,
+		pinPolicy:         pinPolicy,
+	}
+}
+
+// RegisterKiosk creates an active kiosk and returns its device token.
+// The token is only returned here and by RotateKioskToken; it cannot be retrieved later.
+func (s *KioskService) RegisterKiosk(kiosk *domain.Kiosk) (string, error) {
+	if err := s.checkLocation(kiosk.LocationID); err != nil {
+		return "", err
+	}
+
+	token, err := generateOpaqueToken(kioskTokenPrefix)
+	if err != nil {
+		return "", err
+	}
+	kiosk.TokenHash = hashOpaqueToken(token)
+	kiosk.IsActive = true
+
+	if err := s.kioskRepo.Create(kiosk); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// GetKioskByID retrieves a kiosk by its ID
+func (s *KioskService) GetKioskByID(id uint) (*domain.Kiosk, error) {
+	return s.kioskRepo.GetByID(id)
+}
+
+// GetAllKiosks retrieves all kiosks
+func (s *KioskService) GetAllKiosks() ([]domain.Kiosk, error) {
+	return s.kioskRepo.GetAll()
+}
+
+// UpdateKiosk changes the name, location or activation of a kiosk; its token is kept
+func (s *KioskService) UpdateKiosk(kiosk *domain.Kiosk) error {
+	existingKiosk, err := s.kioskRepo.GetByID(kiosk.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkLocation(kiosk.LocationID); err != nil {
+		return err
+	}
+
+	kiosk.TokenHash = existingKiosk.TokenHash
+	kiosk.LastSeenAt = existingKiosk.LastSeenAt
+	kiosk.CreatedAt = existingKiosk.CreatedAt
+
+	return s.kioskRepo.Update(kiosk)
+}
+
+// RotateKioskToken replaces the device token of a kiosk, e.g. after a device was lost.
+// The previous token stops working immediately.
+func (s *KioskService) RotateKioskToken(id uint) (string, error) {
+	kiosk, err := s.kioskRepo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := generateOpaqueToken(kioskTokenPrefix)
+	if err != nil {
+		return "", err
+	}
+	kiosk.TokenHash = hashOpaqueToken(token)
+
+	if err := s.kioskRepo.Update(kiosk); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// DeleteKiosk removes a kiosk; sessions punched on it keep its ID for audit
+func (s *KioskService) DeleteKiosk(id uint) error {
+	return s.kioskRepo.Delete(id)
+}
+
+// AuthenticateKiosk resolves the active kiosk a device token belongs to and records that it was seen
+func (s *KioskService) AuthenticateKiosk(token string) (*domain.Kiosk, error) {
+	if !strings.HasPrefix(token, kioskTokenPrefix) {
+		return nil, domain.ErrInvalidKioskToken
+	}
+
+	kiosk, err := s.kioskRepo.GetByTokenHash(hashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrKioskNotFound) {
+			return nil, domain.ErrInvalidKioskToken
+		}
+		return nil, err
+	}
+	if !kiosk.IsActive {
+		return nil, domain.ErrKioskInactive
+	}
+
+	now := time.Now().UTC()
+	if kiosk.LastSeenAt == nil || now.Sub(*kiosk.LastSeenAt) >= kioskSeenInterval {
+		kiosk.LastSeenAt = &now
+		if err := s.kioskRepo.Update(kiosk); err != nil {
+			return nil, err
+		}
+	}
+
+	return kiosk, nil
+}
+
+// CheckIn identifies the employee at the kiosk and starts a work session for them
+func (s *KioskService) CheckIn(kiosk *domain.Kiosk, credentials domain.KioskCredentials, punch domain.Punch) (*domain.User, *domain.Attendance, error) {
+	user, err := s.identify(kiosk, credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attendance, err := s.attendanceService.CheckIn(user.ID, s.kioskPunch(kiosk, punch))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, attendance, nil
+}
+
+// CheckOut identifies the employee at the kiosk and closes their open work session
+func (s *KioskService) CheckOut(kiosk *domain.Kiosk, credentials domain.KioskCredentials, punch domain.Punch) (*domain.User, *domain.Attendance, error) {
+	user, err := s.identify(kiosk, credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attendance, err := s.attendanceService.CheckOut(user.ID, s.kioskPunch(kiosk, punch))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, attendance, nil
+}
+
+// StartBreak identifies the employee at the kiosk and starts a break in their open attendance
+func (s *KioskService) StartBreak(kiosk *domain.Kiosk, credentials domain.KioskCredentials, punch domain.Punch, breakTypeID *uint, reason string) (*domain.User, *domain.Break, error) {
+	user, err := s.identify(kiosk, credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breakItem, err := s.breakService.StartMyBreak(user.ID, s.kioskPunch(kiosk, punch), breakTypeID, reason)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, breakItem, nil
+}
+
+// EndBreak identifies the employee at the kiosk and ends the break they are on
+func (s *KioskService) EndBreak(kiosk *domain.Kiosk, credentials domain.KioskCredentials, punch domain.Punch) (*domain.User, *domain.Break, error) {
+	user, err := s.identify(kiosk, credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breakItem, err := s.breakService.EndMyBreak(user.ID, s.kioskPunch(kiosk, punch))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, breakItem, nil
+}
+
+// identify resolves the employee behind kiosk credentials.
+// Unknown codes, wrong PINs and unknown badges all fail with the same error so that
+// the kiosk does not reveal which employee codes exist. Wrong PINs are counted per employee code,
+// which is locked at all kiosks once the PIN policy's limit is reached.
+func (s *KioskService) identify(kiosk *domain.Kiosk, credentials domain.KioskCredentials) (*domain.User, error) {
+	if err := credentials.Validate(); err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	var err error
+	if credentials.UsesBadge() {
+		user, err = s.userRepo.GetByBadgeID(strings.TrimSpace(credentials.BadgeID))
+	} else {
+		user, err = s.userRepo.GetByEmployeeCode(strings.ToUpper(strings.TrimSpace(credentials.EmployeeCode)))
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidKioskCredentials
+		}
+		return nil, err
+	}
+
+	if !credentials.UsesBadge() {
+		if !user.HasKioskPIN() {
+			return nil, domain.ErrInvalidKioskCredentials
+		}
+		now := time.Now().UTC()
+		if user.IsKioskPINLockedAt(now) {
+			return nil, domain.ErrKioskPINLocked
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.KioskPIN), []byte(credentials.PIN)); err != nil {
+			return nil, s.recordFailedPIN(user, now)
+		}
+		if user.FailedKioskPINs > 0 || user.KioskPINLockedUntil != nil {
+			user.ResetFailedKioskPINs()
+			if err := s.userRepo.Update(user); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	// A kiosk serves the employees of its own site; employees without a site may use any kiosk
+	if kiosk.LocationID != nil && user.LocationID != nil && *kiosk.LocationID != *user.LocationID {
+		return nil, domain.ErrKioskLocationMismatch
+	}
+
+	user.Sanitize()
+	return user, nil
+}
+
+// recordFailedPIN counts a wrong PIN for the employee and returns the error to answer the kiosk with
+func (s *KioskService) recordFailedPIN(user *domain.User, now time.Time) error {
+	locked := user.RecordFailedKioskPIN(now, s.pinPolicy)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	if locked {
+		return domain.ErrKioskPINLocked
+	}
+	return domain.ErrInvalidKioskCredentials
+}
+
+// kioskPunch records the kiosk on a punch. Kiosks are fixed devices, so punches without
+// device coordinates are placed at the center of the kiosk's location for geofence checks.
+func (s *KioskService) kioskPunch(kiosk *domain.Kiosk, punch domain.Punch) domain.Punch {
+	kioskID := kiosk.ID
+	punch.KioskID = &kioskID
+
+	if punch.HasLocation() || kiosk.LocationID == nil {
+		return punch
+	}
+	location, err := s.locationRepo.GetByID(*kiosk.LocationID)
+	if err != nil || !location.HasGeofence() {
+		return punch
+	}
+	punch.Latitude = location.Latitude
+	punch.Longitude = location.Longitude
+	return punch
+}
+
+// checkLocation verifies that the location a kiosk is placed at exists
+func (s *KioskService) checkLocation(locationID *uint) error {
+	if locationID == nil {
+		return nil
+	}
+	_, err := s.locationRepo.GetByID(*locationID)
+	return err
+}
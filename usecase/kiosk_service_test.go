@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hrm/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestIdentifyLocksEmployeeCodeAfterWrongPINs(t *testing.T) {
+	hashedPIN, err := bcrypt.GenerateFromPassword([]byte("4821"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+	code := "F-1042"
+	userRepo := &fakeUserRepository{users: map[uint]domain.User{
+		1: {ID: 1, Name: "Alice", IsActive: true, EmployeeCode: &code, KioskPIN: string(hashedPIN)},
+	}}
+	service := &KioskService{userRepo: userRepo, pinPolicy: domain.KioskPINPolicy{MaxFailedAttempts: 3, LockoutDuration: time.Hour}}
+	kiosk := &domain.Kiosk{ID: 1}
+	pin := func(pin string) domain.KioskCredentials {
+		return domain.KioskCredentials{EmployeeCode: "f-1042", PIN: pin}
+	}
+
+	steps := []struct {
+		name    string
+		pin     string
+		wantErr error
+	}{
+		{"first wrong PIN", "0000", domain.ErrInvalidKioskCredentials},
+		{"correct PIN resets the count", "4821", nil},
+		{"wrong PIN after the reset", "1111", domain.ErrInvalidKioskCredentials},
+		{"second wrong PIN", "2222", domain.ErrInvalidKioskCredentials},
+		{"third wrong PIN locks the code", "3333", domain.ErrKioskPINLocked},
+		{"correct PIN while locked", "4821", domain.ErrKioskPINLocked},
+	}
+
+	for _, step := range steps {
+		_, err := service.identify(kiosk, pin(step.pin))
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+
+	// An admin lifts the lockout
+	user := userRepo.users[1]
+	user.ResetFailedKioskPINs()
+	userRepo.users[1] = user
+	if _, err := service.identify(kiosk, pin("4821")); err != nil {
+		t.Fatalf("after unlock: err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"hrm/domain"
+)
+
+// LocationService implements the LocationServiceInterface
+// This struct contains all the business logic for location operations
+type LocationService struct {
+	locationRepo domain.LocationRepositoryInterface
+}
+
+// NewLocationService creates a new instance of LocationService
+func NewLocationService(locationRepo domain.LocationRepositoryInterface) domain.LocationServiceInterface {
+	return &LocationService{
+		locationRepo: locationRepo,
+	}
+}
+
+// CreateLocation creates a new location
+func (s *LocationService) CreateLocation(location *domain.Location) error {
+	return s.locationRepo.Create(location)
+}
+
+// GetLocationByID retrieves a location by its ID
+func (s *LocationService) GetLocationByID(id uint) (*domain.Location, error) {
+	return s.locationRepo.GetByID(id)
+}
+
+// GetAllLocations retrieves all locations
+func (s *LocationService) GetAllLocations() ([]domain.Location, error) {
+	return s.locationRepo.GetAll()
+}
+
+// UpdateLocation modifies an existing location
+func (s *LocationService) UpdateLocation(location *domain.Location) error {
+	// Check if location exists
+	existingLocation, err := s.locationRepo.GetByID(location.ID)
+	if err != nil {
+		return err
+	}
+
+	// Preserve the original creation time
+	location.CreatedAt = existingLocation.CreatedAt
+
+	return s.locationRepo.Update(location)
+}
+
+// DeleteLocation removes a location
+func (s *LocationService) DeleteLocation(id uint) error {
+	return s.locationRepo.Delete(id)
+}
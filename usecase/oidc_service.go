@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+// OIDCService implements the OIDCServiceInterface
+// This struct contains the business logic for single sign-on through an OpenID Connect provider:
+// the authorization code flow with PKCE, linking provider accounts to users and mapping groups to roles
+type OIDCService struct {
+	provider          domain.OIDCProviderInterface
+	identityRepo      domain.OIDCIdentityRepositoryInterface
+	stateRepo         domain.OIDCLoginStateRepositoryInterface
+	userRepo          domain.UserRepositoryInterface
+	signInAttemptRepo domain.SignInAttemptRepositoryInterface
+	groupRoles        map[string]string
+	autoProvision     bool
+	stateTTL          time.Duration
+}
+
+// NewOIDCService creates a new instance of OIDCService.
+// Roles are only taken from the provider's groups when a group mapping is configured;
+// without auto-provisioning only users that already have an HRM account can sign in.
+func NewOIDCService(
+	provider domain.OIDCProviderInterface,
+	identityRepo domain.OIDCIdentityRepositoryInterface,
+	stateRepo domain.OIDCLoginStateRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	signInAttemptRepo domain.SignInAttemptRepositoryInterface,
+	groupRoles map[string]string,
+	autoProvision bool,
+	stateTTL time.Duration,
+) domain.OIDCServiceInterface {
+	return &OIDCService{
+		provider:          provider,
+		identityRepo:      identityRepo,
+		stateRepo:         stateRepo,
+		userRepo:          userRepo,
+		signInAttemptRepo: signInAttemptRepo,
+		groupRoles:        groupRoles,
+		autoProvision:     autoProvision,
+		stateTTL:          stateTTL,
+	}
+}
+
+// StartLogin begins a sign-in at the identity provider.
+// The state, nonce and PKCE code verifier are kept server-side; only the state travels with the browser.
+func (s *OIDCService) StartLogin() (*domain.OIDCLoginStart, error) {
+	now := time.Now().UTC()
+
+	// Sign-ins that were never completed are cleaned up as new ones start
+	if _, err := s.stateRepo.DeleteExpired(now); err != nil {
+		log.Printf("Error deleting expired OIDC login states: %v", err)
+	}
+
+	state, err := generateOpaqueToken("")
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generateOpaqueToken("")
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := generateOpaqueToken("")
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &domain.OIDCLoginState{
+		StateHash:    hashOpaqueToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(s.stateTTL),
+	}
+	if err := s.stateRepo.Create(loginState); err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := s.provider.AuthorizationURL(state, nonce, pkceChallenge(codeVerifier))
+	if err != nil {
+		log.Printf("OIDC provider unavailable: %v", err)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	return &domain.OIDCLoginStart{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// CompleteLogin finishes a sign-in the identity provider redirected back.
+// This method performs the following business operations:
+//  1. Checks and uses up the login state, so a callback cannot be replayed
+//  2. Redeems the code with the PKCE verifier and verifies the ID token
+//  3. Finds the user linked to the provider account, or links the user with the same verified email,
+//     or provisions a new user
+//  4. Refuses locked and deactivated users
+//  5. Updates the role from the provider's groups and records the sign-in
+func (s *OIDCService) CompleteLogin(state, code string, client domain.AuthClient) (*domain.User, error) {
+	now := time.Now().UTC()
+
+	// Step 1: Check and use up the login state
+	loginState, err := s.stateRepo.GetByStateHash(hashOpaqueToken(state))
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCLoginStateNotFound) {
+			return nil, domain.ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+	if !loginState.IsUsableAt(now) {
+		return nil, domain.ErrInvalidOIDCState
+	}
+	loginState.UsedAt = &now
+	if err := s.stateRepo.Update(loginState); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Redeem the code and verify the ID token
+	claims, err := s.provider.Exchange(code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Printf("OIDC sign-in failed: %v", err)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	// Step 3: Find, link or provision the user
+	user, identity, err := s.resolveUser(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := &domain.SignInAttempt{UserID: &user.ID, Email: user.Email}
+	applySignInClient(attempt, client)
+
+	// Step 4: Refuse locked and deactivated users
+	if user.IsLockedAt(now) {
+		s.recordSignInAttempt(attempt, domain.SignInResultLocked)
+		return nil, &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+	}
+	if !user.IsActive {
+		s.recordSignInAttempt(attempt, domain.SignInResultInactive)
+		return nil, domain.ErrUserInactive
+	}
+
+	// Step 5: Take the role from the groups, if the provider sent any, and record the sign-in
+	if len(s.groupRoles) > 0 && claims.Groups != nil {
+		if role := domain.RoleForGroups(claims.Groups, s.groupRoles); role != user.Role {
+			log.Printf("User %d role changed from %q to %q by identity provider groups", user.ID, user.Role, role)
+			user.Role = role
+			if err := s.userRepo.Update(user); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	identity.LastLoginAt = &now
+	if claims.Email != "" {
+		identity.Email = claims.Email
+	}
+	if err := s.identityRepo.Update(identity); err != nil {
+		return nil, err
+	}
+	s.recordSignInAttempt(attempt, domain.SignInResultSuccess)
+
+	// Return authenticated user (with password removed)
+	user.Sanitize()
+	return user, nil
+}
+
+// resolveUser returns the user signing in and their identity link, creating either when needed.
+// Accounts are only matched by email when the provider vouches for the address.
+func (s *OIDCService) resolveUser(claims *domain.OIDCClaims) (*domain.User, *domain.OIDCIdentity, error) {
+	// A provider account that signed in before keeps its user, even if its email changed since
+	identity, err := s.identityRepo.GetBySubject(claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := s.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return user, identity, nil
+	}
+	if !errors.Is(err, domain.ErrOIDCIdentityNotFound) {
+		return nil, nil, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetByEmail(claims.Email)
+	switch {
+	case err == nil:
+		// An HRM account can only be linked to one account at the provider
+		existing, err := s.identityRepo.GetByUserID(claims.Issuer, user.ID)
+		if err == nil && existing.Subject != claims.Subject {
+			return nil, nil, domain.ErrOIDCIdentityConflict
+		}
+		if err != nil && !errors.Is(err, domain.ErrOIDCIdentityNotFound) {
+			return nil, nil, err
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		if !s.autoProvision {
+			return nil, nil, domain.ErrOIDCUserNotProvisioned
+		}
+		if user, err = s.provisionUser(claims); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, err
+	}
+
+	identity = &domain.OIDCIdentity{
+		UserID:  user.ID,
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}
+	if err := s.identityRepo.Create(identity); err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Linked user %d to identity provider subject %q", user.ID, claims.Subject)
+
+	return user, identity, nil
+}
+
+// provisionUser creates the HRM account for a provider account signing in for the first time.
+// The account gets an unusable random password; the user can set one with a password reset.
+func (s *OIDCService) provisionUser(claims *domain.OIDCClaims) (*domain.User, error) {
+	hashedPassword, err := unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+
+	now := time.Now().UTC()
+	user := &domain.User{
+		Name:            name,
+		Email:           claims.Email,
+		Password:        hashedPassword,
+		IsActive:        true,
+		Role:            domain.RoleEmployee,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Provisioned user %d from identity provider", user.ID)
+	return user, nil
+}
+
+// recordSignInAttempt adds an attempt to the sign-in history.
+// Failing to record it is logged but does not change the outcome of the sign-in.
+func (s *OIDCService) recordSignInAttempt(attempt *domain.SignInAttempt, result string) {
+	attempt.Result = result
+	if err := s.signInAttemptRepo.Create(attempt); err != nil {
+		log.Printf("Error recording sign-in attempt: %v", err)
+	}
+}
+
+// pkceChallenge derives the S256 code challenge from a PKCE code verifier (RFC 7636)
+func pkceChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
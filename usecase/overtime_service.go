@@ -0,0 +1,524 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"log"
+	"math"
+	"time"
+)
+
+// OvertimeService implements the OvertimeServiceInterface
+// This struct contains the overtime engine and the approval workflow around it
+type OvertimeService struct {
+	entryRepo        domain.OvertimeEntryRepositoryInterface
+	requestRepo      domain.OvertimeRequestRepositoryInterface
+	attendanceRepo   domain.AttendanceRepositoryInterface
+	holidayRepo      domain.HolidayRepositoryInterface
+	userRepo         domain.UserRepositoryInterface
+	locationRepo     domain.LocationRepositoryInterface
+	attendancePolicy domain.AttendancePolicy
+	policy           domain.OvertimePolicy
+}
+
+// NewOvertimeService creates a new instance of OvertimeService
+func NewOvertimeService(
+	entryRepo domain.OvertimeEntryRepositoryInterface,
+	requestRepo domain.OvertimeRequestRepositoryInterface,
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	holidayRepo domain.HolidayRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	attendancePolicy domain.AttendancePolicy,
+	policy domain.OvertimePolicy,
+) domain.OvertimeServiceInterface {
+	return &OvertimeService{
+		entryRepo:        entryRepo,
+		requestRepo:      requestRepo,
+		attendanceRepo:   attendanceRepo,
+		holidayRepo:      holidayRepo,
+		userRepo:         userRepo,
+		locationRepo:     locationRepo,
+		attendancePolicy: attendancePolicy,
+		policy:           policy,
+	}
+}
+
+// RecalculateUserRange recomputes the overtime ledger of a user for every week touching the range.
+// Whole weeks are always recalculated because the weekly threshold depends on earlier days.
+// Users can recalculate their own overtime; others need to be allowed to review the user.
+// The range has to lie within one pay period.
+func (s *OvertimeService) RecalculateUserRange(userID uint, requesterID uint, startDate, endDate time.Time) error {
+	if domain.DateOnly(endDate).Before(domain.DateOnly(startDate)) {
+		return domain.ErrInvalidDateRange
+	}
+	if _, periodEnd := s.policy.PayPeriodFor(startDate); domain.DateOnly(endDate).After(periodEnd) {
+		return domain.ErrOvertimeRangeTooLong
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if userID != requesterID && !canReviewUser(s.userRepo, user, requesterID) {
+		return domain.ErrUnauthorized
+	}
+
+	_, err = s.recalculate(user, startDate, endDate)
+	return err
+}
+
+// RecalculateRecent recomputes the recent overtime of every active user.
+// It returns the number of ledger entries that were created, changed or removed.
+func (s *OvertimeService) RecalculateRecent(now time.Time) (int, error) {
+	users, err := s.userRepo.ListActive()
+	if err != nil {
+		return 0, err
+	}
+
+	lookback := s.policy.LookbackDays
+	if lookback < 1 {
+		lookback = 1
+	}
+
+	changed := 0
+	for i := range users {
+		loc := userTimeLocation(&users[i], s.locationRepo, s.attendancePolicy)
+		today := domain.WorkDay(now, loc)
+
+		count, err := s.recalculate(&users[i], today.AddDate(0, 0, -lookback), today)
+		if err != nil {
+			// Keep going so one broken record does not block the others
+			log.Printf("Overtime recalculation for user %d failed: %v", users[i].ID, err)
+			continue
+		}
+		changed += count
+	}
+
+	return changed, nil
+}
+
+// recalculate rebuilds the ledger entries of a user for the weeks touching the range
+func (s *OvertimeService) recalculate(user *domain.User, startDate, endDate time.Time) (int, error) {
+	start := s.policy.WeekStartFor(startDate)
+	end := s.policy.WeekStartFor(endDate).AddDate(0, 0, 6)
+
+	attendances, err := s.attendanceRepo.GetByUserIDAndDateRange(user.ID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	byDate := make(map[time.Time]*domain.Attendance, len(attendances))
+	for i := range attendances {
+		byDate[domain.DateOnly(attendances[i].Date)] = &attendances[i]
+	}
+
+	holidays, err := s.holidayRepo.GetByDateRange(start, end)
+	if err != nil {
+		return 0, err
+	}
+	holidayDates := make(map[time.Time]bool)
+	for i := range holidays {
+		if holidays[i].AppliesTo(user.LocationID) {
+			holidayDates[domain.DateOnly(holidays[i].Date)] = true
+		}
+	}
+
+	requests, err := s.requestRepo.GetApprovedByUserIDAndDateRange(user.ID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	preApproved := make(map[time.Time]*domain.OvertimeRequest, len(requests))
+	for i := range requests {
+		preApproved[domain.DateOnly(requests[i].Date)] = &requests[i]
+	}
+
+	entries, err := s.entryRepo.GetByUserIDAndDateRange(user.ID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[time.Time]*domain.OvertimeEntry, len(entries))
+	for i := range entries {
+		existing[domain.DateOnly(entries[i].Date)] = &entries[i]
+	}
+
+	changed := 0
+	for weekStart := start; !weekStart.After(end); weekStart = weekStart.AddDate(0, 0, 7) {
+		regularHours := 0.0
+		for offset := 0; offset < 7; offset++ {
+			day := weekStart.AddDate(0, 0, offset)
+			attendance := byDate[day]
+
+			worked := 0.0
+			if attendance != nil {
+				worked = attendance.TotalWorkHours
+			}
+			overtime, kind, multiplier := s.computeDay(day, worked, holidayDates[day], &regularHours)
+
+			// Days still in progress are settled once the employee checks out
+			if attendance != nil && attendance.HasOpenSession() {
+				continue
+			}
+
+			ok, err := s.saveEntry(user.ID, day, attendance, existing[day], preApproved[day], overtime, kind, multiplier)
+			if err != nil {
+				return changed, err
+			}
+			if ok {
+				changed++
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// computeDay splits the hours worked on a day into regular hours and overtime.
+// Weekend and holiday hours are all overtime; on working days the daily threshold
+// applies first and the remaining regular hours count towards the weekly threshold.
+func (s *OvertimeService) computeDay(day time.Time, worked float64, isHoliday bool, regularHours *float64) (float64, domain.OvertimeKind, float64) {
+	if isHoliday {
+		return roundHours(worked), domain.OvertimeKindHoliday, s.policy.HolidayMultiplier
+	}
+	if s.attendancePolicy.IsWeekend(day) {
+		return roundHours(worked), domain.OvertimeKindWeekend, s.policy.WeekendMultiplier
+	}
+
+	regular := worked
+	daily := 0.0
+	if s.policy.DailyThresholdHours > 0 && worked > s.policy.DailyThresholdHours {
+		daily = worked - s.policy.DailyThresholdHours
+		regular = s.policy.DailyThresholdHours
+	}
+
+	weekly := 0.0
+	if s.policy.WeeklyThresholdHours > 0 && *regularHours+regular > s.policy.WeeklyThresholdHours {
+		weekly = math.Min(regular, *regularHours+regular-s.policy.WeeklyThresholdHours)
+		regular -= weekly
+	}
+	*regularHours += regular
+
+	kind := domain.OvertimeKindDaily
+	if weekly > daily {
+		kind = domain.OvertimeKindWeekly
+	}
+	return roundHours(daily + weekly), kind, s.policy.Multiplier
+}
+
+// saveEntry creates, updates or removes the ledger entry of a day.
+// When the overtime of an entry changes it is approved automatically if a pre-approval
+// covers the hours, and otherwise goes back to pending for post-facto review.
+func (s *OvertimeService) saveEntry(
+	userID uint,
+	day time.Time,
+	attendance *domain.Attendance,
+	entry *domain.OvertimeEntry,
+	request *domain.OvertimeRequest,
+	overtime float64,
+	kind domain.OvertimeKind,
+	multiplier float64,
+) (bool, error) {
+	if attendance == nil || overtime <= 0 {
+		if entry == nil {
+			return false, nil
+		}
+		return true, s.entryRepo.Delete(entry.ID)
+	}
+
+	isNew := entry == nil
+	if isNew {
+		entry = &domain.OvertimeEntry{
+			UserID: userID,
+			Date:   day,
+			Status: domain.OvertimeStatusPending,
+		}
+	}
+
+	changed := isNew || entry.OvertimeHours != overtime || entry.Multiplier != multiplier || entry.Kind != kind
+	covered := request != nil && overtime <= request.Hours
+
+	if !changed && !(entry.IsPending() && covered) {
+		return false, nil
+	}
+
+	entry.AttendanceID = attendance.ID
+	entry.PayPeriodStart, entry.PayPeriodEnd = s.policy.PayPeriodFor(day)
+	entry.WorkedHours = roundHours(attendance.TotalWorkHours)
+	entry.OvertimeHours = overtime
+	entry.Multiplier = multiplier
+	entry.WeightedHours = roundHours(overtime * multiplier)
+	entry.Kind = kind
+
+	if covered {
+		now := time.Now().UTC()
+		entry.Status = domain.OvertimeStatusApproved
+		entry.RequestID = &request.ID
+		entry.ReviewedBy = request.ReviewedBy
+		entry.ReviewedAt = &now
+		entry.ReviewNote = "pre-approved"
+	} else {
+		entry.Status = domain.OvertimeStatusPending
+		entry.RequestID = nil
+		entry.ReviewedBy = nil
+		entry.ReviewedAt = nil
+		entry.ReviewNote = ""
+		if request != nil {
+			entry.RequestID = &request.ID
+			entry.ReviewNote = "exceeds pre-approved hours"
+		}
+	}
+
+	if isNew {
+		return true, s.entryRepo.Create(entry)
+	}
+	return true, s.entryRepo.Update(entry)
+}
+
+// GetLedger returns the overtime ledger of a user for the pay period that contains the date.
+// Users can see their own ledger; others need to be allowed to review the user.
+func (s *OvertimeService) GetLedger(userID uint, viewerID uint, date time.Time) (*domain.OvertimeLedger, error) {
+	if userID != viewerID {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, domain.ErrUserNotFound
+		}
+		if !canReviewUser(s.userRepo, user, viewerID) {
+			return nil, domain.ErrUnauthorized
+		}
+	}
+
+	start, end := s.policy.PayPeriodFor(date)
+	entries, err := s.entryRepo.GetByUserIDAndDateRange(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &domain.OvertimeLedger{
+		UserID:      userID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Entries:     entries,
+	}
+	for _, entry := range entries {
+		ledger.TotalOvertimeHours += entry.OvertimeHours
+		ledger.TotalWeightedHours += entry.WeightedHours
+		switch entry.Status {
+		case domain.OvertimeStatusApproved:
+			ledger.ApprovedOvertimeHours += entry.OvertimeHours
+			ledger.ApprovedWeightedHours += entry.WeightedHours
+		case domain.OvertimeStatusPending:
+			ledger.PendingOvertimeHours += entry.OvertimeHours
+		case domain.OvertimeStatusRejected:
+			ledger.RejectedOvertimeHours += entry.OvertimeHours
+		}
+	}
+	ledger.TotalOvertimeHours = roundHours(ledger.TotalOvertimeHours)
+	ledger.TotalWeightedHours = roundHours(ledger.TotalWeightedHours)
+	ledger.ApprovedOvertimeHours = roundHours(ledger.ApprovedOvertimeHours)
+	ledger.ApprovedWeightedHours = roundHours(ledger.ApprovedWeightedHours)
+	ledger.PendingOvertimeHours = roundHours(ledger.PendingOvertimeHours)
+	ledger.RejectedOvertimeHours = roundHours(ledger.RejectedOvertimeHours)
+
+	return ledger, nil
+}
+
+// GetEntryByID retrieves an overtime ledger entry by its ID
+func (s *OvertimeService) GetEntryByID(id uint) (*domain.OvertimeEntry, error) {
+	return s.entryRepo.GetByID(id)
+}
+
+// GetPendingEntries retrieves the unapproved overtime the given approver is allowed to review
+func (s *OvertimeService) GetPendingEntries(approverID uint) ([]domain.OvertimeEntry, error) {
+	pending, err := s.entryRepo.GetByStatus(domain.OvertimeStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := newReviewerCache(s.userRepo)
+	reviewable := make([]domain.OvertimeEntry, 0, len(pending))
+	for _, entry := range pending {
+		if reviewers.canReview(entry.UserID, approverID) {
+			reviewable = append(reviewable, entry)
+		}
+	}
+
+	return reviewable, nil
+}
+
+// ApproveEntry approves recorded overtime after the fact
+func (s *OvertimeService) ApproveEntry(id uint, approverID uint, note string) error {
+	return s.reviewEntry(id, approverID, domain.OvertimeStatusApproved, note)
+}
+
+// RejectEntry rejects recorded overtime
+func (s *OvertimeService) RejectEntry(id uint, approverID uint, note string) error {
+	return s.reviewEntry(id, approverID, domain.OvertimeStatusRejected, note)
+}
+
+// reviewEntry records the decision of an approver on a pending ledger entry
+func (s *OvertimeService) reviewEntry(id uint, approverID uint, status domain.OvertimeStatus, note string) error {
+	entry, err := s.entryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !entry.IsPending() {
+		return domain.ErrOvertimeNotPending
+	}
+	if err := s.checkReviewer(entry.UserID, approverID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	entry.Status = status
+	entry.ReviewedBy = &approverID
+	entry.ReviewedAt = &now
+	entry.ReviewNote = note
+
+	return s.entryRepo.Update(entry)
+}
+
+// RequestPreApproval asks the manager to approve overtime on a future day
+func (s *OvertimeService) RequestPreApproval(userID uint, request *domain.OvertimeRequest) error {
+	// Check if user exists
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	request.UserID = userID
+	request.Status = domain.OvertimeStatusPending
+	request.Date = domain.DateOnly(request.Date)
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Pre-approval only makes sense before the day is over
+	loc := userTimeLocation(user, s.locationRepo, s.attendancePolicy)
+	if request.Date.Before(domain.WorkDay(time.Now().UTC(), loc)) {
+		return domain.ErrOvertimeRequestInPast
+	}
+
+	// Only one open request per day
+	if _, err := s.requestRepo.GetOpenByUserIDAndDate(userID, request.Date); err == nil {
+		return domain.ErrOvertimeRequestAlreadyExists
+	} else if !errors.Is(err, domain.ErrOvertimeRequestNotFound) {
+		return err
+	}
+
+	return s.requestRepo.Create(request)
+}
+
+// GetRequestByID retrieves an overtime request by its ID
+func (s *OvertimeService) GetRequestByID(id uint) (*domain.OvertimeRequest, error) {
+	return s.requestRepo.GetByID(id)
+}
+
+// GetUserRequests retrieves all overtime requests of a user
+func (s *OvertimeService) GetUserRequests(userID uint) ([]domain.OvertimeRequest, error) {
+	return s.requestRepo.GetByUserID(userID)
+}
+
+// GetPendingRequests retrieves the pending requests the given approver is allowed to review
+func (s *OvertimeService) GetPendingRequests(approverID uint) ([]domain.OvertimeRequest, error) {
+	pending, err := s.requestRepo.GetByStatus(domain.OvertimeStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := newReviewerCache(s.userRepo)
+	reviewable := make([]domain.OvertimeRequest, 0, len(pending))
+	for _, request := range pending {
+		if reviewers.canReview(request.UserID, approverID) {
+			reviewable = append(reviewable, request)
+		}
+	}
+
+	return reviewable, nil
+}
+
+// ApproveRequest approves a pre-approval request and applies it to overtime already recorded that day
+func (s *OvertimeService) ApproveRequest(id uint, approverID uint, note string) error {
+	request, err := s.reviewRequest(id, approverID, domain.OvertimeStatusApproved, note)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(request.UserID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = s.recalculate(user, request.Date, request.Date)
+	return err
+}
+
+// RejectRequest rejects a pre-approval request
+func (s *OvertimeService) RejectRequest(id uint, approverID uint, note string) error {
+	_, err := s.reviewRequest(id, approverID, domain.OvertimeStatusRejected, note)
+	return err
+}
+
+// CancelRequest withdraws a pending request; only its owner may cancel it
+func (s *OvertimeService) CancelRequest(id uint, userID uint) error {
+	request, err := s.requestRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if request.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+	if !request.IsPending() {
+		return domain.ErrOvertimeNotPending
+	}
+
+	request.Status = domain.OvertimeStatusCancelled
+	return s.requestRepo.Update(request)
+}
+
+// reviewRequest records the decision of an approver on a pending pre-approval request
+func (s *OvertimeService) reviewRequest(id uint, approverID uint, status domain.OvertimeStatus, note string) (*domain.OvertimeRequest, error) {
+	request, err := s.requestRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsPending() {
+		return nil, domain.ErrOvertimeNotPending
+	}
+	if err := s.checkReviewer(request.UserID, approverID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	request.Status = status
+	request.ReviewedBy = &approverID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+
+	if err := s.requestRepo.Update(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// checkReviewer verifies that the approver may review the requests of the given user
+func (s *OvertimeService) checkReviewer(userID uint, approverID uint) error {
+	if userID == approverID {
+		return domain.ErrSelfApproval
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if !canReviewUser(s.userRepo, user, approverID) {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// roundHours rounds hours to two decimals so recalculations compare stably
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+func TestComputeDayThresholds(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	attendancePolicy := domain.AttendancePolicy{WeekendDays: []time.Weekday{time.Saturday, time.Sunday}}
+	basePolicy := domain.OvertimePolicy{
+		DailyThresholdHours:  8,
+		WeeklyThresholdHours: 40,
+		Multiplier:           1.5,
+		WeekendMultiplier:    2,
+		HolidayMultiplier:    2.5,
+		WeekStart:            time.Monday,
+	}
+	withWeekly := func(hours float64) domain.OvertimePolicy {
+		policy := basePolicy
+		policy.WeeklyThresholdHours = hours
+		return policy
+	}
+
+	tests := []struct {
+		name         string
+		policy       domain.OvertimePolicy
+		worked       [7]float64 // Monday to Sunday
+		holidays     map[int]bool
+		wantOvertime [7]float64
+		wantKinds    map[int]domain.OvertimeKind
+	}{
+		{
+			name:         "hours beyond the daily threshold",
+			policy:       basePolicy,
+			worked:       [7]float64{10, 8, 8, 8, 8, 0, 0},
+			wantOvertime: [7]float64{2, 0, 0, 0, 0, 0, 0},
+			wantKinds:    map[int]domain.OvertimeKind{0: domain.OvertimeKindDaily},
+		},
+		{
+			name:         "regular hours beyond the weekly threshold",
+			policy:       withWeekly(36),
+			worked:       [7]float64{8, 8, 8, 8, 8, 0, 0},
+			wantOvertime: [7]float64{0, 0, 0, 0, 4, 0, 0},
+			wantKinds:    map[int]domain.OvertimeKind{4: domain.OvertimeKindWeekly},
+		},
+		{
+			name:         "daily overtime does not count towards the weekly threshold",
+			policy:       withWeekly(36),
+			worked:       [7]float64{8, 8, 8, 8, 10, 0, 0},
+			wantOvertime: [7]float64{0, 0, 0, 0, 6, 0, 0},
+			wantKinds:    map[int]domain.OvertimeKind{4: domain.OvertimeKindWeekly},
+		},
+		{
+			name:         "all weekend and holiday hours are overtime",
+			policy:       basePolicy,
+			worked:       [7]float64{8, 0, 6, 0, 0, 5, 0},
+			holidays:     map[int]bool{2: true},
+			wantOvertime: [7]float64{0, 0, 6, 0, 0, 5, 0},
+			wantKinds:    map[int]domain.OvertimeKind{2: domain.OvertimeKindHoliday, 5: domain.OvertimeKindWeekend},
+		},
+		{
+			name:         "disabled thresholds",
+			policy:       domain.OvertimePolicy{Multiplier: 1.5, WeekStart: time.Monday},
+			worked:       [7]float64{12, 12, 12, 12, 12, 0, 0},
+			wantOvertime: [7]float64{0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &OvertimeService{attendancePolicy: attendancePolicy, policy: tt.policy}
+			regularHours := 0.0
+			for offset, worked := range tt.worked {
+				day := monday.AddDate(0, 0, offset)
+				overtime, kind, multiplier := service.computeDay(day, worked, tt.holidays[offset], &regularHours)
+				if overtime != tt.wantOvertime[offset] {
+					t.Fatalf("%s: overtime = %v, want %v", day.Weekday(), overtime, tt.wantOvertime[offset])
+				}
+				if overtime == 0 {
+					continue
+				}
+				if kind != tt.wantKinds[offset] {
+					t.Fatalf("%s: kind = %q, want %q", day.Weekday(), kind, tt.wantKinds[offset])
+				}
+				wantMultiplier := tt.policy.Multiplier
+				switch kind {
+				case domain.OvertimeKindWeekend:
+					wantMultiplier = tt.policy.WeekendMultiplier
+				case domain.OvertimeKindHoliday:
+					wantMultiplier = tt.policy.HolidayMultiplier
+				}
+				if multiplier != wantMultiplier {
+					t.Fatalf("%s: multiplier = %v, want %v", day.Weekday(), multiplier, wantMultiplier)
+				}
+			}
+		})
+	}
+}
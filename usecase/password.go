@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"log"
+
+	"hrm/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordManager applies the password policy and history whenever a password is set,
+// for the services that let users choose one
+type passwordManager struct {
+	policy      domain.PasswordPolicy
+	historyRepo domain.PasswordHistoryRepositoryInterface
+}
+
+// hashNewPassword checks a new password against the policy and the user's recent passwords and returns its hash.
+// The user must carry their current password hash; new users have no history yet.
+func (m passwordManager) hashNewPassword(user *domain.User, password string) (string, error) {
+	if err := m.policy.Check(password, user); err != nil {
+		return "", err
+	}
+
+	if m.policy.HistorySize > 0 && user.ID != 0 {
+		if user.Password != "" && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil {
+			return "", domain.ErrPasswordReused
+		}
+		if m.policy.HistorySize > 1 {
+			entries, err := m.historyRepo.ListRecent(user.ID, m.policy.HistorySize-1)
+			if err != nil {
+				return "", err
+			}
+			for _, entry := range entries {
+				if bcrypt.CompareHashAndPassword([]byte(entry.PasswordHash), []byte(password)) == nil {
+					return "", domain.ErrPasswordReused
+				}
+			}
+		}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("Error hashing password: %v", err)
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
+// rememberPassword keeps a replaced password hash and forgets those beyond the history size
+func (m passwordManager) rememberPassword(userID uint, oldHash string) error {
+	keep := m.policy.HistorySize - 1
+	if keep > 0 && oldHash != "" {
+		if err := m.historyRepo.Create(&domain.PasswordHistory{UserID: userID, PasswordHash: oldHash}); err != nil {
+			return err
+		}
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	return m.historyRepo.DeleteAllButRecent(userID, keep)
+}
+
+// unusablePasswordHash returns the hash of a random password nobody knows,
+// for accounts that sign in through an identity provider or the directory
+func unusablePasswordHash() (string, error) {
+	password, err := generateOpaqueToken("")
+	if err != nil {
+		return "", err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
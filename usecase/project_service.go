@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"strings"
+)
+
+// ProjectService implements the ProjectServiceInterface
+// This struct contains all the business logic for project operations
+type ProjectService struct {
+	projectRepo   domain.ProjectRepositoryInterface
+	timeEntryRepo domain.TimeEntryRepositoryInterface
+}
+
+// NewProjectService creates a new instance of ProjectService
+func NewProjectService(
+	projectRepo domain.ProjectRepositoryInterface,
+	timeEntryRepo domain.TimeEntryRepositoryInterface,
+) domain.ProjectServiceInterface {
+	return &ProjectService{
+		projectRepo:   projectRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// CreateProject creates a new, active project
+func (s *ProjectService) CreateProject(project *domain.Project) error {
+	project.Code = strings.ToUpper(strings.TrimSpace(project.Code))
+	project.IsActive = true
+
+	if err := s.checkCodeAvailable(project.Code, 0); err != nil {
+		return err
+	}
+
+	return s.projectRepo.Create(project)
+}
+
+// GetProjectByID retrieves a project by its ID
+func (s *ProjectService) GetProjectByID(id uint) (*domain.Project, error) {
+	return s.projectRepo.GetByID(id)
+}
+
+// GetAllProjects retrieves all projects
+func (s *ProjectService) GetAllProjects() ([]domain.Project, error) {
+	return s.projectRepo.GetAll()
+}
+
+// UpdateProject modifies an existing project
+func (s *ProjectService) UpdateProject(project *domain.Project) error {
+	// Check if project exists
+	existingProject, err := s.projectRepo.GetByID(project.ID)
+	if err != nil {
+		return err
+	}
+
+	project.Code = strings.ToUpper(strings.TrimSpace(project.Code))
+	if err := s.checkCodeAvailable(project.Code, project.ID); err != nil {
+		return err
+	}
+
+	// Preserve the original creation time
+	project.CreatedAt = existingProject.CreatedAt
+
+	return s.projectRepo.Update(project)
+}
+
+// DeleteProject removes a project that has no time booked on it
+func (s *ProjectService) DeleteProject(id uint) error {
+	count, err := s.timeEntryRepo.CountByProjectID(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrProjectInUse
+	}
+
+	return s.projectRepo.Delete(id)
+}
+
+// checkCodeAvailable verifies that no other project uses the code
+func (s *ProjectService) checkCodeAvailable(code string, projectID uint) error {
+	if code == "" {
+		return nil
+	}
+
+	existing, err := s.projectRepo.GetByCode(code)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != projectID {
+		return domain.ErrProjectCodeExists
+	}
+
+	return nil
+}
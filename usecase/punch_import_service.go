@@ -0,0 +1,517 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"hrm/domain"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// importDedupeWindow is how close two punches of the same direction must be to count as one
+const importDedupeWindow = time.Minute
+
+// maxImportedSessionLength is the longest gap between a check-in and check-out that is paired into a session;
+// longer gaps usually mean a punch is missing from the log
+const maxImportedSessionLength = 24 * time.Hour
+
+// PunchImportService implements the PunchImportServiceInterface
+// This struct contains the business logic for turning time clock punch logs into attendance sessions
+type PunchImportService struct {
+	attendanceRepo domain.AttendanceRepositoryInterface
+	sessionRepo    domain.AttendanceSessionRepositoryInterface
+	breakRepo      domain.BreakRepositoryInterface
+	timesheetRepo  domain.TimesheetRepositoryInterface
+	userRepo       domain.UserRepositoryInterface
+	locationRepo   domain.LocationRepositoryInterface
+	policy         domain.AttendancePolicy
+}
+
+// NewPunchImportService creates a new instance of PunchImportService
+func NewPunchImportService(
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	sessionRepo domain.AttendanceSessionRepositoryInterface,
+	breakRepo domain.BreakRepositoryInterface,
+	timesheetRepo domain.TimesheetRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	policy domain.AttendancePolicy,
+) domain.PunchImportServiceInterface {
+	return &PunchImportService{
+		attendanceRepo: attendanceRepo,
+		sessionRepo:    sessionRepo,
+		breakRepo:      breakRepo,
+		timesheetRepo:  timesheetRepo,
+		userRepo:       userRepo,
+		locationRepo:   locationRepo,
+		policy:         policy,
+	}
+}
+
+// importedPunch is a punch log row matched to an employee, with its time and direction resolved
+type importedPunch struct {
+	line      int
+	at        time.Time
+	device    string
+	direction string // domain.PunchDirectionIn, domain.PunchDirectionOut or "" when unknown
+}
+
+// punchImportEmployee collects the punches of one employee found in the log
+type punchImportEmployee struct {
+	user    *domain.User
+	code    string
+	loc     *time.Location
+	punches []importedPunch
+}
+
+// punchImportDayKey identifies the attendance record of an employee on a work day
+type punchImportDayKey struct {
+	userID uint
+	date   string
+}
+
+// punchImport holds the state of one import run
+type punchImport struct {
+	report  *domain.PunchImportReport
+	dryRun  bool
+	now     time.Time
+	days    map[punchImportDayKey]*domain.Attendance
+	touched []*domain.Attendance // Records that got sessions added or closed, in order of first change
+	changed map[punchImportDayKey]bool
+}
+
+// ImportPunchLog reads a punch log, matches the rows to employees by employee code, drops repeated punches,
+// pairs check-ins with check-outs and records the pairs as imported sessions on the employees' attendance days.
+// Rows that cannot be imported are listed in the report; they do not stop the rest of the log.
+// In a dry run the report is produced without saving anything.
+func (s *PunchImportService) ImportPunchLog(log io.Reader, options domain.PunchImportOptions) (*domain.PunchImportReport, error) {
+	rows, err := parsePunchLog(log, options.Format, options.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &punchImport{
+		report:  &domain.PunchImportReport{DryRun: options.DryRun, Rows: len(rows)},
+		dryRun:  options.DryRun,
+		now:     time.Now().UTC(),
+		days:    make(map[punchImportDayKey]*domain.Attendance),
+		changed: make(map[punchImportDayKey]bool),
+	}
+
+	employees, err := s.groupPunches(rows, run.report)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, employee := range employees {
+		if err := s.importEmployee(run, employee); err != nil {
+			return nil, err
+		}
+	}
+
+	// Derive the first check-in, last check-out, status and work hours of every changed day
+	if !run.dryRun {
+		for _, attendance := range run.touched {
+			detailed, err := s.attendanceRepo.GetWithBreaks(attendance.ID)
+			if err != nil {
+				return nil, err
+			}
+			attendance.Sessions = detailed.Sessions
+			attendance.RefreshPunchTimes()
+			if attendance.HasOpenSession() {
+				attendance.Status = "present"
+			} else {
+				attendance.Status = attendance.GetStatus()
+			}
+			if err := recalculateWorkHours(s.attendanceRepo, attendance, s.policy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	run.report.AttendanceDays = len(run.touched)
+
+	return run.report, nil
+}
+
+// groupPunches matches the rows of a log to employees and resolves their times and directions.
+// Employees are returned in the order they first appear in the log.
+func (s *PunchImportService) groupPunches(rows []domain.RawPunch, report *domain.PunchImportReport) ([]*punchImportEmployee, error) {
+	byCode := make(map[string]*punchImportEmployee)
+	var employees []*punchImportEmployee
+
+	for _, row := range rows {
+		code := strings.ToUpper(strings.TrimSpace(row.EmployeeCode))
+		if code == "" {
+			report.AddIssue(row.Line, "", "employee code is missing")
+			continue
+		}
+
+		employee, seen := byCode[code]
+		if !seen {
+			user, err := s.userRepo.GetByEmployeeCode(code)
+			if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+				return nil, err
+			}
+			if err == nil {
+				employee = &punchImportEmployee{
+					user: user,
+					code: code,
+					loc:  userTimeLocation(user, s.locationRepo, s.policy),
+				}
+				employees = append(employees, employee)
+			}
+			byCode[code] = employee // Unknown codes are cached as nil
+		}
+		if employee == nil {
+			report.AddIssue(row.Line, code, "no employee has this employee code")
+			continue
+		}
+
+		if row.Timestamp == "" {
+			report.AddIssue(row.Line, code, "timestamp is missing")
+			continue
+		}
+		at, err := parsePunchTimestamp(row.Timestamp, employee.loc)
+		if err != nil {
+			report.AddIssue(row.Line, code, err.Error())
+			continue
+		}
+		direction, err := normalizePunchDirection(row.Direction)
+		if err != nil {
+			report.AddIssue(row.Line, code, err.Error())
+			continue
+		}
+
+		employee.punches = append(employee.punches, importedPunch{
+			line:      row.Line,
+			at:        at,
+			device:    row.Device,
+			direction: direction,
+		})
+	}
+
+	return employees, nil
+}
+
+// importEmployee pairs the punches of one employee and records the resulting sessions
+func (s *PunchImportService) importEmployee(run *punchImport, employee *punchImportEmployee) error {
+	if len(employee.punches) == 0 {
+		return nil
+	}
+
+	punches := employee.punches
+	sort.SliceStable(punches, func(i, j int) bool {
+		return punches[i].at.Before(punches[j].at)
+	})
+	punches = dropRepeatedPunches(punches, run.report)
+
+	existing, openSession, err := s.loadSessions(employee, punches[0].at, punches[len(punches)-1].at)
+	if err != nil {
+		return err
+	}
+	punches = dropRecordedPunches(punches, existing, run.report)
+
+	var pending *importedPunch
+	for i := range punches {
+		punch := &punches[i]
+		// The session still open in the database can only be closed by a later punch
+		openBefore := openSession != nil && punch.at.After(openSession.CheckInTime)
+
+		direction := punch.direction
+		if direction == "" {
+			direction = domain.PunchDirectionIn
+			if pending != nil || openBefore {
+				direction = domain.PunchDirectionOut
+			}
+		}
+
+		if direction == domain.PunchDirectionIn {
+			if pending != nil {
+				run.report.AddIssue(pending.line, employee.code, "check-in has no matching check-out")
+			}
+			if openBefore {
+				run.report.AddIssue(punch.line, employee.code, fmt.Sprintf("already checked in since %s", openSession.CheckInTime.In(employee.loc).Format(time.RFC3339)))
+				pending = nil
+				continue
+			}
+			pending = punch
+			continue
+		}
+
+		switch {
+		case pending != nil:
+			if punch.at.Sub(pending.at) > maxImportedSessionLength {
+				run.report.AddIssue(pending.line, employee.code, "check-in has no matching check-out within 24 hours")
+				run.report.AddIssue(punch.line, employee.code, "check-out has no matching check-in within 24 hours")
+			} else {
+				session, err := s.addSession(run, employee, existing, pending, punch)
+				if err != nil {
+					return err
+				}
+				if session != nil {
+					existing = append(existing, session)
+				}
+			}
+			pending = nil
+		case openBefore:
+			if punch.at.Sub(openSession.CheckInTime) > maxImportedSessionLength {
+				run.report.AddIssue(punch.line, employee.code, "check-out has no matching check-in within 24 hours")
+			} else if err := s.closeSession(run, employee, existing, openSession, punch); err != nil {
+				return err
+			}
+			openSession = nil
+		default:
+			run.report.AddIssue(punch.line, employee.code, "check-out has no matching check-in")
+		}
+	}
+
+	// A recent check-in without a check-out is an employee still at work
+	if pending != nil {
+		if openSession != nil || run.now.Sub(pending.at) > maxImportedSessionLength {
+			run.report.AddIssue(pending.line, employee.code, "check-in has no matching check-out")
+		} else if _, err := s.addSession(run, employee, existing, pending, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropRepeatedPunches removes punches recorded again within a minute of the previous one,
+// e.g. when an employee presses the time clock twice. Punches must be sorted by time.
+func dropRepeatedPunches(punches []importedPunch, report *domain.PunchImportReport) []importedPunch {
+	kept := punches[:1]
+	for _, punch := range punches[1:] {
+		previous := kept[len(kept)-1]
+		sameDirection := punch.direction == previous.direction || punch.direction == "" || previous.direction == ""
+		if sameDirection && punch.at.Sub(previous.at) <= importDedupeWindow {
+			report.Duplicates++
+			continue
+		}
+		kept = append(kept, punch)
+	}
+	return kept
+}
+
+// dropRecordedPunches removes punches that match a check-in or check-out already recorded,
+// so that importing overlapping exports, or the same export twice, adds nothing new
+func dropRecordedPunches(punches []importedPunch, existing []*domain.AttendanceSession, report *domain.PunchImportReport) []importedPunch {
+	kept := punches[:0]
+	for _, punch := range punches {
+		if punchRecorded(punch, existing) {
+			report.Duplicates++
+			continue
+		}
+		kept = append(kept, punch)
+	}
+	return kept
+}
+
+// punchRecorded reports whether a punch matches a session boundary within the dedupe window
+func punchRecorded(punch importedPunch, existing []*domain.AttendanceSession) bool {
+	near := func(t time.Time) bool {
+		diff := punch.at.Sub(t)
+		return diff >= -importDedupeWindow && diff <= importDedupeWindow
+	}
+	for _, session := range existing {
+		if punch.direction != domain.PunchDirectionOut && near(session.CheckInTime) {
+			return true
+		}
+		if punch.direction != domain.PunchDirectionIn && session.CheckOutTime != nil && near(*session.CheckOutTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSessions returns the recorded sessions of an employee around the imported span, and the session
+// they are currently checked in to, if any. Records checked in before sessions existed are represented
+// by an unsaved session covering their check-in and check-out.
+func (s *PunchImportService) loadSessions(employee *punchImportEmployee, first, last time.Time) ([]*domain.AttendanceSession, *domain.AttendanceSession, error) {
+	// Shifts may cross midnight, so include the neighbouring days
+	startDate := domain.WorkDay(first, employee.loc).AddDate(0, 0, -1)
+	endDate := domain.WorkDay(last, employee.loc).AddDate(0, 0, 1)
+	attendances, err := s.attendanceRepo.GetByUserIDAndDateRange(employee.user.ID, startDate, endDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	openAttendance, err := s.attendanceRepo.GetOpenByUserID(employee.user.ID)
+	if err != nil && !errors.Is(err, domain.ErrAttendanceNotFound) {
+		return nil, nil, err
+	}
+	if openAttendance != nil {
+		found := false
+		for _, attendance := range attendances {
+			found = found || attendance.ID == openAttendance.ID
+		}
+		if !found {
+			attendances = append(attendances, *openAttendance)
+		}
+	}
+
+	var existing []*domain.AttendanceSession
+	var openSession *domain.AttendanceSession
+	for _, attendance := range attendances {
+		sessions, err := s.sessionRepo.GetByAttendanceID(attendance.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(sessions) == 0 && attendance.CheckInTime != nil {
+			sessions = []domain.AttendanceSession{{
+				AttendanceID: attendance.ID,
+				CheckInTime:  *attendance.CheckInTime,
+				CheckOutTime: attendance.CheckOutTime,
+				Source:       domain.SessionSourcePunch,
+			}}
+		}
+		for i := range sessions {
+			session := &sessions[i]
+			if session.IsSuperseded() {
+				continue
+			}
+			existing = append(existing, session)
+			if openAttendance != nil && attendance.ID == openAttendance.ID && session.IsOpen() {
+				openSession = session
+			}
+		}
+	}
+
+	return existing, openSession, nil
+}
+
+// addSession records a check-in and check-out pair as an imported session on the work day of the check-in.
+// A nil check-out leaves the session open. It returns nil without an error when the pair was reported as an issue.
+func (s *PunchImportService) addSession(run *punchImport, employee *punchImportEmployee, existing []*domain.AttendanceSession, checkIn, checkOut *importedPunch) (*domain.AttendanceSession, error) {
+	end := run.now.Add(maxImportedSessionLength)
+	if checkOut != nil {
+		end = checkOut.at
+	}
+	for _, session := range existing {
+		if session.Overlaps(checkIn.at, end) {
+			run.report.AddIssue(checkIn.line, employee.code, "session overlaps a session already recorded")
+			return nil, nil
+		}
+	}
+
+	attendance, err := s.attendanceFor(run, employee, domain.WorkDay(checkIn.at, employee.loc))
+	if err != nil && !errors.Is(err, domain.ErrAttendanceLocked) {
+		return nil, err
+	}
+	if err != nil || attendance.IsLocked() {
+		run.report.AddIssue(checkIn.line, employee.code, domain.ErrAttendanceLocked.Error())
+		return nil, nil
+	}
+
+	session := &domain.AttendanceSession{
+		AttendanceID:  attendance.ID,
+		CheckInTime:   checkIn.at,
+		Source:        domain.SessionSourceImport,
+		CheckInDevice: checkIn.device,
+	}
+	if checkOut != nil {
+		checkOutTime := checkOut.at
+		session.CheckOutTime = &checkOutTime
+		session.CheckOutDevice = checkOut.device
+	}
+
+	if !run.dryRun {
+		if err := s.sessionRepo.Create(session); err != nil {
+			return nil, err
+		}
+	}
+	run.markChanged(employee, attendance)
+	if checkOut != nil {
+		run.report.SessionsCreated++
+	} else {
+		run.report.SessionsOpened++
+	}
+
+	return session, nil
+}
+
+// closeSession records an imported check-out on the session an employee is currently checked in to
+func (s *PunchImportService) closeSession(run *punchImport, employee *punchImportEmployee, existing []*domain.AttendanceSession, openSession *domain.AttendanceSession, checkOut *importedPunch) error {
+	for _, session := range existing {
+		if session != openSession && session.Overlaps(openSession.CheckInTime, checkOut.at) {
+			run.report.AddIssue(checkOut.line, employee.code, "session overlaps a session already recorded")
+			return nil
+		}
+	}
+
+	attendance, err := s.attendanceRepo.GetByID(openSession.AttendanceID)
+	if err != nil {
+		return err
+	}
+	if attendance.IsLocked() {
+		run.report.AddIssue(checkOut.line, employee.code, domain.ErrAttendanceLocked.Error())
+		return nil
+	}
+
+	checkOutTime := checkOut.at
+	openSession.CheckOutTime = &checkOutTime
+	openSession.CheckOutDevice = checkOut.device
+	if !run.dryRun {
+		if openSession.ID == 0 {
+			err = s.sessionRepo.Create(openSession)
+		} else {
+			err = s.sessionRepo.Update(openSession)
+		}
+		if err != nil {
+			return err
+		}
+		if err := endActiveBreak(s.breakRepo, attendance.ID, checkOutTime, "", ""); err != nil {
+			return err
+		}
+	}
+	run.markChanged(employee, attendance)
+	run.report.SessionsClosed++
+
+	return nil
+}
+
+// attendanceFor returns the attendance record of an employee on a work day, creating it if needed.
+// In a dry run missing records are only built in memory.
+func (s *PunchImportService) attendanceFor(run *punchImport, employee *punchImportEmployee, date time.Time) (*domain.Attendance, error) {
+	key := punchImportDayKey{userID: employee.user.ID, date: date.Format("2006-01-02")}
+	if attendance, ok := run.days[key]; ok {
+		return attendance, nil
+	}
+
+	attendance, err := s.attendanceRepo.GetByUserID(employee.user.ID, date)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAttendanceNotFound) {
+			return nil, err
+		}
+		if err := checkWeekUnlocked(s.timesheetRepo, employee.user.ID, date); err != nil {
+			return nil, err
+		}
+		attendance = &domain.Attendance{
+			UserID:   employee.user.ID,
+			Date:     date,
+			Timezone: employee.loc.String(),
+			Status:   "present",
+		}
+		if !run.dryRun {
+			if err := s.attendanceRepo.Create(attendance); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	run.days[key] = attendance
+	return attendance, nil
+}
+
+// markChanged records that an attendance record got a session added or closed
+func (run *punchImport) markChanged(employee *punchImportEmployee, attendance *domain.Attendance) {
+	key := punchImportDayKey{userID: employee.user.ID, date: domain.DateOnly(attendance.Date).Format("2006-01-02")}
+	if run.changed[key] {
+		return
+	}
+	run.changed[key] = true
+	run.days[key] = attendance
+	run.touched = append(run.touched, attendance)
+}
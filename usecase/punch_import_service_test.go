@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+// fakeTimesheetRepository has no approved timesheets
+type fakeTimesheetRepository struct {
+	domain.TimesheetRepositoryInterface
+}
+
+func (r *fakeTimesheetRepository) GetApprovedByUserIDAndDate(userID uint, date time.Time) (*domain.Timesheet, error) {
+	return nil, domain.ErrTimesheetNotFound
+}
+
+// punchAt returns an imported punch on 2024-01-15 at the given UTC hour, minute and second
+func punchAt(line, hour, minute, second int, direction string) importedPunch {
+	return importedPunch{line: line, at: time.Date(2024, 1, 15, hour, minute, second, 0, time.UTC), direction: direction}
+}
+
+func TestDropRepeatedPunches(t *testing.T) {
+	tests := []struct {
+		name           string
+		punches        []importedPunch
+		wantLines      []int
+		wantDuplicates int
+	}{
+		{
+			name:      "punches far apart are kept",
+			punches:   []importedPunch{punchAt(1, 9, 0, 0, "in"), punchAt(2, 9, 2, 0, "out")},
+			wantLines: []int{1, 2},
+		},
+		{
+			name:           "same direction within a minute",
+			punches:        []importedPunch{punchAt(1, 9, 0, 0, "in"), punchAt(2, 9, 0, 40, "in"), punchAt(3, 9, 1, 1, "in")},
+			wantLines:      []int{1, 3},
+			wantDuplicates: 1,
+		},
+		{
+			name:           "unknown direction within a minute",
+			punches:        []importedPunch{punchAt(1, 9, 0, 0, ""), punchAt(2, 9, 0, 30, "out")},
+			wantLines:      []int{1},
+			wantDuplicates: 1,
+		},
+		{
+			name:      "opposite directions within a minute",
+			punches:   []importedPunch{punchAt(1, 9, 0, 0, "in"), punchAt(2, 9, 0, 30, "out")},
+			wantLines: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &domain.PunchImportReport{}
+			kept := dropRepeatedPunches(tt.punches, report)
+			assertPunchLines(t, kept, tt.wantLines)
+			if report.Duplicates != tt.wantDuplicates {
+				t.Fatalf("Duplicates = %d, want %d", report.Duplicates, tt.wantDuplicates)
+			}
+		})
+	}
+}
+
+func TestDropRecordedPunches(t *testing.T) {
+	checkOut := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	existing := []*domain.AttendanceSession{{CheckInTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), CheckOutTime: &checkOut}}
+
+	tests := []struct {
+		name     string
+		punch    importedPunch
+		wantKept bool
+	}{
+		{"check-in at the recorded check-in", punchAt(1, 9, 0, 30, "in"), false},
+		{"unknown direction at the recorded check-in", punchAt(1, 8, 59, 30, ""), false},
+		{"check-out at the recorded check-in", punchAt(1, 9, 0, 30, "out"), true},
+		{"check-out at the recorded check-out", punchAt(1, 17, 1, 0, "out"), false},
+		{"check-in at the recorded check-out", punchAt(1, 17, 0, 0, "in"), true},
+		{"punch beyond the dedupe window", punchAt(1, 9, 1, 1, "in"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &domain.PunchImportReport{}
+			kept := dropRecordedPunches([]importedPunch{tt.punch}, existing, report)
+			if (len(kept) == 1) != tt.wantKept {
+				t.Fatalf("kept = %v, want kept = %v", len(kept) == 1, tt.wantKept)
+			}
+			wantDuplicates := 1
+			if tt.wantKept {
+				wantDuplicates = 0
+			}
+			if report.Duplicates != wantDuplicates {
+				t.Fatalf("Duplicates = %d, want %d", report.Duplicates, wantDuplicates)
+			}
+		})
+	}
+}
+
+func TestImportPunchLogPairing(t *testing.T) {
+	recent := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name           string
+		rows           []string // employee_code,timestamp,direction
+		wantCreated    int
+		wantOpened     int
+		wantDuplicates int
+		wantIssueLines []int
+	}{
+		{
+			name:        "check-in and check-out",
+			rows:        []string{"F-1,2024-01-15 09:00,in", "F-1,2024-01-15 17:00,out"},
+			wantCreated: 1,
+		},
+		{
+			name:        "directions inferred by alternating",
+			rows:        []string{"F-1,2024-01-15 09:00,", "F-1,2024-01-15 12:00,", "F-1,2024-01-15 13:00,", "F-1,2024-01-15 17:00,"},
+			wantCreated: 2,
+		},
+		{
+			name:        "rows out of order",
+			rows:        []string{"F-1,2024-01-15 17:00,out", "F-1,2024-01-15 09:00,in"},
+			wantCreated: 1,
+		},
+		{
+			name:        "night shift across midnight",
+			rows:        []string{"F-1,2024-01-15 22:00,in", "F-1,2024-01-16 06:00,out"},
+			wantCreated: 1,
+		},
+		{
+			name:           "check-out more than 24 hours after the check-in",
+			rows:           []string{"F-1,2024-01-15 09:00,in", "F-1,2024-01-16 09:01,out"},
+			wantIssueLines: []int{2, 3},
+		},
+		{
+			name:        "check-out exactly 24 hours after the check-in",
+			rows:        []string{"F-1,2024-01-15 09:00,in", "F-1,2024-01-16 09:00,out"},
+			wantCreated: 1,
+		},
+		{
+			name:           "check-out without a check-in",
+			rows:           []string{"F-1,2024-01-15 17:00,out"},
+			wantIssueLines: []int{2},
+		},
+		{
+			name:           "check-in followed by another check-in",
+			rows:           []string{"F-1,2024-01-15 08:00,in", "F-1,2024-01-15 09:00,in", "F-1,2024-01-15 17:00,out"},
+			wantCreated:    1,
+			wantIssueLines: []int{2},
+		},
+		{
+			name:           "repeated punch",
+			rows:           []string{"F-1,2024-01-15 09:00:00,in", "F-1,2024-01-15 09:00:40,in", "F-1,2024-01-15 17:00,out"},
+			wantCreated:    1,
+			wantDuplicates: 1,
+		},
+		{
+			name:           "old check-in without a check-out",
+			rows:           []string{"F-1,2024-01-15 09:00,in"},
+			wantIssueLines: []int{2},
+		},
+		{
+			name:       "recent check-in without a check-out",
+			rows:       []string{"F-1," + recent + ",in"},
+			wantOpened: 1,
+		},
+		{
+			name:           "unknown employee code",
+			rows:           []string{"X-9,2024-01-15 09:00,in", "F-1,2024-01-15 09:00,in", "F-1,2024-01-15 17:00,out"},
+			wantCreated:    1,
+			wantIssueLines: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := "F-1"
+			userRepo := &fakeUserRepository{users: map[uint]domain.User{1: {ID: 1, Name: "Alice", Timezone: "UTC", EmployeeCode: &code}}}
+			attendanceRepo := &fakeAttendanceRepository{}
+			service := NewPunchImportService(attendanceRepo, &fakeSessionRepository{attendances: attendanceRepo}, nil, &fakeTimesheetRepository{}, userRepo, nil, domain.AttendancePolicy{})
+
+			log := "employee_code,timestamp,direction\n" + strings.Join(tt.rows, "\n") + "\n"
+			report, err := service.ImportPunchLog(strings.NewReader(log), domain.PunchImportOptions{Format: domain.PunchLogFormatCSV, DryRun: true})
+			if err != nil {
+				t.Fatalf("ImportPunchLog: %v", err)
+			}
+
+			if report.SessionsCreated != tt.wantCreated || report.SessionsOpened != tt.wantOpened || report.Duplicates != tt.wantDuplicates {
+				t.Fatalf("created %d, opened %d, duplicates %d; want %d, %d, %d",
+					report.SessionsCreated, report.SessionsOpened, report.Duplicates, tt.wantCreated, tt.wantOpened, tt.wantDuplicates)
+			}
+			var issueLines []int
+			for _, issue := range report.Issues {
+				issueLines = append(issueLines, issue.Line)
+			}
+			if len(issueLines) != len(tt.wantIssueLines) {
+				t.Fatalf("issues = %+v, want lines %v", report.Issues, tt.wantIssueLines)
+			}
+			for i := range issueLines {
+				if issueLines[i] != tt.wantIssueLines[i] {
+					t.Fatalf("issues = %+v, want lines %v", report.Issues, tt.wantIssueLines)
+				}
+			}
+			if attendanceRepo.created != 0 {
+				t.Fatal("a dry run created attendance records")
+			}
+		})
+	}
+}
+
+// assertPunchLines checks that the punches are those of the given log lines, in order
+func assertPunchLines(t *testing.T, punches []importedPunch, want []int) {
+	t.Helper()
+	if len(punches) != len(want) {
+		t.Fatalf("kept %d punches, want lines %v", len(punches), want)
+	}
+	for i, punch := range punches {
+		if punch.line != want[i] {
+			t.Fatalf("kept line %d at %d, want lines %v", punch.line, i, want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+// punchFieldAliases lists the header names time clock exports commonly use for each punch field
+var punchFieldAliases = map[string][]string{
+	domain.PunchFieldEmployeeCode: {"employee_code", "emp_code", "employee_id", "emp_id", "employee_no", "emp_no", "user_code", "enroll_no", "code"},
+	domain.PunchFieldTimestamp:    {"timestamp", "punch_time", "datetime", "date_time", "check_time", "log_time", "time"},
+	domain.PunchFieldDevice:       {"device", "device_id", "device_name", "terminal", "terminal_id", "machine", "clock"},
+	domain.PunchFieldDirection:    {"direction", "punch_type", "in_out", "inout", "check_type", "state", "type"},
+}
+
+// punchFields are the fields read from every punch log row
+var punchFields = []string{
+	domain.PunchFieldEmployeeCode,
+	domain.PunchFieldTimestamp,
+	domain.PunchFieldDevice,
+	domain.PunchFieldDirection,
+}
+
+// punchTimestampLayouts are the timestamp formats accepted in punch logs.
+// Layouts without a zone are read in the employee's time zone.
+var punchTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+// parsePunchLog reads the rows of a CSV or JSON punch log.
+// A log without an employee code or timestamp column is rejected as a whole;
+// the values of individual rows are validated when the rows are imported.
+func parsePunchLog(reader io.Reader, format string, mapping domain.PunchFieldMapping) ([]domain.RawPunch, error) {
+	switch strings.ToLower(format) {
+	case domain.PunchLogFormatCSV:
+		return parsePunchCSV(reader, mapping)
+	case domain.PunchLogFormatJSON:
+		return parsePunchJSON(reader, mapping)
+	default:
+		return nil, domain.ErrUnsupportedPunchLogFormat
+	}
+}
+
+// parsePunchCSV reads a CSV punch log whose first row holds the column names
+func parsePunchCSV(reader io.Reader, mapping domain.PunchFieldMapping) ([]domain.RawPunch, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", domain.ErrInvalidPunchLog)
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPunchLog, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Spreadsheet exports often start with a byte order mark
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[normalizePunchHeader(name)] = i
+	}
+	indexes, err := resolvePunchColumns(columns, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var punches []domain.RawPunch
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidPunchLog, line, err)
+		}
+
+		values := make(map[string]string, len(punchFields))
+		empty := true
+		for field, index := range indexes {
+			if index < len(record) {
+				values[field] = strings.TrimSpace(record[index])
+				empty = empty && values[field] == ""
+			}
+		}
+		if empty {
+			continue
+		}
+		punches = append(punches, newRawPunch(line, values))
+	}
+
+	return punches, nil
+}
+
+// parsePunchJSON reads a JSON punch log: either a list of punch objects or an object with a "punches" list
+func parsePunchJSON(reader io.Reader, mapping domain.PunchFieldMapping) ([]domain.RawPunch, error) {
+	var document interface{}
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPunchLog, err)
+	}
+
+	items, ok := document.([]interface{})
+	if !ok {
+		wrapper, isObject := document.(map[string]interface{})
+		if isObject {
+			items, ok = wrapper["punches"].([]interface{})
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a list of punches", domain.ErrInvalidPunchLog)
+		}
+	}
+
+	punches := make([]domain.RawPunch, 0, len(items))
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: punch %d is not an object", domain.ErrInvalidPunchLog, i+1)
+		}
+
+		keys := make(map[string]int, len(object))
+		fields := make([]string, 0, len(object))
+		for key := range object {
+			keys[normalizePunchHeader(key)] = len(fields)
+			fields = append(fields, key)
+		}
+		indexes, err := resolvePunchColumns(keys, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("punch %d: %w", i+1, err)
+		}
+
+		values := make(map[string]string, len(punchFields))
+		for field, index := range indexes {
+			values[field] = strings.TrimSpace(jsonScalarString(object[fields[index]]))
+		}
+		punches = append(punches, newRawPunch(i+1, values))
+	}
+
+	return punches, nil
+}
+
+// resolvePunchColumns finds the column of each punch field among the normalized column names.
+// Explicitly mapped columns must exist; otherwise the first known alias present is used.
+func resolvePunchColumns(columns map[string]int, mapping domain.PunchFieldMapping) (map[string]int, error) {
+	indexes := make(map[string]int, len(punchFields))
+	for _, field := range punchFields {
+		if column := mapping.Column(field); column != "" {
+			index, ok := columns[normalizePunchHeader(column)]
+			if !ok {
+				return nil, fmt.Errorf("%w: mapped %s column %q not found", domain.ErrInvalidPunchLog, field, column)
+			}
+			indexes[field] = index
+			continue
+		}
+		for _, alias := range punchFieldAliases[field] {
+			if index, ok := columns[alias]; ok {
+				indexes[field] = index
+				break
+			}
+		}
+	}
+
+	for _, field := range []string{domain.PunchFieldEmployeeCode, domain.PunchFieldTimestamp} {
+		if _, ok := indexes[field]; !ok {
+			return nil, fmt.Errorf("%w: no %s column found; map it explicitly", domain.ErrInvalidPunchLog, field)
+		}
+	}
+	return indexes, nil
+}
+
+// newRawPunch builds a raw punch from the values read for each field
+func newRawPunch(line int, values map[string]string) domain.RawPunch {
+	return domain.RawPunch{
+		Line:         line,
+		EmployeeCode: values[domain.PunchFieldEmployeeCode],
+		Timestamp:    values[domain.PunchFieldTimestamp],
+		Device:       values[domain.PunchFieldDevice],
+		Direction:    values[domain.PunchFieldDirection],
+	}
+}
+
+// normalizePunchHeader lowercases a column name and joins its words with underscores
+func normalizePunchHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_", ".", "_").Replace(name)
+}
+
+// jsonScalarString converts a JSON string, number or boolean to its text; other values become ""
+func jsonScalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// parsePunchTimestamp reads an exported punch time. Times without a zone are taken in loc;
+// plain integers are Unix timestamps in seconds.
+func parsePunchTimestamp(value string, loc *time.Location) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range punchTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
+// normalizePunchDirection maps the direction codes of common time clocks to PunchDirectionIn or
+// PunchDirectionOut. An empty direction stays empty and is inferred while pairing.
+func normalizePunchDirection(value string) (string, error) {
+	switch normalizePunchHeader(value) {
+	case "":
+		return "", nil
+	case "in", "i", "0", "check_in", "checkin", "c/in", "clock_in", "entry", "enter":
+		return domain.PunchDirectionIn, nil
+	case "out", "o", "1", "check_out", "checkout", "c/out", "clock_out", "exit", "leave":
+		return domain.PunchDirectionOut, nil
+	}
+	return "", fmt.Errorf("unsupported direction %q", value)
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+func TestParsePunchLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		log     string
+		mapping domain.PunchFieldMapping
+		want    []domain.RawPunch
+		wantErr error
+	}{
+		{
+			name:   "CSV with common header names",
+			format: domain.PunchLogFormatCSV,
+			log:    "Emp Code,Punch-Time,Terminal,In-Out\nF-1,2024-01-15 09:00,Gate A,0\n",
+			want:   []domain.RawPunch{{Line: 2, EmployeeCode: "F-1", Timestamp: "2024-01-15 09:00", Device: "Gate A", Direction: "0"}},
+		},
+		{
+			name:   "CSV with a byte order mark and blank rows",
+			format: domain.PunchLogFormatCSV,
+			log:    "\ufeffemployee_code,timestamp,direction\nF-1,2024-01-15 09:00,in\n,,\nF-1,2024-01-15 17:00,out\n",
+			want: []domain.RawPunch{
+				{Line: 2, EmployeeCode: "F-1", Timestamp: "2024-01-15 09:00", Direction: "in"},
+				{Line: 4, EmployeeCode: "F-1", Timestamp: "2024-01-15 17:00", Direction: "out"},
+			},
+		},
+		{
+			name:    "CSV with explicitly mapped columns",
+			format:  domain.PunchLogFormatCSV,
+			log:     "Badge No,When\nF-1,2024-01-15 09:00\n",
+			mapping: domain.PunchFieldMapping{EmployeeCode: "Badge No", Timestamp: "when"},
+			want:    []domain.RawPunch{{Line: 2, EmployeeCode: "F-1", Timestamp: "2024-01-15 09:00"}},
+		},
+		{
+			name:    "CSV without a timestamp column",
+			format:  domain.PunchLogFormatCSV,
+			log:     "employee_code,badge\nF-1,1\n",
+			wantErr: domain.ErrInvalidPunchLog,
+		},
+		{
+			name:    "CSV with a mapped column that does not exist",
+			format:  domain.PunchLogFormatCSV,
+			log:     "employee_code,timestamp\nF-1,2024-01-15 09:00\n",
+			mapping: domain.PunchFieldMapping{Device: "Terminal"},
+			wantErr: domain.ErrInvalidPunchLog,
+		},
+		{
+			name:    "empty CSV",
+			format:  domain.PunchLogFormatCSV,
+			log:     "",
+			wantErr: domain.ErrInvalidPunchLog,
+		},
+		{
+			name:   "JSON list with numbers",
+			format: domain.PunchLogFormatJSON,
+			log:    `[{"emp_id": 1042, "log_time": 1705309200, "state": 1}]`,
+			want:   []domain.RawPunch{{Line: 1, EmployeeCode: "1042", Timestamp: "1705309200", Direction: "1"}},
+		},
+		{
+			name:   "JSON object with a punches list",
+			format: "JSON",
+			log:    `{"punches": [{"employee_code": "F-1", "timestamp": "2024-01-15T09:00:00Z", "device": "Gate A"}]}`,
+			want:   []domain.RawPunch{{Line: 1, EmployeeCode: "F-1", Timestamp: "2024-01-15T09:00:00Z", Device: "Gate A"}},
+		},
+		{
+			name:    "JSON that is not a list",
+			format:  domain.PunchLogFormatJSON,
+			log:     `{"employee_code": "F-1"}`,
+			wantErr: domain.ErrInvalidPunchLog,
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			log:     "<punches/>",
+			wantErr: domain.ErrUnsupportedPunchLogFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePunchLog(strings.NewReader(tt.log), tt.format, tt.mapping)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("punches = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePunchTimestamp(t *testing.T) {
+	karachi, err := time.LoadLocation("Asia/Karachi")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	want := time.Date(2024, 1, 15, 4, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"RFC 3339 with a zone", "2024-01-15T04:00:00Z", false},
+		{"offset other than the employee's zone", "2024-01-15 06:00:00+02:00", false},
+		{"local time in the employee's zone", "2024-01-15 09:00:00", false},
+		{"local time without seconds", "2024/01/15 09:00", false},
+		{"Unix seconds", "1705291200", false},
+		{"unrecognized", "15.01.2024 09:00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePunchTimestamp(tt.value, karachi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error = %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(want) {
+				t.Fatalf("parsePunchTimestamp(%q) = %v, want %v", tt.value, got, want)
+			}
+		})
+	}
+}
+
+func TestNormalizePunchDirection(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"IN", domain.PunchDirectionIn, false},
+		{"0", domain.PunchDirectionIn, false},
+		{"Check-In", domain.PunchDirectionIn, false},
+		{"C/In", domain.PunchDirectionIn, false},
+		{"out", domain.PunchDirectionOut, false},
+		{"1", domain.PunchDirectionOut, false},
+		{"clock out", domain.PunchDirectionOut, false},
+		{"break", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := normalizePunchDirection(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("normalizePunchDirection(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"hrm/domain"
+)
+
+// reviewerCache answers "may this approver review that user?" while loading each user only once
+type reviewerCache struct {
+	userRepo domain.UserRepositoryInterface
+	users    map[uint]*domain.User
+}
+
+// newReviewerCache creates an empty cache backed by the user repository
+func newReviewerCache(userRepo domain.UserRepositoryInterface) *reviewerCache {
+	return &reviewerCache{
+		userRepo: userRepo,
+		users:    make(map[uint]*domain.User),
+	}
+}
+
+// canReview returns true if the approver may review requests of the given user
+func (c *reviewerCache) canReview(userID uint, approverID uint) bool {
+	user := c.get(userID)
+	return user != nil && user.CanBeReviewedBy(c.get(approverID))
+}
+
+// get loads a user once; unknown users are nil
+func (c *reviewerCache) get(id uint) *domain.User {
+	user, ok := c.users[id]
+	if !ok {
+		var err error
+		user, err = c.userRepo.GetByID(id)
+		if err != nil {
+			user = nil
+		}
+		c.users[id] = user
+	}
+	return user
+}
+
+// canReviewUser returns true if the reviewer may review requests of the user; unknown reviewers may not
+func canReviewUser(userRepo domain.UserRepositoryInterface, user *domain.User, reviewerID uint) bool {
+	reviewer, err := userRepo.GetByID(reviewerID)
+	return err == nil && user.CanBeReviewedBy(reviewer)
+}
@@ -0,0 +1,651 @@
+package usecase
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"hrm/domain"
+	"hrm/scim"
+)
+
+// scimGroupRoles are the roles SCIM clients see and manage as groups; users in neither are employees
+var scimGroupRoles = []string{domain.RoleAdmin, domain.RoleSecurityAdmin}
+
+// SCIMService implements the SCIMServiceInterface, provisioning users and role groups for identity providers
+type SCIMService struct {
+	userRepository        domain.UserRepositoryInterface
+	authSessionRepository domain.AuthSessionRepositoryInterface
+	apiTokenRepository    domain.APITokenRepositoryInterface
+	passwords             passwordManager // Password policy and history applied to passwords clients set
+	tokenHash             string          // Hash of the bearer token SCIM clients authenticate with
+	baseURL               string          // URL of the SCIM endpoint, for resource locations
+}
+
+// NewSCIMService creates and returns a new SCIMService instance.
+// Clients authenticate with the given bearer token; only its hash is kept.
+func NewSCIMService(
+	userRepository domain.UserRepositoryInterface,
+	authSessionRepository domain.AuthSessionRepositoryInterface,
+	apiTokenRepository domain.APITokenRepositoryInterface,
+	passwordHistoryRepository domain.PasswordHistoryRepositoryInterface,
+	passwordPolicy domain.PasswordPolicy,
+	token string,
+	baseURL string,
+) domain.SCIMServiceInterface {
+	return &SCIMService{
+		userRepository:        userRepository,
+		authSessionRepository: authSessionRepository,
+		apiTokenRepository:    apiTokenRepository,
+		passwords:             passwordManager{policy: passwordPolicy, historyRepo: passwordHistoryRepository},
+		tokenHash:             hashOpaqueToken(token),
+		baseURL:               strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Authenticate checks the bearer token SCIM clients authenticate with, in constant time
+func (s *SCIMService) Authenticate(token string) error {
+	if token == "" || subtle.ConstantTimeCompare([]byte(hashOpaqueToken(token)), []byte(s.tokenHash)) != 1 {
+		return domain.ErrInvalidSCIMToken
+	}
+	return nil
+}
+
+// ListUsers returns the page of users matching the query's filter, ordered by ID, and the number of matching users
+func (s *SCIMService) ListUsers(query domain.SCIMListQuery) ([]domain.SCIMUser, int, error) {
+	filter, err := parseSCIMFilter(query.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, err := s.listUsers()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matched []domain.SCIMUser
+	for i := range users {
+		resource := s.toSCIMUser(&users[i])
+		ok, err := matchesSCIMFilter(filter, resource)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			matched = append(matched, *resource)
+		}
+	}
+
+	start, end := scimPage(len(matched), query)
+	return matched[start:end], len(matched), nil
+}
+
+// GetUser retrieves a user by SCIM ID
+func (s *SCIMService) GetUser(id string) (*domain.SCIMUser, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSCIMUser(user), nil
+}
+
+// CreateUser provisions a new user.
+// This method performs the following business operations:
+// 1. Takes over the attributes of the resource
+// 2. Checks that no user has the email address yet
+// 3. Checks and hashes the password; users created without one sign in through single sign-on, the directory or a password reset
+// 4. Creates the user
+func (s *SCIMService) CreateUser(resource *domain.SCIMUser) (*domain.SCIMUser, error) {
+	// Step 1: Take over the attributes
+	user := &domain.User{IsActive: true, Role: domain.RoleEmployee}
+	if err := s.applySCIMUser(user, resource); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Email addresses are unique
+	if err := s.checkEmailAvailable(user.Email, 0); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Set the password
+	var err error
+	if resource.Password != "" {
+		user.Password, err = s.passwords.hashNewPassword(user, resource.Password)
+	} else {
+		user.Password, err = unusablePasswordHash()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 4: Create the user; GORM applies the column default to an inactive user, so it is saved again
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	active := user.IsActive
+	if err := s.userRepository.Create(user); err != nil {
+		return nil, err
+	}
+	if !active {
+		user.IsActive = false
+		if err := s.userRepository.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("SCIM client created user %d (%s)", user.ID, user.Email)
+	return s.toSCIMUser(user), nil
+}
+
+// ReplaceUser replaces the attributes of a user with those of the resource.
+// Active, the time zone and the enterprise extension are kept when the resource leaves them out.
+func (s *SCIMService) ReplaceUser(id string, resource *domain.SCIMUser) (*domain.SCIMUser, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *user
+	if err := s.applySCIMUser(user, resource); err != nil {
+		return nil, err
+	}
+	if err := s.saveUser(user, previous, resource.Password); err != nil {
+		return nil, err
+	}
+	return s.toSCIMUser(user), nil
+}
+
+// PatchUser applies PATCH operations to a user.
+// The operations are applied to the user's SCIM representation, which then replaces the user,
+// so attributes the operations remove are removed from the user.
+func (s *SCIMService) PatchUser(id string, operations []domain.SCIMPatchOperation) (*domain.SCIMUser, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := s.toSCIMUser(user)
+	if resource.Enterprise == nil {
+		resource.Enterprise = &domain.SCIMEnterpriseUser{}
+	}
+	values, err := scim.ToMap(resource)
+	if err != nil {
+		return nil, err
+	}
+	if err := scim.ApplyPatch(values, operations); err != nil {
+		return nil, err
+	}
+	normalizeSCIMBooleans(values, "active")
+
+	var patched domain.SCIMUser
+	if err := scim.FromMap(values, &patched); err != nil {
+		return nil, err
+	}
+	patched.Name = &domain.SCIMName{Formatted: patchedSCIMName(resource, &patched)}
+	if patched.Timezone == nil {
+		patched.Timezone = new(string)
+	}
+	if patched.Enterprise == nil {
+		patched.Enterprise = &domain.SCIMEnterpriseUser{}
+	}
+
+	previous := *user
+	if err := s.applySCIMUser(user, &patched); err != nil {
+		return nil, err
+	}
+	if err := s.saveUser(user, previous, patched.Password); err != nil {
+		return nil, err
+	}
+	return s.toSCIMUser(user), nil
+}
+
+// DeleteUser deprovisions a user.
+// The user is deactivated, signed out everywhere and their API tokens are revoked, but kept with their
+// attendance, leave and timesheet records. SCIM no longer finds deprovisioned users, as RFC 7644 requires.
+func (s *SCIMService) DeleteUser(id string) error {
+	user, err := s.getUser(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	user.IsActive = false
+	user.DeprovisionedAt = &now
+	if err := s.userRepository.Update(user); err != nil {
+		return err
+	}
+	if err := s.authSessionRepository.RevokeAllByUserID(user.ID, domain.SessionRevokedUserDeleted); err != nil {
+		return err
+	}
+	if err := s.apiTokenRepository.RevokeAllByUserID(user.ID); err != nil {
+		return err
+	}
+
+	log.Printf("SCIM client deprovisioned user %d (%s)", user.ID, user.Email)
+	return nil
+}
+
+// ListGroups returns the page of role groups matching the query's filter and the number of matching groups
+func (s *SCIMService) ListGroups(query domain.SCIMListQuery) ([]domain.SCIMGroup, int, error) {
+	filter, err := parseSCIMFilter(query.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, err := s.listUsers()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matched []domain.SCIMGroup
+	for _, role := range scimGroupRoles {
+		group := s.toSCIMGroup(role, users)
+		ok, err := matchesSCIMFilter(filter, group)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			matched = append(matched, *group)
+		}
+	}
+
+	start, end := scimPage(len(matched), query)
+	return matched[start:end], len(matched), nil
+}
+
+// GetGroup retrieves a role group by SCIM ID, which is the role name
+func (s *SCIMService) GetGroup(id string) (*domain.SCIMGroup, error) {
+	role, err := scimGroupRole(id)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.listUsers()
+	if err != nil {
+		return nil, err
+	}
+	return s.toSCIMGroup(role, users), nil
+}
+
+// ReplaceGroup gives the role to the resource's members and makes the other users with the role employees
+func (s *SCIMService) ReplaceGroup(id string, resource *domain.SCIMGroup) (*domain.SCIMGroup, error) {
+	role, err := scimGroupRole(id)
+	if err != nil {
+		return nil, err
+	}
+	if resource.DisplayName != "" && resource.DisplayName != role {
+		return nil, domain.ErrSCIMGroupImmutable
+	}
+
+	users, err := s.listUsers()
+	if err != nil {
+		return nil, err
+	}
+	return s.setGroupMembers(role, resource.Members, users)
+}
+
+// PatchGroup applies PATCH operations to a role group; only its members can change
+func (s *SCIMService) PatchGroup(id string, operations []domain.SCIMPatchOperation) (*domain.SCIMGroup, error) {
+	role, err := scimGroupRole(id)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.listUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := scim.ToMap(s.toSCIMGroup(role, users))
+	if err != nil {
+		return nil, err
+	}
+	if err := scim.ApplyPatch(values, operations); err != nil {
+		return nil, err
+	}
+
+	var patched domain.SCIMGroup
+	if err := scim.FromMap(values, &patched); err != nil {
+		return nil, err
+	}
+	if patched.DisplayName != role {
+		return nil, domain.ErrSCIMGroupImmutable
+	}
+	return s.setGroupMembers(role, patched.Members, users)
+}
+
+// setGroupMembers gives the role to the members and makes the other users with the role employees.
+// Every member is checked before any role changes. A user has one role, so joining a group leaves the other one.
+func (s *SCIMService) setGroupMembers(role string, members []domain.SCIMMultiValue, users []domain.User) (*domain.SCIMGroup, error) {
+	known := make(map[uint]bool, len(users))
+	for _, user := range users {
+		known[user.ID] = true
+	}
+	wanted := make(map[uint]bool, len(members))
+	for _, member := range members {
+		id, err := parseSCIMID(member.Value)
+		if err != nil || !known[id] {
+			return nil, fmt.Errorf("%w: unknown member %q", domain.ErrSCIMInvalidValue, member.Value)
+		}
+		wanted[id] = true
+	}
+
+	for i := range users {
+		user := &users[i]
+		switch {
+		case wanted[user.ID] && user.Role != role:
+			user.Role = role
+		case !wanted[user.ID] && user.Role == role:
+			user.Role = domain.RoleEmployee
+		default:
+			continue
+		}
+		if err := s.userRepository.Update(user); err != nil {
+			return nil, err
+		}
+		log.Printf("SCIM client changed the role of user %d to %s", user.ID, user.Role)
+	}
+
+	return s.toSCIMGroup(role, users), nil
+}
+
+// applySCIMUser takes over the attributes of a SCIM user that HRM keeps.
+// Email addresses come from the client's directory, so they count as verified.
+func (s *SCIMService) applySCIMUser(user *domain.User, resource *domain.SCIMUser) error {
+	email := strings.TrimSpace(resource.UserName)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return domain.ErrInvalidEmail
+	}
+	name := scimUserName(resource)
+	if name == "" {
+		return domain.ErrInvalidName
+	}
+
+	if !strings.EqualFold(email, user.Email) || user.EmailVerifiedAt == nil {
+		now := time.Now().UTC()
+		user.EmailVerifiedAt = &now
+	}
+	user.Email = email
+	user.Name = name
+
+	user.ExternalID = nil
+	if externalID := strings.TrimSpace(resource.ExternalID); externalID != "" {
+		user.ExternalID = &externalID
+	}
+	if resource.Active != nil {
+		user.IsActive = *resource.Active
+	}
+	if resource.Timezone != nil {
+		user.Timezone = *resource.Timezone
+	}
+
+	if resource.Enterprise != nil {
+		user.ManagerID = nil
+		if manager := resource.Enterprise.Manager; manager != nil && manager.Value != "" {
+			managerID, err := parseSCIMID(manager.Value)
+			if err != nil {
+				return domain.ErrManagerNotFound
+			}
+			if _, err := s.userRepository.GetByID(managerID); err != nil {
+				if errors.Is(err, domain.ErrUserNotFound) {
+					return domain.ErrManagerNotFound
+				}
+				return err
+			}
+			user.ManagerID = &managerID
+		}
+	}
+	return nil
+}
+
+// saveUser saves a replaced or patched user.
+// A new password is checked against the policy, and setting it or deactivating the user signs them out everywhere.
+func (s *SCIMService) saveUser(user *domain.User, previous domain.User, password string) error {
+	if err := s.checkEmailAvailable(user.Email, user.ID); err != nil {
+		return err
+	}
+
+	if password != "" {
+		hashedPassword, err := s.passwords.hashNewPassword(user, password)
+		if err != nil {
+			return err
+		}
+		user.Password = hashedPassword
+	}
+
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	if err := s.userRepository.Update(user); err != nil {
+		return err
+	}
+
+	if password != "" {
+		if err := s.passwords.rememberPassword(user.ID, previous.Password); err != nil {
+			return err
+		}
+		return s.authSessionRepository.RevokeAllByUserID(user.ID, domain.SessionRevokedPasswordChanged)
+	}
+	if previous.IsActive && !user.IsActive {
+		log.Printf("SCIM client deactivated user %d (%s)", user.ID, user.Email)
+		return s.authSessionRepository.RevokeAllByUserID(user.ID, domain.SessionRevokedUserDeactivated)
+	}
+	return nil
+}
+
+// checkEmailAvailable returns ErrUserAlreadyExists if a user other than userID has the email address
+func (s *SCIMService) checkEmailAvailable(email string, userID uint) error {
+	existing, err := s.userRepository.GetByEmail(email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != userID {
+		return domain.ErrUserAlreadyExists
+	}
+	return nil
+}
+
+// getUser retrieves a user by SCIM ID; IDs that are not user IDs, service accounts and deprovisioned users are not found
+func (s *SCIMService) getUser(id string) (*domain.User, error) {
+	userID, err := parseSCIMID(id)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsServiceAccount || user.DeprovisionedAt != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// listUsers retrieves the users SCIM clients manage, ordered by ID.
+// Service accounts are managed in HRM and left out, so group updates do not change their roles;
+// deprovisioned users are left out as deleted.
+func (s *SCIMService) listUsers() ([]domain.User, error) {
+	users, err := s.userRepository.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	people := users[:0]
+	for _, user := range users {
+		if !user.IsServiceAccount && user.DeprovisionedAt == nil {
+			people = append(people, user)
+		}
+	}
+	return people, nil
+}
+
+// toSCIMUser converts a user to its SCIM representation
+func (s *SCIMService) toSCIMUser(user *domain.User) *domain.SCIMUser {
+	id := strconv.FormatUint(uint64(user.ID), 10)
+	active := user.IsActive
+	createdAt, updatedAt := user.CreatedAt, user.UpdatedAt
+	givenName, familyName := splitSCIMName(user.Name)
+
+	resource := &domain.SCIMUser{
+		Schemas:     []string{domain.SCIMSchemaUser},
+		ID:          id,
+		UserName:    user.Email,
+		Name:        &domain.SCIMName{Formatted: user.Name, GivenName: givenName, FamilyName: familyName},
+		DisplayName: user.Name,
+		Emails:      []domain.SCIMMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &domain.SCIMMeta{
+			ResourceType: "User",
+			Created:      &createdAt,
+			LastModified: &updatedAt,
+			Location:     s.baseURL + "/Users/" + id,
+		},
+	}
+	if user.ExternalID != nil {
+		resource.ExternalID = *user.ExternalID
+	}
+	if user.Timezone != "" {
+		timezone := user.Timezone
+		resource.Timezone = &timezone
+	}
+	if slices.Contains(scimGroupRoles, user.Role) {
+		resource.Groups = []domain.SCIMMultiValue{{Value: user.Role, Display: user.Role, Type: "direct", Ref: s.baseURL + "/Groups/" + user.Role}}
+	}
+	if user.ManagerID != nil {
+		managerID := strconv.FormatUint(uint64(*user.ManagerID), 10)
+		resource.Schemas = append(resource.Schemas, domain.SCIMSchemaEnterpriseUser)
+		resource.Enterprise = &domain.SCIMEnterpriseUser{
+			Manager: &domain.SCIMManager{Value: managerID, Ref: s.baseURL + "/Users/" + managerID},
+		}
+	}
+	return resource
+}
+
+// toSCIMGroup converts a role to its SCIM group, with the users having the role as members
+func (s *SCIMService) toSCIMGroup(role string, users []domain.User) *domain.SCIMGroup {
+	members := []domain.SCIMMultiValue{}
+	for _, user := range users {
+		if user.Role != role {
+			continue
+		}
+		id := strconv.FormatUint(uint64(user.ID), 10)
+		members = append(members, domain.SCIMMultiValue{Value: id, Display: user.Name, Type: "User", Ref: s.baseURL + "/Users/" + id})
+	}
+
+	return &domain.SCIMGroup{
+		Schemas:     []string{domain.SCIMSchemaGroup},
+		ID:          role,
+		DisplayName: role,
+		Members:     members,
+		Meta:        &domain.SCIMMeta{ResourceType: "Group", Location: s.baseURL + "/Groups/" + role},
+	}
+}
+
+// scimGroupRole returns the role a SCIM group ID refers to
+func scimGroupRole(id string) (string, error) {
+	if !slices.Contains(scimGroupRoles, id) {
+		return "", domain.ErrSCIMGroupNotFound
+	}
+	return id, nil
+}
+
+// parseSCIMID parses the SCIM ID of a user, which is the user ID
+func parseSCIMID(id string) (uint, error) {
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || parsed == 0 {
+		return 0, domain.ErrUserNotFound
+	}
+	return uint(parsed), nil
+}
+
+// parseSCIMFilter parses a list query's filter; an empty filter yields nil, which matches everything
+func parseSCIMFilter(filter string) (scim.Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	return scim.ParseFilter(filter)
+}
+
+// matchesSCIMFilter reports whether a resource satisfies a filter; a nil filter matches everything
+func matchesSCIMFilter(filter scim.Filter, resource interface{}) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	values, err := scim.ToMap(resource)
+	if err != nil {
+		return false, err
+	}
+	return filter.Matches(values), nil
+}
+
+// scimPage returns the bounds of the requested page within total matching resources
+func scimPage(total int, query domain.SCIMListQuery) (int, int) {
+	start := min(max(query.StartIndex, 1)-1, total)
+	end := min(start+max(query.Count, 0), total)
+	return start, end
+}
+
+// scimUserName returns the full name of a SCIM user: the formatted name, the given and family names, or the display name
+func scimUserName(resource *domain.SCIMUser) string {
+	if resource.Name != nil {
+		if formatted := strings.TrimSpace(resource.Name.Formatted); formatted != "" {
+			return formatted
+		}
+		if joined := strings.TrimSpace(resource.Name.GivenName + " " + resource.Name.FamilyName); joined != "" {
+			return joined
+		}
+	}
+	return strings.TrimSpace(resource.DisplayName)
+}
+
+// patchedSCIMName returns the full name after a patch, from whichever of the formatted name,
+// the given and family names or the display name the patch changed
+func patchedSCIMName(original, patched *domain.SCIMUser) string {
+	name := patched.Name
+	if name == nil {
+		name = &domain.SCIMName{}
+	}
+	switch {
+	case name.Formatted != original.Name.Formatted:
+		return name.Formatted
+	case name.GivenName != original.Name.GivenName || name.FamilyName != original.Name.FamilyName:
+		return strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+	case patched.DisplayName != original.DisplayName:
+		return patched.DisplayName
+	}
+	return original.Name.Formatted
+}
+
+// splitSCIMName splits a full name into given and family names at the last space
+func splitSCIMName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// normalizeSCIMBooleans turns boolean attributes sent as strings, such as "False", into booleans
+func normalizeSCIMBooleans(values map[string]interface{}, names ...string) {
+	for key, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				if parsed, err := strconv.ParseBool(text); err == nil {
+					values[key] = parsed
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"hrm/domain"
+)
+
+// fakeAuthSessionRepository records the users whose sessions were revoked
+type fakeAuthSessionRepository struct {
+	domain.AuthSessionRepositoryInterface
+	revoked map[uint]string
+}
+
+func (r *fakeAuthSessionRepository) RevokeAllByUserID(userID uint, reason string) error {
+	r.revoked[userID] = reason
+	return nil
+}
+
+// fakeAPITokenRepository records the users whose tokens were revoked
+type fakeAPITokenRepository struct {
+	domain.APITokenRepositoryInterface
+	revoked map[uint]bool
+}
+
+func (r *fakeAPITokenRepository) RevokeAllByUserID(userID uint) error {
+	r.revoked[userID] = true
+	return nil
+}
+
+func TestSCIMDeleteUserDeprovisions(t *testing.T) {
+	userRepo := &fakeUserRepository{users: map[uint]domain.User{
+		1: {ID: 1, Name: "Alice", Email: "alice@example.com", Password: "hash", IsActive: true},
+	}}
+	sessionRepo := &fakeAuthSessionRepository{revoked: map[uint]string{}}
+	tokenRepo := &fakeAPITokenRepository{revoked: map[uint]bool{}}
+	service := NewSCIMService(userRepo, sessionRepo, tokenRepo, nil, domain.PasswordPolicy{}, "token", "https://hrm.example.com/scim/v2")
+
+	if err := service.DeleteUser("1"); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	saved, ok := userRepo.users[1]
+	if !ok {
+		t.Fatal("DeleteUser removed the user")
+	}
+	if saved.IsActive || saved.DeprovisionedAt == nil {
+		t.Fatalf("IsActive = %v, DeprovisionedAt = %v, want an inactive, deprovisioned user", saved.IsActive, saved.DeprovisionedAt)
+	}
+	if reason := sessionRepo.revoked[1]; reason != domain.SessionRevokedUserDeleted {
+		t.Fatalf("session revoke reason = %q, want %q", reason, domain.SessionRevokedUserDeleted)
+	}
+	if !tokenRepo.revoked[1] {
+		t.Fatal("DeleteUser kept the user's API tokens")
+	}
+
+	if _, err := service.GetUser("1"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("GetUser after DeleteUser: err = %v, want ErrUserNotFound", err)
+	}
+	if err := service.DeleteUser("1"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Fatalf("DeleteUser again: err = %v, want ErrUserNotFound", err)
+	}
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"hrm/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// rsaKeyBits is the size of generated RS256 keys
+	rsaKeyBits = 2048
+
+	// signingKeyCacheTTL is how long loaded keys are used before they are read again,
+	// so instances sharing the database pick up keys rotated by another instance
+	signingKeyCacheTTL = time.Minute
+)
+
+// parsedSigningKey is a signing key with its PEM halves decoded
+type parsedSigningKey struct {
+	key        domain.SigningKey
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// SigningKeyService implements the SigningKeyServiceInterface
+// This struct contains the business logic for signing access tokens: the shared secret for HS256,
+// or rotating key pairs identified by "kid" for RS256 and EdDSA
+type SigningKeyService struct {
+	keyRepo          domain.SigningKeyRepositoryInterface
+	algorithm        string
+	rotationInterval time.Duration
+	accessTokenTTL   time.Duration
+
+	mu       sync.Mutex
+	keys     []parsedSigningKey // Verifiable keys, newest first
+	loadedAt time.Time
+}
+
+// NewSigningKeyService creates a new instance of SigningKeyService.
+// A zero rotation interval keeps the current key until it is rotated by hand.
+func NewSigningKeyService(
+	keyRepo domain.SigningKeyRepositoryInterface,
+	algorithm string,
+	rotationInterval time.Duration,
+	accessTokenTTL time.Duration,
+) domain.SigningKeyServiceInterface {
+	return &SigningKeyService{
+		keyRepo:          keyRepo,
+		algorithm:        algorithm,
+		rotationInterval: rotationInterval,
+		accessTokenTTL:   accessTokenTTL,
+	}
+}
+
+// SignToken signs the claims with the configured algorithm.
+// Asymmetric tokens name their key in the "kid" header; a first key is created if none exists yet.
+func (s *SigningKeyService) SignToken(claims map[string]interface{}) (string, error) {
+	if s.algorithm == domain.SigningAlgHS256 {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return "", domain.ErrJWTSecretMissing
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims)).SignedString([]byte(secret))
+	}
+
+	current, err := s.currentKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(current.key.Algorithm), jwt.MapClaims(claims))
+	token.Header["kid"] = current.key.KID
+	return token.SignedString(current.privateKey)
+}
+
+// VerificationKey returns the key a token with the given "kid" and algorithm is verified with.
+// HS256 tokens are only accepted while HS256 is the configured algorithm.
+func (s *SigningKeyService) VerificationKey(kid, algorithm string) (interface{}, error) {
+	if algorithm == domain.SigningAlgHS256 {
+		if s.algorithm != domain.SigningAlgHS256 {
+			return nil, domain.ErrUnsupportedAlgorithm
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}
+
+	now := time.Now().UTC()
+	key, err := s.findKey(kid, now)
+	if err != nil {
+		return nil, err
+	}
+	if key.key.Algorithm != algorithm || !key.key.IsVerifiableAt(now) {
+		return nil, domain.ErrSigningKeyNotFound
+	}
+	return key.publicKey, nil
+}
+
+// RotateKeys makes sure a current key of the configured algorithm exists and is younger than
+// the rotation interval. This method performs the following business operations:
+// 1. Creates a new key when there is none, the current one is too old or the algorithm changed
+// 2. Retires every other key, keeping it verifiable until the tokens it signed have expired
+// 3. Removes keys no valid token can refer to any more
+// It returns whether a new key was created.
+func (s *SigningKeyService) RotateKeys(now time.Time) (bool, error) {
+	if !domain.IsAsymmetricAlgorithm(s.algorithm) {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.keyRepo.GetVerifiable(now)
+	if err != nil {
+		return false, err
+	}
+
+	// Step 1: Create a new key when needed
+	var current *domain.SigningKey
+	for i := range keys {
+		if !keys[i].IsRetired() && keys[i].Algorithm == s.algorithm {
+			current = &keys[i]
+			break
+		}
+	}
+	rotated := current == nil || (s.rotationInterval > 0 && now.Sub(current.CreatedAt) >= s.rotationInterval)
+	if rotated {
+		current, err = generateSigningKey(s.algorithm)
+		if err != nil {
+			return false, err
+		}
+		if err := s.keyRepo.Create(current); err != nil {
+			return false, err
+		}
+		log.Printf("Created %s signing key %s", current.Algorithm, current.KID)
+	}
+
+	// Step 2: Retire the other keys; tokens already signed stay valid until they expire,
+	// and instances that have not reloaded their keys yet may still sign with them for a while
+	for i := range keys {
+		key := &keys[i]
+		if key.ID == current.ID || key.IsRetired() {
+			continue
+		}
+		key.Retire(now, s.accessTokenTTL+signingKeyCacheTTL)
+		if err := s.keyRepo.Update(key); err != nil {
+			return rotated, err
+		}
+	}
+
+	// Step 3: Remove keys past their verification window
+	if _, err := s.keyRepo.DeleteExpired(now); err != nil {
+		return rotated, err
+	}
+
+	return rotated, s.reload(now)
+}
+
+// GetJWKS returns the public keys tokens may currently be verified with.
+// The set is empty while tokens are signed with the shared HS256 secret.
+func (s *SigningKeyService) GetJWKS() (*domain.JSONWebKeySet, error) {
+	jwks := &domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	if !domain.IsAsymmetricAlgorithm(s.algorithm) {
+		return jwks, nil
+	}
+
+	now := time.Now().UTC()
+	keys, err := s.loadedKeys(now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if !key.key.IsVerifiableAt(now) {
+			continue
+		}
+		jwk, err := toJSONWebKey(key)
+		if err != nil {
+			return nil, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
+// currentKey returns the key new tokens are signed with, creating the first one if needed
+func (s *SigningKeyService) currentKey() (*parsedSigningKey, error) {
+	now := time.Now().UTC()
+	for attempt := 0; attempt < 2; attempt++ {
+		keys, err := s.loadedKeys(now)
+		if err != nil {
+			return nil, err
+		}
+		for i := range keys {
+			if !keys[i].key.IsRetired() && keys[i].key.Algorithm == s.algorithm {
+				return &keys[i], nil
+			}
+		}
+		if _, err := s.RotateKeys(now); err != nil {
+			return nil, err
+		}
+	}
+	return nil, domain.ErrSigningKeyNotFound
+}
+
+// findKey looks up a key by its "kid", reading the keys again when it is unknown
+// since another instance may have just rotated
+func (s *SigningKeyService) findKey(kid string, now time.Time) (*parsedSigningKey, error) {
+	keys, err := s.loadedKeys(now)
+	if err != nil {
+		return nil, err
+	}
+	if key := findParsedKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	s.mu.Lock()
+	err = s.reload(now)
+	keys = s.keys
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if key := findParsedKey(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, domain.ErrSigningKeyNotFound
+}
+
+// loadedKeys returns the cached keys, reading them again once the cache is stale
+func (s *SigningKeyService) loadedKeys(now time.Time) ([]parsedSigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || now.Sub(s.loadedAt) >= signingKeyCacheTTL {
+		if err := s.reload(now); err != nil {
+			return nil, err
+		}
+	}
+	return s.keys, nil
+}
+
+// reload reads the verifiable keys from the database; the caller must hold s.mu
+func (s *SigningKeyService) reload(now time.Time) error {
+	keys, err := s.keyRepo.GetVerifiable(now)
+	if err != nil {
+		return err
+	}
+
+	parsed := make([]parsedSigningKey, 0, len(keys))
+	for _, key := range keys {
+		parsedKey, err := parseSigningKey(key)
+		if err != nil {
+			log.Printf("Skipping signing key %s: %v", key.KID, err)
+			continue
+		}
+		parsed = append(parsed, *parsedKey)
+	}
+
+	s.keys = parsed
+	s.loadedAt = now
+	return nil
+}
+
+// findParsedKey returns the key with the given "kid", or nil
+func findParsedKey(keys []parsedSigningKey, kid string) *parsedSigningKey {
+	for i := range keys {
+		if keys[i].key.KID == kid {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+// generateSigningKey creates a new key pair for the algorithm, identified by a hash of its public key
+func generateSigningKey(algorithm string) (*domain.SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case domain.SigningAlgRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case domain.SigningAlgEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, domain.ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(publicDER)
+	return &domain.SigningKey{
+		KID:        base64.RawURLEncoding.EncodeToString(sum[:16]),
+		Algorithm:  algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+	}, nil
+}
+
+// parseSigningKey decodes the PEM halves of a stored key
+func parseSigningKey(key domain.SigningKey) (*parsedSigningKey, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+
+	return &parsedSigningKey{key: key, privateKey: signer, publicKey: signer.Public()}, nil
+}
+
+// toJSONWebKey converts the public half of a key to its JWKS form
+func toJSONWebKey(key parsedSigningKey) (domain.JSONWebKey, error) {
+	jwk := domain.JSONWebKey{
+		KeyID:     key.key.KID,
+		Algorithm: key.key.Algorithm,
+		Use:       "sig",
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	default:
+		return jwk, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return jwk, nil
+}
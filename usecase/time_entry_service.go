@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"hrm/domain"
+	"sort"
+	"time"
+)
+
+// allocationTolerance absorbs floating point noise when comparing allocated and worked hours
+const allocationTolerance = 0.001
+
+// TimeEntryService implements the TimeEntryServiceInterface
+// This struct contains the business logic for allocating worked hours to projects
+type TimeEntryService struct {
+	timeEntryRepo  domain.TimeEntryRepositoryInterface
+	projectRepo    domain.ProjectRepositoryInterface
+	attendanceRepo domain.AttendanceRepositoryInterface
+}
+
+// NewTimeEntryService creates a new instance of TimeEntryService
+func NewTimeEntryService(
+	timeEntryRepo domain.TimeEntryRepositoryInterface,
+	projectRepo domain.ProjectRepositoryInterface,
+	attendanceRepo domain.AttendanceRepositoryInterface,
+) domain.TimeEntryServiceInterface {
+	return &TimeEntryService{
+		timeEntryRepo:  timeEntryRepo,
+		projectRepo:    projectRepo,
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+// LogTime books hours of the user's attendance day on a project
+func (s *TimeEntryService) LogTime(userID uint, entry *domain.TimeEntry) error {
+	entry.UserID = userID
+	entry.Date = domain.DateOnly(entry.Date)
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	// Time can only be allocated from a day the user actually attended
+	attendance, err := s.attendanceRepo.GetByUserID(userID, entry.Date)
+	if err != nil {
+		return err
+	}
+	entry.AttendanceID = attendance.ID
+
+	if err := s.checkAllocation(attendance, entry); err != nil {
+		return err
+	}
+
+	return s.timeEntryRepo.Create(entry)
+}
+
+// GetTimeEntryByID retrieves a time entry by its ID
+func (s *TimeEntryService) GetTimeEntryByID(id uint) (*domain.TimeEntry, error) {
+	return s.timeEntryRepo.GetByID(id)
+}
+
+// GetUserTimeEntries retrieves the time entries of a user within a date range
+func (s *TimeEntryService) GetUserTimeEntries(userID uint, startDate, endDate time.Time) ([]domain.TimeEntry, error) {
+	if endDate.Before(startDate) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	return s.timeEntryRepo.GetByUserIDAndDateRange(userID, domain.DateOnly(startDate), domain.DateOnly(endDate))
+}
+
+// UpdateTimeEntry changes the project, hours or description of one of the user's entries
+func (s *TimeEntryService) UpdateTimeEntry(userID uint, entry *domain.TimeEntry) error {
+	// Check if time entry exists
+	existingEntry, err := s.timeEntryRepo.GetByID(entry.ID)
+	if err != nil {
+		return err
+	}
+	if existingEntry.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	// The day of an entry never changes; delete and re-log to move it
+	entry.UserID = existingEntry.UserID
+	entry.AttendanceID = existingEntry.AttendanceID
+	entry.Date = existingEntry.Date
+	entry.CreatedAt = existingEntry.CreatedAt
+
+	attendance, err := s.attendanceRepo.GetByID(entry.AttendanceID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkAllocation(attendance, entry); err != nil {
+		return err
+	}
+
+	return s.timeEntryRepo.Update(entry)
+}
+
+// DeleteTimeEntry removes one of the user's entries
+func (s *TimeEntryService) DeleteTimeEntry(id uint, userID uint) error {
+	entry, err := s.timeEntryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if entry.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+
+	attendance, err := s.attendanceRepo.GetByID(entry.AttendanceID)
+	if err == nil && attendance.IsLocked() {
+		return domain.ErrAttendanceLocked
+	}
+
+	return s.timeEntryRepo.Delete(id)
+}
+
+// checkAllocation verifies that the project accepts time and that the day's allocations,
+// including the given entry, stay within the hours worked
+func (s *TimeEntryService) checkAllocation(attendance *domain.Attendance, entry *domain.TimeEntry) error {
+	if attendance.IsLocked() {
+		return domain.ErrAttendanceLocked
+	}
+
+	project, err := s.projectRepo.GetByID(entry.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !project.IsActive {
+		return domain.ErrProjectInactive
+	}
+
+	entries, err := s.timeEntryRepo.GetByAttendanceID(attendance.ID)
+	if err != nil {
+		return err
+	}
+
+	allocated := entry.Hours
+	for _, existing := range entries {
+		if existing.ID != entry.ID {
+			allocated += existing.Hours
+		}
+	}
+	if allocated > attendance.TotalWorkHours+allocationTolerance {
+		return domain.ErrTimeEntryExceedsWorkHours
+	}
+
+	return nil
+}
+
+// GetUtilization reports the hours booked per project and how much of each user's
+// worked time was allocated, optionally narrowed to one project or user
+func (s *TimeEntryService) GetUtilization(startDate, endDate time.Time, projectID uint, userID uint) (*domain.UtilizationReport, error) {
+	startDate, endDate = domain.DateOnly(startDate), domain.DateOnly(endDate)
+	if endDate.Before(startDate) {
+		return nil, domain.ErrInvalidDateRange
+	}
+	if projectID != 0 {
+		if _, err := s.projectRepo.GetByID(projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.timeEntryRepo.GetByDateRange(startDate, endDate, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.UtilizationReport{StartDate: startDate, EndDate: endDate}
+
+	projects := make(map[uint]*domain.ProjectUtilization)
+	projectUsers := make(map[uint]map[uint]float64)
+	allocatedByUser := make(map[uint]float64)
+	if userID != 0 {
+		allocatedByUser[userID] = 0
+	}
+
+	for _, entry := range entries {
+		project, ok := projects[entry.ProjectID]
+		if !ok {
+			project = &domain.ProjectUtilization{
+				ProjectID:   entry.ProjectID,
+				ProjectName: entry.Project.Name,
+				ProjectCode: entry.Project.Code,
+			}
+			projects[entry.ProjectID] = project
+			projectUsers[entry.ProjectID] = make(map[uint]float64)
+		}
+
+		project.Hours += entry.Hours
+		projectUsers[entry.ProjectID][entry.UserID] += entry.Hours
+		allocatedByUser[entry.UserID] += entry.Hours
+		report.AllocatedHours += entry.Hours
+	}
+
+	for id, project := range projects {
+		project.Hours = roundHours(project.Hours)
+		for user, hours := range projectUsers[id] {
+			project.Users = append(project.Users, domain.ProjectUserHours{UserID: user, Hours: roundHours(hours)})
+		}
+		sort.Slice(project.Users, func(i, j int) bool { return project.Users[i].UserID < project.Users[j].UserID })
+		report.Projects = append(report.Projects, *project)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool { return report.Projects[i].ProjectCode < report.Projects[j].ProjectCode })
+
+	// Utilization compares the booked hours with everything the user worked in the range
+	for user, allocated := range allocatedByUser {
+		attendances, err := s.attendanceRepo.GetByUserIDAndDateRange(user, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+
+		worked := 0.0
+		for _, attendance := range attendances {
+			worked += attendance.TotalWorkHours
+		}
+
+		utilization := domain.UserUtilization{
+			UserID:         user,
+			WorkedHours:    roundHours(worked),
+			AllocatedHours: roundHours(allocated),
+		}
+		if worked > 0 {
+			utilization.Utilization = roundHours(allocated / worked * 100)
+		}
+		report.Users = append(report.Users, utilization)
+		report.WorkedHours += worked
+	}
+	sort.Slice(report.Users, func(i, j int) bool { return report.Users[i].UserID < report.Users[j].UserID })
+
+	report.WorkedHours = roundHours(report.WorkedHours)
+	report.AllocatedHours = roundHours(report.AllocatedHours)
+
+	return report, nil
+}
@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"errors"
+	"hrm/domain"
+	"strings"
+	"time"
+)
+
+// TimesheetService implements the TimesheetServiceInterface
+// This struct contains the business logic for weekly timesheets and their approval
+type TimesheetService struct {
+	timesheetRepo  domain.TimesheetRepositoryInterface
+	attendanceRepo domain.AttendanceRepositoryInterface
+	userRepo       domain.UserRepositoryInterface
+	locationRepo   domain.LocationRepositoryInterface
+	policy         domain.AttendancePolicy
+	weekStart      time.Weekday
+}
+
+// NewTimesheetService creates a new instance of TimesheetService
+func NewTimesheetService(
+	timesheetRepo domain.TimesheetRepositoryInterface,
+	attendanceRepo domain.AttendanceRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	locationRepo domain.LocationRepositoryInterface,
+	policy domain.AttendancePolicy,
+	weekStart time.Weekday,
+) domain.TimesheetServiceInterface {
+	return &TimesheetService{
+		timesheetRepo:  timesheetRepo,
+		attendanceRepo: attendanceRepo,
+		userRepo:       userRepo,
+		locationRepo:   locationRepo,
+		policy:         policy,
+		weekStart:      weekStart,
+	}
+}
+
+// GetWeek retrieves the timesheet of the week containing the given day.
+// Weeks that were never annotated or submitted are returned as an unsaved draft.
+func (s *TimesheetService) GetWeek(userID uint, viewerID uint, date time.Time) (*domain.Timesheet, error) {
+	if err := s.checkViewer(userID, viewerID); err != nil {
+		return nil, err
+	}
+
+	timesheet, err := s.findOrNew(userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillDays(timesheet); err != nil {
+		return nil, err
+	}
+
+	return timesheet, nil
+}
+
+// GetTimesheetByID retrieves a stored timesheet with its daily figures
+func (s *TimesheetService) GetTimesheetByID(id uint, viewerID uint) (*domain.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkViewer(timesheet.UserID, viewerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.fillDays(timesheet); err != nil {
+		return nil, err
+	}
+
+	return timesheet, nil
+}
+
+// GetUserTimesheets retrieves all stored timesheets of a user
+func (s *TimesheetService) GetUserTimesheets(userID uint) ([]domain.Timesheet, error) {
+	return s.timesheetRepo.GetByUserID(userID)
+}
+
+// Annotate stores the employee's notes on a week; dayNotes replaces the notes of every day
+func (s *TimesheetService) Annotate(userID uint, date time.Time, note string, dayNotes []domain.TimesheetDayNote) (*domain.Timesheet, error) {
+	timesheet, err := s.findOrNew(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	if !timesheet.IsEditable() {
+		return nil, domain.ErrTimesheetNotEditable
+	}
+
+	notes := make([]domain.TimesheetDayNote, 0, len(dayNotes))
+	for _, dayNote := range dayNotes {
+		if !timesheet.Contains(dayNote.Date) {
+			return nil, domain.ErrInvalidTimesheetDay
+		}
+		text := strings.TrimSpace(dayNote.Note)
+		if text == "" {
+			continue
+		}
+		notes = append(notes, domain.TimesheetDayNote{Date: domain.DateOnly(dayNote.Date), Note: text})
+	}
+
+	timesheet.EmployeeNote = strings.TrimSpace(note)
+	if err := s.save(timesheet); err != nil {
+		return nil, err
+	}
+	if err := s.timesheetRepo.ReplaceDayNotes(timesheet.ID, notes); err != nil {
+		return nil, err
+	}
+
+	return s.GetTimesheetByID(timesheet.ID, userID)
+}
+
+// Submit sends the week to the employee's manager for approval
+func (s *TimesheetService) Submit(userID uint, date time.Time) (*domain.Timesheet, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	timesheet, err := s.findOrNew(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	if !timesheet.IsEditable() {
+		return nil, domain.ErrTimesheetNotEditable
+	}
+
+	// A week can be submitted once it has ended, so no punches can be added after approval
+	loc := userTimeLocation(user, s.locationRepo, s.policy)
+	if !timesheet.WeekEnd.Before(domain.WorkDay(time.Now().UTC(), loc)) {
+		return nil, domain.ErrTimesheetWeekNotEnded
+	}
+
+	if err := s.fillDays(timesheet); err != nil {
+		return nil, err
+	}
+	if err := s.snapshotTotals(timesheet); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	timesheet.Status = domain.TimesheetStatusSubmitted
+	timesheet.SubmittedAt = &now
+
+	if err := s.save(timesheet); err != nil {
+		return nil, err
+	}
+
+	return timesheet, nil
+}
+
+// GetPendingTimesheets retrieves the submitted timesheets the given approver is allowed to review
+func (s *TimesheetService) GetPendingTimesheets(approverID uint) ([]domain.Timesheet, error) {
+	submitted, err := s.timesheetRepo.GetByStatus(domain.TimesheetStatusSubmitted)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := newReviewerCache(s.userRepo)
+	reviewable := make([]domain.Timesheet, 0, len(submitted))
+	for _, timesheet := range submitted {
+		if reviewers.canReview(timesheet.UserID, approverID) {
+			reviewable = append(reviewable, timesheet)
+		}
+	}
+
+	return reviewable, nil
+}
+
+// Approve accepts a submitted timesheet and locks the attendance records of its week
+func (s *TimesheetService) Approve(id uint, approverID uint, note string) (*domain.Timesheet, error) {
+	timesheet, err := s.getReviewable(id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Regularizations may have been approved since submission; store the figures being locked
+	if err := s.fillDays(timesheet); err != nil {
+		return nil, err
+	}
+	if err := s.snapshotTotals(timesheet); err != nil {
+		return nil, err
+	}
+
+	if err := s.attendanceRepo.LockByUserIDAndDateRange(timesheet.UserID, timesheet.WeekStart, timesheet.WeekEnd, timesheet.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.review(timesheet, approverID, domain.TimesheetStatusApproved, note); err != nil {
+		return nil, err
+	}
+
+	return timesheet, nil
+}
+
+// Return sends a submitted timesheet back to the employee for corrections
+func (s *TimesheetService) Return(id uint, approverID uint, note string) (*domain.Timesheet, error) {
+	timesheet, err := s.getReviewable(id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillDays(timesheet); err != nil {
+		return nil, err
+	}
+
+	if err := s.review(timesheet, approverID, domain.TimesheetStatusReturned, note); err != nil {
+		return nil, err
+	}
+
+	return timesheet, nil
+}
+
+// review records the decision of an approver on a submitted timesheet
+func (s *TimesheetService) review(timesheet *domain.Timesheet, approverID uint, status domain.TimesheetStatus, note string) error {
+	now := time.Now().UTC()
+	timesheet.Status = status
+	timesheet.ReviewedBy = &approverID
+	timesheet.ReviewedAt = &now
+	timesheet.ReviewNote = note
+
+	return s.timesheetRepo.Update(timesheet)
+}
+
+// getReviewable loads a timesheet and verifies that the approver may decide on it
+func (s *TimesheetService) getReviewable(id uint, approverID uint) (*domain.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if timesheet.Status != domain.TimesheetStatusSubmitted {
+		return nil, domain.ErrTimesheetNotSubmitted
+	}
+
+	if timesheet.UserID == approverID {
+		return nil, domain.ErrSelfApproval
+	}
+
+	user, err := s.userRepo.GetByID(timesheet.UserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if !canReviewUser(s.userRepo, user, approverID) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return timesheet, nil
+}
+
+// checkViewer verifies that the viewer is the employee or someone allowed to review them
+func (s *TimesheetService) checkViewer(userID uint, viewerID uint) error {
+	if userID == viewerID {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if !canReviewUser(s.userRepo, user, viewerID) {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// findOrNew loads the stored timesheet of the week or prepares an unsaved draft
+func (s *TimesheetService) findOrNew(userID uint, date time.Time) (*domain.Timesheet, error) {
+	weekStart := domain.StartOfWeek(date, s.weekStart)
+
+	timesheet, err := s.timesheetRepo.GetByUserIDAndWeek(userID, weekStart)
+	if err == nil {
+		return timesheet, nil
+	}
+	if !errors.Is(err, domain.ErrTimesheetNotFound) {
+		return nil, err
+	}
+
+	return &domain.Timesheet{
+		UserID:    userID,
+		WeekStart: weekStart,
+		WeekEnd:   weekStart.AddDate(0, 0, 6),
+		Status:    domain.TimesheetStatusDraft,
+	}, nil
+}
+
+// save creates the timesheet on first write and updates it afterwards
+func (s *TimesheetService) save(timesheet *domain.Timesheet) error {
+	if timesheet.ID == 0 {
+		return s.timesheetRepo.Create(timesheet)
+	}
+	return s.timesheetRepo.Update(timesheet)
+}
+
+// fillDays aggregates the attendance and breaks of every day of the week
+func (s *TimesheetService) fillDays(timesheet *domain.Timesheet) error {
+	attendances, err := s.attendanceRepo.GetByUserIDAndDateRange(timesheet.UserID, timesheet.WeekStart, timesheet.WeekEnd)
+	if err != nil {
+		return err
+	}
+	byDate := make(map[time.Time]*domain.Attendance, len(attendances))
+	for i := range attendances {
+		byDate[domain.DateOnly(attendances[i].Date)] = &attendances[i]
+	}
+
+	notes := make(map[time.Time]string, len(timesheet.DayNotes))
+	for _, dayNote := range timesheet.DayNotes {
+		notes[domain.DateOnly(dayNote.Date)] = dayNote.Note
+	}
+
+	timesheet.Days = make([]domain.TimesheetDay, 0, 7)
+	for offset := 0; offset < 7; offset++ {
+		day := timesheet.WeekStart.AddDate(0, 0, offset)
+		entry := domain.TimesheetDay{Date: day, Note: notes[day]}
+
+		if attendance := byDate[day]; attendance != nil {
+			withBreaks, err := s.attendanceRepo.GetWithBreaks(attendance.ID)
+			if err != nil {
+				return err
+			}
+
+			id := withBreaks.ID
+			entry.AttendanceID = &id
+			entry.Status = withBreaks.GetStatus()
+			entry.CheckInTime = withBreaks.CheckInTime
+			entry.CheckOutTime = withBreaks.CheckOutTime
+			entry.WorkHours = withBreaks.TotalWorkHours
+			entry.BreakCount = len(withBreaks.Breaks)
+			for _, breakItem := range withBreaks.Breaks {
+				entry.BreakMinutes += breakItem.Duration
+			}
+		}
+
+		timesheet.Days = append(timesheet.Days, entry)
+	}
+
+	// Totals are stored on submission; until then show the running figures
+	if timesheet.IsEditable() {
+		timesheet.TotalWorkHours, timesheet.TotalBreakMinutes = sumTimesheetDays(timesheet.Days)
+	}
+
+	return nil
+}
+
+// snapshotTotals stores the weekly totals; a week with an open check-in cannot be settled yet
+func (s *TimesheetService) snapshotTotals(timesheet *domain.Timesheet) error {
+	for _, day := range timesheet.Days {
+		if day.CheckInTime != nil && day.CheckOutTime == nil {
+			return domain.ErrTimesheetOpenSession
+		}
+	}
+
+	timesheet.TotalWorkHours, timesheet.TotalBreakMinutes = sumTimesheetDays(timesheet.Days)
+	return nil
+}
+
+// sumTimesheetDays adds up the work hours and break minutes of the given days
+func sumTimesheetDays(days []domain.TimesheetDay) (float64, float64) {
+	workHours, breakMinutes := 0.0, 0.0
+	for _, day := range days {
+		workHours += day.WorkHours
+		breakMinutes += day.BreakMinutes
+	}
+	return roundHours(workHours), roundHours(breakMinutes)
+}
+
+// checkWeekUnlocked refuses a new attendance record on a day of a week whose timesheet was approved.
+// Approval only locks the records that existed then, so days without one are checked here.
+func checkWeekUnlocked(timesheetRepo domain.TimesheetRepositoryInterface, userID uint, date time.Time) error {
+	if _, err := timesheetRepo.GetApprovedByUserIDAndDate(userID, date); err == nil {
+		return domain.ErrAttendanceLocked
+	} else if !errors.Is(err, domain.ErrTimesheetNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"time"
+
+	"hrm/domain"
+)
+
+// userTimeLocation resolves the time zone an employee works in.
+// The user's own override wins, then the zone of their assigned location,
+// and finally the organisation default from the attendance policy.
+func userTimeLocation(user *domain.User, locationRepo domain.LocationRepositoryInterface, policy domain.AttendancePolicy) *time.Location {
+	if user.Timezone != "" {
+		if loc, err := time.LoadLocation(user.Timezone); err == nil {
+			return loc
+		}
+	}
+
+	if user.LocationID != nil && locationRepo != nil {
+		if location, err := locationRepo.GetByID(*user.LocationID); err == nil {
+			return location.TimeLocation()
+		}
+	}
+
+	return policy.DefaultLocation()
+}
+
+// userWorkLocation returns the location an employee is assigned to, or nil if none is set or it cannot be loaded
+func userWorkLocation(user *domain.User, locationRepo domain.LocationRepositoryInterface) *domain.Location {
+	if user.LocationID == nil || locationRepo == nil {
+		return nil
+	}
+
+	location, err := locationRepo.GetByID(*user.LocationID)
+	if err != nil {
+		return nil
+	}
+	return location
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// generateOpaqueToken returns a random token with a readable prefix, e.g. "kiosk_3f9a...".
+// Opaque tokens are shown to the caller once and only their hash is stored.
+func generateOpaqueToken(prefix string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(buf), nil
+}
+
+// hashOpaqueToken returns the hex-encoded SHA-256 hash an opaque token is stored and looked up by
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
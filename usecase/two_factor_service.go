@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"hrm/domain"
+)
+
+const (
+	// twoFactorChallengeTokenPrefix makes sign-in challenge tokens recognizable
+	twoFactorChallengeTokenPrefix = "hrm2fa_"
+
+	// maxTwoFactorChallengeAttempts is how many wrong codes a challenge accepts before the user has to sign in again
+	maxTwoFactorChallengeAttempts = 5
+
+	// recoveryCodeCount is how many recovery codes a user gets at a time
+	recoveryCodeCount = 10
+)
+
+// TwoFactorService implements the TwoFactorServiceInterface
+// This struct contains the business logic for TOTP two-factor authentication:
+// enrolling authenticator apps, recovery codes and the second step of signing in
+type TwoFactorService struct {
+	credentialRepo    domain.TOTPCredentialRepositoryInterface
+	challengeRepo     domain.TwoFactorChallengeRepositoryInterface
+	userRepo          domain.UserRepositoryInterface
+	signInAttemptRepo domain.SignInAttemptRepositoryInterface
+	issuer            string
+	requiredRoles     []string
+	challengeTTL      time.Duration
+	signInPolicy      domain.SignInPolicy
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService.
+// Users with one of the required roles have to enroll before they can sign in;
+// everyone else may enroll voluntarily.
+func NewTwoFactorService(
+	credentialRepo domain.TOTPCredentialRepositoryInterface,
+	challengeRepo domain.TwoFactorChallengeRepositoryInterface,
+	userRepo domain.UserRepositoryInterface,
+	signInAttemptRepo domain.SignInAttemptRepositoryInterface,
+	issuer string,
+	requiredRoles []string,
+	challengeTTL time.Duration,
+	signInPolicy domain.SignInPolicy,
+) domain.TwoFactorServiceInterface {
+	return &TwoFactorService{
+		credentialRepo:    credentialRepo,
+		challengeRepo:     challengeRepo,
+		userRepo:          userRepo,
+		signInAttemptRepo: signInAttemptRepo,
+		issuer:            issuer,
+		requiredRoles:     requiredRoles,
+		challengeTTL:      challengeTTL,
+		signInPolicy:      signInPolicy,
+	}
+}
+
+// BeginSignIn is called once a user's password was accepted. It returns nil if tokens can be issued
+// right away, or a challenge if the user has 2FA enabled or has to set it up first.
+func (s *TwoFactorService) BeginSignIn(user *domain.User) (*domain.TwoFactorTicket, error) {
+	credential, err := s.credentialRepo.GetByUserID(user.ID)
+	if err != nil && !errors.Is(err, domain.ErrTOTPCredentialNotFound) {
+		return nil, err
+	}
+	enabled := credential != nil && credential.IsConfirmed()
+	if !enabled && !s.isRequired(user.Role) {
+		return nil, nil
+	}
+
+	token, err := generateOpaqueToken(twoFactorChallengeTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+	challenge := &domain.TwoFactorChallenge{
+		UserID:        user.ID,
+		TokenHash:     hashOpaqueToken(token),
+		SetupRequired: !enabled,
+		ExpiresAt:     time.Now().UTC().Add(s.challengeTTL),
+	}
+	if err := s.challengeRepo.Create(challenge); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorTicket{
+		ChallengeToken: token,
+		ExpiresAt:      challenge.ExpiresAt,
+		SetupRequired:  challenge.SetupRequired,
+	}, nil
+}
+
+// StartChallengeSetup starts the enrollment a sign-in challenge requires,
+// for users whose role requires 2FA but who have not set it up yet
+func (s *TwoFactorService) StartChallengeSetup(challengeToken string) (*domain.TOTPEnrollment, error) {
+	challenge, err := s.usableChallenge(challengeToken, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.SetupRequired {
+		return nil, domain.ErrTwoFactorSetupNotRequired
+	}
+
+	user, err := s.userRepo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.startEnrollment(user)
+}
+
+// CompleteSignIn checks the code for a sign-in challenge.
+// This method performs the following business operations:
+//  1. Checks the challenge and that the user may still sign in
+//  2. Verifies a TOTP or recovery code; a challenge that requires setup only accepts a TOTP code
+//     from the new enrollment, which it confirms
+//  3. Counts wrong codes against the challenge and the account, locking it like wrong passwords do
+//  4. Uses up the challenge and returns the user the tokens are issued for
+func (s *TwoFactorService) CompleteSignIn(challengeToken, code string, client domain.AuthClient) (*domain.TwoFactorSignIn, error) {
+	now := time.Now().UTC()
+
+	// Step 1: Check the challenge and the user
+	challenge, err := s.usableChallenge(challengeToken, now)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(challenge.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	if user.IsLockedAt(now) {
+		return nil, &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+	}
+
+	credential, err := s.credentialRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Verify the code
+	result := &domain.TwoFactorSignIn{}
+	var verifyErr error
+	if challenge.SetupRequired && !credential.IsConfirmed() {
+		verifyErr = s.confirm(credential, code, now)
+		if verifyErr == nil {
+			result.RecoveryCodes, err = s.issueRecoveryCodes(user.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		verifyErr = s.verifyCode(credential, code, now)
+	}
+
+	// Step 3: Count wrong codes
+	if verifyErr != nil {
+		if !errors.Is(verifyErr, domain.ErrInvalidTwoFactorCode) {
+			return nil, verifyErr
+		}
+		return nil, s.recordWrongCode(challenge, credential, user, client, now)
+	}
+
+	// Step 4: Use up the challenge
+	challenge.UsedAt = &now
+	if err := s.challengeRepo.Update(challenge); err != nil {
+		return nil, err
+	}
+	if credential.FailedCodes > 0 {
+		credential.FailedCodes = 0
+		if err := s.credentialRepo.Save(credential); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Sanitize()
+	result.User = user
+	return result, nil
+}
+
+// GetStatus describes a user's two-factor authentication setup
+func (s *TwoFactorService) GetStatus(userID uint) (*domain.TwoFactorStatus, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &domain.TwoFactorStatus{Required: s.isRequired(user.Role)}
+
+	credential, err := s.credentialRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTOTPCredentialNotFound) {
+			return status, nil
+		}
+		return nil, err
+	}
+	if !credential.IsConfirmed() {
+		return status, nil
+	}
+
+	codes, err := s.credentialRepo.GetUnusedRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	status.Enabled = true
+	status.ConfirmedAt = credential.ConfirmedAt
+	status.RecoveryCodesRemaining = len(codes)
+	return status, nil
+}
+
+// StartEnrollment creates a new secret for a signed-in user. It only protects sign-in once
+// confirmed with ConfirmEnrollment; starting again replaces an unconfirmed secret.
+func (s *TwoFactorService) StartEnrollment(userID uint) (*domain.TOTPEnrollment, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.startEnrollment(user)
+}
+
+// ConfirmEnrollment enables 2FA with a first code from the authenticator app and returns the recovery codes.
+// The codes are only shown this once.
+func (s *TwoFactorService) ConfirmEnrollment(userID uint, code string) ([]string, error) {
+	credential, err := s.credentialRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential.IsConfirmed() {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.confirm(credential, code, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	log.Printf("User %d enabled two-factor authentication", userID)
+	return s.issueRecoveryCodes(userID)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of a user after checking a current code
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	credential, err := s.confirmedCredential(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyCode(credential, code, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(userID)
+}
+
+// Disable turns 2FA off after checking a current code. Users whose role requires 2FA cannot turn it off.
+func (s *TwoFactorService) Disable(userID uint, code string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if s.isRequired(user.Role) {
+		return domain.ErrTwoFactorRequired
+	}
+
+	credential, err := s.confirmedCredential(userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyCode(credential, code, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	log.Printf("User %d disabled two-factor authentication", userID)
+	return s.credentialRepo.DeleteByUserID(userID)
+}
+
+// Reset removes a user's 2FA setup, e.g. after they lost their authenticator app and recovery codes.
+// Users whose role requires 2FA have to set it up again at their next sign-in.
+func (s *TwoFactorService) Reset(userID uint) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+	if _, err := s.confirmedCredential(userID); err != nil {
+		return err
+	}
+
+	log.Printf("Two-factor authentication of user %d was reset", userID)
+	return s.credentialRepo.DeleteByUserID(userID)
+}
+
+// startEnrollment saves a new unconfirmed secret for the user and returns how to add it to an authenticator app
+func (s *TwoFactorService) startEnrollment(user *domain.User) (*domain.TOTPEnrollment, error) {
+	credential, err := s.credentialRepo.GetByUserID(user.ID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrTOTPCredentialNotFound) {
+			return nil, err
+		}
+		credential = &domain.TOTPCredential{UserID: user.ID}
+	}
+	if credential.IsConfirmed() {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	credential.Secret = secret
+	credential.LastUsedStep = 0
+	if err := s.credentialRepo.Save(credential); err != nil {
+		return nil, err
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totpProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// confirm enables an enrollment with a TOTP code from the new authenticator app
+func (s *TwoFactorService) confirm(credential *domain.TOTPCredential, code string, now time.Time) error {
+	step, ok := matchTOTP(credential.Secret, normalizeSecondFactorCode(code), now, credential.LastUsedStep)
+	if !ok {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	credential.ConfirmedAt = &now
+	credential.LastUsedStep = step
+	credential.FailedCodes = 0
+	return s.credentialRepo.Save(credential)
+}
+
+// verifyCode checks a TOTP code or uses up a recovery code of an enabled enrollment
+func (s *TwoFactorService) verifyCode(credential *domain.TOTPCredential, code string, now time.Time) error {
+	code = normalizeSecondFactorCode(code)
+	if code == "" {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	if isTOTPCode(code) {
+		step, ok := matchTOTP(credential.Secret, code, now, credential.LastUsedStep)
+		if !ok {
+			return domain.ErrInvalidTwoFactorCode
+		}
+		credential.LastUsedStep = step
+		return s.credentialRepo.Save(credential)
+	}
+
+	codes, err := s.credentialRepo.GetUnusedRecoveryCodes(credential.UserID)
+	if err != nil {
+		return err
+	}
+	codeHash := hashOpaqueToken(code)
+	for _, recoveryCode := range codes {
+		if recoveryCode.CodeHash == codeHash {
+			log.Printf("User %d used a recovery code", credential.UserID)
+			return s.credentialRepo.UseRecoveryCode(recoveryCode.ID, now)
+		}
+	}
+	return domain.ErrInvalidTwoFactorCode
+}
+
+// recordWrongCode counts a wrong sign-in code against the challenge and the account and returns the error to report.
+// Wrong codes are counted on the enrollment so that signing in again with the password does not reset them.
+func (s *TwoFactorService) recordWrongCode(
+	challenge *domain.TwoFactorChallenge,
+	credential *domain.TOTPCredential,
+	user *domain.User,
+	client domain.AuthClient,
+	now time.Time,
+) error {
+	attempt := &domain.SignInAttempt{UserID: &user.ID, Email: user.Email, Result: domain.SignInResultInvalidSecondFactor}
+	applySignInClient(attempt, client)
+	if err := s.signInAttemptRepo.Create(attempt); err != nil {
+		log.Printf("Error recording sign-in attempt: %v", err)
+	}
+
+	challenge.Attempts++
+	if challenge.Attempts >= maxTwoFactorChallengeAttempts {
+		challenge.UsedAt = &now
+	}
+	if err := s.challengeRepo.Update(challenge); err != nil {
+		return err
+	}
+
+	credential.FailedCodes++
+	policy := s.signInPolicy
+	locked := policy.MaxFailedAttempts > 0 && credential.FailedCodes >= policy.MaxFailedAttempts
+	if locked {
+		credential.FailedCodes = 0
+	}
+	if err := s.credentialRepo.Save(credential); err != nil {
+		return err
+	}
+
+	if locked {
+		lockedUntil := now.Add(policy.LockoutDuration)
+		user.LockedUntil = &lockedUntil
+		if err := s.userRepo.Update(user); err != nil {
+			return err
+		}
+		log.Printf("User %d locked until %s after too many wrong two-factor codes", user.ID, lockedUntil.Format(time.RFC3339))
+		return &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: lockedUntil}
+	}
+	if challenge.UsedAt != nil {
+		return domain.ErrInvalidTwoFactorChallenge
+	}
+	return domain.ErrInvalidTwoFactorCode
+}
+
+// issueRecoveryCodes replaces the recovery codes of a user with new ones and returns them in plain text
+func (s *TwoFactorService) issueRecoveryCodes(userID uint) ([]string, error) {
+	plain := make([]string, 0, recoveryCodeCount)
+	codes := make([]domain.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		plain = append(plain, code)
+		codes = append(codes, domain.RecoveryCode{CodeHash: hashOpaqueToken(normalizeSecondFactorCode(code))})
+	}
+
+	if err := s.credentialRepo.ReplaceRecoveryCodes(userID, codes); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// usableChallenge looks up a challenge by its token and checks it can still be completed
+func (s *TwoFactorService) usableChallenge(token string, now time.Time) (*domain.TwoFactorChallenge, error) {
+	challenge, err := s.challengeRepo.GetByTokenHash(hashOpaqueToken(strings.TrimSpace(token)))
+	if err != nil {
+		if errors.Is(err, domain.ErrTwoFactorChallengeNotFound) {
+			return nil, domain.ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+	if !challenge.IsUsableAt(now) {
+		return nil, domain.ErrInvalidTwoFactorChallenge
+	}
+	return challenge, nil
+}
+
+// confirmedCredential returns the user's enrollment if 2FA is enabled
+func (s *TwoFactorService) confirmedCredential(userID uint) (*domain.TOTPCredential, error) {
+	credential, err := s.credentialRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTOTPCredentialNotFound) {
+			return nil, domain.ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+	if !credential.IsConfirmed() {
+		return nil, domain.ErrTwoFactorNotEnabled
+	}
+	return credential, nil
+}
+
+// isRequired reports whether users with the role have to use 2FA; users from before roles existed are employees
+func (s *TwoFactorService) isRequired(role string) bool {
+	if role == "" {
+		role = domain.RoleEmployee
+	}
+	for _, required := range s.requiredRoles {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
@@ -2,14 +2,12 @@ package usecase
 
 import (
 	"errors"
-	"os"
-	"strconv"
+	"strings"
 	"time"
 
 	"hrm/domain"
 	"log"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -17,21 +15,45 @@ import (
 // for user operations. This layer orchestrates between the repository layer and
 // domain entities, applying business rules and validation.
 type UserService struct {
-	userRepository domain.UserRepositoryInterface // Dependency on user repository
+	userRepository          domain.UserRepositoryInterface          // Dependency on user repository
+	locationRepository      domain.LocationRepositoryInterface      // Dependency on location repository
+	authSessionRepository   domain.AuthSessionRepositoryInterface   // Dependency on sign-in session repository
+	signInAttemptRepository domain.SignInAttemptRepositoryInterface // Dependency on sign-in history repository
+	signInPolicy            domain.SignInPolicy                     // Lockout and throttling rules for sign-in
+	passwords               passwordManager                         // Password policy and history applied to new passwords
+	directory               domain.DirectoryInterface               // LDAP directory synced users sign in with (nil when not configured)
 }
 
 // NewUserService creates and returns a new UserService instance.
 // This function acts as a constructor and ensures proper dependency injection.
-// It takes a user repository interface, making it easy to test with mock repositories.
-func NewUserService(userRepository domain.UserRepositoryInterface) domain.UserServiceInterface {
-	return &UserService{userRepository: userRepository}
+// It takes repository interfaces, making it easy to test with mock repositories.
+// Without a directory, all users sign in with their HRM password.
+func NewUserService(
+	userRepository domain.UserRepositoryInterface,
+	locationRepository domain.LocationRepositoryInterface,
+	authSessionRepository domain.AuthSessionRepositoryInterface,
+	signInAttemptRepository domain.SignInAttemptRepositoryInterface,
+	passwordHistoryRepository domain.PasswordHistoryRepositoryInterface,
+	signInPolicy domain.SignInPolicy,
+	passwordPolicy domain.PasswordPolicy,
+	directory domain.DirectoryInterface,
+) domain.UserServiceInterface {
+	return &UserService{
+		userRepository:          userRepository,
+		locationRepository:      locationRepository,
+		authSessionRepository:   authSessionRepository,
+		signInAttemptRepository: signInAttemptRepository,
+		signInPolicy:            signInPolicy,
+		passwords:               passwordManager{policy: passwordPolicy, historyRepo: passwordHistoryRepository},
+		directory:               directory,
+	}
 }
 
 // SignUp registers a new user with the system.
 // This method performs the following business operations:
 // 1. Validates the user input data
 // 2. Checks if a user with the same email already exists
-// 3. Hashes the password for security
+// 3. Checks the password against the password policy and hashes it
 // 4. Creates the user in the database
 // 5. Sanitizes the user data before returning
 func (s *UserService) SignUp(user *domain.User) error {
@@ -51,13 +73,12 @@ func (s *UserService) SignUp(user *domain.User) error {
 		return domain.ErrUserAlreadyExists
 	}
 
-	// Step 3: Hash the password for security
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	// Step 3: Check the password against the policy and hash it
+	hashedPassword, err := s.passwords.hashNewPassword(user, user.Password)
 	if err != nil {
-		log.Printf("Error hashing password: %v", err)
 		return err
 	}
-	user.Password = string(hashedPassword)
+	user.Password = hashedPassword
 
 	// Step 4: Create the user in the database
 	if err := s.userRepository.Create(user); err != nil {
@@ -71,28 +92,196 @@ func (s *UserService) SignUp(user *domain.User) error {
 
 // SignIn authenticates a user with their email and password.
 // This method performs the following business operations:
-// 1. Retrieves the user by email
-// 2. Verifies the provided password against the stored hash
-// 3. Returns the authenticated user (with password removed)
-func (s *UserService) SignIn(email, password string) (*domain.User, error) {
-	// Step 1: Get user by email
+// 1. Refuses attempts from an IP address with too many recent failures
+// 2. Retrieves the user by email; directory users signing in for the first time get their account now
+// 3. Refuses locked accounts and attempts made before the progressive delay has passed
+// 4. Verifies the provided password against the stored hash (or the directory), counting failures towards lockout
+// 5. Rejects deactivated users
+// 6. Records the attempt in the sign-in history and returns the authenticated user (with password removed)
+func (s *UserService) SignIn(email, password string, client domain.AuthClient) (*domain.User, error) {
+	now := time.Now().UTC()
+	attempt := &domain.SignInAttempt{Email: email}
+	applySignInClient(attempt, client)
+	policy := s.signInPolicy
+
+	// Step 1: Throttle IP addresses guessing passwords across many accounts
+	if policy.MaxFailedAttemptsPerIP > 0 && client.IPAddress != "" {
+		failures, err := s.signInAttemptRepository.CountFailuresByIP(client.IPAddress, now.Add(-policy.FailureWindow))
+		if err != nil {
+			return nil, err
+		}
+		if failures >= int64(policy.MaxFailedAttemptsPerIP) {
+			s.recordSignInAttempt(attempt, domain.SignInResultThrottled)
+			return nil, &domain.SignInBlockedError{Reason: domain.ErrTooManySignInAttempts, RetryAt: now.Add(policy.FailureWindow)}
+		}
+	}
+
+	// Step 2: Get user by email
+	authenticated := false
 	user, err := s.userRepository.GetByEmail(email)
 	if err != nil {
-		// Don't reveal whether the email exists or not for security
-		return nil, domain.ErrInvalidCredentials
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		if s.directory != nil {
+			user, err = s.provisionFromDirectory(email, password)
+			if err != nil {
+				return nil, err
+			}
+			authenticated = user != nil
+		}
+		if user == nil {
+			// Don't reveal whether the email exists or not for security
+			s.recordSignInAttempt(attempt, domain.SignInResultInvalidCredentials)
+			return nil, domain.ErrInvalidCredentials
+		}
 	}
+	attempt.UserID = &user.ID
 
-	// Step 2: Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	// Step 3: Refuse locked accounts and attempts that come too fast
+	if user.IsLockedAt(now) {
+		s.recordSignInAttempt(attempt, domain.SignInResultLocked)
+		return nil, &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+	}
+	if next := user.NextSignInAt(policy); now.Before(next) {
+		s.recordSignInAttempt(attempt, domain.SignInResultThrottled)
+		return nil, &domain.SignInBlockedError{Reason: domain.ErrTooManySignInAttempts, RetryAt: next}
+	}
+
+	// Step 4: Verify password
+	var passwordErr error
+	if !authenticated {
+		passwordErr = s.checkPassword(user, password)
+	}
+	switch {
+	case passwordErr == nil:
+	case errors.Is(passwordErr, domain.ErrInvalidCredentials):
 		// Password doesn't match
+		locked := user.RecordFailedSignIn(now, policy)
+		if err := s.userRepository.Update(user); err != nil {
+			return nil, err
+		}
+		s.recordSignInAttempt(attempt, domain.SignInResultInvalidCredentials)
+		if locked {
+			log.Printf("User %d locked until %s after too many failed sign-in attempts", user.ID, user.LockedUntil.Format(time.RFC3339))
+			return nil, &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+		}
 		return nil, domain.ErrInvalidCredentials
+	case errors.Is(passwordErr, domain.ErrUserInactive):
+		// Disabled in the directory
+		s.recordSignInAttempt(attempt, domain.SignInResultInactive)
+		return nil, domain.ErrUserInactive
+	default:
+		return nil, passwordErr
+	}
+
+	// Step 5: Deactivated users cannot sign in
+	if !user.IsActive {
+		s.recordSignInAttempt(attempt, domain.SignInResultInactive)
+		return nil, domain.ErrUserInactive
+	}
+
+	// Step 6: A successful sign-in forgets earlier failures
+	if user.FailedSignIns > 0 || user.LockedUntil != nil {
+		user.ResetFailedSignIns()
+		if err := s.userRepository.Update(user); err != nil {
+			return nil, err
+		}
 	}
+	s.recordSignInAttempt(attempt, domain.SignInResultSuccess)
 
-	// Step 3: Return authenticated user (with password removed)
+	// Return authenticated user (with password removed)
 	user.Sanitize()
 	return user, nil
 }
 
+// checkPassword verifies a sign-in password. Directory users are checked against the directory,
+// which also refuses accounts disabled there before the next sync deactivates them.
+// Service accounts have no password and only authenticate with API tokens.
+func (s *UserService) checkPassword(user *domain.User, password string) error {
+	if user.IsServiceAccount {
+		return domain.ErrInvalidCredentials
+	}
+	if s.directory == nil || !user.IsFromDirectory() {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+			return domain.ErrInvalidCredentials
+		}
+		return nil
+	}
+
+	entry, err := s.directory.Authenticate(user.Email, password)
+	if err != nil {
+		if errors.Is(err, domain.ErrDirectoryInvalidCredentials) {
+			return domain.ErrInvalidCredentials
+		}
+		return err
+	}
+	if entry.Disabled {
+		return domain.ErrUserInactive
+	}
+	return nil
+}
+
+// provisionFromDirectory signs in an email HRM does not know against the directory.
+// It returns the user synced from the entry, creating it if needed, or nil if the directory refuses the password.
+func (s *UserService) provisionFromDirectory(email, password string) (*domain.User, error) {
+	entry, err := s.directory.Authenticate(email, password)
+	if err != nil {
+		if errors.Is(err, domain.ErrDirectoryInvalidCredentials) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if entry.Disabled {
+		return nil, nil
+	}
+
+	// The email changed in the directory since the last sync
+	user, err := s.userRepository.GetByDirectoryDN(entry.DN)
+	if err == nil {
+		user.Email = entry.Email
+		if err := s.userRepository.Update(user); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	user, err = newDirectoryUser(*entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepository.Create(user); err != nil {
+		return nil, err
+	}
+	log.Printf("Provisioned user %d from directory entry %q", user.ID, entry.DN)
+	return user, nil
+}
+
+// recordSignInAttempt adds an attempt to the sign-in history.
+// Failing to record it is logged but does not change the outcome of the sign-in.
+func (s *UserService) recordSignInAttempt(attempt *domain.SignInAttempt, result string) {
+	attempt.Result = result
+	if err := s.signInAttemptRepository.Create(attempt); err != nil {
+		log.Printf("Error recording sign-in attempt: %v", err)
+	}
+}
+
+// applySignInClient records the device a sign-in attempt came from
+func applySignInClient(attempt *domain.SignInAttempt, client domain.AuthClient) {
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	attempt.UserAgent = userAgent
+	attempt.IPAddress = client.IPAddress
+	if len(attempt.Email) > 255 {
+		attempt.Email = attempt.Email[:255]
+	}
+}
+
 // GetUserByID retrieves a user by their ID.
 // This method performs the following business operations:
 // 1. Retrieves the user from the database
@@ -126,42 +315,126 @@ func (s *UserService) GetCurrentUser(userID uint) (*domain.User, error) {
 	return user, nil
 }
 
-// UpdateUser modifies an existing user's information.
+// UpdateUser modifies an existing user's profile.
 // This method performs the following business operations:
-// 1. Validates the updated user data
-// 2. Checks if the user exists
-// 3. Hashes the password if it has changed
-// 4. Updates the user in the database
+// 1. Checks if the user exists
+// 2. Copies the editable profile fields onto it
+// 3. Validates the updated user data
+// 4. Checks that the assigned location and manager exist
+// 5. Updates the user in the database
+//
+// Only the name, email, time zone, location and manager are taken from user. Everything else has its own
+// operation (password, activation, role, lockout, kiosk credentials) or is managed by the directory sync
+// and SCIM, and is kept as stored. On success user holds the saved profile.
 func (s *UserService) UpdateUser(user *domain.User) error {
-	// Step 1: Validate user input data
-	if err := user.Validate(); err != nil {
+	// Step 1: Check if user exists
+	existingUser, err := s.userRepository.GetByID(user.ID)
+	if err != nil {
 		return err
 	}
 
-	// Step 2: Check if user exists
-	existingUser, err := s.userRepository.GetByID(user.ID)
+	// Step 2: Copy the editable profile fields; a new email address has to be verified again
+	if !strings.EqualFold(user.Email, existingUser.Email) {
+		existingUser.EmailVerifiedAt = nil
+	}
+	existingUser.Name = user.Name
+	existingUser.Email = user.Email
+	existingUser.Timezone = user.Timezone
+	existingUser.LocationID = user.LocationID
+	existingUser.ManagerID = user.ManagerID
+
+	// Step 3: Validate user input data
+	if err := existingUser.Validate(); err != nil {
+		return err
+	}
+
+	// Step 4: Check that the assigned location and manager exist
+	if existingUser.LocationID != nil {
+		if _, err := s.locationRepository.GetByID(*existingUser.LocationID); err != nil {
+			return err
+		}
+	}
+	if existingUser.ManagerID != nil {
+		if _, err := s.userRepository.GetByID(*existingUser.ManagerID); err != nil {
+			return domain.ErrManagerNotFound
+		}
+	}
+
+	// Step 5: Update user in database
+	if err := s.userRepository.Update(existingUser); err != nil {
+		return err
+	}
+
+	*user = *existingUser
+	return nil
+}
+
+// ChangePassword replaces a signed-in user's password.
+// This method performs the following business operations:
+// 1. Refuses locked accounts
+// 2. Verifies the current password; wrong ones count towards the sign-in lockout, and directory users are refused
+// 3. Checks the new password against the password policy and the user's recent passwords
+// 4. Saves the new password and remembers the old one
+// 5. Signs out every other device; the session the change was made with stays signed in
+func (s *UserService) ChangePassword(userID, sessionID uint, currentPassword, newPassword string) error {
+	now := time.Now().UTC()
+
+	// Step 1: A locked account cannot be used to guess the password either
+	user, err := s.userRepository.GetByID(userID)
 	if err != nil {
 		return err
 	}
+	if user.IsLockedAt(now) {
+		return &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+	}
 
-	// Step 3: Hash password if it has changed
-	if user.Password != existingUser.Password {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-		if err != nil {
-			log.Printf("Error hashing password: %v", err)
+	// Step 2: Verify the current password; directory users change it in the directory
+	if s.directory != nil && user.IsFromDirectory() {
+		return domain.ErrPasswordManagedByDirectory
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		locked := user.RecordFailedSignIn(now, s.signInPolicy)
+		if err := s.userRepository.Update(user); err != nil {
 			return err
 		}
-		user.Password = string(hashedPassword)
+		if locked {
+			log.Printf("User %d locked until %s after too many wrong current passwords", user.ID, user.LockedUntil.Format(time.RFC3339))
+			return &domain.SignInBlockedError{Reason: domain.ErrAccountLocked, RetryAt: *user.LockedUntil}
+		}
+		return domain.ErrIncorrectPassword
 	}
 
-	// Step 4: Update user in database
-	return s.userRepository.Update(user)
+	// Step 3: Check the new password
+	hashedPassword, err := s.passwords.hashNewPassword(user, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Step 4: Save the new password
+	oldHash := user.Password
+	user.Password = hashedPassword
+	user.ResetFailedSignIns()
+	if err := s.userRepository.Update(user); err != nil {
+		return err
+	}
+	if err := s.passwords.rememberPassword(user.ID, oldHash); err != nil {
+		return err
+	}
+
+	// Step 5: Tokens issued with the old password must not outlive it on other devices
+	return s.authSessionRepository.RevokeOthersByUserID(user.ID, sessionID, domain.SessionRevokedPasswordChanged)
+}
+
+// GetPasswordPolicy returns the rules new passwords have to follow
+func (s *UserService) GetPasswordPolicy() domain.PasswordPolicy {
+	return s.passwords.policy
 }
 
 // DeleteUser removes a user from the system.
 // This method performs the following business operations:
 // 1. Checks if the user exists
-// 2. Deletes the user from the database
+// 2. Signs the user out everywhere
+// 3. Deletes the user from the database
 func (s *UserService) DeleteUser(id uint) error {
 	// Step 1: Check if user exists
 	_, err := s.userRepository.GetByID(id)
@@ -169,7 +442,12 @@ func (s *UserService) DeleteUser(id uint) error {
 		return err
 	}
 
-	// Step 2: Delete user from database
+	// Step 2: Revoke the user's sessions so their tokens stop working
+	if err := s.authSessionRepository.RevokeAllByUserID(id, domain.SessionRevokedUserDeleted); err != nil {
+		return err
+	}
+
+	// Step 3: Delete user from database
 	return s.userRepository.Delete(id)
 }
 
@@ -192,45 +470,153 @@ func (s *UserService) ListUsers(limit, offset int) ([]domain.User, error) {
 	return users, nil
 }
 
-// GenerateJWTToken generates a JWT token for the user.
-// This method creates a secure token containing user information that can be used
-// for authentication in subsequent requests. The token includes:
-// - User ID
-// - User email
-// - Token expiration time
-// - Token issuance time
-func (s *UserService) GenerateJWTToken(user *domain.User) (string, error) {
-	// Step 1: Get JWT secret from environment
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return "", errors.New("JWT_SECRET environment variable is required")
+// SetUserActive activates or deactivates a user.
+// Inactive users cannot sign in and no absences are recorded for them;
+// deactivating a user also signs them out on all devices.
+// Activating a user a SCIM client deleted returns them to SCIM.
+func (s *UserService) SetUserActive(id uint, active bool) error {
+	user, err := s.userRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	user.IsActive = active
+	if active {
+		user.DeprovisionedAt = nil
+	}
+	if err := s.userRepository.Update(user); err != nil {
+		return err
+	}
+
+	if !active {
+		return s.authSessionRepository.RevokeAllByUserID(id, domain.SessionRevokedUserDeactivated)
+	}
+	return nil
+}
+
+// SetKioskCredentials assigns the employee code, PIN and badge a user identifies with at shared kiosks.
+// This method performs the following business operations:
+// 1. Normalizes and validates the employee code, PIN and badge
+// 2. Checks that no other user has the same employee code or badge
+// 3. Hashes the PIN and saves the credentials
+func (s *UserService) SetKioskCredentials(id uint, employeeCode string, pin, badgeID *string) (*domain.User, error) {
+	user, err := s.userRepository.GetByID(id)
+	if err != nil {
+		return nil, err
 	}
 
-	// Step 2: Get token expiry from environment (default 24 hours)
-	expiryHours := 24
-	if expiryStr := os.Getenv("JWT_EXPIRY_HOURS"); expiryStr != "" {
-		if hours, err := strconv.Atoi(expiryStr); err == nil {
-			expiryHours = hours
+	// Step 1: Normalize and validate the credentials
+	employeeCode = strings.ToUpper(strings.TrimSpace(employeeCode))
+	if employeeCode == "" || len(employeeCode) > 32 {
+		return nil, domain.ErrInvalidEmployeeCode
+	}
+	if pin != nil && *pin != "" && !validKioskPIN(*pin) {
+		return nil, domain.ErrInvalidKioskPIN
+	}
+
+	// Step 2: Employee codes and badges identify exactly one user
+	if existing, err := s.userRepository.GetByEmployeeCode(employeeCode); err == nil && existing.ID != user.ID {
+		return nil, domain.ErrEmployeeCodeExists
+	} else if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	user.EmployeeCode = &employeeCode
+
+	if badgeID != nil {
+		badge := strings.TrimSpace(*badgeID)
+		if badge == "" {
+			user.BadgeID = nil
+		} else {
+			if existing, err := s.userRepository.GetByBadgeID(badge); err == nil && existing.ID != user.ID {
+				return nil, domain.ErrBadgeIDExists
+			} else if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+				return nil, err
+			}
+			user.BadgeID = &badge
+		}
+	}
+
+	// Step 3: Hash the PIN like a password; an empty PIN removes it.
+	// A new PIN lifts a lockout caused by guesses of the old one.
+	if pin != nil {
+		user.KioskPIN = ""
+		user.ResetFailedKioskPINs()
+		if *pin != "" {
+			hashedPIN, err := bcrypt.GenerateFromPassword([]byte(*pin), bcrypt.DefaultCost)
+			if err != nil {
+				log.Printf("Error hashing kiosk PIN: %v", err)
+				return nil, err
+			}
+			user.KioskPIN = string(hashedPIN)
 		}
 	}
 
-	// Step 3: Create JWT claims
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"exp":     time.Now().Add(time.Duration(expiryHours) * time.Hour).Unix(),
-		"iat":     time.Now().Unix(),
+	if err := s.userRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	user.Sanitize()
+	return user, nil
+}
+
+// SetUserRole assigns one of the Role* roles to a user
+func (s *UserService) SetUserRole(id uint, role string) (*domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	user, err := s.userRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	if err := s.userRepository.Update(user); err != nil {
+		return nil, err
 	}
 
-	// Step 4: Create JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	user.Sanitize()
+	return user, nil
+}
 
-	// Step 5: Sign the token with the secret
-	tokenString, err := token.SignedString([]byte(jwtSecret))
+// GetUserRole returns the role of a user; users from before roles existed are employees
+func (s *UserService) GetUserRole(id uint) (string, error) {
+	user, err := s.userRepository.GetByID(id)
 	if err != nil {
-		log.Printf("Error generating JWT token: %v", err)
 		return "", err
 	}
+	if user.Role == "" {
+		return domain.RoleEmployee, nil
+	}
+	return user.Role, nil
+}
+
+// UnlockUser lifts a sign-in or kiosk PIN lockout and forgets the failed attempts, e.g. after the user confirmed their identity
+func (s *UserService) UnlockUser(id uint) error {
+	user, err := s.userRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	user.ResetFailedSignIns()
+	user.ResetFailedKioskPINs()
+	return s.userRepository.Update(user)
+}
 
-	return tokenString, nil
+// ListSignInAttempts retrieves the sign-in history matching the filter, newest first
+func (s *UserService) ListSignInAttempts(filter domain.SignInAttemptFilter) ([]domain.SignInAttempt, error) {
+	return s.signInAttemptRepository.List(filter)
+}
+
+// validKioskPIN reports whether a PIN consists of 4 to 8 digits
+func validKioskPIN(pin string) bool {
+	if len(pin) < 4 || len(pin) > 8 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"hrm/domain"
+)
+
+// fakeUserRepository keeps users in memory; methods the tests do not use are left unimplemented
+type fakeUserRepository struct {
+	domain.UserRepositoryInterface
+	users map[uint]domain.User
+}
+
+func (r *fakeUserRepository) GetByID(id uint) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (r *fakeUserRepository) GetByEmployeeCode(code string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.EmployeeCode != nil && *user.EmployeeCode == code {
+			return &user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) Update(user *domain.User) error {
+	r.users[user.ID] = *user
+	return nil
+}
+
+func TestUpdateUserOnlyChangesProfileFields(t *testing.T) {
+	dn := "uid=alice,ou=people,dc=example,dc=com"
+	externalID := "00u1a2b3c4"
+	code := "F-1042"
+	badge := "04A1B2C3"
+	verifiedAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	lockedUntil := time.Date(2024, 1, 15, 9, 15, 0, 0, time.UTC)
+	existing := domain.User{
+		ID:                  1,
+		Name:                "Alice",
+		Email:               "alice@example.com",
+		Password:            "hash",
+		IsActive:            true,
+		Role:                domain.RoleEmployee,
+		EmployeeCode:        &code,
+		KioskPIN:            "pin-hash",
+		FailedKioskPINs:     2,
+		KioskPINLockedUntil: &lockedUntil,
+		BadgeID:             &badge,
+		EmailVerifiedAt:     &verifiedAt,
+		FailedSignIns:       3,
+		LockedUntil:         &lockedUntil,
+		DirectoryDN:         &dn,
+		ExternalID:          &externalID,
+		IsServiceAccount:    true,
+		DeprovisionedAt:     &verifiedAt,
+	}
+	repo := &fakeUserRepository{users: map[uint]domain.User{existing.ID: existing}}
+	service := NewUserService(repo, nil, nil, nil, nil, domain.SignInPolicy{}, domain.PasswordPolicy{}, nil)
+
+	// A client can only send the profile fields; every other field of the update is ignored
+	update := &domain.User{
+		ID:       existing.ID,
+		Name:     "Alice Smith",
+		Email:    existing.Email,
+		Timezone: "Asia/Karachi",
+		Password: "replaced",
+		Role:     domain.RoleAdmin,
+	}
+	if err := service.UpdateUser(update); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	saved := repo.users[existing.ID]
+
+	tests := []struct {
+		field string
+		got   interface{}
+		want  interface{}
+	}{
+		{"Name", saved.Name, "Alice Smith"},
+		{"Timezone", saved.Timezone, "Asia/Karachi"},
+		{"Password", saved.Password, existing.Password},
+		{"IsActive", saved.IsActive, existing.IsActive},
+		{"Role", saved.Role, existing.Role},
+		{"EmployeeCode", saved.EmployeeCode, existing.EmployeeCode},
+		{"KioskPIN", saved.KioskPIN, existing.KioskPIN},
+		{"FailedKioskPINs", saved.FailedKioskPINs, existing.FailedKioskPINs},
+		{"KioskPINLockedUntil", saved.KioskPINLockedUntil, existing.KioskPINLockedUntil},
+		{"BadgeID", saved.BadgeID, existing.BadgeID},
+		{"EmailVerifiedAt", saved.EmailVerifiedAt, existing.EmailVerifiedAt},
+		{"FailedSignIns", saved.FailedSignIns, existing.FailedSignIns},
+		{"LockedUntil", saved.LockedUntil, existing.LockedUntil},
+		{"DirectoryDN", saved.DirectoryDN, existing.DirectoryDN},
+		{"ExternalID", saved.ExternalID, existing.ExternalID},
+		{"IsServiceAccount", saved.IsServiceAccount, existing.IsServiceAccount},
+		{"DeprovisionedAt", saved.DeprovisionedAt, existing.DeprovisionedAt},
+		{"returned Role", update.Role, existing.Role},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Fatalf("%s = %v, want %v", tt.field, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateUserClearsVerificationOfANewEmail(t *testing.T) {
+	verifiedAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := &fakeUserRepository{users: map[uint]domain.User{
+		1: {ID: 1, Name: "Alice", Email: "alice@example.com", Password: "hash", EmailVerifiedAt: &verifiedAt},
+	}}
+	service := NewUserService(repo, nil, nil, nil, nil, domain.SignInPolicy{}, domain.PasswordPolicy{}, nil)
+
+	if err := service.UpdateUser(&domain.User{ID: 1, Name: "Alice", Email: "alice@example.org"}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if saved := repo.users[1]; saved.EmailVerifiedAt != nil {
+		t.Fatalf("EmailVerifiedAt = %v, want nil after the email changed", saved.EmailVerifiedAt)
+	}
+}